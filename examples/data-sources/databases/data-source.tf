@@ -0,0 +1,7 @@
+data "postgresql_databases" "apps" {
+  name_regex = "^app_"
+}
+
+data "postgresql_databases" "all" {
+  include_system = true
+}
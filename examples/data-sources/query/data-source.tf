@@ -0,0 +1,8 @@
+data "postgresql_query" "active_tenants" {
+  query = "SELECT id, name FROM app.tenants WHERE active AND region = $1 ORDER BY id"
+  args  = ["eu-west"]
+}
+
+output "tenant_names" {
+  value = [for row in data.postgresql_query.active_tenants.rows : row["name"]]
+}
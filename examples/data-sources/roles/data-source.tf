@@ -0,0 +1,7 @@
+data "postgresql_roles" "team" {
+  name_regex = "^team_"
+}
+
+locals {
+  login_roles = [for role in data.postgresql_roles.team.roles : role.name if role.login]
+}
@@ -0,0 +1,4 @@
+data "postgresql_schemas" "tenants" {
+  database   = "app"
+  name_regex = "^tenant_"
+}
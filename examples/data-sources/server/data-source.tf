@@ -0,0 +1,8 @@
+data "postgresql_server" "this" {}
+
+check "server" {
+  assert {
+    condition     = data.postgresql_server.this.version_num >= 140000 && !data.postgresql_server.this.in_recovery
+    error_message = "The pipeline requires a PostgreSQL 14+ primary server."
+  }
+}
@@ -0,0 +1,7 @@
+data "postgresql_settings" "wal" {
+  names = ["wal_level", "max_replication_slots"]
+}
+
+data "postgresql_settings" "memory" {
+  category = "Resource Usage / Memory"
+}
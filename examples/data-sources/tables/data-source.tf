@@ -0,0 +1,9 @@
+data "postgresql_tables" "reporting" {
+  database     = "app"
+  schema_regex = "^reporting$"
+}
+
+data "postgresql_tables" "events" {
+  database   = "app"
+  name_regex = "^events_"
+}
@@ -0,0 +1,2 @@
+# Aggregates can be imported by specifying the id, with the types of the arguments
+terraform import postgresql_aggregate.weighted_avg "database_name.public.weighted_avg(numeric, numeric)"
@@ -0,0 +1,15 @@
+resource "postgresql_aggregate" "weighted_avg" {
+  schema = "public"
+  name   = "weighted_avg"
+  args = [
+    { name = "value", type = "numeric" },
+    { name = "weight", type = "numeric" },
+  ]
+  state_func   = "public.weighted_avg_state"
+  state_type   = "numeric[]"
+  final_func   = "public.weighted_avg_final"
+  combine_func = "public.weighted_avg_combine"
+  init_cond    = "{0,0}"
+  parallel     = "SAFE"
+  comment      = "Average of the values, weighted by the weights"
+}
@@ -0,0 +1,2 @@
+# Database settings can be imported by specifying the id, in the format database_name.parameter_name
+terraform import postgresql_database_setting.shop_statement_timeout "shop.statement_timeout"
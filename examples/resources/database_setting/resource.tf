@@ -0,0 +1,5 @@
+resource "postgresql_database_setting" "shop_statement_timeout" {
+  database = "shop"
+  name     = "statement_timeout"
+  value    = "30s"
+}
@@ -8,3 +8,19 @@ resource "postgresql_event_trigger" "test" {
   comment   = "Test event trigger"
   owner     = "postgres"
 }
+
+resource "postgresql_event_trigger" "audit" {
+  name      = "audit_ddl"
+  event     = "ddl_command_end"
+  exec_func = "audit_ddl_command"
+
+  function = {
+    schema           = "audit"
+    security_definer = true
+    body             = <<-EOT
+      BEGIN
+        INSERT INTO audit.ddl_log (tag, executed_by) VALUES (tg_tag, session_user);
+      END;
+    EOT
+  }
+}
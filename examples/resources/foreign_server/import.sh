@@ -0,0 +1,2 @@
+# Foreign servers can be imported by specifying the id with the format <database_name>.<server_name>
+terraform import postgresql_foreign_server.example_server "example_database.example_server"
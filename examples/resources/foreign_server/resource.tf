@@ -0,0 +1,12 @@
+resource "postgresql_foreign_server" "billing" {
+  name                 = "billing"
+  database             = "reporting"
+  foreign_data_wrapper = "postgres_fdw"
+
+  options = {
+    host   = "billing.example.com"
+    port   = "5432"
+    dbname = "billing"
+  }
+  comment = "Billing database, read through postgres_fdw"
+}
@@ -0,0 +1,2 @@
+# Foreign tables can be imported by specifying the id with the format <database_name>.<schema_name>.<table_name>
+terraform import postgresql_foreign_table.example_table "example_database.example_schema.example_table"
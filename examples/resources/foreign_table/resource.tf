@@ -0,0 +1,17 @@
+resource "postgresql_foreign_table" "invoices" {
+  name     = "invoices"
+  database = "reporting"
+  schema   = "billing"
+  server   = postgresql_foreign_server.billing.name
+
+  columns = [
+    { name = "id", type = "bigint", nullable = false },
+    { name = "customer_id", type = "bigint", nullable = false },
+    { name = "total", type = "numeric(10, 2)", options = { column_name = "amount" } },
+  ]
+  options = {
+    schema_name = "public"
+    table_name  = "invoices"
+  }
+  comment = "Invoices of the billing database"
+}
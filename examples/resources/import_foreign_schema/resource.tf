@@ -0,0 +1,9 @@
+resource "postgresql_import_foreign_schema" "billing" {
+  database      = "reporting"
+  server        = postgresql_foreign_server.billing.name
+  remote_schema = "public"
+  local_schema  = "billing"
+
+  except  = ["audit_log"]
+  options = { import_default = "true" }
+}
@@ -0,0 +1,2 @@
+# Indexes can be imported by specifying the id with the format <database_name>.<schema_name>.<index_name>
+terraform import postgresql_index.example_index "example_database.public.example_index"
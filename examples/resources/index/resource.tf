@@ -0,0 +1,29 @@
+resource "postgresql_index" "users_email" {
+  name     = "users_email_key"
+  database = "postgres"
+  schema   = "public"
+  table    = "users"
+
+  keys = [
+    { expression = "lower(email)" },
+  ]
+  unique = true
+  where  = "deleted_at IS NULL"
+
+  storage_parameters = {
+    fillfactor = "90"
+  }
+
+  # build and drop the index without blocking writes on the table
+  concurrently = true
+}
+
+resource "postgresql_index" "orders_created_at" {
+  name  = "orders_created_at_idx"
+  table = "orders"
+
+  keys = [
+    { column = "created_at", order = "DESC", nulls = "LAST" },
+  ]
+  include = ["status"]
+}
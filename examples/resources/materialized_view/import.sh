@@ -0,0 +1,2 @@
+# Materialized views can be imported by specifying the id with the format <database_name>.<schema_name>.<materialized_view_name>
+terraform import postgresql_materialized_view.example_materialized_view "example_database.public.example_materialized_view"
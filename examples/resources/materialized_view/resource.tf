@@ -0,0 +1,28 @@
+resource "postgresql_materialized_view" "daily_sales" {
+  name     = "daily_sales"
+  database = "postgres"
+  schema   = "reporting"
+
+  query = <<-SQL
+    SELECT date_trunc('day', created_at) AS day, sum(total) AS total
+    FROM public.orders
+    GROUP BY 1
+  SQL
+
+  indexes = [
+    { name = "daily_sales_day_key", keys = [{ column = "day" }], unique = true },
+  ]
+
+  # refresh whenever the data loader is released
+  refresh_triggers = {
+    loader_version = "1.4.0"
+  }
+}
+
+resource "postgresql_materialized_view" "top_customers" {
+  name         = "top_customers"
+  query        = "SELECT customer_id, count(*) AS orders FROM orders GROUP BY customer_id"
+  with_no_data = true
+
+  refresh_on_change = true
+}
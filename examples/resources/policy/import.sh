@@ -0,0 +1,2 @@
+# Policies can be imported by specifying the id with the format <database_name>.<schema_name>.<table_name>.<policy_name>
+terraform import postgresql_policy.example_policy "example_database.public.example_table.example_policy"
@@ -0,0 +1,27 @@
+resource "postgresql_row_level_security" "documents" {
+  database = "postgres"
+  schema   = "app"
+  table    = "documents"
+}
+
+resource "postgresql_policy" "documents_tenant" {
+  name     = "documents_tenant"
+  database = "postgres"
+  schema   = "app"
+  table    = "documents"
+
+  command    = "ALL"
+  roles      = ["app_user"]
+  using      = "tenant_id = current_setting('app.tenant_id')::int"
+  with_check = "tenant_id = current_setting('app.tenant_id')::int"
+  comment    = "Restricts the documents to the tenant of the session"
+}
+
+resource "postgresql_policy" "documents_not_archived" {
+  name       = "documents_not_archived"
+  schema     = "app"
+  table      = "documents"
+  permissive = false
+  command    = "SELECT"
+  using      = "NOT archived"
+}
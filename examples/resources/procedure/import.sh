@@ -0,0 +1,2 @@
+# Procedures can be imported by specifying the id, with the types of the arguments
+terraform import postgresql_procedure.archive_orders "database_name.public.archive_orders(interval, integer)"
@@ -0,0 +1,32 @@
+resource "postgresql_procedure" "archive_orders" {
+  schema = "public"
+  name   = "archive_orders"
+  args = [
+    { name = "older_than", type = "interval" },
+    { name = "batch_size", type = "integer" },
+  ]
+  body = <<-EOT
+    BEGIN
+      LOOP
+        WITH moved AS (
+          DELETE FROM public.orders
+          WHERE id IN (SELECT id FROM public.orders WHERE created_at < now() - older_than LIMIT batch_size)
+          RETURNING *
+        )
+        INSERT INTO public.orders_archive SELECT * FROM moved;
+        EXIT WHEN NOT FOUND;
+        -- every batch is committed on its own, when called with CALL outside a transaction block
+        COMMIT;
+      END LOOP;
+    END;
+  EOT
+  comment = "Moves the old orders to the archive, batch by batch"
+}
+
+resource "postgresql_procedure" "refresh_reports" {
+  name             = "refresh_reports"
+  language         = "sql"
+  body             = "REFRESH MATERIALIZED VIEW public.daily_sales"
+  security_definer = true
+  owner            = "reporting"
+}
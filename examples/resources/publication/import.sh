@@ -0,0 +1,2 @@
+# Publications can be imported by specifying the id with the format <database_name>.<publication_name>
+terraform import postgresql_publication.example_publication "example_database.example_publication"
@@ -0,0 +1,24 @@
+resource "postgresql_publication" "orders" {
+  name     = "orders"
+  database = "postgres"
+
+  tables = [
+    {
+      schema     = "app"
+      name       = "orders"
+      columns    = ["id", "status", "total"]
+      row_filter = "status <> 'draft'"
+    },
+    {
+      schema = "app"
+      name   = "order_items"
+    },
+  ]
+  publish = ["insert", "update", "delete"]
+  comment = "Orders replicated to the reporting server"
+}
+
+resource "postgresql_publication" "everything" {
+  name       = "everything"
+  all_tables = true
+}
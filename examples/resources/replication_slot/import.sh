@@ -0,0 +1,2 @@
+# Replication slots can be imported by specifying the id with the format <database_name>.<slot_name>
+terraform import postgresql_replication_slot.example_slot "example_database.example_slot"
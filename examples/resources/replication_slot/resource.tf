@@ -0,0 +1,17 @@
+resource "postgresql_replication_slot" "debezium" {
+  name     = "debezium"
+  database = "app"
+  plugin   = "pgoutput"
+
+  # the connector is stopped when the slot is destroyed
+  terminate_active = true
+}
+
+resource "postgresql_replication_slot" "standby" {
+  name = "standby_1"
+  type = "physical"
+}
+
+output "debezium_lag_bytes" {
+  value = postgresql_replication_slot.debezium.lag_bytes
+}
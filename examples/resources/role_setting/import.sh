@@ -0,0 +1,2 @@
+# Role settings can be imported by specifying the id, in the format role_name.database_name.parameter_name (* for every database)
+terraform import postgresql_role_setting.app_work_mem "app.*.work_mem"
@@ -0,0 +1,12 @@
+resource "postgresql_role_setting" "app_work_mem" {
+  role  = "app"
+  name  = "work_mem"
+  value = "64MB"
+}
+
+resource "postgresql_role_setting" "app_search_path" {
+  role     = "app"
+  database = "shop"
+  name     = "search_path"
+  value    = "\"$user\", app, public"
+}
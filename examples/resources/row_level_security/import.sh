@@ -0,0 +1,2 @@
+# Row level security settings can be imported by specifying the id with the format <database_name>.<schema_name>.<table_name>
+terraform import postgresql_row_level_security.example_table "example_database.public.example_table"
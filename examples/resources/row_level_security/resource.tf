@@ -0,0 +1,8 @@
+resource "postgresql_row_level_security" "documents" {
+  database = "postgres"
+  schema   = "app"
+  table    = "documents"
+
+  # apply the policies to the owner of the table as well
+  forced = true
+}
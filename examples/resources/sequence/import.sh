@@ -0,0 +1,2 @@
+# Sequences can be imported by specifying the id with the format <database_name>.<schema_name>.<sequence_name>
+terraform import postgresql_sequence.example_sequence "example_database.public.example_sequence"
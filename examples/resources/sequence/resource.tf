@@ -0,0 +1,22 @@
+resource "postgresql_sequence" "invoice_number" {
+  name     = "invoice_number_seq"
+  database = "postgres"
+  schema   = "billing"
+
+  data_type = "integer"
+  start     = 1000
+  increment = 1
+  cache     = 10
+
+  # the sequence is dropped along with the column
+  owned_by = "invoices.number"
+  comment  = "Numbers of the issued invoices"
+}
+
+resource "postgresql_sequence" "countdown" {
+  name      = "countdown_seq"
+  increment = -1
+  min_value = 0
+  max_value = 10
+  cycle     = true
+}
@@ -0,0 +1,17 @@
+resource "postgresql_sql" "audit_log" {
+  create_sql  = <<-EOT
+    CREATE TABLE audit.log (id bigint GENERATED ALWAYS AS IDENTITY, payload jsonb);
+    GRANT INSERT ON audit.log TO app;
+  EOT
+  destroy_sql = "DROP TABLE audit.log;"
+  read_sql    = "SELECT 1 FROM pg_catalog.pg_tables WHERE schemaname = 'audit' AND tablename = 'log'"
+}
+
+# commands refusing transactions run one by one
+resource "postgresql_sql" "log_payload_index" {
+  create_statements  = ["CREATE INDEX CONCURRENTLY log_payload_idx ON audit.log USING gin (payload);"]
+  destroy_statements = ["DROP INDEX CONCURRENTLY audit.log_payload_idx;"]
+  transaction        = false
+
+  depends_on = [postgresql_sql.audit_log]
+}
@@ -0,0 +1,2 @@
+# Subscriptions can be imported by specifying the id with the format <database_name>.<subscription_name>
+terraform import postgresql_subscription.example_subscription "example_database.example_subscription"
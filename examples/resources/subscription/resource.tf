@@ -0,0 +1,14 @@
+variable "replication_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "postgresql_subscription" "orders" {
+  name            = "orders"
+  database        = "reporting"
+  connection_info = "host=primary.example.com port=5432 dbname=postgres user=replicator password=${var.replication_password}"
+  publications    = ["orders"]
+
+  streaming = "on"
+  comment   = "Orders replicated from the primary server"
+}
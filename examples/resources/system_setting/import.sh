@@ -0,0 +1,2 @@
+# System settings can be imported by specifying the id, the name of the parameter
+terraform import postgresql_system_setting.work_mem "work_mem"
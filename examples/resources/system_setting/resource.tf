@@ -0,0 +1,10 @@
+resource "postgresql_system_setting" "work_mem" {
+  name  = "work_mem"
+  value = "64MB"
+}
+
+# applied once the server restarts, see pending_restart
+resource "postgresql_system_setting" "shared_preload_libraries" {
+  name  = "shared_preload_libraries"
+  value = "pg_stat_statements, auto_explain"
+}
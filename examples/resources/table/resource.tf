@@ -0,0 +1,52 @@
+resource "postgresql_table" "countries" {
+  name     = "countries"
+  database = "postgres"
+  schema   = "public"
+
+  columns = [
+    { name = "id", type = "integer", identity = "always" },
+    { name = "code", type = "char(2)", nullable = false },
+    { name = "name", type = "text", nullable = false },
+    { name = "population", type = "bigint", default = "0" },
+  ]
+
+  primary_key = {
+    columns = ["id"]
+  }
+  unique_constraints = [
+    { name = "countries_code_key", columns = ["code"] },
+  ]
+  check_constraints = [
+    { name = "countries_population_check", expression = "population >= 0" },
+  ]
+
+  comment = "Lookup table of countries"
+}
+
+resource "postgresql_table" "cities" {
+  name = "cities"
+
+  columns = [
+    { name = "id", type = "integer", identity = "by_default" },
+    { name = "country_id", type = "integer", nullable = false },
+    { name = "name", type = "text", nullable = false },
+  ]
+
+  primary_key = {
+    columns = ["id"]
+  }
+  foreign_keys = [
+    {
+      name               = "cities_country_id_fkey"
+      columns            = ["country_id"]
+      references_table   = "public.countries"
+      references_columns = ["id"]
+      on_delete          = "CASCADE"
+    },
+  ]
+
+  # dropping columns or changing their type is refused unless explicitly allowed
+  allow_destructive_changes = false
+
+  depends_on = [postgresql_table.countries]
+}
@@ -0,0 +1,2 @@
+# Tablespaces can be imported by specifying the id, the name of the tablespace
+terraform import postgresql_tablespace.example_tablespace "example_tablespace"
@@ -0,0 +1,16 @@
+resource "postgresql_tablespace" "cold" {
+  name     = "cold"
+  location = "/mnt/hdd/postgresql"
+
+  seq_page_cost            = 2
+  random_page_cost         = 8
+  effective_io_concurrency = 2
+  comment                  = "Archived data, on spinning disks"
+}
+
+resource "postgresql_index" "orders_archive_created_at" {
+  name       = "orders_archive_created_at_idx"
+  table      = "orders_archive"
+  keys       = [{ column = "created_at" }]
+  tablespace = postgresql_tablespace.cold.name
+}
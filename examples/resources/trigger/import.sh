@@ -0,0 +1,2 @@
+# Triggers can be imported by specifying the id with the format <database_name>.<schema_name>.<table_name>.<trigger_name>
+terraform import postgresql_trigger.example_trigger "example_database.public.example_table.example_trigger"
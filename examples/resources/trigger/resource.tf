@@ -0,0 +1,40 @@
+resource "postgresql_trigger" "orders_audit" {
+  name     = "orders_audit"
+  database = "postgres"
+  schema   = "shop"
+  table    = "orders"
+
+  timing         = "AFTER"
+  events         = ["INSERT", "UPDATE"]
+  update_columns = ["status"]
+  level          = "ROW"
+  when           = "NEW.status IS DISTINCT FROM OLD.status"
+
+  function  = "audit.log_changes"
+  arguments = ["orders"]
+
+  # keep firing on the replicas fed by logical replication
+  firing_mode = "always"
+  comment     = "Records the status changes of the orders"
+}
+
+resource "postgresql_trigger" "orders_batch" {
+  name      = "orders_batch"
+  table     = "orders"
+  timing    = "AFTER"
+  events    = ["INSERT"]
+  new_table = "inserted_orders"
+  function  = "shop.summarize_orders"
+}
+
+resource "postgresql_trigger" "balance_check" {
+  name               = "balance_check"
+  table              = "accounts"
+  timing             = "AFTER"
+  events             = ["UPDATE"]
+  level              = "ROW"
+  constraint         = true
+  deferrable         = true
+  initially_deferred = true
+  function           = "check_balance"
+}
@@ -0,0 +1,2 @@
+# Types can be imported by specifying the id with the format <database_name>.<schema_name>.<type_name>
+terraform import postgresql_type.example_type "example_database.public.example_type"
@@ -0,0 +1,43 @@
+resource "postgresql_type" "order_status" {
+  name   = "order_status"
+  schema = "shop"
+
+  # new values can be added at any position, existing ones can't be removed or reordered
+  enum = {
+    values = ["pending", "paid", "shipped", "delivered"]
+  }
+  comment = "Lifecycle of an order"
+}
+
+resource "postgresql_type" "address" {
+  name = "address"
+
+  composite = {
+    attributes = [
+      { name = "street", type = "text" },
+      { name = "city", type = "text" },
+      { name = "zip_code", type = "varchar(10)" },
+    ]
+  }
+}
+
+resource "postgresql_type" "float_range" {
+  name = "float_range"
+
+  range = {
+    subtype      = "float8"
+    subtype_diff = "float8mi"
+  }
+}
+
+resource "postgresql_type" "email" {
+  name = "email"
+
+  domain = {
+    base_type = "text"
+    not_null  = true
+    check_constraints = [
+      { name = "email_format", expression = "VALUE ~ '^[^@]+@[^@]+$'" },
+    ]
+  }
+}
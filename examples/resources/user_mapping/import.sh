@@ -0,0 +1,2 @@
+# User mappings can be imported by specifying the id with the format <database_name>.<server_name>.<user_name>
+terraform import postgresql_user_mapping.example_mapping "example_database.example_server.example_user"
@@ -0,0 +1,15 @@
+variable "billing_password" {
+  type      = string
+  sensitive = true
+}
+
+resource "postgresql_user_mapping" "reporting" {
+  database = "reporting"
+  server   = postgresql_foreign_server.billing.name
+  user     = "reporting"
+
+  options = {
+    user     = "billing_reader"
+    password = var.billing_password
+  }
+}
@@ -0,0 +1,2 @@
+# Views can be imported by specifying the id with the format <database_name>.<schema_name>.<view_name>
+terraform import postgresql_view.example_view "example_database.public.example_view"
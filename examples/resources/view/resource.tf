@@ -0,0 +1,23 @@
+resource "postgresql_view" "active_users" {
+  name     = "active_users"
+  database = "postgres"
+  schema   = "reporting"
+
+  query = <<-SQL
+    SELECT id, email, created_at
+    FROM public.users
+    WHERE deleted_at IS NULL
+  SQL
+
+  comment = "Users that were not deleted"
+}
+
+resource "postgresql_view" "open_orders" {
+  name    = "open_orders"
+  query   = "SELECT * FROM orders WHERE status = 'open'"
+  columns = ["order_id", "customer_id", "status", "total"]
+
+  # rows inserted through the view must be visible through it
+  check_option     = "CASCADED"
+  security_barrier = true
+}
@@ -1,6 +1,7 @@
 module terraform-provider-postgresql
 
-go 1.21.0
+go 1.22.0
+
 toolchain go1.22.5
 
 require (
@@ -0,0 +1,332 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+const aggregateObjectType = "AGGREGATE"
+
+// AggregateParallelCodes maps the pg_proc.proparallel codes to the PARALLEL values of an aggregate.
+var AggregateParallelCodes = map[string]string{
+	"s": "SAFE",
+	"r": "RESTRICTED",
+	"u": "UNSAFE",
+}
+
+type aggregateSQL struct {
+	db *sql.DB
+}
+
+// AggregateModel describes a custom aggregate, computing a single result from a set of rows by
+// feeding each row to the state function. An aggregate is identified by its schema, name and the
+// types of its arguments.
+type AggregateModel struct {
+	Schema    string       `json:"schema" validate:"required"`
+	Name      string       `json:"name" validate:"required"`
+	Database  string       `json:"database"`
+	Args      []RoutineArg `json:"args" validate:"dive"`
+	StateFunc string       `json:"state_func" validate:"required"`
+	StateType string       `json:"state_type" validate:"required"`
+	// FinalFunc computes the result from the final state, the state itself is the result when empty.
+	FinalFunc string `json:"final_func"`
+	// CombineFunc merges two states, it allows the aggregate to be computed by parallel workers.
+	CombineFunc string `json:"combine_func"`
+	// InitCond is the initial value of the state, nil meaning null.
+	InitCond *string `json:"init_cond"`
+	Parallel string  `json:"parallel" validate:"omitempty,oneof=SAFE RESTRICTED UNSAFE"`
+	Owner    string  `json:"owner"`
+	Comment  string  `json:"comment"`
+}
+
+type AggregateUpdateParams struct {
+	Current AggregateModel
+	Desired AggregateModel `validate:"required"`
+}
+
+type AggregateRepository interface {
+	Create(ctx context.Context, params AggregateModel) error
+	Drop(ctx context.Context, schema, name string, argTypes []string) error
+	Get(ctx context.Context, schema, name string, argTypes []string) (*AggregateModel, error)
+	Update(ctx context.Context, params AggregateUpdateParams) (*AggregateModel, error)
+	Exists(ctx context.Context, schema, name string, argTypes []string) (bool, error)
+	Normalize(ctx context.Context, model AggregateModel) (*AggregateModel, error)
+}
+
+var _ AggregateRepository = &aggregateSQL{}
+
+func NewAggregateRepository(db *sql.DB) AggregateRepository {
+	return &aggregateSQL{
+		db: db,
+	}
+}
+
+func (a *aggregateSQL) Create(ctx context.Context, params AggregateModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, a.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateAggregate, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, aggregateCreateQuery(params, false))); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateAggregate)
+	}
+
+	name := aggregateName(params.Schema, params.Name, params.Args)
+	if params.Owner != "" && params.Owner != GetAssumeRoleFromCtx(ctx) {
+		statement := fmt.Sprintf("ALTER %s %s OWNER TO %s;", aggregateObjectType, name, pq.QuoteIdentifier(params.Owner))
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateAggregate)
+		}
+	}
+	if params.Comment != "" {
+		if err = CreateComment(ctx, txn, aggregateObjectType, name, params.Comment); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateAggregate)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateAggregate, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (a *aggregateSQL) Drop(ctx context.Context, schema, name string, argTypes []string) error {
+	txn, err := BeginTxWithRole(ctx, a.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropAggregate, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	signature := fmt.Sprintf("%s(%s)", pgQualifiedName(schema, name), aggregateIdentityArgs(strings.Join(argTypes, ", ")))
+	if err = DropObject(ctx, txn, aggregateObjectType, signature); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropAggregate)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropAggregate, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (a *aggregateSQL) Get(ctx context.Context, schema, name string, argTypes []string) (*AggregateModel, error) {
+	model, err := readAggregate(ctx, a.db, routineOid(schema, name, argTypes))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetAggregate)
+	}
+	return model, nil
+}
+
+// Update renames the aggregate and replaces its definition with CREATE OR REPLACE when the
+// functions, the initial condition or the parallel safety changed. Neither the arguments nor the
+// state type can be changed in place.
+func (a *aggregateSQL) Update(ctx context.Context, params AggregateUpdateParams) (*AggregateModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, a.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateAggregate, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range aggregateUpdateStatements(params.Current, params.Desired) {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateAggregate)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateAggregate, "pg_cmd", opCommitTransaction)
+	}
+
+	return a.Get(ctx, params.Desired.Schema, params.Desired.Name, RoutineArgTypes(params.Desired.Args))
+}
+
+func (a *aggregateSQL) Exists(ctx context.Context, schema, name string, argTypes []string) (bool, error) {
+	existsQuery := `
+		SELECT EXISTS (SELECT 1
+					   FROM pg_catalog.pg_aggregate a
+					   WHERE a.aggfnoid::oid = %s);`
+
+	var exists bool
+	row := a.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, routineOid(schema, name, argTypes)))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsAggregate, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
+
+// Normalize returns the definition as PostgreSQL would store it: types through format_type and
+// functions through regproc. The definition is created as a temporary aggregate inside a
+// transaction that is always rolled back.
+func (a *aggregateSQL) Normalize(ctx context.Context, model AggregateModel) (*AggregateModel, error) {
+	txn, err := a.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeAggregate, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	temporary := model
+	temporary.Schema = "pg_temp"
+	temporary.Name = "tf_normalize_" + model.Name
+
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, aggregateCreateQuery(temporary, false))); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeAggregate)
+	}
+
+	normalized, err := readAggregate(ctx, txn, routineOid(temporary.Schema, temporary.Name, RoutineArgTypes(temporary.Args)))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeAggregate)
+	}
+	normalized.Schema, normalized.Name, normalized.Database = model.Schema, model.Name, model.Database
+	normalized.Owner, normalized.Comment = model.Owner, model.Comment
+
+	return normalized, nil
+}
+
+// PreserveAggregateSpelling returns the actual aggregate, but keeping the spelling of the prior
+// types and functions whose normalized form (see AggregateRepository.Normalize) matches the actual one.
+func PreserveAggregateSpelling(prior, normalizedPrior, actual AggregateModel) AggregateModel {
+	result := actual
+	result.Args = PreserveRoutineArgsSpelling(prior.Args, normalizedPrior.Args, actual.Args)
+
+	if normalizedPrior.StateFunc == actual.StateFunc {
+		result.StateFunc = prior.StateFunc
+	}
+	if normalizedPrior.StateType == actual.StateType {
+		result.StateType = prior.StateType
+	}
+	if normalizedPrior.FinalFunc == actual.FinalFunc {
+		result.FinalFunc = prior.FinalFunc
+	}
+	if normalizedPrior.CombineFunc == actual.CombineFunc {
+		result.CombineFunc = prior.CombineFunc
+	}
+	return result
+}
+
+// aggregateIdentityArgs returns the argument list of an aggregate in ALTER and DROP statements,
+// an aggregate without arguments being written '*'.
+func aggregateIdentityArgs(args string) string {
+	if args == "" {
+		return "*"
+	}
+	return args
+}
+
+// aggregateName returns the quoted name and argument list of an aggregate.
+func aggregateName(schema, name string, args []RoutineArg) string {
+	return routineName(schema, name, aggregateIdentityArgs(routineArgsDefinition(args)))
+}
+
+func aggregateCreateQuery(model AggregateModel, replace bool) string {
+	options := []string{
+		fmt.Sprintf("SFUNC = %s", pgQuoteQualifiedName(model.StateFunc)),
+		fmt.Sprintf("STYPE = %s", model.StateType),
+	}
+	if model.FinalFunc != "" {
+		options = append(options, fmt.Sprintf("FINALFUNC = %s", pgQuoteQualifiedName(model.FinalFunc)))
+	}
+	if model.CombineFunc != "" {
+		options = append(options, fmt.Sprintf("COMBINEFUNC = %s", pgQuoteQualifiedName(model.CombineFunc)))
+	}
+	if model.InitCond != nil {
+		options = append(options, fmt.Sprintf("INITCOND = %s", pq.QuoteLiteral(*model.InitCond)))
+	}
+	if model.Parallel != "" {
+		options = append(options, fmt.Sprintf("PARALLEL = %s", model.Parallel))
+	}
+
+	var orReplace string
+	if replace {
+		orReplace = " OR REPLACE"
+	}
+	return fmt.Sprintf("CREATE%s AGGREGATE %s (%s);",
+		orReplace, aggregateName(model.Schema, model.Name, model.Args), strings.Join(options, ", "))
+}
+
+// aggregateUpdateStatements returns the statements turning the current aggregate into the desired one.
+func aggregateUpdateStatements(current, desired AggregateModel) []string {
+	identityArgs := aggregateIdentityArgs(routineArgsDefinition(current.Args))
+	statements := routineRenameStatements(aggregateObjectType, identityArgs, current.Schema, current.Name, desired.Schema, desired.Name)
+	name := routineName(desired.Schema, desired.Name, identityArgs)
+
+	if current.StateFunc != desired.StateFunc || current.FinalFunc != desired.FinalFunc || current.CombineFunc != desired.CombineFunc ||
+		!equalStringPointers(current.InitCond, desired.InitCond) || current.Parallel != desired.Parallel {
+		statements = append(statements, aggregateCreateQuery(desired, true))
+	}
+	if desired.Owner != "" && current.Owner != desired.Owner {
+		statements = append(statements, fmt.Sprintf("ALTER %s %s OWNER TO %s;", aggregateObjectType, name, pq.QuoteIdentifier(desired.Owner)))
+	}
+	if current.Comment != desired.Comment {
+		statements = append(statements, fmt.Sprintf("COMMENT ON %s %s IS %s;", aggregateObjectType, name, pq.QuoteLiteral(desired.Comment)))
+	}
+	return statements
+}
+
+// readAggregate reads the definition of the aggregate whose oid is returned by the SQL expression.
+func readAggregate(ctx context.Context, q pgQueryer, oid string) (*AggregateModel, error) {
+	aggregateQuery := `
+		SELECT n.nspname                                                        as "schema",
+			   p.proname                                                        as "name",
+			   pg_catalog.current_database()                                    as "database",
+			   a.aggtransfn::text                                               as "state_func",
+			   pg_catalog.format_type(a.aggtranstype, NULL)                     as "state_type",
+			   CASE WHEN a.aggfinalfn::oid = 0 THEN '' ELSE a.aggfinalfn::text END as "final_func",
+			   CASE WHEN a.aggcombinefn::oid = 0 THEN '' ELSE a.aggcombinefn::text END as "combine_func",
+			   a.agginitval                                                     as "init_cond",
+			   p.proparallel::text                                              as "parallel",
+			   pg_catalog.pg_get_userbyid(p.proowner)                           as "owner",
+			   COALESCE(pg_catalog.obj_description(p.oid, 'pg_proc'), '')       as "comment"
+		FROM pg_catalog.pg_aggregate a
+				 JOIN pg_catalog.pg_proc p ON p.oid = a.aggfnoid
+				 JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace
+		WHERE a.aggfnoid::oid = %s;`
+
+	var model AggregateModel
+	var initCond sql.NullString
+	row := q.QueryRowContext(ctx, fmt.Sprintf(aggregateQuery, oid))
+	err := row.Scan(
+		&model.Schema,
+		&model.Name,
+		&model.Database,
+		&model.StateFunc,
+		&model.StateType,
+		&model.FinalFunc,
+		&model.CombineFunc,
+		&initCond,
+		&model.Parallel,
+		&model.Owner,
+		&model.Comment,
+	)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opScanRowResult, "model", "aggregate")
+	}
+	if initCond.Valid {
+		model.InitCond = &initCond.String
+	}
+	model.Parallel = AggregateParallelCodes[model.Parallel]
+
+	if model.Args, err = readRoutineArgs(ctx, q, oid); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
+
+func equalStringPointers(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
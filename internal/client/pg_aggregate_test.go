@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func testPrepareAggregateTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_aggregate_db",
+		Username: "test_aggregate_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `
+		CREATE ROLE test_aggregate_owner;
+		CREATE SCHEMA app;
+		CREATE FUNCTION public.test_sum_state(numeric[], numeric) RETURNS numeric[]
+			LANGUAGE sql IMMUTABLE PARALLEL SAFE AS 'SELECT ARRAY[$1[1] + $2, $1[2] + 1]';
+		CREATE FUNCTION public.test_sum_combine(numeric[], numeric[]) RETURNS numeric[]
+			LANGUAGE sql IMMUTABLE PARALLEL SAFE AS 'SELECT ARRAY[$1[1] + $2[1], $1[2] + $2[2]]';
+		CREATE FUNCTION public.test_avg_final(numeric[]) RETURNS numeric
+			LANGUAGE sql IMMUTABLE PARALLEL SAFE AS 'SELECT CASE WHEN $1[2] = 0 THEN NULL ELSE $1[1] / $1[2] END';
+		CREATE FUNCTION public.test_sum_final(numeric[]) RETURNS numeric
+			LANGUAGE sql IMMUTABLE PARALLEL SAFE AS 'SELECT $1[1]';`)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func TestAggregateCreateQuery(t *testing.T) {
+	initCond := "{0,0}"
+	model := AggregateModel{
+		Schema:      "public",
+		Name:        "test_avg",
+		Args:        []RoutineArg{{Type: "numeric"}},
+		StateFunc:   "public.test_sum_state",
+		StateType:   "numeric[]",
+		FinalFunc:   "test_avg_final",
+		CombineFunc: "public.test_sum_combine",
+		InitCond:    &initCond,
+		Parallel:    "SAFE",
+	}
+	assert.Equal(t,
+		`CREATE AGGREGATE "public"."test_avg"(numeric) (SFUNC = "public"."test_sum_state", STYPE = numeric[], FINALFUNC = "test_avg_final", COMBINEFUNC = "public"."test_sum_combine", INITCOND = '{0,0}', PARALLEL = SAFE);`,
+		aggregateCreateQuery(model, false))
+
+	minimal := AggregateModel{Schema: "public", Name: "test_count", StateFunc: "int8inc", StateType: "bigint"}
+	assert.Equal(t,
+		`CREATE OR REPLACE AGGREGATE "public"."test_count"(*) (SFUNC = "int8inc", STYPE = bigint);`,
+		aggregateCreateQuery(minimal, true))
+}
+
+func TestAggregateUpdateStatements(t *testing.T) {
+	current := AggregateModel{Schema: "public", Name: "test_avg", Args: []RoutineArg{{Type: "numeric"}}, StateFunc: "f", StateType: "numeric[]", Parallel: "UNSAFE", Owner: "dba"}
+
+	desired := current
+	desired.Schema, desired.Name, desired.Owner, desired.Comment = "app", "mean", "admin", "average"
+	assert.Equal(t, []string{
+		`ALTER AGGREGATE "public"."test_avg"(numeric) RENAME TO "mean";`,
+		`ALTER AGGREGATE "public"."mean"(numeric) SET SCHEMA "app";`,
+		`ALTER AGGREGATE "app"."mean"(numeric) OWNER TO "admin";`,
+		`COMMENT ON AGGREGATE "app"."mean"(numeric) IS 'average';`,
+	}, aggregateUpdateStatements(current, desired))
+
+	initCond := ""
+	desired = current
+	desired.InitCond = &initCond
+	assert.Equal(t, []string{aggregateCreateQuery(desired, true)}, aggregateUpdateStatements(current, desired))
+
+	assert.Empty(t, aggregateUpdateStatements(current, current))
+}
+
+func TestAggregateSQL_CreateGetAndUpdate(t *testing.T) {
+	ctx, db := testPrepareAggregateTestCase(t)
+	defer db.Close()
+
+	initCond := "{0,0}"
+	repo := NewAggregateRepository(db)
+	require.NoError(t, repo.Create(ctx, AggregateModel{
+		Schema:    "public",
+		Name:      "test_avg",
+		Args:      []RoutineArg{{Name: "value", Type: "numeric"}},
+		StateFunc: "test_sum_state",
+		StateType: "numeric[]",
+		FinalFunc: "test_avg_final",
+		InitCond:  &initCond,
+		Comment:   "test comment",
+	}))
+
+	got, err := repo.Get(ctx, "public", "test_avg", []string{"numeric"})
+	require.NoError(t, err)
+	assert.Equal(t, []RoutineArg{{Name: "value", Type: "numeric", Mode: "IN"}}, got.Args)
+	assert.Equal(t, "test_sum_state", got.StateFunc)
+	assert.Equal(t, "numeric[]", got.StateType)
+	assert.Equal(t, "test_avg_final", got.FinalFunc)
+	assert.Empty(t, got.CombineFunc)
+	assert.Equal(t, &initCond, got.InitCond)
+	assert.Equal(t, "UNSAFE", got.Parallel)
+	assert.Equal(t, "test_aggregate_user", got.Owner)
+	assert.Equal(t, "test comment", got.Comment)
+
+	var avg string
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT round(public.test_avg(v), 2)::text FROM (VALUES (1.0), (2.0), (6.0)) t(v);`).Scan(&avg))
+	assert.Equal(t, "3.00", avg)
+
+	desired := *got
+	desired.Schema = "app"
+	desired.Name = "test_sum"
+	desired.FinalFunc = "test_sum_final"
+	desired.CombineFunc = "test_sum_combine"
+	desired.Parallel = "SAFE"
+	desired.Owner = "test_aggregate_owner"
+	desired.Comment = ""
+	got, err = repo.Update(ctx, AggregateUpdateParams{Current: *got, Desired: desired})
+	require.NoError(t, err)
+	assert.Equal(t, desired, *got)
+
+	exists, err := repo.Exists(ctx, "public", "test_avg", []string{"numeric"})
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, repo.Drop(ctx, "app", "test_sum", []string{"numeric"}))
+	exists, err = repo.Exists(ctx, "app", "test_sum", []string{"numeric"})
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestAggregateSQL_NormalizeAndPreserveSpelling(t *testing.T) {
+	ctx, db := testPrepareAggregateTestCase(t)
+	defer db.Close()
+
+	repo := NewAggregateRepository(db)
+	prior := AggregateModel{
+		Schema:      "public",
+		Name:        "test_total",
+		Args:        []RoutineArg{{Type: "decimal", Mode: "IN"}},
+		StateFunc:   "public.test_sum_state",
+		StateType:   "decimal[]",
+		FinalFunc:   "public.test_sum_final",
+		CombineFunc: "test_sum_combine",
+		Parallel:    "SAFE",
+	}
+	require.NoError(t, repo.Create(ctx, prior))
+
+	normalized, err := repo.Normalize(ctx, prior)
+	require.NoError(t, err)
+	assert.Equal(t, "numeric", normalized.Args[0].Type)
+	assert.Equal(t, "numeric[]", normalized.StateType)
+	assert.Equal(t, "test_sum_state", normalized.StateFunc)
+
+	actual, err := repo.Get(ctx, "public", "test_total", []string{"numeric"})
+	require.NoError(t, err)
+	preserved := PreserveAggregateSpelling(prior, *normalized, *actual)
+	assert.Equal(t, prior.Args, preserved.Args)
+	assert.Equal(t, prior.StateFunc, preserved.StateFunc)
+	assert.Equal(t, prior.StateType, preserved.StateType)
+	assert.Equal(t, prior.FinalFunc, preserved.FinalFunc)
+	assert.Equal(t, prior.CombineFunc, preserved.CombineFunc)
+}
@@ -63,6 +63,7 @@ func (p *pgClientPool) GetConnection(ctx context.Context, targetDb ...string) (P
 	if err != nil {
 		return nil, fmt.Errorf("error create a client connector: %v", err)
 	}
+	p.connPool[connString] = pgConnector
 
 	return pgConnector, nil
 }
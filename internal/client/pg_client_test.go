@@ -70,3 +70,27 @@ func TestPgClientPool_GetConnection(t *testing.T) {
 		})
 	}
 }
+
+func TestPgClientPool_GetConnectionReused(t *testing.T) {
+	targetDb := "test_client_connection_reused"
+	runOpts := test.PostgresContainerRunOptions{Database: targetDb}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	opts := &PgConnectionOpts{}
+	assert.NoError(t, opts.FromConnectionString(connString))
+	pool := NewPgClient(*opts)
+
+	conn, err := pool.GetConnection(ctx, targetDb)
+	assert.NoError(t, err)
+
+	// the connector of a database is opened once, along with its cached server info and repositories
+	again, err := pool.GetConnection(ctx, targetDb)
+	assert.NoError(t, err)
+	assert.Same(t, conn, again)
+
+	other, err := pool.GetConnection(ctx, "postgres")
+	assert.NoError(t, err)
+	assert.NotSame(t, conn, other)
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"slices"
+	"strings"
+)
+
+// The catalog of the command tags is generated from the "Event Trigger Firing Matrix" of the
+// documentation of each supported version, see pg_command_tags_gen.go.
+//go:generate go run ../../tools/commandtags -from 12 -to 17 -output pg_command_tags_gen.go
+
+// EventTriggerCommandTag is a row of the "Event Trigger Firing Matrix" of the PostgreSQL documentation.
+// Every command tag fires the ddl_command_start and ddl_command_end events, only some of them also
+// fire sql_drop and table_rewrite.
+type EventTriggerCommandTag struct {
+	Tag          string
+	SQLDrop      bool
+	TableRewrite bool
+	// MinVersion is the first server_version_num where the command fires event triggers.
+	MinVersion int
+}
+
+// NormalizeCommandTag returns the canonical spelling of a command tag: upper case with single spaces.
+func NormalizeCommandTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToUpper(tag)), " ")
+}
+
+// GetEventTriggerCommandTag returns the catalog entry of a command tag, in any case.
+func GetEventTriggerCommandTag(tag string) (EventTriggerCommandTag, bool) {
+	tag = NormalizeCommandTag(tag)
+	i, found := slices.BinarySearchFunc(eventTriggerCommandTags, tag, func(e EventTriggerCommandTag, t string) int {
+		return strings.Compare(e.Tag, t)
+	})
+	if !found {
+		return EventTriggerCommandTag{}, false
+	}
+	return eventTriggerCommandTags[i], true
+}
+
+// FiresEvent reports whether the command fires the given event on a server running versionNum.
+// A versionNum of 0 skips the version check.
+func (c EventTriggerCommandTag) FiresEvent(event string, versionNum int) bool {
+	if versionNum > 0 && versionNum < c.MinVersion {
+		return false
+	}
+
+	switch event {
+	case "ddl_command_start", "ddl_command_end":
+		return true
+	case "sql_drop":
+		return c.SQLDrop
+	case "table_rewrite":
+		return c.TableRewrite
+	default:
+		return false
+	}
+}
+
+// EventTriggerCommandTags returns every command tag firing the given event on a server running versionNum.
+func EventTriggerCommandTags(event string, versionNum int) []string {
+	var tags []string
+	for _, c := range eventTriggerCommandTags {
+		if c.FiresEvent(event, versionNum) {
+			tags = append(tags, c.Tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"slices"
+	"strings"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestEventTriggerCommandTags_Sorted(t *testing.T) {
+	// GetEventTriggerCommandTag relies on a binary search
+	assert.True(t, slices.IsSortedFunc(eventTriggerCommandTags, func(a, b EventTriggerCommandTag) int {
+		return strings.Compare(a.Tag, b.Tag)
+	}))
+}
+
+func TestNormalizeCommandTag(t *testing.T) {
+	assert.Equal(t, "CREATE TABLE", NormalizeCommandTag("create table"))
+	assert.Equal(t, "CREATE TABLE AS", NormalizeCommandTag("  Create   Table as "))
+	assert.Equal(t, "GRANT", NormalizeCommandTag("GRANT"))
+}
+
+func TestEventTriggerCommandTag_FiresEvent(t *testing.T) {
+	tests := []struct {
+		name       string
+		tag        string
+		event      string
+		versionNum int
+		expected   bool
+	}{
+		{name: "CreateTableOnStart", tag: "create table", event: "ddl_command_start", expected: true},
+		{name: "CreateTableOnSQLDrop", tag: "CREATE TABLE", event: "sql_drop", expected: false},
+		{name: "DropTableOnSQLDrop", tag: "DROP TABLE", event: "sql_drop", expected: true},
+		{name: "AlterTableOnRewrite", tag: "ALTER TABLE", event: "table_rewrite", expected: true},
+		{name: "AlterViewOnRewrite", tag: "ALTER VIEW", event: "table_rewrite", expected: false},
+		{name: "ReindexOnPG16", tag: "REINDEX", event: "ddl_command_end", versionNum: 160004, expected: false},
+		{name: "ReindexOnPG17", tag: "REINDEX", event: "ddl_command_end", versionNum: 170000, expected: true},
+		{name: "AnyTagOnLogin", tag: "CREATE TABLE", event: EventTriggerEventLogin, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commandTag, ok := GetEventTriggerCommandTag(tt.tag)
+			assert.True(t, ok)
+			assert.Equal(t, tt.expected, commandTag.FiresEvent(tt.event, tt.versionNum))
+		})
+	}
+
+	t.Run("UnknownTag", func(t *testing.T) {
+		_, ok := GetEventTriggerCommandTag("CREATE DATABASE")
+		assert.False(t, ok)
+	})
+}
+
+// TestEventTriggerCommandTags_MatchServer checks the generated catalog against each supported
+// server, which refuses the tags an event doesn't fire for. The sql_drop column is not checked, the
+// server accepts the same tags as for ddl_command_start.
+func TestEventTriggerCommandTags_MatchServer(t *testing.T) {
+	for _, image := range test.SupportedPostgresImages {
+		t.Run(image, func(t *testing.T) {
+			runOpts := test.PostgresContainerRunOptions{Image: image, Database: "test_command_tags"}
+			pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+			connString := test.GetPostgresConnectionString(t, pgContainer)
+			ctx := context.TODO()
+
+			db, err := postgres.Open(ctx, connString)
+			require.NoError(t, err)
+			defer db.Close()
+
+			_, err = db.ExecContext(ctx, `CREATE FUNCTION test_command_tags_func() RETURNS event_trigger LANGUAGE plpgsql AS $$BEGIN END;$$;`)
+			require.NoError(t, err)
+
+			versionNum, err := serverVersionNum(ctx, db)
+			require.NoError(t, err)
+
+			for _, event := range []string{"ddl_command_start", "table_rewrite"} {
+				for _, c := range eventTriggerCommandTags {
+					txn, err := db.BeginTx(ctx, nil)
+					require.NoError(t, err)
+					_, err = txn.ExecContext(ctx, fmt.Sprintf(
+						"CREATE EVENT TRIGGER test_command_tags ON %s WHEN TAG IN (%s) EXECUTE FUNCTION test_command_tags_func();",
+						event, pq.QuoteLiteral(c.Tag)))
+					assert.Equal(t, c.FiresEvent(event, versionNum), err == nil, "tag %s on %s: %v", c.Tag, event, err)
+					assert.NoError(t, txn.Rollback())
+				}
+			}
+		})
+	}
+}
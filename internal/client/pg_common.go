@@ -5,8 +5,61 @@ import (
 	"database/sql"
 	"fmt"
 	"github.com/lib/pq"
+	"slices"
+	"strings"
 )
 
+// pg_class.relkind
+const (
+	relKindForeignTable     = "f"
+	relKindMaterializedView = "m"
+	relKindSequence         = "S"
+	relKindView             = "v"
+)
+
+// Firing modes of triggers and event triggers, i.e. the session_replication_role settings they fire with.
+const (
+	FiringModeOrigin   = "origin"
+	FiringModeReplica  = "replica"
+	FiringModeAlways   = "always"
+	FiringModeDisabled = "disabled"
+)
+
+var (
+	FiringModes = []string{FiringModeOrigin, FiringModeReplica, FiringModeAlways, FiringModeDisabled}
+
+	// pg_trigger.tgenabled and pg_event_trigger.evtenabled
+	firingModeCodes = map[string]string{
+		"O": FiringModeOrigin,
+		"R": FiringModeReplica,
+		"A": FiringModeAlways,
+		"D": FiringModeDisabled,
+	}
+	firingModeClauses = map[string]string{
+		FiringModeOrigin:   "ENABLE",
+		FiringModeReplica:  "ENABLE REPLICA",
+		FiringModeAlways:   "ENABLE ALWAYS",
+		FiringModeDisabled: "DISABLE",
+	}
+)
+
+// firingModeFromCode returns the firing mode stored in the catalogs with the given code.
+// The triggers fire in "origin" (and "local") mode unless stated otherwise.
+func firingModeFromCode(code string) string {
+	if mode, ok := firingModeCodes[code]; ok {
+		return mode
+	}
+	return FiringModeOrigin
+}
+
+// firingModeClause returns the ALTER clause setting the given firing mode, e.g. 'ENABLE REPLICA'.
+func firingModeClause(mode string) string {
+	if clause, ok := firingModeClauses[mode]; ok {
+		return clause
+	}
+	return firingModeClauses[FiringModeOrigin]
+}
+
 type pgExecContextFunc func(ctx context.Context, query string, args ...any) (sql.Result, error)
 
 func parseExecContextFunc[T *sql.DB | *sql.Tx](d T) pgExecContextFunc {
@@ -42,3 +95,107 @@ func DropObject[T *sql.DB | *sql.Tx](ctx context.Context, d T, oType, oName stri
 	}
 	return nil
 }
+
+// pgQualifiedName returns the quoted, schema qualified name of an object.
+// The schema is omitted when empty so the object is resolved through the search_path.
+func pgQualifiedName(schema, name string) string {
+	if schema == "" {
+		return pq.QuoteIdentifier(name)
+	}
+	return fmt.Sprintf("%s.%s", pq.QuoteIdentifier(schema), pq.QuoteIdentifier(name))
+}
+
+func pgQuoteListOfIdentifiers(list []string) string {
+	quoted := make([]string, len(list))
+	for i, item := range list {
+		quoted[i] = pq.QuoteIdentifier(item)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// parseRelOptions maps the 'name=value' entries of pg_class.reloptions, nil when there are none.
+func parseRelOptions(relOptions []string) map[string]string {
+	if len(relOptions) == 0 {
+		return nil
+	}
+	options := make(map[string]string, len(relOptions))
+	for _, option := range relOptions {
+		key, value, _ := strings.Cut(option, "=")
+		options[key] = value
+	}
+	return options
+}
+
+// pgOptionsClause returns the OPTIONS clause of the foreign data objects, sorted by option name,
+// or an empty string when there are no options.
+func pgOptionsClause(options map[string]string) string {
+	if len(options) == 0 {
+		return ""
+	}
+	items := make([]string, 0, len(options))
+	for _, key := range sortedOptionNames(options) {
+		items = append(items, fmt.Sprintf("%s %s", pq.QuoteIdentifier(key), pq.QuoteLiteral(options[key])))
+	}
+	return fmt.Sprintf("OPTIONS (%s)", strings.Join(items, ", "))
+}
+
+// pgOptionsAlterClause returns the OPTIONS clause turning the current options into the desired
+// ones, as a list of ADD, SET and DROP actions, or an empty string when they're equal.
+func pgOptionsAlterClause(current, desired map[string]string) string {
+	var actions []string
+	for _, key := range sortedOptionNames(current) {
+		if _, ok := desired[key]; !ok {
+			actions = append(actions, fmt.Sprintf("DROP %s", pq.QuoteIdentifier(key)))
+		}
+	}
+	for _, key := range sortedOptionNames(desired) {
+		value, ok := current[key]
+		switch {
+		case !ok:
+			actions = append(actions, fmt.Sprintf("ADD %s %s", pq.QuoteIdentifier(key), pq.QuoteLiteral(desired[key])))
+		case value != desired[key]:
+			actions = append(actions, fmt.Sprintf("SET %s %s", pq.QuoteIdentifier(key), pq.QuoteLiteral(desired[key])))
+		}
+	}
+	if len(actions) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("OPTIONS (%s)", strings.Join(actions, ", "))
+}
+
+func sortedOptionNames(options map[string]string) []string {
+	names := make([]string, 0, len(options))
+	for name := range options {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// relationRenameStatements returns the statements that rename a relation and move it to another schema.
+func relationRenameStatements(objectType, currentSchema, currentName, schema, name string) []string {
+	var statements []string
+	if currentName != name {
+		statements = append(statements, fmt.Sprintf("ALTER %s %s RENAME TO %s;", objectType, pgQualifiedName(currentSchema, currentName), pq.QuoteIdentifier(name)))
+	}
+	if currentSchema != schema {
+		statements = append(statements, fmt.Sprintf("ALTER %s %s SET SCHEMA %s;", objectType, pgQualifiedName(currentSchema, name), pq.QuoteIdentifier(schema)))
+	}
+	return statements
+}
+
+// relationExists reports whether the relation with the given qualified name and kind exists.
+func relationExists(ctx context.Context, q pgQueryer, qualifiedName, relKind string) (bool, error) {
+	existsQuery := `
+		SELECT EXISTS (SELECT 1
+					   FROM pg_catalog.pg_class
+					   WHERE oid = pg_catalog.to_regclass(%s)
+						 AND relkind = %s);`
+
+	var exists bool
+	row := q.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(qualifiedName), pq.QuoteLiteral(relKind)))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
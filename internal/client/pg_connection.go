@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/url"
 	"strconv"
+	"sync"
 )
 
 const (
@@ -17,8 +18,40 @@ const (
 type pgConnection struct {
 	*sql.DB
 
-	eventTriggerRepository EventTriggerRepository
-	userFunctionRepository UserFunctionRepository
+	eventTriggerRepository        EventTriggerRepository
+	userFunctionRepository        UserFunctionRepository
+	tableRepository               TableRepository
+	indexRepository               IndexRepository
+	viewRepository                ViewRepository
+	matViewRepository             MaterializedViewRepository
+	sequenceRepository            SequenceRepository
+	typeRepository                TypeRepository
+	triggerRepository             TriggerRepository
+	policyRepository              PolicyRepository
+	rlsRepository                 RowLevelSecurityRepository
+	publicationRepository         PublicationRepository
+	subscriptionRepository        SubscriptionRepository
+	replicationSlotRepository     ReplicationSlotRepository
+	foreignServerRepository       ForeignServerRepository
+	userMappingRepository         UserMappingRepository
+	foreignTableRepository        ForeignTableRepository
+	foreignSchemaImportRepository ForeignSchemaImportRepository
+	tablespaceRepository          TablespaceRepository
+	procedureRepository           ProcedureRepository
+	aggregateRepository           AggregateRepository
+	dbRoleSettingRepository       DbRoleSettingRepository
+	systemSettingRepository       SystemSettingRepository
+	settingRepository             SettingRepository
+	queryRepository               QueryRepository
+	scriptRepository              ScriptRepository
+	discoveryRepository           DiscoveryRepository
+
+	// repositoriesLock guards the lazy creation of the repositories, the connector being
+	// shared by the resources that Terraform manages in parallel
+	repositoriesLock sync.Mutex
+
+	serverInfoLock sync.Mutex
+	serverInfo     *ServerInfo
 }
 
 type PgConnectionOpts struct {
@@ -31,6 +64,7 @@ type PgConnectionOpts struct {
 	SSLMode     string `json:"ssl_mode" validate:"required_if=Scheme postgres"`
 	MaxOpenConn int    `json:"max_open_conn" validate:"min=0"`
 	MaxIdleConn int    `json:"max_idle_conn" validate:"min=0"`
+	AssumeRole  string `json:"assume_role"`
 }
 
 type PgConnectionOptsFn func(*PgConnectionOpts) error
@@ -38,6 +72,32 @@ type PgConnectionOptsFn func(*PgConnectionOpts) error
 type PgConnector interface {
 	EventTriggerRepository() EventTriggerRepository
 	UserFunctionRepository() UserFunctionRepository
+	TableRepository() TableRepository
+	IndexRepository() IndexRepository
+	ViewRepository() ViewRepository
+	MaterializedViewRepository() MaterializedViewRepository
+	SequenceRepository() SequenceRepository
+	TypeRepository() TypeRepository
+	TriggerRepository() TriggerRepository
+	PolicyRepository() PolicyRepository
+	RowLevelSecurityRepository() RowLevelSecurityRepository
+	PublicationRepository() PublicationRepository
+	SubscriptionRepository() SubscriptionRepository
+	ReplicationSlotRepository() ReplicationSlotRepository
+	ForeignServerRepository() ForeignServerRepository
+	UserMappingRepository() UserMappingRepository
+	ForeignTableRepository() ForeignTableRepository
+	ForeignSchemaImportRepository() ForeignSchemaImportRepository
+	TablespaceRepository() TablespaceRepository
+	ProcedureRepository() ProcedureRepository
+	AggregateRepository() AggregateRepository
+	DbRoleSettingRepository() DbRoleSettingRepository
+	SystemSettingRepository() SystemSettingRepository
+	SettingRepository() SettingRepository
+	QueryRepository() QueryRepository
+	ScriptRepository() ScriptRepository
+	DiscoveryRepository() DiscoveryRepository
+	ServerInfo(ctx context.Context) (*ServerInfo, error)
 }
 
 var _ PgConnector = &pgConnection{}
@@ -127,6 +187,9 @@ func NewPgConnector(db *sql.DB) (PgConnector, error) {
 }
 
 func (p *pgConnection) EventTriggerRepository() EventTriggerRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
 	if p.eventTriggerRepository == nil {
 		p.eventTriggerRepository = NewEventTriggerRepository(p.DB)
 	}
@@ -134,12 +197,281 @@ func (p *pgConnection) EventTriggerRepository() EventTriggerRepository {
 }
 
 func (p *pgConnection) UserFunctionRepository() UserFunctionRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
 	if p.userFunctionRepository == nil {
 		p.userFunctionRepository = NewUserFunctionRepository(p.DB)
 	}
 	return p.userFunctionRepository
 }
 
+func (p *pgConnection) TableRepository() TableRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.tableRepository == nil {
+		p.tableRepository = NewTableRepository(p.DB)
+	}
+	return p.tableRepository
+}
+
+func (p *pgConnection) IndexRepository() IndexRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.indexRepository == nil {
+		p.indexRepository = NewIndexRepository(p.DB)
+	}
+	return p.indexRepository
+}
+
+func (p *pgConnection) ViewRepository() ViewRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.viewRepository == nil {
+		p.viewRepository = NewViewRepository(p.DB)
+	}
+	return p.viewRepository
+}
+
+func (p *pgConnection) MaterializedViewRepository() MaterializedViewRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.matViewRepository == nil {
+		p.matViewRepository = NewMaterializedViewRepository(p.DB)
+	}
+	return p.matViewRepository
+}
+
+func (p *pgConnection) SequenceRepository() SequenceRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.sequenceRepository == nil {
+		p.sequenceRepository = NewSequenceRepository(p.DB)
+	}
+	return p.sequenceRepository
+}
+
+func (p *pgConnection) TypeRepository() TypeRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.typeRepository == nil {
+		p.typeRepository = NewTypeRepository(p.DB)
+	}
+	return p.typeRepository
+}
+
+func (p *pgConnection) TriggerRepository() TriggerRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.triggerRepository == nil {
+		p.triggerRepository = NewTriggerRepository(p.DB)
+	}
+	return p.triggerRepository
+}
+
+func (p *pgConnection) PolicyRepository() PolicyRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.policyRepository == nil {
+		p.policyRepository = NewPolicyRepository(p.DB)
+	}
+	return p.policyRepository
+}
+
+func (p *pgConnection) RowLevelSecurityRepository() RowLevelSecurityRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.rlsRepository == nil {
+		p.rlsRepository = NewRowLevelSecurityRepository(p.DB)
+	}
+	return p.rlsRepository
+}
+
+func (p *pgConnection) PublicationRepository() PublicationRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.publicationRepository == nil {
+		p.publicationRepository = NewPublicationRepository(p.DB)
+	}
+	return p.publicationRepository
+}
+
+func (p *pgConnection) SubscriptionRepository() SubscriptionRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.subscriptionRepository == nil {
+		p.subscriptionRepository = NewSubscriptionRepository(p.DB)
+	}
+	return p.subscriptionRepository
+}
+
+func (p *pgConnection) ReplicationSlotRepository() ReplicationSlotRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.replicationSlotRepository == nil {
+		p.replicationSlotRepository = NewReplicationSlotRepository(p.DB)
+	}
+	return p.replicationSlotRepository
+}
+
+func (p *pgConnection) ForeignServerRepository() ForeignServerRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.foreignServerRepository == nil {
+		p.foreignServerRepository = NewForeignServerRepository(p.DB)
+	}
+	return p.foreignServerRepository
+}
+
+func (p *pgConnection) UserMappingRepository() UserMappingRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.userMappingRepository == nil {
+		p.userMappingRepository = NewUserMappingRepository(p.DB)
+	}
+	return p.userMappingRepository
+}
+
+func (p *pgConnection) ForeignTableRepository() ForeignTableRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.foreignTableRepository == nil {
+		p.foreignTableRepository = NewForeignTableRepository(p.DB)
+	}
+	return p.foreignTableRepository
+}
+
+func (p *pgConnection) ForeignSchemaImportRepository() ForeignSchemaImportRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.foreignSchemaImportRepository == nil {
+		p.foreignSchemaImportRepository = NewForeignSchemaImportRepository(p.DB)
+	}
+	return p.foreignSchemaImportRepository
+}
+
+func (p *pgConnection) TablespaceRepository() TablespaceRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.tablespaceRepository == nil {
+		p.tablespaceRepository = NewTablespaceRepository(p.DB)
+	}
+	return p.tablespaceRepository
+}
+
+func (p *pgConnection) ProcedureRepository() ProcedureRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.procedureRepository == nil {
+		p.procedureRepository = NewProcedureRepository(p.DB)
+	}
+	return p.procedureRepository
+}
+
+func (p *pgConnection) AggregateRepository() AggregateRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.aggregateRepository == nil {
+		p.aggregateRepository = NewAggregateRepository(p.DB)
+	}
+	return p.aggregateRepository
+}
+
+func (p *pgConnection) DbRoleSettingRepository() DbRoleSettingRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.dbRoleSettingRepository == nil {
+		p.dbRoleSettingRepository = NewDbRoleSettingRepository(p.DB)
+	}
+	return p.dbRoleSettingRepository
+}
+
+func (p *pgConnection) SystemSettingRepository() SystemSettingRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.systemSettingRepository == nil {
+		p.systemSettingRepository = NewSystemSettingRepository(p.DB)
+	}
+	return p.systemSettingRepository
+}
+
+func (p *pgConnection) SettingRepository() SettingRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.settingRepository == nil {
+		p.settingRepository = NewSettingRepository(p.DB)
+	}
+	return p.settingRepository
+}
+
+func (p *pgConnection) QueryRepository() QueryRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.queryRepository == nil {
+		p.queryRepository = NewQueryRepository(p.DB)
+	}
+	return p.queryRepository
+}
+
+func (p *pgConnection) ScriptRepository() ScriptRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.scriptRepository == nil {
+		p.scriptRepository = NewScriptRepository(p.DB)
+	}
+	return p.scriptRepository
+}
+
+func (p *pgConnection) DiscoveryRepository() DiscoveryRepository {
+	p.repositoriesLock.Lock()
+	defer p.repositoriesLock.Unlock()
+
+	if p.discoveryRepository == nil {
+		p.discoveryRepository = NewDiscoveryRepository(p.DB)
+	}
+	return p.discoveryRepository
+}
+
+// ServerInfo returns the version, current user, configuration and capabilities of the server.
+// The information is loaded on the first call and cached for the lifetime of the connection.
+func (p *pgConnection) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	p.serverInfoLock.Lock()
+	defer p.serverInfoLock.Unlock()
+
+	if p.serverInfo == nil {
+		info, err := getServerInfo(ctx, p.DB)
+		if err != nil {
+			return nil, err
+		}
+		p.serverInfo = info
+	}
+	return p.serverInfo, nil
+}
+
 func WithMaxOpenConn(maxOpenConn int) PgConnectionOptsFn {
 	return func(o *PgConnectionOpts) error {
 		o.MaxOpenConn = maxOpenConn
@@ -154,6 +486,13 @@ func WithMaxIdleConn(maxIdleConn int) PgConnectionOptsFn {
 	}
 }
 
+func WithAssumeRole(role string) PgConnectionOptsFn {
+	return func(o *PgConnectionOpts) error {
+		o.AssumeRole = role
+		return nil
+	}
+}
+
 func WithSSLMode(sslMode string) PgConnectionOptsFn {
 	return func(o *PgConnectionOpts) error {
 		o.SSLMode = sslMode
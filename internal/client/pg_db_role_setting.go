@@ -0,0 +1,215 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+type dbRoleSettingSQL struct {
+	db *sql.DB
+}
+
+// DbRoleSettingModel describes the default value of a configuration parameter for a role, a
+// database, or a role in a database, applied when a session starts (see pg_db_role_setting).
+type DbRoleSettingModel struct {
+	// Role is empty for a setting of the database, applied to every role.
+	Role string `json:"role" validate:"required_without=Database"`
+	// Database is empty for a setting of the role, applied in every database.
+	Database string `json:"database" validate:"required_without=Role"`
+	Name     string `json:"name" validate:"required"`
+	Value    string `json:"value"`
+}
+
+type DbRoleSettingUpdateParams struct {
+	Current DbRoleSettingModel
+	Desired DbRoleSettingModel `validate:"required"`
+}
+
+type DbRoleSettingRepository interface {
+	Create(ctx context.Context, params DbRoleSettingModel) error
+	Drop(ctx context.Context, role, database, name string) error
+	Get(ctx context.Context, role, database, name string) (*DbRoleSettingModel, error)
+	Update(ctx context.Context, params DbRoleSettingUpdateParams) (*DbRoleSettingModel, error)
+	Exists(ctx context.Context, role, database, name string) (bool, error)
+	// Definition returns the definition of the parameter, nil when the server doesn't know it.
+	Definition(ctx context.Context, name string) (*SettingDefinition, error)
+}
+
+var _ DbRoleSettingRepository = &dbRoleSettingSQL{}
+
+func NewDbRoleSettingRepository(db *sql.DB) DbRoleSettingRepository {
+	return &dbRoleSettingSQL{
+		db: db,
+	}
+}
+
+func (s *dbRoleSettingSQL) Create(ctx context.Context, params DbRoleSettingModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, s.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateDbRoleSetting, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, dbRoleSettingSetQuery(params))); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateDbRoleSetting)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateDbRoleSetting, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (s *dbRoleSettingSQL) Drop(ctx context.Context, role, database, name string) error {
+	txn, err := BeginTxWithRole(ctx, s.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropDbRoleSetting, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	statement := dbRoleSettingResetQuery(DbRoleSettingModel{Role: role, Database: database, Name: name})
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropDbRoleSetting)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropDbRoleSetting, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (s *dbRoleSettingSQL) Get(ctx context.Context, role, database, name string) (*DbRoleSettingModel, error) {
+	settingQuery := `
+		SELECT substr(c.config, strpos(c.config, '=') + 1) as "value"
+		FROM pg_catalog.pg_db_role_setting s,
+			 pg_catalog.unnest(s.setconfig) c(config)
+		WHERE s.setrole = %s
+		  AND s.setdatabase = %s
+		  AND lower(split_part(c.config, '=', 1)) = lower(%s);`
+
+	model := DbRoleSettingModel{Role: role, Database: database, Name: name}
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf(settingQuery, dbRoleSettingRoleOid(role), dbRoleSettingDatabaseOid(database), pq.QuoteLiteral(name)))
+	if err := row.Scan(&model.Value); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetDbRoleSetting, "pg_cmd", opScanRowResult)
+	}
+	return &model, nil
+}
+
+// Update sets the new value of the parameter. When the parameter, the role or the database
+// changed, the current setting is reset first.
+func (s *dbRoleSettingSQL) Update(ctx context.Context, params DbRoleSettingUpdateParams) (*DbRoleSettingModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, s.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateDbRoleSetting, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range dbRoleSettingUpdateStatements(params.Current, params.Desired) {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateDbRoleSetting)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateDbRoleSetting, "pg_cmd", opCommitTransaction)
+	}
+
+	desired := params.Desired
+	return s.Get(ctx, desired.Role, desired.Database, desired.Name)
+}
+
+func (s *dbRoleSettingSQL) Exists(ctx context.Context, role, database, name string) (bool, error) {
+	existsQuery := `
+		SELECT EXISTS (SELECT 1
+					   FROM pg_catalog.pg_db_role_setting s,
+							pg_catalog.unnest(s.setconfig) c(config)
+					   WHERE s.setrole = %s
+						 AND s.setdatabase = %s
+						 AND lower(split_part(c.config, '=', 1)) = lower(%s));`
+
+	var exists bool
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, dbRoleSettingRoleOid(role), dbRoleSettingDatabaseOid(database), pq.QuoteLiteral(name)))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsDbRoleSetting, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
+
+func (s *dbRoleSettingSQL) Definition(ctx context.Context, name string) (*SettingDefinition, error) {
+	definition, err := readSettingDefinition(ctx, s.db, name)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetSettingDefinition)
+	}
+	return definition, nil
+}
+
+// PreserveSettingSpelling returns the actual value, but keeping the prior one when both have the
+// same normalized form (see SettingDefinition.Normalize), e.g. '1GB' and '1024MB'.
+func PreserveSettingSpelling(definition *SettingDefinition, prior, actual string) string {
+	if definition == nil || prior == actual {
+		return actual
+	}
+	if definition.Normalize(prior) == definition.Normalize(actual) {
+		return prior
+	}
+	return actual
+}
+
+// dbRoleSettingTarget returns the object altered to change the setting, e.g. 'ROLE "app" IN DATABASE "shop"'.
+func dbRoleSettingTarget(model DbRoleSettingModel) string {
+	switch {
+	case model.Role == "":
+		return fmt.Sprintf("DATABASE %s", pq.QuoteIdentifier(model.Database))
+	case model.Database == "":
+		return fmt.Sprintf("ROLE %s", pq.QuoteIdentifier(model.Role))
+	default:
+		return fmt.Sprintf("ROLE %s IN DATABASE %s", pq.QuoteIdentifier(model.Role), pq.QuoteIdentifier(model.Database))
+	}
+}
+
+func dbRoleSettingSetQuery(model DbRoleSettingModel) string {
+	return fmt.Sprintf("ALTER %s SET %s = %s;", dbRoleSettingTarget(model), settingNameClause(model.Name), settingValueClause(model.Name, model.Value))
+}
+
+func dbRoleSettingResetQuery(model DbRoleSettingModel) string {
+	return fmt.Sprintf("ALTER %s RESET %s;", dbRoleSettingTarget(model), settingNameClause(model.Name))
+}
+
+func dbRoleSettingUpdateStatements(current, desired DbRoleSettingModel) []string {
+	var statements []string
+	if current.Role != desired.Role || current.Database != desired.Database || !strings.EqualFold(current.Name, desired.Name) {
+		statements = append(statements, dbRoleSettingResetQuery(current))
+	} else if current.Value == desired.Value {
+		return nil
+	}
+	return append(statements, dbRoleSettingSetQuery(desired))
+}
+
+// dbRoleSettingRoleOid returns the SQL expression of the oid of the role, 0 for every role.
+func dbRoleSettingRoleOid(role string) string {
+	if role == "" {
+		return "0"
+	}
+	return fmt.Sprintf("(SELECT r.oid FROM pg_catalog.pg_roles r WHERE r.rolname = %s)", pq.QuoteLiteral(role))
+}
+
+// dbRoleSettingDatabaseOid returns the SQL expression of the oid of the database, 0 for every database.
+func dbRoleSettingDatabaseOid(database string) string {
+	if database == "" {
+		return "0"
+	}
+	return fmt.Sprintf("(SELECT d.oid FROM pg_catalog.pg_database d WHERE d.datname = %s)", pq.QuoteLiteral(database))
+}
@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func testPrepareDbRoleSettingTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_db_role_setting_db",
+		Username: "test_db_role_setting_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `CREATE ROLE test_app;`)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func TestDbRoleSettingStatements(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    DbRoleSettingModel
+		set      string
+		reset    string
+		expected string
+	}{
+		{
+			name:  "Role",
+			model: DbRoleSettingModel{Role: "app", Name: "work_mem", Value: "64MB"},
+			set:   `ALTER ROLE "app" SET "work_mem" = '64MB';`,
+			reset: `ALTER ROLE "app" RESET "work_mem";`,
+		},
+		{
+			name:  "Database",
+			model: DbRoleSettingModel{Database: "shop", Name: "search_path", Value: `"$user", app`},
+			set:   `ALTER DATABASE "shop" SET "search_path" = '$user', 'app';`,
+			reset: `ALTER DATABASE "shop" RESET "search_path";`,
+		},
+		{
+			name:  "RoleInDatabase",
+			model: DbRoleSettingModel{Role: "app", Database: "shop", Name: "app.tenant", Value: "acme"},
+			set:   `ALTER ROLE "app" IN DATABASE "shop" SET "app"."tenant" = 'acme';`,
+			reset: `ALTER ROLE "app" IN DATABASE "shop" RESET "app"."tenant";`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.set, dbRoleSettingSetQuery(tt.model))
+			assert.Equal(t, tt.reset, dbRoleSettingResetQuery(tt.model))
+		})
+	}
+
+	current := DbRoleSettingModel{Role: "app", Name: "work_mem", Value: "64MB"}
+	desired := current
+	assert.Empty(t, dbRoleSettingUpdateStatements(current, desired))
+
+	desired.Value = "1GB"
+	assert.Equal(t, []string{`ALTER ROLE "app" SET "work_mem" = '1GB';`}, dbRoleSettingUpdateStatements(current, desired))
+
+	desired.Database = "shop"
+	assert.Equal(t, []string{
+		`ALTER ROLE "app" RESET "work_mem";`,
+		`ALTER ROLE "app" IN DATABASE "shop" SET "work_mem" = '1GB';`,
+	}, dbRoleSettingUpdateStatements(current, desired))
+}
+
+func TestPreserveSettingSpelling(t *testing.T) {
+	workMem := &SettingDefinition{Name: "work_mem", Type: SettingTypeInteger, Unit: "kB"}
+
+	assert.Equal(t, "1GB", PreserveSettingSpelling(workMem, "1GB", "1024MB"))
+	assert.Equal(t, "2GB", PreserveSettingSpelling(workMem, "1GB", "2GB"))
+	assert.Equal(t, "1024MB", PreserveSettingSpelling(nil, "1GB", "1024MB"))
+}
+
+func TestDbRoleSettingSQL_CreateGetAndUpdate(t *testing.T) {
+	ctx, db := testPrepareDbRoleSettingTestCase(t)
+	defer db.Close()
+
+	repo := NewDbRoleSettingRepository(db)
+	settings := []DbRoleSettingModel{
+		{Role: "test_app", Name: "work_mem", Value: "64MB"},
+		{Database: "test_db_role_setting_db", Name: "search_path", Value: `"$user", public`},
+		{Role: "test_app", Database: "test_db_role_setting_db", Name: "statement_timeout", Value: "30s"},
+	}
+	for _, setting := range settings {
+		require.NoError(t, repo.Create(ctx, setting))
+
+		got, err := repo.Get(ctx, setting.Role, setting.Database, setting.Name)
+		assert.NoError(t, err)
+		assert.Equal(t, setting, *got)
+	}
+
+	// the settings don't overlap: the role setting is not the one of the role in the database
+	exists, err := repo.Exists(ctx, "test_app", "", "statement_timeout")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	desired := settings[0]
+	desired.Value = "1GB"
+	got, err := repo.Update(ctx, DbRoleSettingUpdateParams{Current: settings[0], Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, desired, *got)
+
+	for _, setting := range settings {
+		require.NoError(t, repo.Drop(ctx, setting.Role, setting.Database, setting.Name))
+
+		exists, err = repo.Exists(ctx, setting.Role, setting.Database, setting.Name)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	}
+}
+
+func TestDbRoleSettingSQL_Definition(t *testing.T) {
+	ctx, db := testPrepareDbRoleSettingTestCase(t)
+	defer db.Close()
+
+	repo := NewDbRoleSettingRepository(db)
+	definition, err := repo.Definition(ctx, "work_mem")
+	require.NoError(t, err)
+	assert.Equal(t, SettingTypeInteger, definition.Type)
+	assert.Equal(t, "kB", definition.Unit)
+	assert.Equal(t, "user", definition.Context)
+	assert.Equal(t, 64.0, *definition.MinValue)
+
+	definition, err = repo.Definition(ctx, "client_min_messages")
+	require.NoError(t, err)
+	assert.Contains(t, definition.EnumValues, "warning")
+
+	definition, err = repo.Definition(ctx, "app.tenant")
+	assert.NoError(t, err)
+	assert.Nil(t, definition)
+}
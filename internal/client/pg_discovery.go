@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+)
+
+// the system schemas, shared by the lists of schemas and of tables.
+const discoverySystemSchemaCondition = `%[1]s ~ '^pg_' OR %[1]s = 'information_schema'`
+
+type discoverySQL struct {
+	db *sql.DB
+}
+
+// ListedDatabase is a database of the server, as listed by DiscoveryRepository.ListDatabases.
+type ListedDatabase struct {
+	Name             string `json:"name"`
+	Owner            string `json:"owner"`
+	Encoding         string `json:"encoding"`
+	Collate          string `json:"collate"`
+	Ctype            string `json:"ctype"`
+	IsTemplate       bool   `json:"is_template"`
+	AllowConnections bool   `json:"allow_connections"`
+	ConnectionLimit  int64  `json:"connection_limit"`
+	// Size is the disk space of the database in bytes, nil without the CONNECT privilege on it.
+	Size    *int64 `json:"size"`
+	Comment string `json:"comment"`
+}
+
+// ListedSchema is a schema of the database, as listed by DiscoveryRepository.ListSchemas.
+type ListedSchema struct {
+	Name    string `json:"name"`
+	Owner   string `json:"owner"`
+	Comment string `json:"comment"`
+}
+
+// ListedRole is a role of the server, as listed by DiscoveryRepository.ListRoles.
+type ListedRole struct {
+	Name            string `json:"name"`
+	Superuser       bool   `json:"superuser"`
+	Inherit         bool   `json:"inherit"`
+	CreateRole      bool   `json:"create_role"`
+	CreateDatabase  bool   `json:"create_database"`
+	Login           bool   `json:"login"`
+	Replication     bool   `json:"replication"`
+	BypassRls       bool   `json:"bypass_rls"`
+	ConnectionLimit int64  `json:"connection_limit"`
+	// ValidUntil is the expiration of the password, empty when it never expires.
+	ValidUntil string `json:"valid_until"`
+	// MemberOf are the roles the role is a direct member of.
+	MemberOf []string `json:"member_of"`
+	Comment  string   `json:"comment"`
+}
+
+// ListedTable is a table of the database, as listed by DiscoveryRepository.ListTables.
+type ListedTable struct {
+	Schema      string `json:"schema"`
+	Name        string `json:"name"`
+	Owner       string `json:"owner"`
+	Partitioned bool   `json:"partitioned"`
+	// RowEstimate is the number of rows estimated by the planner, -1 when never analyzed (0 before PostgreSQL 14).
+	RowEstimate int64  `json:"row_estimate"`
+	Comment     string `json:"comment"`
+}
+
+// DiscoveryRepository lists the existing objects, selected with a ListFilter.
+type DiscoveryRepository interface {
+	ListDatabases(ctx context.Context, filter ListFilter) ([]ListedDatabase, error)
+	ListSchemas(ctx context.Context, filter ListFilter) ([]ListedSchema, error)
+	ListRoles(ctx context.Context, filter ListFilter) ([]ListedRole, error)
+	ListTables(ctx context.Context, filter ListFilter) ([]ListedTable, error)
+}
+
+var _ DiscoveryRepository = &discoverySQL{}
+
+func NewDiscoveryRepository(db *sql.DB) DiscoveryRepository {
+	return &discoverySQL{
+		db: db,
+	}
+}
+
+func (d *discoverySQL) ListDatabases(ctx context.Context, filter ListFilter) ([]ListedDatabase, error) {
+	databasesQuery := `
+		SELECT d.datname                                                 as "name",
+			   pg_catalog.pg_get_userbyid(d.datdba)                      as "owner",
+			   pg_catalog.pg_encoding_to_char(d.encoding)                as "encoding",
+			   d.datcollate                                              as "collate",
+			   d.datctype                                                as "ctype",
+			   d.datistemplate                                           as "is_template",
+			   d.datallowconn                                            as "allow_connections",
+			   d.datconnlimit                                            as "connection_limit",
+			   CASE
+				   WHEN pg_catalog.has_database_privilege(d.oid, 'CONNECT')
+					   THEN pg_catalog.pg_database_size(d.oid) END        as "size",
+			   COALESCE(pg_catalog.shobj_description(d.oid, 'pg_database'), '') as "comment"
+		FROM pg_catalog.pg_database d
+		WHERE %s
+		ORDER BY d.datname;`
+
+	spec := listSpec{nameColumn: "d.datname", systemCondition: "d.datistemplate"}
+	databases, err := listRows(ctx, d.db, databasesQuery, spec, filter, func(rows *sql.Rows) (ListedDatabase, error) {
+		var database ListedDatabase
+		var size sql.NullInt64
+		err := rows.Scan(&database.Name, &database.Owner, &database.Encoding, &database.Collate, &database.Ctype,
+			&database.IsTemplate, &database.AllowConnections, &database.ConnectionLimit, &size, &database.Comment)
+		if size.Valid {
+			database.Size = &size.Int64
+		}
+		return database, err
+	})
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opListDatabases)
+	}
+	return databases, nil
+}
+
+func (d *discoverySQL) ListSchemas(ctx context.Context, filter ListFilter) ([]ListedSchema, error) {
+	schemasQuery := `
+		SELECT n.nspname                                                  as "name",
+			   pg_catalog.pg_get_userbyid(n.nspowner)                     as "owner",
+			   COALESCE(pg_catalog.obj_description(n.oid, 'pg_namespace'), '') as "comment"
+		FROM pg_catalog.pg_namespace n
+		WHERE %s
+		ORDER BY n.nspname;`
+
+	spec := listSpec{nameColumn: "n.nspname", systemCondition: discoverySystemCondition("n.nspname")}
+	schemas, err := listRows(ctx, d.db, schemasQuery, spec, filter, func(rows *sql.Rows) (ListedSchema, error) {
+		var schema ListedSchema
+		err := rows.Scan(&schema.Name, &schema.Owner, &schema.Comment)
+		return schema, err
+	})
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opListSchemas)
+	}
+	return schemas, nil
+}
+
+func (d *discoverySQL) ListRoles(ctx context.Context, filter ListFilter) ([]ListedRole, error) {
+	rolesQuery := `
+		SELECT r.rolname                                   as "name",
+			   r.rolsuper                                  as "superuser",
+			   r.rolinherit                                as "inherit",
+			   r.rolcreaterole                             as "create_role",
+			   r.rolcreatedb                               as "create_database",
+			   r.rolcanlogin                               as "login",
+			   r.rolreplication                            as "replication",
+			   r.rolbypassrls                              as "bypass_rls",
+			   r.rolconnlimit                              as "connection_limit",
+			   COALESCE(r.rolvaliduntil::text, '')         as "valid_until",
+			   ARRAY(SELECT g.rolname
+					 FROM pg_catalog.pg_auth_members m
+							  JOIN pg_catalog.pg_roles g ON g.oid = m.roleid
+					 WHERE m.member = r.oid
+					 ORDER BY g.rolname)                   as "member_of",
+			   COALESCE(pg_catalog.shobj_description(r.oid, 'pg_authid'), '') as "comment"
+		FROM pg_catalog.pg_roles r
+		WHERE %s
+		ORDER BY r.rolname;`
+
+	// the predefined roles, e.g. pg_read_all_data
+	spec := listSpec{nameColumn: "r.rolname", systemCondition: "r.rolname ~ '^pg_'"}
+	roles, err := listRows(ctx, d.db, rolesQuery, spec, filter, func(rows *sql.Rows) (ListedRole, error) {
+		var role ListedRole
+		err := rows.Scan(&role.Name, &role.Superuser, &role.Inherit, &role.CreateRole, &role.CreateDatabase,
+			&role.Login, &role.Replication, &role.BypassRls, &role.ConnectionLimit, &role.ValidUntil,
+			(*pq.StringArray)(&role.MemberOf), &role.Comment)
+		return role, err
+	})
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opListRoles)
+	}
+	return roles, nil
+}
+
+func (d *discoverySQL) ListTables(ctx context.Context, filter ListFilter) ([]ListedTable, error) {
+	tablesQuery := `
+		SELECT n.nspname                                                  as "schema",
+			   c.relname                                                  as "name",
+			   pg_catalog.pg_get_userbyid(c.relowner)                     as "owner",
+			   c.relkind = 'p'                                            as "partitioned",
+			   c.reltuples::bigint                                        as "row_estimate",
+			   COALESCE(pg_catalog.obj_description(c.oid, 'pg_class'), '') as "comment"
+		FROM pg_catalog.pg_class c
+				 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relkind IN ('r', 'p')
+		  AND NOT c.relispartition
+		  AND %s
+		ORDER BY n.nspname, c.relname;`
+
+	spec := listSpec{nameColumn: "c.relname", schemaColumn: "n.nspname", systemCondition: discoverySystemCondition("n.nspname")}
+	tables, err := listRows(ctx, d.db, tablesQuery, spec, filter, func(rows *sql.Rows) (ListedTable, error) {
+		var table ListedTable
+		err := rows.Scan(&table.Schema, &table.Name, &table.Owner, &table.Partitioned, &table.RowEstimate, &table.Comment)
+		return table, err
+	})
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opListTables)
+	}
+	return tables, nil
+}
+
+// discoverySystemCondition returns the condition matching the system schemas, e.g. pg_catalog or pg_toast.
+func discoverySystemCondition(schemaColumn string) string {
+	return fmt.Sprintf(discoverySystemSchemaCondition, schemaColumn)
+}
@@ -0,0 +1,113 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func testPrepareDiscoveryTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_discovery_db",
+		Username: "test_discovery_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `
+		CREATE ROLE test_discovery_readers;
+		CREATE ROLE test_discovery_app LOGIN CONNECTION LIMIT 5 IN ROLE test_discovery_readers;
+		COMMENT ON ROLE test_discovery_app IS 'application';
+		CREATE SCHEMA test_discovery_app AUTHORIZATION test_discovery_app;
+		CREATE TABLE test_discovery_app.orders (id int) PARTITION BY RANGE (id);
+		CREATE TABLE test_discovery_app.orders_1 PARTITION OF test_discovery_app.orders FOR VALUES FROM (0) TO (10);
+		CREATE TABLE test_discovery_app.customers (id int);
+		CREATE TABLE public.test_discovery_orders (id int);`)
+	require.NoError(t, err)
+	return ctx, db
+}
+
+func TestDiscoverySQL_ListDatabasesAndRoles(t *testing.T) {
+	ctx, db := testPrepareDiscoveryTestCase(t)
+	defer db.Close()
+
+	repo := NewDiscoveryRepository(db)
+	databases, err := repo.ListDatabases(ctx, ListFilter{NameRegex: "^test_discovery"})
+	require.NoError(t, err)
+	require.Len(t, databases, 1)
+	assert.Equal(t, "test_discovery_db", databases[0].Name)
+	assert.Equal(t, "test_discovery_user", databases[0].Owner)
+	assert.Equal(t, "UTF8", databases[0].Encoding)
+	assert.True(t, databases[0].AllowConnections)
+	require.NotNil(t, databases[0].Size)
+	assert.Greater(t, *databases[0].Size, int64(0))
+
+	databases, err = repo.ListDatabases(ctx, ListFilter{NameRegex: "^template"})
+	require.NoError(t, err)
+	assert.Empty(t, databases)
+
+	databases, err = repo.ListDatabases(ctx, ListFilter{NameRegex: "^template", IncludeSystem: true})
+	require.NoError(t, err)
+	assert.Len(t, databases, 2)
+
+	roles, err := repo.ListRoles(ctx, ListFilter{NameRegex: "^test_discovery"})
+	require.NoError(t, err)
+	require.Len(t, roles, 2)
+	assert.Equal(t, ListedRole{
+		Name:            "test_discovery_app",
+		Inherit:         true,
+		Login:           true,
+		ConnectionLimit: 5,
+		MemberOf:        []string{"test_discovery_readers"},
+		Comment:         "application",
+	}, roles[0])
+	assert.Empty(t, roles[1].MemberOf)
+
+	roles, err = repo.ListRoles(ctx, ListFilter{NameRegex: "^pg_monitor$", IncludeSystem: true})
+	require.NoError(t, err)
+	assert.Len(t, roles, 1)
+}
+
+func TestDiscoverySQL_ListSchemasAndTables(t *testing.T) {
+	ctx, db := testPrepareDiscoveryTestCase(t)
+	defer db.Close()
+
+	repo := NewDiscoveryRepository(db)
+	schemas, err := repo.ListSchemas(ctx, ListFilter{})
+	require.NoError(t, err)
+	require.Len(t, schemas, 2)
+	assert.Equal(t, "public", schemas[0].Name)
+	assert.Equal(t, ListedSchema{Name: "test_discovery_app", Owner: "test_discovery_app"}, schemas[1])
+
+	schemas, err = repo.ListSchemas(ctx, ListFilter{NameRegex: "^pg_catalog$", IncludeSystem: true})
+	require.NoError(t, err)
+	assert.Len(t, schemas, 1)
+
+	// the partitions are left out, the partitioned table stands for them
+	tables, err := repo.ListTables(ctx, ListFilter{SchemaRegex: "^test_discovery_app$"})
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+	assert.Equal(t, "customers", tables[0].Name)
+	assert.False(t, tables[0].Partitioned)
+	assert.Equal(t, "orders", tables[1].Name)
+	assert.True(t, tables[1].Partitioned)
+	assert.Equal(t, "test_discovery_app", tables[1].Owner)
+
+	tables, err = repo.ListTables(ctx, ListFilter{NameRegex: "orders"})
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+	assert.Equal(t, "public", tables[0].Schema)
+	assert.Equal(t, "test_discovery_app", tables[1].Schema)
+
+	tables, err = repo.ListTables(ctx, ListFilter{NameRegex: "^pg_class$", IncludeSystem: true})
+	require.NoError(t, err)
+	assert.Len(t, tables, 1)
+}
@@ -3,15 +3,21 @@ package client
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/lib/pq"
-	"strings"
 )
 
-const eventTriggerObject = "EVENT TRIGGER"
+const (
+	eventTriggerObject     = "EVENT TRIGGER"
+	EventTriggerEventLogin = "login"
+)
+
+var errEventTriggerLoginTags = errors.New("event triggers on the 'login' event do not support tags")
 
 type eventTriggerSQL struct {
-	db *sql.DB
+	db        *sql.DB
+	functions UserFunctionRepository
 }
 
 type EventTriggerModel struct {
@@ -28,7 +34,9 @@ type EventTriggerModel struct {
 type EventTriggerRepository interface {
 	Create(ctx context.Context, params EventTriggerCreateParams) error
 	Drop(ctx context.Context, name string) error
+	DropWithFunction(ctx context.Context, name, functionSchema, functionName string) error
 	Get(ctx context.Context, name string) (*EventTriggerModel, error)
+	GetFunction(ctx context.Context, name string) (*UserFunctionModel, error)
 	Update(ctx context.Context, params EventTriggerUpdateParams) (*EventTriggerModel, error)
 	Exists(ctx context.Context, name string) (bool, error)
 	Scan(row *sql.Row) (*EventTriggerModel, error)
@@ -36,24 +44,33 @@ type EventTriggerRepository interface {
 
 type EventTriggerCreateParams struct {
 	Name     string   `validate:"required"`
-	Event    string   `validate:"required,oneof=ddl_command_start ddl_command_end sql_drop table_rewrite"`
+	Event    string   `validate:"required,oneof=ddl_command_start ddl_command_end sql_drop table_rewrite login"`
 	ExecFunc string   `validate:"required"`
 	Enabled  bool     `validate:"boolean"`
 	Tags     []string `validate:"unique"`
 	Comment  string
+	// Owner is only applied with an explicit ALTER when it differs from the role that creates the trigger.
+	Owner string
+	// Function is created or replaced before the trigger in the same transaction, the trigger executes it.
+	Function *UserFunctionCreateParams
 }
 
+// EventTriggerUpdateParams holds the changes to apply to an existing event trigger.
+// Only the non-nil fields are altered.
 type EventTriggerUpdateParams struct {
-	Name    string  `validate:"required"`
-	NewName *string `validate:"required_without_all=Enabled Owner"`
-	Enabled *bool   `validate:"required_without_all=NewName Owner Comment"`
-	Owner   *string `validate:"required_without_all=NewName Enabled Comment"`
-	Comment *string `validate:"required_without_all=NewName Enabled Owner"`
+	Name    string `validate:"required"`
+	NewName *string
+	Enabled *bool
+	Owner   *string
+	Comment *string
+	// Function replaces the function executed by the trigger, in the same transaction.
+	Function *UserFunctionCreateParams
 }
 
 func NewEventTriggerRepository(db *sql.DB) EventTriggerRepository {
 	return &eventTriggerSQL{
-		db: db,
+		db:        db,
+		functions: NewUserFunctionRepository(db),
 	}
 }
 
@@ -62,16 +79,31 @@ func (e *eventTriggerSQL) Create(ctx context.Context, params EventTriggerCreateP
 	if err := validate.Struct(params); err != nil {
 		return PgErrWithMetadata(err, "operation", opStructValidation)
 	}
+	if params.Event == EventTriggerEventLogin && len(params.Tags) > 0 {
+		return PgErrWithMetadata(errEventTriggerLoginTags, "operation", opStructValidation)
+	}
 
-	txn, err := e.db.BeginTx(ctx, nil)
+	txn, err := BeginTxWithRole(ctx, e.db)
 	if err != nil {
 		return PgErrWithMetadata(err, "operation", opCreateEventTrigger, "pg_cmd", opStartTransaction)
 	}
 	defer DeferredRollback(txn)
 
+	execFunc := params.ExecFunc
+	if params.Function != nil {
+		if err = e.functions.CreateInTx(ctx, txn, *params.Function); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateEventTrigger)
+		}
+		execFunc = userFunctionName(params.Function.Schema, params.Function.Name)
+	}
+
 	whenClause := ""
 	if len(params.Tags) > 0 {
-		whenClause = fmt.Sprintf("WHEN TAG IN (%s)", pgQuoteListOfLiterals(params.Tags))
+		tags := make([]string, len(params.Tags))
+		for i, tag := range params.Tags {
+			tags[i] = NormalizeCommandTag(tag)
+		}
+		whenClause = fmt.Sprintf("WHEN TAG IN (%s)", pgQuoteListOfLiterals(tags))
 	}
 
 	createQuery := `
@@ -80,7 +112,7 @@ func (e *eventTriggerSQL) Create(ctx context.Context, params EventTriggerCreateP
 			%s
 		EXECUTE FUNCTION %s();`
 
-	err = WithQueryExecHandler(txn.ExecContext(ctx, fmt.Sprintf(createQuery, params.Name, params.Event, whenClause, params.ExecFunc)))
+	err = WithQueryExecHandler(txn.ExecContext(ctx, fmt.Sprintf(createQuery, params.Name, params.Event, whenClause, execFunc)))
 	if err != nil {
 		return PgErrWithMetadata(err, "operation", opCreateEventTrigger)
 	}
@@ -90,6 +122,20 @@ func (e *eventTriggerSQL) Create(ctx context.Context, params EventTriggerCreateP
 		return PgErrWithMetadata(err, "operation", opCreateEventTrigger)
 	}
 
+	var alterClauses []string
+	if !params.Enabled {
+		alterClauses = append(alterClauses, firingModeClause(FiringModeDisabled))
+	}
+	if params.Owner != "" && params.Owner != GetAssumeRoleFromCtx(ctx) {
+		alterClauses = append(alterClauses, fmt.Sprintf("OWNER TO %s", pq.QuoteIdentifier(params.Owner)))
+	}
+	for _, clause := range alterClauses {
+		err = WithQueryExecHandler(txn.ExecContext(ctx, fmt.Sprintf(`ALTER EVENT TRIGGER %s %s;`, params.Name, clause)))
+		if err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateEventTrigger)
+		}
+	}
+
 	if err = txn.Commit(); err != nil {
 		return PgErrWithMetadata(err, "operation", opCreateEventTrigger, "pg_cmd", opCommitTransaction)
 	}
@@ -97,7 +143,18 @@ func (e *eventTriggerSQL) Create(ctx context.Context, params EventTriggerCreateP
 }
 
 func (e *eventTriggerSQL) Drop(ctx context.Context, name string) error {
-	txn, err := e.db.BeginTx(ctx, nil)
+	return e.drop(ctx, name, nil)
+}
+
+// DropWithFunction drops the trigger and then the function it executes, in the same transaction.
+func (e *eventTriggerSQL) DropWithFunction(ctx context.Context, name, functionSchema, functionName string) error {
+	return e.drop(ctx, name, func(txn *sql.Tx) error {
+		return e.functions.DropInTx(ctx, txn, functionSchema, functionName)
+	})
+}
+
+func (e *eventTriggerSQL) drop(ctx context.Context, name string, dropFunction func(txn *sql.Tx) error) error {
+	txn, err := BeginTxWithRole(ctx, e.db)
 	if err != nil {
 		return PgErrWithMetadata(err, "operation", opDropEventTrigger, "pg_cmd", opStartTransaction)
 	}
@@ -108,8 +165,14 @@ func (e *eventTriggerSQL) Drop(ctx context.Context, name string) error {
 		return PgErrWithMetadata(err, "operation", opDropEventTrigger)
 	}
 
+	if dropFunction != nil {
+		if err = dropFunction(txn); err != nil {
+			return PgErrWithMetadata(err, "operation", opDropEventTrigger)
+		}
+	}
+
 	if err = txn.Commit(); err != nil {
-		return PgErrWithMetadata(err, "operation", opCreateEventTrigger, "pg_cmd", opCommitTransaction)
+		return PgErrWithMetadata(err, "operation", opDropEventTrigger, "pg_cmd", opCommitTransaction)
 	}
 	return nil
 }
@@ -137,29 +200,57 @@ func (e *eventTriggerSQL) Get(ctx context.Context, name string) (*EventTriggerMo
 	return model, nil
 }
 
+// GetFunction returns the function executed by the trigger.
+func (e *eventTriggerSQL) GetFunction(ctx context.Context, name string) (*UserFunctionModel, error) {
+	functionQuery := `
+		SELECT n.nspname as "schema",
+			   p.proname as "name"
+		FROM pg_catalog.pg_event_trigger e
+				 JOIN pg_catalog.pg_proc p ON p.oid = e.evtfoid
+				 JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace
+		WHERE e.evtname = %s;`
+
+	var schema, functionName string
+	row := e.db.QueryRowContext(ctx, fmt.Sprintf(functionQuery, pq.QuoteLiteral(name)))
+	if err := row.Scan(&schema, &functionName); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetEventTriggerFunction, "pg_cmd", opQueryRow)
+	}
+
+	function, err := e.functions.Get(ctx, pgQualifiedName(schema, functionName))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetEventTriggerFunction)
+	}
+	return function, nil
+}
+
 func (e *eventTriggerSQL) Update(ctx context.Context, params EventTriggerUpdateParams) (*EventTriggerModel, error) {
 	validate := GetValidatorFromCtx(ctx)
 	if err := validate.Struct(params); err != nil {
 		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
 	}
 
-	txn, err := e.db.BeginTx(ctx, nil)
+	txn, err := BeginTxWithRole(ctx, e.db)
 	if err != nil {
 		return nil, PgErrWithMetadata(err, "operation", opUpdateEventTrigger, "pg_cmd", opStartTransaction)
 	}
 	defer DeferredRollback(txn)
 
+	if params.Function != nil {
+		if err = e.functions.CreateInTx(ctx, txn, *params.Function); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateEventTrigger)
+		}
+	}
+
+	// ALTER EVENT TRIGGER accepts a single action per statement,
+	// the rename goes last so the previous actions can still use the current name.
 	var operations []string
 
-	if params.NewName != nil {
-		operations = append(operations, fmt.Sprintf("RENAME TO %s", pq.QuoteIdentifier(*params.NewName)))
-	}
 	if params.Enabled != nil {
-		enabledText := "ALWAYS"
+		mode := FiringModeAlways
 		if !*params.Enabled {
-			enabledText = "DISABLE"
+			mode = FiringModeDisabled
 		}
-		operations = append(operations, fmt.Sprintf("ENABLE %s", enabledText))
+		operations = append(operations, firingModeClause(mode))
 	}
 	if params.Owner != nil {
 		operations = append(operations, fmt.Sprintf("OWNER TO %s", pq.QuoteIdentifier(*params.Owner)))
@@ -167,25 +258,35 @@ func (e *eventTriggerSQL) Update(ctx context.Context, params EventTriggerUpdateP
 
 	updateQuery := `ALTER EVENT TRIGGER %s %s;`
 
-	if (len(operations)) == 0 {
-		err = WithQueryExecHandler(txn.ExecContext(ctx, fmt.Sprintf(updateQuery, *params.NewName, strings.Join(operations, " "))))
+	for _, operation := range operations {
+		err = WithQueryExecHandler(txn.ExecContext(ctx, fmt.Sprintf(updateQuery, params.Name, operation)))
 		if err != nil {
 			return nil, PgErrWithMetadata(err, "operation", opUpdateEventTrigger)
 		}
 	}
 
 	if params.Comment != nil {
-		err = CreateComment(ctx, txn, "EVENT TRIGGER", params.Name, *params.Comment)
+		err = CreateComment(ctx, txn, eventTriggerObject, params.Name, *params.Comment)
+		if err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateEventTrigger)
+		}
+	}
+
+	name := params.Name
+	if params.NewName != nil && *params.NewName != params.Name {
+		renameClause := fmt.Sprintf("RENAME TO %s", pq.QuoteIdentifier(*params.NewName))
+		err = WithQueryExecHandler(txn.ExecContext(ctx, fmt.Sprintf(updateQuery, params.Name, renameClause)))
 		if err != nil {
 			return nil, PgErrWithMetadata(err, "operation", opUpdateEventTrigger)
 		}
+		name = *params.NewName
 	}
 
 	if err = txn.Commit(); err != nil {
-		return nil, PgErrWithMetadata(err, "operation", opCreateEventTrigger, "pg_cmd", opCommitTransaction)
+		return nil, PgErrWithMetadata(err, "operation", opUpdateEventTrigger, "pg_cmd", opCommitTransaction)
 	}
 
-	return e.Get(ctx, params.Name)
+	return e.Get(ctx, name)
 }
 
 func (e *eventTriggerSQL) Exists(ctx context.Context, name string) (bool, error) {
@@ -229,11 +330,7 @@ func (e *eventTriggerSQL) Scan(row *sql.Row) (*EventTriggerModel, error) {
 	eventTrigger.Comment = comment.String
 
 	// evtenabled: Controls in which session_replication_role modes the event trigger fires.
-	// O = trigger fires in “origin” and “local” modes
-	// D = trigger is disabled
-	// R = trigger fires in “replica” mode
-	// A = trigger fires always.
-	eventTrigger.Enabled = enabledRaw != "D"
+	eventTrigger.Enabled = firingModeFromCode(enabledRaw) != FiringModeDisabled
 
 	return &eventTrigger, nil
 }
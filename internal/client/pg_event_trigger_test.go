@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"gocloud.dev/postgres"
 	"terraform-provider-postgresql/internal/test"
@@ -77,6 +78,17 @@ func TestEventTriggerSQL_Create(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "SuccessLowerCaseTags",
+			createParams: func(t *testing.T) EventTriggerCreateParams {
+				validParams := mockEventTriggerCreateParams(t)
+				validParams.Name = "test_trigger_lower_case_tags"
+				validParams.ExecFunc = mockUserFunctionCreateParams(t).Name
+				validParams.Tags = []string{"create table", "drop  table"}
+				return validParams
+			},
+			wantErr: false,
+		},
 		{
 			name:         "FailEventTriggerExists",
 			createParams: mockEventTriggerCreateParams,
@@ -186,3 +198,135 @@ func TestEventTriggerSQL_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestEventTriggerSQL_CreateWithAssumeRole(t *testing.T) {
+	ctx, db := testPrepareEventTriggerTestCase(t)
+	defer db.Close()
+
+	userFunctionRepo := NewUserFunctionRepository(db)
+	eventTriggerRepo := NewEventTriggerRepository(db)
+
+	// event triggers can only be owned by superusers
+	ownerRole := "test_event_trigger_owner"
+	_, err := db.ExecContext(ctx, "CREATE ROLE "+ownerRole+" SUPERUSER;")
+	assert.NoError(t, err)
+
+	userFuncCreateParams := mockUserFunctionCreateParamsForEventTrigger(t)
+	assert.NoError(t, userFunctionRepo.Create(ctx, userFuncCreateParams))
+
+	testMatrix := []struct {
+		name          string
+		role          string
+		owner         string
+		expectedOwner string
+		wantErr       bool
+	}{
+		{
+			name:          "SuccessOwnedByAssumedRole",
+			role:          ownerRole,
+			expectedOwner: ownerRole,
+		},
+		{
+			name:          "SuccessExplicitOwner",
+			role:          ownerRole,
+			owner:         testEventTriggerUser,
+			expectedOwner: testEventTriggerUser,
+		},
+		{
+			name:    "FailRoleNotFound",
+			role:    "test_missing_role",
+			wantErr: true,
+		},
+	}
+
+	for i, tt := range testMatrix {
+		t.Run(tt.name, func(t *testing.T) {
+			params := mockEventTriggerCreateParams(t)
+			params.Name = fmt.Sprintf("test_trigger_assume_role_%d", i)
+			params.ExecFunc = userFuncCreateParams.Name
+			params.Owner = tt.owner
+
+			roleCtx := ContextWithAssumeRole(ctx, tt.role)
+			err := eventTriggerRepo.Create(roleCtx, params)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			m, err := eventTriggerRepo.Get(ctx, params.Name)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOwner, m.Owner)
+		})
+	}
+}
+
+func TestEventTriggerSQL_CreateLoginWithTags(t *testing.T) {
+	params := mockEventTriggerCreateParams(t)
+	params.Event = EventTriggerEventLogin
+
+	// validation fails before any connection is used
+	err := NewEventTriggerRepository(nil).Create(context.Background(), params)
+	assert.ErrorIs(t, err, errEventTriggerLoginTags)
+}
+
+func TestEventTriggerSQL_BundledFunction(t *testing.T) {
+	ctx, db := testPrepareEventTriggerTestCase(t)
+	defer db.Close()
+
+	eventTriggerRepo := NewEventTriggerRepository(db)
+	userFunctionRepo := NewUserFunctionRepository(db)
+
+	_, err := db.ExecContext(ctx, "CREATE SCHEMA audit;")
+	assert.NoError(t, err)
+
+	function := mockUserFunctionCreateParamsForEventTrigger(t)
+	function.Name = "test_bundled_func"
+	function.Schema = "audit"
+	function.SecurityDefiner = true
+
+	params := mockEventTriggerCreateParams(t)
+	params.Name = "test_trigger_bundled_function"
+	params.ExecFunc = function.Name
+	params.Function = &function
+
+	// the function is created with the trigger
+	assert.NoError(t, eventTriggerRepo.Create(ctx, params))
+
+	m, err := eventTriggerRepo.GetFunction(ctx, params.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, "audit", m.Schema)
+	assert.Equal(t, function.Name, m.Name)
+	assert.Equal(t, function.Body, m.Body)
+	assert.True(t, m.SecurityDefiner)
+
+	// the function is replaced in place
+	function.Body = "BEGIN RAISE NOTICE 'replaced'; END;"
+	function.SecurityDefiner = false
+	_, err = eventTriggerRepo.Update(ctx, EventTriggerUpdateParams{Name: params.Name, Function: &function})
+	assert.NoError(t, err)
+
+	m, err = eventTriggerRepo.GetFunction(ctx, params.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, function.Body, m.Body)
+	assert.False(t, m.SecurityDefiner)
+
+	// a failing trigger rolls back the function
+	failing := mockUserFunctionCreateParamsForEventTrigger(t)
+	failing.Name = "test_bundled_func_rollback"
+	failingParams := params
+	failingParams.ExecFunc = failing.Name
+	failingParams.Function = &failing
+	assert.Error(t, eventTriggerRepo.Create(ctx, failingParams))
+
+	exists, err := userFunctionRepo.Exists(ctx, failing.Name)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// the function is dropped with the trigger
+	assert.NoError(t, eventTriggerRepo.DropWithFunction(ctx, params.Name, "audit", function.Name))
+
+	exists, err = userFunctionRepo.Exists(ctx, "audit."+function.Name)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
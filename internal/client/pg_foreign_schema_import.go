@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"slices"
+	"strings"
+)
+
+type foreignSchemaImportSQL struct {
+	db *sql.DB
+}
+
+// ForeignSchemaImportModel describes an IMPORT FOREIGN SCHEMA, the creation of the foreign tables
+// matching the tables of a remote schema. It's a one-off operation: later changes of the remote
+// schema are not followed.
+type ForeignSchemaImportModel struct {
+	Database     string `json:"database"`
+	Server       string `json:"server" validate:"required"`
+	RemoteSchema string `json:"remote_schema" validate:"required"`
+	LocalSchema  string `json:"local_schema" validate:"required"`
+	// LimitTo and Except restrict the remote tables imported, only one of them can be given.
+	LimitTo []string `json:"limit_to" validate:"unique"`
+	Except  []string `json:"except" validate:"unique,excluded_with=LimitTo"`
+	// Options are the wrapper specific options of the import, e.g. import_default for postgres_fdw.
+	Options map[string]string `json:"options"`
+	// Tables are the foreign tables created by the import, sorted by name.
+	Tables []string `json:"tables"`
+}
+
+type ForeignSchemaImportRepository interface {
+	Import(ctx context.Context, params ForeignSchemaImportModel) (*ForeignSchemaImportModel, error)
+	Drop(ctx context.Context, localSchema string, tables []string) error
+	Get(ctx context.Context, params ForeignSchemaImportModel) (*ForeignSchemaImportModel, error)
+}
+
+var _ ForeignSchemaImportRepository = &foreignSchemaImportSQL{}
+
+func NewForeignSchemaImportRepository(db *sql.DB) ForeignSchemaImportRepository {
+	return &foreignSchemaImportSQL{
+		db: db,
+	}
+}
+
+// Import imports the remote schema and returns the model with the foreign tables it created, the
+// ones of the server in the local schema that didn't exist before.
+func (f *foreignSchemaImportSQL) Import(ctx context.Context, params ForeignSchemaImportModel) (*ForeignSchemaImportModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, f.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opImportForeignSchema, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	existing, err := listForeignTables(ctx, txn, params.Server, params.LocalSchema)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opImportForeignSchema)
+	}
+
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, foreignSchemaImportQuery(params))); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opImportForeignSchema)
+	}
+
+	imported, err := listForeignTables(ctx, txn, params.Server, params.LocalSchema)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opImportForeignSchema)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opImportForeignSchema, "pg_cmd", opCommitTransaction)
+	}
+
+	model := params
+	model.Tables = slices.DeleteFunc(imported, func(table string) bool { return slices.Contains(existing, table) })
+	return &model, nil
+}
+
+// Drop drops the given foreign tables of the local schema, the ones already gone are skipped.
+func (f *foreignSchemaImportSQL) Drop(ctx context.Context, localSchema string, tables []string) error {
+	if len(tables) == 0 {
+		return nil
+	}
+
+	txn, err := BeginTxWithRole(ctx, f.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropImportedForeignTables, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	names := make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = pgQualifiedName(localSchema, table)
+	}
+	err = DropObject(ctx, txn, foreignTableObjectType, strings.Join(names, ", "))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropImportedForeignTables)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropImportedForeignTables, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+// Get returns the model with the imported tables that still exist.
+func (f *foreignSchemaImportSQL) Get(ctx context.Context, params ForeignSchemaImportModel) (*ForeignSchemaImportModel, error) {
+	existing, err := listForeignTables(ctx, f.db, params.Server, params.LocalSchema)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetImportedForeignTables)
+	}
+
+	model := params
+	model.Tables = make([]string, 0, len(params.Tables))
+	for _, table := range params.Tables {
+		if slices.Contains(existing, table) {
+			model.Tables = append(model.Tables, table)
+		}
+	}
+	return &model, nil
+}
+
+func foreignSchemaImportQuery(model ForeignSchemaImportModel) string {
+	query := fmt.Sprintf("IMPORT FOREIGN SCHEMA %s", pq.QuoteIdentifier(model.RemoteSchema))
+	switch {
+	case len(model.LimitTo) > 0:
+		query += fmt.Sprintf(" LIMIT TO (%s)", pgQuoteListOfIdentifiers(model.LimitTo))
+	case len(model.Except) > 0:
+		query += fmt.Sprintf(" EXCEPT (%s)", pgQuoteListOfIdentifiers(model.Except))
+	}
+	query += fmt.Sprintf(" FROM SERVER %s INTO %s", pq.QuoteIdentifier(model.Server), pq.QuoteIdentifier(model.LocalSchema))
+	if options := pgOptionsClause(model.Options); options != "" {
+		query += " " + options
+	}
+	return query + ";"
+}
+
+// listForeignTables returns the names of the foreign tables of the server in the schema, sorted.
+func listForeignTables(ctx context.Context, q pgQueryer, server, schema string) ([]string, error) {
+	listQuery := `
+		SELECT c.relname
+		FROM pg_catalog.pg_foreign_table ft
+				 JOIN pg_catalog.pg_class c ON c.oid = ft.ftrelid
+				 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+				 JOIN pg_catalog.pg_foreign_server s ON s.oid = ft.ftserver
+		WHERE s.srvname = %s
+		  AND n.nspname = %s
+		ORDER BY c.relname;`
+
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(listQuery, pq.QuoteLiteral(server), pq.QuoteLiteral(schema)))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery)
+	}
+	defer rows.Close()
+
+	tables := make([]string, 0)
+	for rows.Next() {
+		var table string
+		if err = rows.Scan(&table); err != nil {
+			return nil, PgErrWithMetadata(err, "pg_cmd", opScanRowResult)
+		}
+		tables = append(tables, table)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery)
+	}
+	return tables, nil
+}
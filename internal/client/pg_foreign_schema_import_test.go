@@ -0,0 +1,56 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestForeignSchemaImportQuery(t *testing.T) {
+	model := ForeignSchemaImportModel{Server: "remote", RemoteSchema: "remote_app", LocalSchema: "local_app"}
+	assert.Equal(t,
+		`IMPORT FOREIGN SCHEMA "remote_app" FROM SERVER "remote" INTO "local_app";`,
+		foreignSchemaImportQuery(model),
+	)
+
+	model.LimitTo = []string{"orders"}
+	model.Options = map[string]string{"import_default": "true"}
+	assert.Equal(t,
+		`IMPORT FOREIGN SCHEMA "remote_app" LIMIT TO ("orders") FROM SERVER "remote" INTO "local_app" OPTIONS ("import_default" 'true');`,
+		foreignSchemaImportQuery(model),
+	)
+
+	model.LimitTo, model.Options = nil, nil
+	model.Except = []string{"items"}
+	assert.Equal(t,
+		`IMPORT FOREIGN SCHEMA "remote_app" EXCEPT ("items") FROM SERVER "remote" INTO "local_app";`,
+		foreignSchemaImportQuery(model),
+	)
+}
+
+func TestForeignSchemaImportSQL_ImportGetAndDrop(t *testing.T) {
+	ctx, db := testPrepareForeignServerTestCase(t)
+	defer db.Close()
+	testCreateRemoteServer(t, ctx, db)
+
+	// a foreign table of the server that already exists is not part of the import
+	_, err := db.ExecContext(ctx, `CREATE FOREIGN TABLE local_app.manual (id int) SERVER remote;`)
+	require.NoError(t, err)
+
+	repo := NewForeignSchemaImportRepository(db)
+	got, err := repo.Import(ctx, ForeignSchemaImportModel{Server: "remote", RemoteSchema: "remote_app", LocalSchema: "local_app"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"items", "orders"}, got.Tables)
+
+	_, err = db.ExecContext(ctx, `DROP FOREIGN TABLE local_app.items;`)
+	require.NoError(t, err)
+
+	got, err = repo.Get(ctx, *got)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"orders"}, got.Tables)
+
+	assert.NoError(t, repo.Drop(ctx, "local_app", []string{"items", "orders"}))
+	got, err = repo.Get(ctx, *got)
+	assert.NoError(t, err)
+	assert.Empty(t, got.Tables)
+}
@@ -0,0 +1,225 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+const foreignServerObjectType = "SERVER"
+
+type foreignServerSQL struct {
+	db *sql.DB
+}
+
+// ForeignServerModel describes a foreign server, the connection details of a remote data source
+// accessed through a foreign data wrapper like postgres_fdw.
+type ForeignServerModel struct {
+	Name     string `json:"name" validate:"required"`
+	Database string `json:"database"`
+	// Wrapper is the foreign data wrapper of the server, e.g. postgres_fdw.
+	Wrapper string `json:"wrapper" validate:"required"`
+	Type    string `json:"type"`
+	Version string `json:"version"`
+	// Options are the wrapper specific options of the server, e.g. host, port and dbname for postgres_fdw.
+	Options map[string]string `json:"options"`
+	Owner   string            `json:"owner"`
+	Comment string            `json:"comment"`
+}
+
+type ForeignServerUpdateParams struct {
+	Current ForeignServerModel
+	Desired ForeignServerModel `validate:"required"`
+}
+
+type ForeignServerRepository interface {
+	Create(ctx context.Context, params ForeignServerModel) error
+	Drop(ctx context.Context, name string) error
+	Get(ctx context.Context, name string) (*ForeignServerModel, error)
+	Update(ctx context.Context, params ForeignServerUpdateParams) (*ForeignServerModel, error)
+	Exists(ctx context.Context, name string) (bool, error)
+}
+
+var _ ForeignServerRepository = &foreignServerSQL{}
+
+func NewForeignServerRepository(db *sql.DB) ForeignServerRepository {
+	return &foreignServerSQL{
+		db: db,
+	}
+}
+
+func (f *foreignServerSQL) Create(ctx context.Context, params ForeignServerModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, f.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateForeignServer, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	statements := []string{foreignServerCreateQuery(params)}
+	if params.Owner != "" && params.Owner != GetAssumeRoleFromCtx(ctx) {
+		statements = append(statements, fmt.Sprintf("ALTER SERVER %s OWNER TO %s;", pq.QuoteIdentifier(params.Name), pq.QuoteIdentifier(params.Owner)))
+	}
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateForeignServer)
+		}
+	}
+
+	if params.Comment != "" {
+		if err = CreateComment(ctx, txn, foreignServerObjectType, pq.QuoteIdentifier(params.Name), params.Comment); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateForeignServer)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateForeignServer, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+// Drop drops the server. It fails while user mappings or foreign tables still depend on it.
+func (f *foreignServerSQL) Drop(ctx context.Context, name string) error {
+	txn, err := BeginTxWithRole(ctx, f.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropForeignServer, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	err = DropObject(ctx, txn, foreignServerObjectType, pq.QuoteIdentifier(name))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropForeignServer)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropForeignServer, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (f *foreignServerSQL) Get(ctx context.Context, name string) (*ForeignServerModel, error) {
+	readQuery := `
+		SELECT s.srvname                                                               as "name",
+			   pg_catalog.current_database()                                           as "database",
+			   w.fdwname                                                               as "wrapper",
+			   COALESCE(s.srvtype, '')                                                 as "type",
+			   COALESCE(s.srvversion, '')                                              as "version",
+			   COALESCE(s.srvoptions, '{}')                                            as "options",
+			   pg_catalog.pg_get_userbyid(s.srvowner)                                  as "owner",
+			   COALESCE(pg_catalog.obj_description(s.oid, 'pg_foreign_server'), '')    as "comment"
+		FROM pg_catalog.pg_foreign_server s
+				 JOIN pg_catalog.pg_foreign_data_wrapper w ON w.oid = s.srvfdw
+		WHERE s.srvname = %s;`
+
+	var model ForeignServerModel
+	var options []string
+	row := f.db.QueryRowContext(ctx, fmt.Sprintf(readQuery, pq.QuoteLiteral(name)))
+	err := row.Scan(
+		&model.Name,
+		&model.Database,
+		&model.Wrapper,
+		&model.Type,
+		&model.Version,
+		(*pq.StringArray)(&options),
+		&model.Owner,
+		&model.Comment,
+	)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetForeignServer, "pg_cmd", opScanRowResult)
+	}
+
+	model.Options = parseRelOptions(options)
+	return &model, nil
+}
+
+// Update applies the changes with ALTER SERVER, the options are added, set and dropped one by one.
+// The wrapper and the type of a server can't be changed.
+func (f *foreignServerSQL) Update(ctx context.Context, params ForeignServerUpdateParams) (*ForeignServerModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, f.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateForeignServer, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range foreignServerUpdateStatements(params.Current, params.Desired) {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateForeignServer)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateForeignServer, "pg_cmd", opCommitTransaction)
+	}
+
+	return f.Get(ctx, params.Desired.Name)
+}
+
+func (f *foreignServerSQL) Exists(ctx context.Context, name string) (bool, error) {
+	existsQuery := `SELECT EXISTS (SELECT 1 FROM pg_catalog.pg_foreign_server s WHERE s.srvname = %s);`
+
+	var exists bool
+	row := f.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(name)))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsForeignServer, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
+
+func foreignServerCreateQuery(model ForeignServerModel) string {
+	query := fmt.Sprintf("CREATE SERVER %s", pq.QuoteIdentifier(model.Name))
+	if model.Type != "" {
+		query += fmt.Sprintf(" TYPE %s", pq.QuoteLiteral(model.Type))
+	}
+	if model.Version != "" {
+		query += fmt.Sprintf(" VERSION %s", pq.QuoteLiteral(model.Version))
+	}
+	query += fmt.Sprintf(" FOREIGN DATA WRAPPER %s", pq.QuoteIdentifier(model.Wrapper))
+	if options := pgOptionsClause(model.Options); options != "" {
+		query += " " + options
+	}
+	return query + ";"
+}
+
+// foreignServerUpdateStatements returns the statements turning the current server into the desired one.
+func foreignServerUpdateStatements(current, desired ForeignServerModel) []string {
+	name := pq.QuoteIdentifier(desired.Name)
+
+	var statements []string
+	if current.Name != desired.Name {
+		statements = append(statements, fmt.Sprintf("ALTER SERVER %s RENAME TO %s;", pq.QuoteIdentifier(current.Name), name))
+	}
+
+	var changes []string
+	switch {
+	case current.Version == desired.Version:
+	case desired.Version == "":
+		changes = append(changes, "VERSION NULL")
+	default:
+		changes = append(changes, fmt.Sprintf("VERSION %s", pq.QuoteLiteral(desired.Version)))
+	}
+	if options := pgOptionsAlterClause(current.Options, desired.Options); options != "" {
+		changes = append(changes, options)
+	}
+	if len(changes) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER SERVER %s %s;", name, strings.Join(changes, " ")))
+	}
+
+	if desired.Owner != "" && current.Owner != desired.Owner {
+		statements = append(statements, fmt.Sprintf("ALTER SERVER %s OWNER TO %s;", name, pq.QuoteIdentifier(desired.Owner)))
+	}
+	if current.Comment != desired.Comment {
+		statements = append(statements, fmt.Sprintf("COMMENT ON SERVER %s IS %s;", name, pq.QuoteLiteral(desired.Comment)))
+	}
+	return statements
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+const (
+	testForeignServerDb = "test_foreign_server_db"
+	testRemoteDb        = "test_remote_db"
+	testRemoteUser      = "test_remote_user"
+	testRemotePassword  = "test_remote_password"
+)
+
+// testPrepareForeignServerTestCase starts a remote server holding the remote_app schema, and a local
+// one with postgres_fdw sharing its network, where the remote is reachable as 'remote'.
+func testPrepareForeignServerTestCase(t *testing.T) (context.Context, *sql.DB) {
+	nw := test.NewTestNetwork(t)
+	remoteOpts := test.PostgresContainerRunOptions{
+		Database:     testRemoteDb,
+		Username:     testRemoteUser,
+		Password:     testRemotePassword,
+		Network:      nw,
+		NetworkAlias: "remote",
+	}
+	localOpts := test.PostgresContainerRunOptions{
+		Database: testForeignServerDb,
+		Username: "test_foreign_server_user",
+		Network:  nw,
+	}
+	ctx := context.TODO()
+
+	remote, err := postgres.Open(ctx, test.GetPostgresConnectionString(t, test.LoadPostgresTestContainer(t, remoteOpts, false)))
+	require.NoError(t, err)
+	defer remote.Close()
+
+	_, err = remote.ExecContext(ctx, `
+		CREATE SCHEMA remote_app;
+		CREATE TABLE remote_app.orders (id int PRIMARY KEY, status text NOT NULL DEFAULT 'new');
+		CREATE TABLE remote_app.items (id int PRIMARY KEY, order_id int, name text);
+		INSERT INTO remote_app.orders VALUES (1, 'paid');`)
+	require.NoError(t, err)
+
+	db, err := postgres.Open(ctx, test.GetPostgresConnectionString(t, test.LoadPostgresTestContainer(t, localOpts, false)))
+	require.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `
+		CREATE EXTENSION postgres_fdw;
+		CREATE SCHEMA local_app;
+		CREATE ROLE test_foreign_reader;`)
+	require.NoError(t, err)
+	return ctx, db
+}
+
+// testCreateRemoteServer creates the 'remote' server and the mapping of the current user to the remote one.
+func testCreateRemoteServer(t *testing.T, ctx context.Context, db *sql.DB) {
+	t.Helper()
+	require.NoError(t, NewForeignServerRepository(db).Create(ctx, mockForeignServerModel(t)))
+	require.NoError(t, NewUserMappingRepository(db).Create(ctx, UserMappingModel{
+		Server:  "remote",
+		User:    "test_foreign_server_user",
+		Options: map[string]string{"user": testRemoteUser, "password": testRemotePassword},
+	}))
+}
+
+func mockForeignServerModel(t *testing.T) ForeignServerModel {
+	t.Helper()
+	return ForeignServerModel{
+		Name:    "remote",
+		Wrapper: "postgres_fdw",
+		Version: "16",
+		Options: map[string]string{"host": "remote", "port": "5432", "dbname": testRemoteDb},
+		Comment: "test comment",
+	}
+}
+
+func TestPgOptionsAlterClause(t *testing.T) {
+	tests := []struct {
+		name     string
+		current  map[string]string
+		desired  map[string]string
+		expected string
+	}{
+		{name: "Equal", current: map[string]string{"a": "1"}, desired: map[string]string{"a": "1"}, expected: ""},
+		{name: "BothEmpty", expected: ""},
+		{
+			name:     "AddSetDrop",
+			current:  map[string]string{"host": "a", "port": "5432", "fetch_size": "100"},
+			desired:  map[string]string{"host": "b", "port": "5432", "dbname": "app"},
+			expected: `OPTIONS (DROP "fetch_size", ADD "dbname" 'app', SET "host" 'b')`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, pgOptionsAlterClause(tt.current, tt.desired))
+		})
+	}
+}
+
+func TestForeignServerCreateQuery(t *testing.T) {
+	assert.Equal(t,
+		`CREATE SERVER "remote" VERSION '16' FOREIGN DATA WRAPPER "postgres_fdw" OPTIONS ("dbname" 'test_remote_db', "host" 'remote', "port" '5432');`,
+		foreignServerCreateQuery(mockForeignServerModel(t)),
+	)
+	assert.Equal(t,
+		`CREATE SERVER "files" TYPE 'csv' FOREIGN DATA WRAPPER "file_fdw";`,
+		foreignServerCreateQuery(ForeignServerModel{Name: "files", Wrapper: "file_fdw", Type: "csv"}),
+	)
+}
+
+func TestForeignServerUpdateStatements(t *testing.T) {
+	current := mockForeignServerModel(t)
+	desired := current
+	desired.Name = "remote_renamed"
+	desired.Version = ""
+	desired.Options = map[string]string{"host": "other", "dbname": testRemoteDb}
+	desired.Owner = "test_foreign_reader"
+	desired.Comment = ""
+
+	assert.Equal(t, []string{
+		`ALTER SERVER "remote" RENAME TO "remote_renamed";`,
+		`ALTER SERVER "remote_renamed" VERSION NULL OPTIONS (DROP "port", SET "host" 'other');`,
+		`ALTER SERVER "remote_renamed" OWNER TO "test_foreign_reader";`,
+		`COMMENT ON SERVER "remote_renamed" IS '';`,
+	}, foreignServerUpdateStatements(current, desired))
+
+	assert.Empty(t, foreignServerUpdateStatements(current, current))
+}
+
+func TestForeignServerSQL_CreateGetAndUpdate(t *testing.T) {
+	ctx, db := testPrepareForeignServerTestCase(t)
+	defer db.Close()
+
+	repo := NewForeignServerRepository(db)
+	model := mockForeignServerModel(t)
+	require.NoError(t, repo.Create(ctx, model))
+
+	got, err := repo.Get(ctx, "remote")
+	assert.NoError(t, err)
+	assert.Equal(t, testForeignServerDb, got.Database)
+	assert.Equal(t, "postgres_fdw", got.Wrapper)
+	assert.Equal(t, "16", got.Version)
+	assert.Equal(t, model.Options, got.Options)
+	assert.Equal(t, "test comment", got.Comment)
+
+	desired := *got
+	desired.Name = "remote_renamed"
+	desired.Version = ""
+	desired.Options = map[string]string{"host": "remote", "dbname": testRemoteDb, "fetch_size": "500"}
+	desired.Owner = "test_foreign_reader"
+	got, err = repo.Update(ctx, ForeignServerUpdateParams{Current: *got, Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, "remote_renamed", got.Name)
+	assert.Empty(t, got.Version)
+	assert.Equal(t, desired.Options, got.Options)
+	assert.Equal(t, "test_foreign_reader", got.Owner)
+
+	assert.NoError(t, repo.Drop(ctx, "remote_renamed"))
+	exists, err := repo.Exists(ctx, "remote_renamed")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
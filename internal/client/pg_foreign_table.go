@@ -0,0 +1,323 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+const foreignTableObjectType = "FOREIGN TABLE"
+
+type foreignTableSQL struct {
+	db *sql.DB
+}
+
+type ForeignTableColumn struct {
+	Name     string `json:"name" validate:"required"`
+	Type     string `json:"type" validate:"required"`
+	Nullable bool   `json:"nullable"`
+	// Options are the wrapper specific options of the column, e.g. column_name for postgres_fdw.
+	Options map[string]string `json:"options"`
+}
+
+// ForeignTableModel describes a foreign table, a table whose rows are stored on a foreign server.
+type ForeignTableModel struct {
+	Schema   string               `json:"schema" validate:"required"`
+	Name     string               `json:"name" validate:"required"`
+	Database string               `json:"database"`
+	Server   string               `json:"server" validate:"required"`
+	Columns  []ForeignTableColumn `json:"columns" validate:"dive"`
+	// Options are the wrapper specific options of the table, e.g. schema_name and table_name for postgres_fdw.
+	Options map[string]string `json:"options"`
+	Comment string            `json:"comment"`
+}
+
+type ForeignTableUpdateParams struct {
+	Current ForeignTableModel
+	Desired ForeignTableModel `validate:"required"`
+}
+
+type ForeignTableRepository interface {
+	Create(ctx context.Context, params ForeignTableModel) error
+	Drop(ctx context.Context, schema, name string) error
+	Get(ctx context.Context, schema, name string) (*ForeignTableModel, error)
+	Update(ctx context.Context, params ForeignTableUpdateParams) (*ForeignTableModel, error)
+	Exists(ctx context.Context, schema, name string) (bool, error)
+	Normalize(ctx context.Context, model ForeignTableModel) (*ForeignTableModel, error)
+}
+
+var _ ForeignTableRepository = &foreignTableSQL{}
+
+func NewForeignTableRepository(db *sql.DB) ForeignTableRepository {
+	return &foreignTableSQL{
+		db: db,
+	}
+}
+
+func (f *foreignTableSQL) Create(ctx context.Context, params ForeignTableModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, f.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateForeignTable, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, foreignTableCreateQuery(params))); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateForeignTable)
+	}
+
+	if params.Comment != "" {
+		if err = CreateComment(ctx, txn, foreignTableObjectType, pgQualifiedName(params.Schema, params.Name), params.Comment); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateForeignTable)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateForeignTable, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (f *foreignTableSQL) Drop(ctx context.Context, schema, name string) error {
+	txn, err := BeginTxWithRole(ctx, f.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropForeignTable, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	err = DropObject(ctx, txn, foreignTableObjectType, pgQualifiedName(schema, name))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropForeignTable)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropForeignTable, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (f *foreignTableSQL) Get(ctx context.Context, schema, name string) (*ForeignTableModel, error) {
+	relation := fmt.Sprintf("pg_catalog.to_regclass(%s)", pq.QuoteLiteral(pgQualifiedName(schema, name)))
+	model, err := readForeignTable(ctx, f.db, relation)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetForeignTable)
+	}
+	return model, nil
+}
+
+// Update applies the changes with a single ALTER FOREIGN TABLE. Columns are matched by name, the
+// options are added, set and dropped one by one. The server of a foreign table can't be changed.
+func (f *foreignTableSQL) Update(ctx context.Context, params ForeignTableUpdateParams) (*ForeignTableModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, f.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateForeignTable, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range foreignTableUpdateStatements(params.Current, params.Desired) {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateForeignTable)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateForeignTable, "pg_cmd", opCommitTransaction)
+	}
+
+	return f.Get(ctx, params.Desired.Schema, params.Desired.Name)
+}
+
+func (f *foreignTableSQL) Exists(ctx context.Context, schema, name string) (bool, error) {
+	exists, err := relationExists(ctx, f.db, pgQualifiedName(schema, name), relKindForeignTable)
+	if err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsForeignTable)
+	}
+	return exists, nil
+}
+
+// Normalize returns the definition as PostgreSQL would store it, with the types through format_type.
+// Foreign tables can't be temporary: the definition is created under another name inside a
+// transaction that is always rolled back. The foreign server is never contacted.
+func (f *foreignTableSQL) Normalize(ctx context.Context, model ForeignTableModel) (*ForeignTableModel, error) {
+	txn, err := f.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeForeignTable, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	temporary := model
+	temporary.Name = "tf_normalize_" + model.Name
+
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, foreignTableCreateQuery(temporary))); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeForeignTable)
+	}
+
+	relation := fmt.Sprintf("pg_catalog.to_regclass(%s)", pq.QuoteLiteral(pgQualifiedName(temporary.Schema, temporary.Name)))
+	normalized, err := readForeignTable(ctx, txn, relation)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeForeignTable)
+	}
+
+	normalized.Name = model.Name
+	normalized.Database = model.Database
+	normalized.Comment = model.Comment
+	return normalized, nil
+}
+
+// PreserveForeignTableSpelling returns the actual foreign table, but keeping the order of the prior
+// columns and the spelling of the column types whose normalized form (see ForeignTableRepository.Normalize)
+// matches the actual one.
+func PreserveForeignTableSpelling(prior, normalizedPrior, actual ForeignTableModel) ForeignTableModel {
+	result := actual
+	result.Columns = orderLike(actual.Columns, prior.Columns, func(c ForeignTableColumn) string { return c.Name })
+
+	for i, column := range result.Columns {
+		priorIdx := indexByName(prior.Columns, column.Name, func(c ForeignTableColumn) string { return c.Name })
+		normIdx := indexByName(normalizedPrior.Columns, column.Name, func(c ForeignTableColumn) string { return c.Name })
+		if priorIdx >= 0 && normIdx >= 0 && normalizedPrior.Columns[normIdx].Type == column.Type {
+			result.Columns[i].Type = prior.Columns[priorIdx].Type
+		}
+	}
+	return result
+}
+
+func foreignTableColumnDefinition(column ForeignTableColumn) string {
+	definition := fmt.Sprintf("%s %s", pq.QuoteIdentifier(column.Name), column.Type)
+	if options := pgOptionsClause(column.Options); options != "" {
+		definition += " " + options
+	}
+	if !column.Nullable {
+		definition += " NOT NULL"
+	}
+	return definition
+}
+
+func foreignTableCreateQuery(model ForeignTableModel) string {
+	columns := make([]string, len(model.Columns))
+	for i, column := range model.Columns {
+		columns[i] = foreignTableColumnDefinition(column)
+	}
+
+	query := fmt.Sprintf("CREATE FOREIGN TABLE %s (%s) SERVER %s",
+		pgQualifiedName(model.Schema, model.Name), strings.Join(columns, ", "), pq.QuoteIdentifier(model.Server))
+	if options := pgOptionsClause(model.Options); options != "" {
+		query += " " + options
+	}
+	return query + ";"
+}
+
+// foreignTableUpdateStatements returns the statements turning the current foreign table into the
+// desired one: the rename, a single ALTER FOREIGN TABLE for the columns and the options, and the comment.
+func foreignTableUpdateStatements(current, desired ForeignTableModel) []string {
+	statements := relationRenameStatements(foreignTableObjectType, current.Schema, current.Name, desired.Schema, desired.Name)
+	columnName := func(c ForeignTableColumn) string { return c.Name }
+
+	var actions []string
+	for _, column := range current.Columns {
+		if indexByName(desired.Columns, column.Name, columnName) < 0 {
+			actions = append(actions, fmt.Sprintf("DROP COLUMN %s", pq.QuoteIdentifier(column.Name)))
+		}
+	}
+	for _, column := range desired.Columns {
+		idx := indexByName(current.Columns, column.Name, columnName)
+		if idx < 0 {
+			actions = append(actions, fmt.Sprintf("ADD COLUMN %s", foreignTableColumnDefinition(column)))
+			continue
+		}
+
+		currentColumn, name := current.Columns[idx], pq.QuoteIdentifier(column.Name)
+		if currentColumn.Type != column.Type {
+			actions = append(actions, fmt.Sprintf("ALTER COLUMN %s TYPE %s", name, column.Type))
+		}
+		if currentColumn.Nullable != column.Nullable {
+			nullability := "SET NOT NULL"
+			if column.Nullable {
+				nullability = "DROP NOT NULL"
+			}
+			actions = append(actions, fmt.Sprintf("ALTER COLUMN %s %s", name, nullability))
+		}
+		if options := pgOptionsAlterClause(currentColumn.Options, column.Options); options != "" {
+			actions = append(actions, fmt.Sprintf("ALTER COLUMN %s %s", name, options))
+		}
+	}
+	if options := pgOptionsAlterClause(current.Options, desired.Options); options != "" {
+		actions = append(actions, options)
+	}
+	if len(actions) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER FOREIGN TABLE %s %s;",
+			pgQualifiedName(desired.Schema, desired.Name), strings.Join(actions, ", ")))
+	}
+
+	if current.Comment != desired.Comment {
+		statements = append(statements, fmt.Sprintf("COMMENT ON FOREIGN TABLE %s IS %s;",
+			pgQualifiedName(desired.Schema, desired.Name), pq.QuoteLiteral(desired.Comment)))
+	}
+	return statements
+}
+
+// readForeignTable reads the definition of the foreign table whose oid is returned by the relation SQL expression.
+func readForeignTable(ctx context.Context, q pgQueryer, relation string) (*ForeignTableModel, error) {
+	tableQuery := `
+		SELECT n.nspname                                                   as "schema",
+			   c.relname                                                   as "name",
+			   pg_catalog.current_database()                               as "database",
+			   s.srvname                                                   as "server",
+			   COALESCE(ft.ftoptions, '{}')                                as "options",
+			   COALESCE(pg_catalog.obj_description(c.oid, 'pg_class'), '') as "comment"
+		FROM pg_catalog.pg_foreign_table ft
+				 JOIN pg_catalog.pg_class c ON c.oid = ft.ftrelid
+				 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+				 JOIN pg_catalog.pg_foreign_server s ON s.oid = ft.ftserver
+		WHERE ft.ftrelid = %s;`
+
+	var model ForeignTableModel
+	var options []string
+	row := q.QueryRowContext(ctx, fmt.Sprintf(tableQuery, relation))
+	err := row.Scan(&model.Schema, &model.Name, &model.Database, &model.Server, (*pq.StringArray)(&options), &model.Comment)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opScanRowResult)
+	}
+	model.Options = parseRelOptions(options)
+
+	columnsQuery := `
+		SELECT a.attname                                      as "name",
+			   pg_catalog.format_type(a.atttypid, a.atttypmod) as "type",
+			   NOT a.attnotnull                               as "nullable",
+			   COALESCE(a.attfdwoptions, '{}')                as "options"
+		FROM pg_catalog.pg_attribute a
+		WHERE a.attrelid = %s
+		  AND a.attnum > 0
+		  AND NOT a.attisdropped
+		ORDER BY a.attnum;`
+
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(columnsQuery, relation))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var column ForeignTableColumn
+		var columnOptions []string
+		if err = rows.Scan(&column.Name, &column.Type, &column.Nullable, (*pq.StringArray)(&columnOptions)); err != nil {
+			return nil, PgErrWithMetadata(err, "pg_cmd", opScanRowResult)
+		}
+		column.Options = parseRelOptions(columnOptions)
+		model.Columns = append(model.Columns, column)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery)
+	}
+	return &model, nil
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func mockForeignTableModel(t *testing.T) ForeignTableModel {
+	t.Helper()
+	return ForeignTableModel{
+		Schema: "local_app",
+		Name:   "orders",
+		Server: "remote",
+		Columns: []ForeignTableColumn{
+			{Name: "id", Type: "int"},
+			{Name: "state", Type: "text", Nullable: true, Options: map[string]string{"column_name": "status"}},
+		},
+		Options: map[string]string{"schema_name": "remote_app", "table_name": "orders"},
+		Comment: "test comment",
+	}
+}
+
+func TestForeignTableCreateQuery(t *testing.T) {
+	assert.Equal(t,
+		`CREATE FOREIGN TABLE "local_app"."orders" ("id" int NOT NULL, "state" text OPTIONS ("column_name" 'status')) `+
+			`SERVER "remote" OPTIONS ("schema_name" 'remote_app', "table_name" 'orders');`,
+		foreignTableCreateQuery(mockForeignTableModel(t)),
+	)
+}
+
+func TestForeignTableUpdateStatements(t *testing.T) {
+	current := mockForeignTableModel(t)
+	desired := mockForeignTableModel(t)
+	desired.Name = "remote_orders"
+	desired.Columns = []ForeignTableColumn{
+		{Name: "id", Type: "bigint", Nullable: true},
+		{Name: "state", Type: "text", Nullable: true},
+		{Name: "total", Type: "numeric(10,2)", Nullable: true},
+	}
+	desired.Options = map[string]string{"schema_name": "remote_app", "table_name": "orders", "use_remote_estimate": "true"}
+
+	assert.Equal(t, []string{
+		`ALTER FOREIGN TABLE "local_app"."orders" RENAME TO "remote_orders";`,
+		`ALTER FOREIGN TABLE "local_app"."remote_orders" ALTER COLUMN "id" TYPE bigint, ALTER COLUMN "id" DROP NOT NULL, ` +
+			`ALTER COLUMN "state" OPTIONS (DROP "column_name"), ADD COLUMN "total" numeric(10,2), OPTIONS (ADD "use_remote_estimate" 'true');`,
+	}, foreignTableUpdateStatements(current, desired))
+
+	desired = mockForeignTableModel(t)
+	desired.Columns = desired.Columns[:1]
+	desired.Comment = ""
+	assert.Equal(t, []string{
+		`ALTER FOREIGN TABLE "local_app"."orders" DROP COLUMN "state";`,
+		`COMMENT ON FOREIGN TABLE "local_app"."orders" IS '';`,
+	}, foreignTableUpdateStatements(current, desired))
+}
+
+func TestPreserveForeignTableSpelling(t *testing.T) {
+	prior := mockForeignTableModel(t)
+	prior.Columns = []ForeignTableColumn{{Name: "state", Type: "text"}, {Name: "id", Type: "int"}}
+	normalizedPrior := prior
+	normalizedPrior.Columns = []ForeignTableColumn{{Name: "state", Type: "text"}, {Name: "id", Type: "integer"}}
+	actual := prior
+	actual.Columns = []ForeignTableColumn{{Name: "id", Type: "integer"}, {Name: "state", Type: "character varying"}}
+
+	got := PreserveForeignTableSpelling(prior, normalizedPrior, actual)
+	assert.Equal(t, []ForeignTableColumn{{Name: "state", Type: "character varying"}, {Name: "id", Type: "int"}}, got.Columns)
+}
+
+func TestForeignTableSQL_CreateGetAndUpdate(t *testing.T) {
+	ctx, db := testPrepareForeignServerTestCase(t)
+	defer db.Close()
+	testCreateRemoteServer(t, ctx, db)
+
+	repo := NewForeignTableRepository(db)
+	model := mockForeignTableModel(t)
+	require.NoError(t, repo.Create(ctx, model))
+
+	got, err := repo.Get(ctx, "local_app", "orders")
+	assert.NoError(t, err)
+	assert.Equal(t, testForeignServerDb, got.Database)
+	assert.Equal(t, "remote", got.Server)
+	assert.Equal(t, "integer", got.Columns[0].Type)
+	assert.False(t, got.Columns[0].Nullable)
+	assert.Equal(t, model.Columns[1].Options, got.Columns[1].Options)
+	assert.Equal(t, model.Options, got.Options)
+	assert.Equal(t, "test comment", got.Comment)
+
+	normalized, err := repo.Normalize(ctx, model)
+	assert.NoError(t, err)
+	assert.Equal(t, "orders", normalized.Name)
+	assert.Equal(t, "int", PreserveForeignTableSpelling(model, *normalized, *got).Columns[0].Type)
+
+	// the rows are fetched from the remote server
+	var state string
+	assert.NoError(t, db.QueryRowContext(ctx, `SELECT state FROM local_app.orders WHERE id = 1;`).Scan(&state))
+	assert.Equal(t, "paid", state)
+
+	desired := *got
+	desired.Columns = append(desired.Columns, ForeignTableColumn{Name: "note", Type: "text", Nullable: true})
+	desired.Options = map[string]string{"schema_name": "remote_app", "table_name": "orders", "fetch_size": "10"}
+	got, err = repo.Update(ctx, ForeignTableUpdateParams{Current: *got, Desired: desired})
+	assert.NoError(t, err)
+	assert.Len(t, got.Columns, 3)
+	assert.Equal(t, desired.Options, got.Options)
+
+	assert.NoError(t, repo.Drop(ctx, "local_app", "orders"))
+	exists, err := repo.Exists(ctx, "local_app", "orders")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"github.com/go-playground/validator/v10"
@@ -22,22 +23,151 @@ const (
 )
 
 const (
-	opCommitTransaction   = "commit_transaction"
-	opCreateEventTrigger  = "create_event_trigger"
-	opCreateComment       = "create_comment"
-	opCreateUserFunction  = "create_user_function"
-	opDropObject          = "drop_object"
-	opDropEventTrigger    = "drop_event_trigger"
-	opExecute             = "execute"
-	opExistsEventTrigger  = "exists_event_trigger"
-	opGetEventTrigger     = "get_event_trigger"
-	opQuery               = "query"
-	opQueryRow            = "query_row"
-	opRollbackTransaction = "rollback_transaction"
-	opStartTransaction    = "start_transaction"
-	opStructValidation    = "struct_validation"
-	opScanRowResult       = "scan_row_result"
-	opUpdateEventTrigger  = "update_event_trigger"
+	opCallProcedure             = "call_procedure"
+	opCheckScript               = "check_script"
+	opCommitTransaction         = "commit_transaction"
+	opCreateAggregate           = "create_aggregate"
+	opCreateComment             = "create_comment"
+	opCreateDbRoleSetting       = "create_db_role_setting"
+	opCreateEventTrigger        = "create_event_trigger"
+	opCreateForeignServer       = "create_foreign_server"
+	opCreateForeignTable        = "create_foreign_table"
+	opCreateIndex               = "create_index"
+	opCreateMaterializedView    = "create_materialized_view"
+	opCreatePolicy              = "create_policy"
+	opCreateProcedure           = "create_procedure"
+	opCreatePublication         = "create_publication"
+	opCreateReplicationSlot     = "create_replication_slot"
+	opCreateSequence            = "create_sequence"
+	opCreateSubscription        = "create_subscription"
+	opCreateSystemSetting       = "create_system_setting"
+	opCreateTable               = "create_table"
+	opCreateTablespace          = "create_tablespace"
+	opCreateTrigger             = "create_trigger"
+	opCreateType                = "create_type"
+	opCreateUserFunction        = "create_user_function"
+	opCreateUserMapping         = "create_user_mapping"
+	opCreateView                = "create_view"
+	opDropAggregate             = "drop_aggregate"
+	opDropDbRoleSetting         = "drop_db_role_setting"
+	opDropEventTrigger          = "drop_event_trigger"
+	opDropForeignServer         = "drop_foreign_server"
+	opDropForeignTable          = "drop_foreign_table"
+	opDropImportedForeignTables = "drop_imported_foreign_tables"
+	opDropIndex                 = "drop_index"
+	opDropMaterializedView      = "drop_materialized_view"
+	opDropObject                = "drop_object"
+	opDropPolicy                = "drop_policy"
+	opDropProcedure             = "drop_procedure"
+	opDropPublication           = "drop_publication"
+	opDropReplicationSlot       = "drop_replication_slot"
+	opDropSequence              = "drop_sequence"
+	opDropSubscription          = "drop_subscription"
+	opDropSystemSetting         = "drop_system_setting"
+	opDropTable                 = "drop_table"
+	opDropTablespace            = "drop_tablespace"
+	opDropTrigger               = "drop_trigger"
+	opDropType                  = "drop_type"
+	opDropUserFunction          = "drop_user_function"
+	opDropUserMapping           = "drop_user_mapping"
+	opDropView                  = "drop_view"
+	opExecScript                = "exec_script"
+	opExecute                   = "execute"
+	opExistsAggregate           = "exists_aggregate"
+	opExistsDbRoleSetting       = "exists_db_role_setting"
+	opExistsEventTrigger        = "exists_event_trigger"
+	opExistsForeignServer       = "exists_foreign_server"
+	opExistsForeignTable        = "exists_foreign_table"
+	opExistsIndex               = "exists_index"
+	opExistsMaterializedView    = "exists_materialized_view"
+	opExistsPolicy              = "exists_policy"
+	opExistsProcedure           = "exists_procedure"
+	opExistsPublication         = "exists_publication"
+	opExistsReplicationSlot     = "exists_replication_slot"
+	opExistsSequence            = "exists_sequence"
+	opExistsSubscription        = "exists_subscription"
+	opExistsSystemSetting       = "exists_system_setting"
+	opExistsTable               = "exists_table"
+	opExistsTablespace          = "exists_tablespace"
+	opExistsTrigger             = "exists_trigger"
+	opExistsType                = "exists_type"
+	opExistsUserFunction        = "exists_user_function"
+	opExistsUserMapping         = "exists_user_mapping"
+	opExistsView                = "exists_view"
+	opGetAggregate              = "get_aggregate"
+	opGetConnection             = "get_connection"
+	opGetDbRoleSetting          = "get_db_role_setting"
+	opGetEventTrigger           = "get_event_trigger"
+	opGetEventTriggerFunction   = "get_event_trigger_function"
+	opGetForeignServer          = "get_foreign_server"
+	opGetForeignTable           = "get_foreign_table"
+	opGetImportedForeignTables  = "get_imported_foreign_tables"
+	opGetIndex                  = "get_index"
+	opGetMaterializedView       = "get_materialized_view"
+	opGetPolicy                 = "get_policy"
+	opGetProcedure              = "get_procedure"
+	opGetPublication            = "get_publication"
+	opGetReplicationSlot        = "get_replication_slot"
+	opGetRowLevelSecurity       = "get_row_level_security"
+	opGetServerInfo             = "get_server_info"
+	opGetSettingDefinition      = "get_setting_definition"
+	opGetSubscription           = "get_subscription"
+	opGetSystemSetting          = "get_system_setting"
+	opGetTable                  = "get_table"
+	opGetTablespace             = "get_tablespace"
+	opGetTrigger                = "get_trigger"
+	opGetType                   = "get_type"
+	opGetUserFunction           = "get_user_function"
+	opGetUserMapping            = "get_user_mapping"
+	opGetView                   = "get_view"
+	opImportForeignSchema       = "import_foreign_schema"
+	opListDatabases             = "list_databases"
+	opListRoles                 = "list_roles"
+	opListSchemas               = "list_schemas"
+	opListSettings              = "list_settings"
+	opListTables                = "list_tables"
+	opNormalizeAggregate        = "normalize_aggregate"
+	opNormalizeForeignTable     = "normalize_foreign_table"
+	opNormalizeIndex            = "normalize_index"
+	opNormalizeMaterializedView = "normalize_materialized_view"
+	opNormalizePolicy           = "normalize_policy"
+	opNormalizeProcedure        = "normalize_procedure"
+	opNormalizePublication      = "normalize_publication"
+	opNormalizeTable            = "normalize_table"
+	opNormalizeTrigger          = "normalize_trigger"
+	opNormalizeType             = "normalize_type"
+	opNormalizeView             = "normalize_view"
+	opPrepareStatement          = "prepare_statement"
+	opQuery                     = "query"
+	opQueryRow                  = "query_row"
+	opRefreshMaterializedView   = "refresh_materialized_view"
+	opReplaceScript             = "replace_script"
+	opRollbackTransaction       = "rollback_transaction"
+	opRunQuery                  = "run_query"
+	opScanRowResult             = "scan_row_result"
+	opSetRole                   = "set_role"
+	opStartTransaction          = "start_transaction"
+	opStructValidation          = "struct_validation"
+	opUpdateAggregate           = "update_aggregate"
+	opUpdateDbRoleSetting       = "update_db_role_setting"
+	opUpdateEventTrigger        = "update_event_trigger"
+	opUpdateForeignServer       = "update_foreign_server"
+	opUpdateForeignTable        = "update_foreign_table"
+	opUpdateIndex               = "update_index"
+	opUpdateMaterializedView    = "update_materialized_view"
+	opUpdatePolicy              = "update_policy"
+	opUpdateProcedure           = "update_procedure"
+	opUpdatePublication         = "update_publication"
+	opUpdateRowLevelSecurity    = "update_row_level_security"
+	opUpdateSequence            = "update_sequence"
+	opUpdateSubscription        = "update_subscription"
+	opUpdateSystemSetting       = "update_system_setting"
+	opUpdateTable               = "update_table"
+	opUpdateTablespace          = "update_tablespace"
+	opUpdateTrigger             = "update_trigger"
+	opUpdateType                = "update_type"
+	opUpdateUserMapping         = "update_user_mapping"
+	opUpdateView                = "update_view"
 )
 
 func pgQuoteListOfLiterals(list []string) string {
@@ -56,6 +186,79 @@ func pgMapToFuncArg(mapArg map[string]string) string {
 	return strings.Join(args, ", ")
 }
 
+type pgContextKey string
+
+const ctxKeyAssumeRole pgContextKey = "assume_role"
+
+// ContextWithAssumeRole returns a copy of ctx carrying the role that repositories
+// should assume (SET LOCAL ROLE) inside their transactions. An empty role is a no-op.
+func ContextWithAssumeRole(ctx context.Context, role string) context.Context {
+	return context.WithValue(ctx, ctxKeyAssumeRole, role)
+}
+
+// GetAssumeRoleFromCtx returns the role set with ContextWithAssumeRole, or an empty string.
+func GetAssumeRoleFromCtx(ctx context.Context) string {
+	if role, ok := ctx.Value(ctxKeyAssumeRole).(string); ok {
+		return role
+	}
+
+	return ""
+}
+
+// BeginTxWithRole starts a new transaction and, when the context carries a role to assume,
+// switches to it with SET LOCAL ROLE so that every object created inside the transaction
+// is owned by that role. The role is reset automatically when the transaction ends.
+func BeginTxWithRole(ctx context.Context, db *sql.DB) (*sql.Tx, error) {
+	txn, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	role := GetAssumeRoleFromCtx(ctx)
+	if role == "" {
+		return txn, nil
+	}
+
+	if _, err = txn.ExecContext(ctx, fmt.Sprintf("SET LOCAL ROLE %s;", pq.QuoteIdentifier(role))); err != nil {
+		DeferredRollback(txn)
+		return nil, PgErrWithMetadata(err, "pg_cmd", opSetRole, "role", role)
+	}
+
+	return txn, nil
+}
+
+// ExecWithRole runs the statements outside a transaction, for the commands that refuse to run
+// inside one like CREATE INDEX CONCURRENTLY. The statements share a dedicated connection, so the
+// role assumed with SET ROLE never leaks to other users of the pool: the role is reset before the
+// connection is released, and a connection that fails to reset is discarded.
+func ExecWithRole(ctx context.Context, db *sql.DB, statements ...string) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return PgErrWithMetadata(err, "pg_cmd", opGetConnection)
+	}
+	defer conn.Close()
+
+	if role := GetAssumeRoleFromCtx(ctx); role != "" {
+		if _, err = conn.ExecContext(ctx, fmt.Sprintf("SET ROLE %s;", pq.QuoteIdentifier(role))); err != nil {
+			return PgErrWithMetadata(err, "pg_cmd", opSetRole, "role", role)
+		}
+		defer func() {
+			// the reset must run even when ctx is already cancelled
+			if _, errReset := conn.ExecContext(context.Background(), "RESET ROLE;"); errReset != nil {
+				slog.Warn("discarding connection after failing to reset the role", "error", errReset)
+				_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+			}
+		}()
+	}
+
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(conn.ExecContext(ctx, statement)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func GetValidatorFromCtx(ctx context.Context) *validator.Validate {
 	if v, ok := ctx.Value("validator").(*validator.Validate); ok {
 		return v
@@ -0,0 +1,458 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"slices"
+	"strings"
+)
+
+const (
+	indexObjectType = "INDEX"
+
+	// pg_index.indoption flags
+	indexOptionDesc       = 1
+	indexOptionNullsFirst = 2
+)
+
+var errIndexInvalid = errors.New("the index is invalid")
+
+type indexSQL struct {
+	db *sql.DB
+}
+
+// IndexKey is a key of the index, either a column or an expression.
+type IndexKey struct {
+	Column     string `json:"column" validate:"required_without=Expression,excluded_with=Expression"`
+	Expression string `json:"expression"`
+	Order      string `json:"order" validate:"omitempty,oneof=ASC DESC"`
+	Nulls      string `json:"nulls" validate:"omitempty,oneof=FIRST LAST"`
+}
+
+type IndexModel struct {
+	Schema            string            `json:"schema" validate:"required"`
+	Name              string            `json:"name" validate:"required"`
+	Table             string            `json:"table" validate:"required"`
+	Database          string            `json:"database"`
+	Keys              []IndexKey        `json:"keys" validate:"required,min=1,dive"`
+	Method            string            `json:"method"`
+	Unique            bool              `json:"unique"`
+	Where             string            `json:"where"`
+	Include           []string          `json:"include" validate:"unique"`
+	StorageParameters map[string]string `json:"storage_parameters"`
+	Tablespace        string            `json:"tablespace"`
+	// Valid is false when a concurrent build failed and left the index behind.
+	Valid      bool   `json:"valid"`
+	Definition string `json:"definition"`
+}
+
+type IndexUpdateParams struct {
+	Current IndexModel
+	Desired IndexModel `validate:"required"`
+}
+
+type IndexRepository interface {
+	Create(ctx context.Context, params IndexModel, concurrently bool) error
+	Drop(ctx context.Context, schema, name string, concurrently bool) error
+	Get(ctx context.Context, schema, name string) (*IndexModel, error)
+	Update(ctx context.Context, params IndexUpdateParams) (*IndexModel, error)
+	Exists(ctx context.Context, schema, name string) (bool, error)
+	Normalize(ctx context.Context, model IndexModel) (*IndexModel, error)
+}
+
+var _ IndexRepository = &indexSQL{}
+
+func NewIndexRepository(db *sql.DB) IndexRepository {
+	return &indexSQL{
+		db: db,
+	}
+}
+
+// Create builds the index. A concurrent build runs outside a transaction; when it fails, the
+// invalid index it leaves behind is dropped and reported in the returned error, unless an index of
+// that name existed before the build.
+func (i *indexSQL) Create(ctx context.Context, params IndexModel, concurrently bool) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	createQuery := indexCreateQuery(params, concurrently)
+
+	if !concurrently {
+		txn, err := BeginTxWithRole(ctx, i.db)
+		if err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateIndex, "pg_cmd", opStartTransaction)
+		}
+		defer DeferredRollback(txn)
+
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, createQuery)); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateIndex)
+		}
+		if err = txn.Commit(); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateIndex, "pg_cmd", opCommitTransaction)
+		}
+		return nil
+	}
+
+	// an index of the same name that predates the build is not a leftover of it
+	existed, err := i.Exists(ctx, params.Schema, params.Name)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateIndex)
+	}
+
+	err = ExecWithRole(ctx, i.db, createQuery)
+	if err == nil {
+		return nil
+	}
+	if existed {
+		return PgErrWithMetadata(err, "operation", opCreateIndex)
+	}
+
+	leftover, errGet := i.Get(ctx, params.Schema, params.Name)
+	if errGet != nil || leftover.Valid {
+		return PgErrWithMetadata(err, "operation", opCreateIndex)
+	}
+	err = fmt.Errorf("%w: the concurrent build of '%s' failed and left an invalid index behind: %w", errIndexInvalid, params.Name, err)
+	if errDrop := i.Drop(ctx, params.Schema, params.Name, true); errDrop != nil {
+		return PgErrWithMetadata(err, "operation", opCreateIndex, "cleanup", errDrop.Error())
+	}
+	return PgErrWithMetadata(err, "operation", opCreateIndex, "cleanup", "invalid index dropped")
+}
+
+func (i *indexSQL) Drop(ctx context.Context, schema, name string, concurrently bool) error {
+	if concurrently {
+		err := ExecWithRole(ctx, i.db, fmt.Sprintf("DROP INDEX CONCURRENTLY IF EXISTS %s;", pgQualifiedName(schema, name)))
+		if err != nil {
+			return PgErrWithMetadata(err, "operation", opDropIndex)
+		}
+		return nil
+	}
+
+	txn, err := BeginTxWithRole(ctx, i.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropIndex, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	err = DropObject(ctx, txn, indexObjectType, pgQualifiedName(schema, name))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropIndex)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropIndex, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (i *indexSQL) Get(ctx context.Context, schema, name string) (*IndexModel, error) {
+	relation := fmt.Sprintf("pg_catalog.to_regclass(%s)", pq.QuoteLiteral(pgQualifiedName(schema, name)))
+
+	model, err := readIndex(ctx, i.db, relation)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetIndex)
+	}
+	return model, nil
+}
+
+// Update renames the index and changes its tablespace and storage parameters, every other
+// attribute requires the index to be rebuilt.
+func (i *indexSQL) Update(ctx context.Context, params IndexUpdateParams) (*IndexModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	current, desired := params.Current, params.Desired
+	name := pgQualifiedName(current.Schema, current.Name)
+
+	var statements []string
+	if current.Name != desired.Name {
+		statements = append(statements, fmt.Sprintf("ALTER INDEX %s RENAME TO %s;", name, pq.QuoteIdentifier(desired.Name)))
+		name = pgQualifiedName(current.Schema, desired.Name)
+	}
+	if current.Tablespace != desired.Tablespace {
+		tablespace := desired.Tablespace
+		if tablespace == "" {
+			tablespace = "pg_default"
+		}
+		statements = append(statements, fmt.Sprintf("ALTER INDEX %s SET TABLESPACE %s;", name, pq.QuoteIdentifier(tablespace)))
+	}
+
+	var reset []string
+	changed := map[string]string{}
+	for key, value := range desired.StorageParameters {
+		if currentValue, ok := current.StorageParameters[key]; !ok || currentValue != value {
+			changed[key] = value
+		}
+	}
+	for key := range current.StorageParameters {
+		if _, ok := desired.StorageParameters[key]; !ok {
+			reset = append(reset, key)
+		}
+	}
+	if len(reset) > 0 {
+		slices.Sort(reset)
+		statements = append(statements, fmt.Sprintf("ALTER INDEX %s RESET (%s);", name, pgQuoteListOfIdentifiers(reset)))
+	}
+	if len(changed) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER INDEX %s SET (%s);", name, indexStorageParameters(changed)))
+	}
+
+	txn, err := BeginTxWithRole(ctx, i.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateIndex, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateIndex)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateIndex, "pg_cmd", opCommitTransaction)
+	}
+
+	return i.Get(ctx, current.Schema, desired.Name)
+}
+
+func (i *indexSQL) Exists(ctx context.Context, schema, name string) (bool, error) {
+	existsQuery := `
+		SELECT EXISTS (SELECT 1
+					   FROM pg_catalog.pg_index
+					   WHERE indexrelid = pg_catalog.to_regclass(%s));`
+
+	var exists bool
+	row := i.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(pgQualifiedName(schema, name))))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsIndex, "pg_cmd", opQueryRow)
+	}
+
+	return exists, nil
+}
+
+// Normalize returns the definition as PostgreSQL would store it. The index is built on an empty
+// temporary copy of the table, inside a transaction that is always rolled back, so the real table
+// is neither locked nor scanned.
+func (i *indexSQL) Normalize(ctx context.Context, model IndexModel) (*IndexModel, error) {
+	txn, err := i.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeIndex, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	temporary := model
+	temporary.Schema = "pg_temp"
+	temporary.Table = "tf_normalize_" + model.Table
+	temporary.Name = "tf_normalize_" + model.Name
+	temporary.Tablespace = ""
+
+	statements := []string{
+		fmt.Sprintf("CREATE TEMPORARY TABLE %s (LIKE %s);", pgQualifiedName(temporary.Schema, temporary.Table), pgQualifiedName(model.Schema, model.Table)),
+		indexCreateQuery(temporary, false),
+	}
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opNormalizeIndex)
+		}
+	}
+
+	relation := fmt.Sprintf("pg_catalog.to_regclass(%s)", pq.QuoteLiteral(pgQualifiedName(temporary.Schema, temporary.Name)))
+	normalized, err := readIndex(ctx, txn, relation)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeIndex)
+	}
+
+	normalized.Schema = model.Schema
+	normalized.Name = model.Name
+	normalized.Table = model.Table
+	normalized.Database = model.Database
+	normalized.Tablespace = model.Tablespace
+
+	return normalized, nil
+}
+
+// PreserveIndexSpelling returns the actual index, but keeping the spelling of the prior definition
+// for the keys and predicate whose normalized form (see IndexRepository.Normalize) matches the actual one.
+func PreserveIndexSpelling(prior, normalizedPrior, actual IndexModel) IndexModel {
+	result := actual
+
+	if len(prior.Keys) == len(actual.Keys) && len(normalizedPrior.Keys) == len(actual.Keys) {
+		result.Keys = make([]IndexKey, len(actual.Keys))
+		for k, key := range actual.Keys {
+			result.Keys[k] = key
+			if normalizedPrior.Keys[k] == key {
+				result.Keys[k] = prior.Keys[k]
+			}
+		}
+	}
+	if normalizedPrior.Where == actual.Where {
+		result.Where = prior.Where
+	}
+
+	return result
+}
+
+func indexCreateQuery(model IndexModel, concurrently bool) string {
+	var query strings.Builder
+
+	query.WriteString("CREATE ")
+	if model.Unique {
+		query.WriteString("UNIQUE ")
+	}
+	query.WriteString("INDEX ")
+	if concurrently {
+		query.WriteString("CONCURRENTLY ")
+	}
+	fmt.Fprintf(&query, "%s ON %s", pq.QuoteIdentifier(model.Name), pgQualifiedName(model.Schema, model.Table))
+	if model.Method != "" {
+		fmt.Fprintf(&query, " USING %s", pq.QuoteIdentifier(model.Method))
+	}
+
+	keys := make([]string, len(model.Keys))
+	for k, key := range model.Keys {
+		keys[k] = pq.QuoteIdentifier(key.Column)
+		if key.Expression != "" {
+			keys[k] = fmt.Sprintf("(%s)", key.Expression)
+		}
+		if key.Order != "" {
+			keys[k] += " " + key.Order
+		}
+		if key.Nulls != "" {
+			keys[k] += " NULLS " + key.Nulls
+		}
+	}
+	fmt.Fprintf(&query, " (%s)", strings.Join(keys, ", "))
+
+	if len(model.Include) > 0 {
+		fmt.Fprintf(&query, " INCLUDE (%s)", pgQuoteListOfIdentifiers(model.Include))
+	}
+	if len(model.StorageParameters) > 0 {
+		fmt.Fprintf(&query, " WITH (%s)", indexStorageParameters(model.StorageParameters))
+	}
+	if model.Tablespace != "" {
+		fmt.Fprintf(&query, " TABLESPACE %s", pq.QuoteIdentifier(model.Tablespace))
+	}
+	if model.Where != "" {
+		fmt.Fprintf(&query, " WHERE %s", model.Where)
+	}
+	query.WriteString(";")
+
+	return query.String()
+}
+
+// indexStorageParameters returns the parameters sorted by name, e.g. "fillfactor" = '70'.
+func indexStorageParameters(parameters map[string]string) string {
+	keys := make([]string, 0, len(parameters))
+	for key := range parameters {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	params := make([]string, len(keys))
+	for k, key := range keys {
+		params[k] = fmt.Sprintf("%s = %s", pq.QuoteIdentifier(key), pq.QuoteLiteral(parameters[key]))
+	}
+	return strings.Join(params, ", ")
+}
+
+// readIndex reads the definition of the index whose oid is returned by the relation SQL expression.
+func readIndex(ctx context.Context, q pgQueryer, relation string) (*IndexModel, error) {
+	var model IndexModel
+	var storageParameters []string
+
+	indexQuery := `
+		SELECT n.nspname                                                      as "schema",
+			   c.relname                                                      as "name",
+			   t.relname                                                      as "table",
+			   pg_catalog.current_database()                                  as "database",
+			   am.amname                                                      as "method",
+			   i.indisunique                                                  as "unique",
+			   COALESCE(pg_catalog.pg_get_expr(i.indpred, i.indrelid, true), '') as "where",
+			   COALESCE(c.reloptions, '{}')                                   as "storage_parameters",
+			   COALESCE(ts.spcname, '')                                       as "tablespace",
+			   i.indisvalid                                                   as "valid",
+			   pg_catalog.pg_get_indexdef(c.oid)                              as "definition"
+		FROM pg_catalog.pg_index i
+				 JOIN pg_catalog.pg_class c ON c.oid = i.indexrelid
+				 JOIN pg_catalog.pg_class t ON t.oid = i.indrelid
+				 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+				 JOIN pg_catalog.pg_am am ON am.oid = c.relam
+				 LEFT JOIN pg_catalog.pg_tablespace ts ON ts.oid = c.reltablespace
+		WHERE i.indexrelid = %s;`
+
+	row := q.QueryRowContext(ctx, fmt.Sprintf(indexQuery, relation))
+	err := row.Scan(
+		&model.Schema,
+		&model.Name,
+		&model.Table,
+		&model.Database,
+		&model.Method,
+		&model.Unique,
+		&model.Where,
+		(*pq.StringArray)(&storageParameters),
+		&model.Tablespace,
+		&model.Valid,
+		&model.Definition,
+	)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "index")
+	}
+
+	model.StorageParameters = parseRelOptions(storageParameters)
+
+	// key columns come first, followed by the INCLUDE columns
+	keysQuery := `
+		SELECT k.ord <= i.indnkeyatts                                         as "is_key",
+			   COALESCE(a.attname, '')                                        as "column",
+			   CASE WHEN k.attnum = 0 THEN pg_catalog.pg_get_indexdef(i.indexrelid, k.ord::int, true) ELSE '' END as "expression",
+			   COALESCE(i.indoption[k.ord - 1], 0)                            as "option"
+		FROM pg_catalog.pg_index i
+				 CROSS JOIN LATERAL pg_catalog.unnest(i.indkey::int2[]) WITH ORDINALITY k(attnum, ord)
+				 LEFT JOIN pg_catalog.pg_attribute a ON a.attrelid = i.indrelid AND a.attnum = k.attnum AND k.attnum <> 0
+		WHERE i.indexrelid = %s
+		ORDER BY k.ord;`
+
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(keysQuery, relation))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery, "model", "index_key")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var isKey bool
+		var key IndexKey
+		var option int
+		if err = rows.Scan(&isKey, &key.Column, &key.Expression, &option); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "index_key")
+		}
+		if !isKey {
+			model.Include = append(model.Include, key.Column)
+			continue
+		}
+
+		// only the non default ordering is reported: ASC NULLS LAST and DESC NULLS FIRST
+		desc, nullsFirst := option&indexOptionDesc != 0, option&indexOptionNullsFirst != 0
+		if desc {
+			key.Order = "DESC"
+		}
+		switch {
+		case nullsFirst && !desc:
+			key.Nulls = "FIRST"
+		case !nullsFirst && desc:
+			key.Nulls = "LAST"
+		}
+		model.Keys = append(model.Keys, key)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery, "model", "index_key")
+	}
+
+	return &model, nil
+}
@@ -0,0 +1,162 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+const (
+	testIndexDb   = "test_index_db"
+	testIndexUser = "test_index_user"
+)
+
+func testPrepareIndexTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: testIndexDb,
+		Username: testIndexUser,
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `CREATE TABLE public.test_index_table (id int, email text, active bool, created_at timestamptz);`)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func mockIndexModel(t *testing.T) IndexModel {
+	t.Helper()
+	return IndexModel{
+		Schema: "public",
+		Name:   "test_index",
+		Table:  "test_index_table",
+		Keys: []IndexKey{
+			{Expression: "lower(email)"},
+			{Column: "created_at", Order: "DESC", Nulls: "LAST"},
+		},
+		Method:            "btree",
+		Unique:            true,
+		Where:             "active",
+		Include:           []string{"id"},
+		StorageParameters: map[string]string{"fillfactor": "70"},
+	}
+}
+
+func TestIndexCreateQuery(t *testing.T) {
+	assert.Equal(t,
+		`CREATE UNIQUE INDEX CONCURRENTLY "test_index" ON "public"."test_index_table" USING "btree" ((lower(email)), "created_at" DESC NULLS LAST) INCLUDE ("id") WITH ("fillfactor" = '70') WHERE active;`,
+		indexCreateQuery(mockIndexModel(t), true),
+	)
+	assert.Equal(t,
+		`CREATE INDEX "test_index" ON "public"."test_index_table" ("id");`,
+		indexCreateQuery(IndexModel{Schema: "public", Name: "test_index", Table: "test_index_table", Keys: []IndexKey{{Column: "id"}}}, false),
+	)
+}
+
+func TestIndexSQL_CreateAndGet(t *testing.T) {
+	ctx, db := testPrepareIndexTestCase(t)
+	defer db.Close()
+
+	indexRepo := NewIndexRepository(db)
+
+	for _, concurrently := range []bool{false, true} {
+		model := mockIndexModel(t)
+		assert.NoError(t, indexRepo.Create(ctx, model, concurrently))
+
+		got, err := indexRepo.Get(ctx, model.Schema, model.Name)
+		assert.NoError(t, err)
+		assert.True(t, got.Valid)
+		assert.Equal(t, testIndexDb, got.Database)
+		assert.Equal(t, model.Table, got.Table)
+		assert.Equal(t, "btree", got.Method)
+		assert.True(t, got.Unique)
+		assert.Equal(t, []IndexKey{{Expression: "lower(email)"}, {Column: "created_at", Order: "DESC", Nulls: "LAST"}}, got.Keys)
+		assert.Equal(t, []string{"id"}, got.Include)
+		assert.Equal(t, map[string]string{"fillfactor": "70"}, got.StorageParameters)
+		assert.Contains(t, got.Definition, "CREATE UNIQUE INDEX test_index ON public.test_index_table USING btree")
+
+		normalized, err := indexRepo.Normalize(ctx, model)
+		assert.NoError(t, err)
+		result := PreserveIndexSpelling(model, *normalized, *got)
+		assert.Equal(t, model.Keys, result.Keys)
+		assert.Equal(t, model.Where, result.Where)
+
+		assert.NoError(t, indexRepo.Drop(ctx, model.Schema, model.Name, concurrently))
+		exists, err := indexRepo.Exists(ctx, model.Schema, model.Name)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	}
+}
+
+func TestIndexSQL_CreateConcurrentlyInvalid(t *testing.T) {
+	ctx, db := testPrepareIndexTestCase(t)
+	defer db.Close()
+
+	_, err := db.ExecContext(ctx, `INSERT INTO public.test_index_table (id) VALUES (1), (1);`)
+	assert.NoError(t, err)
+
+	indexRepo := NewIndexRepository(db)
+	model := IndexModel{Schema: "public", Name: "test_index_invalid", Table: "test_index_table", Keys: []IndexKey{{Column: "id"}}, Unique: true}
+
+	err = indexRepo.Create(ctx, model, true)
+	assert.ErrorIs(t, err, errIndexInvalid)
+
+	exists, err := indexRepo.Exists(ctx, model.Schema, model.Name)
+	assert.NoError(t, err)
+	assert.False(t, exists, "the invalid index must be dropped")
+
+	// an invalid index left by an earlier build isn't the leftover of this one
+	_, err = db.ExecContext(ctx, `CREATE UNIQUE INDEX CONCURRENTLY test_index_invalid ON public.test_index_table (id);`)
+	assert.Error(t, err)
+
+	err = indexRepo.Create(ctx, model, true)
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, errIndexInvalid)
+
+	exists, err = indexRepo.Exists(ctx, model.Schema, model.Name)
+	assert.NoError(t, err)
+	assert.True(t, exists, "the existing index must be kept")
+}
+
+func TestIndexSQL_Update(t *testing.T) {
+	ctx, db := testPrepareIndexTestCase(t)
+	defer db.Close()
+
+	indexRepo := NewIndexRepository(db)
+	current := mockIndexModel(t)
+	assert.NoError(t, indexRepo.Create(ctx, current, false))
+
+	desired := mockIndexModel(t)
+	desired.Name = "test_index_renamed"
+	desired.StorageParameters = map[string]string{"deduplicate_items": "off"}
+
+	got, err := indexRepo.Update(ctx, IndexUpdateParams{Current: current, Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, desired.Name, got.Name)
+	assert.Equal(t, desired.StorageParameters, got.StorageParameters)
+}
+
+func TestExecWithRole(t *testing.T) {
+	ctx, db := testPrepareIndexTestCase(t)
+	defer db.Close()
+
+	_, err := db.ExecContext(ctx, `CREATE ROLE test_exec_role;`)
+	assert.NoError(t, err)
+
+	// a single connection makes sure the role is reset before the connection is reused
+	db.SetMaxOpenConns(1)
+	roleCtx := ContextWithAssumeRole(ctx, "test_exec_role")
+	assert.NoError(t, ExecWithRole(roleCtx, db, `CREATE TEMPORARY TABLE test_exec_role_check AS SELECT current_user AS "role";`))
+
+	var role, currentUser string
+	assert.NoError(t, db.QueryRowContext(ctx, `SELECT "role", current_user FROM test_exec_role_check;`).Scan(&role, &currentUser))
+	assert.Equal(t, "test_exec_role", role)
+	assert.Equal(t, testIndexUser, currentUser)
+}
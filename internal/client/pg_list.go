@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// ListFilter selects the objects returned by the list queries, every non-system object when empty.
+type ListFilter struct {
+	// NameRegex is a POSIX regular expression that the name of the objects must match.
+	NameRegex string
+	// SchemaRegex is a POSIX regular expression that the schema of the objects must match,
+	// ignored for the objects without schema.
+	SchemaRegex string
+	// IncludeSystem includes the system objects, e.g. the pg_catalog schema or the template databases.
+	IncludeSystem bool
+}
+
+// listSpec tells how a ListFilter applies to a list query.
+type listSpec struct {
+	// nameColumn is the expression of the name of the objects, e.g. 'd.datname'.
+	nameColumn string
+	// schemaColumn is the expression of the schema of the objects, empty for the objects without schema.
+	schemaColumn string
+	// systemCondition is the condition matching the system objects, empty when there are none.
+	systemCondition string
+}
+
+// where returns the WHERE condition matching the objects selected by the filter.
+func (s listSpec) where(filter ListFilter) string {
+	conditions := []string{"true"}
+	if filter.NameRegex != "" {
+		conditions = append(conditions, fmt.Sprintf("%s ~ %s", s.nameColumn, pq.QuoteLiteral(filter.NameRegex)))
+	}
+	if filter.SchemaRegex != "" && s.schemaColumn != "" {
+		conditions = append(conditions, fmt.Sprintf("%s ~ %s", s.schemaColumn, pq.QuoteLiteral(filter.SchemaRegex)))
+	}
+	if !filter.IncludeSystem && s.systemCondition != "" {
+		conditions = append(conditions, fmt.Sprintf("NOT (%s)", s.systemCondition))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// listRows runs a list query, whose '%s' placeholder receives the WHERE condition of the filter,
+// and scans every row.
+func listRows[T any](ctx context.Context, q pgQueryer, query string, spec listSpec, filter ListFilter, scan func(rows *sql.Rows) (T, error)) ([]T, error) {
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(query, spec.where(filter)))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery)
+	}
+	defer rows.Close()
+
+	items := make([]T, 0)
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, PgErrWithMetadata(err, "pg_cmd", opScanRowResult)
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery)
+	}
+	return items, nil
+}
@@ -0,0 +1,19 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestListSpec_Where(t *testing.T) {
+	spec := listSpec{nameColumn: "c.relname", schemaColumn: "n.nspname", systemCondition: "n.nspname ~ '^pg_'"}
+
+	assert.Equal(t, "true AND NOT (n.nspname ~ '^pg_')", spec.where(ListFilter{}))
+	assert.Equal(t, "true", spec.where(ListFilter{IncludeSystem: true}))
+	assert.Equal(t, `true AND c.relname ~  E'^orders_\\d+$' AND n.nspname ~ 'app' AND NOT (n.nspname ~ '^pg_')`,
+		spec.where(ListFilter{NameRegex: `^orders_\d+$`, SchemaRegex: "app"}))
+
+	// the schema filter is ignored for the objects without schema
+	spec = listSpec{nameColumn: "r.rolname"}
+	assert.Equal(t, "true AND r.rolname ~ 'app'", spec.where(ListFilter{NameRegex: "app", SchemaRegex: "public"}))
+}
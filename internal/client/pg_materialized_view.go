@@ -0,0 +1,310 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+const materializedViewObjectType = "MATERIALIZED VIEW"
+
+type materializedViewSQL struct {
+	db *sql.DB
+}
+
+type MaterializedViewModel struct {
+	Schema   string   `json:"schema" validate:"required"`
+	Name     string   `json:"name" validate:"required"`
+	Database string   `json:"database"`
+	Query    string   `json:"query" validate:"required"`
+	Columns  []string `json:"columns" validate:"unique"`
+	Owner    string   `json:"owner"`
+	Comment  string   `json:"comment"`
+	// WithNoData creates the materialized view without populating it, it's only used on creation.
+	WithNoData bool `json:"with_no_data"`
+	Populated  bool `json:"populated"`
+	// Indexes are created along with the materialized view, their schema and table are filled automatically.
+	Indexes []IndexModel `json:"indexes" validate:"dive"`
+}
+
+type MaterializedViewUpdateParams struct {
+	Current MaterializedViewModel
+	Desired MaterializedViewModel `validate:"required"`
+	// Refresh runs REFRESH MATERIALIZED VIEW once the changes are applied.
+	Refresh bool
+}
+
+type MaterializedViewRepository interface {
+	Create(ctx context.Context, params MaterializedViewModel) error
+	Drop(ctx context.Context, schema, name string) error
+	Get(ctx context.Context, schema, name string) (*MaterializedViewModel, error)
+	Update(ctx context.Context, params MaterializedViewUpdateParams) (*MaterializedViewModel, error)
+	Exists(ctx context.Context, schema, name string) (bool, error)
+	Normalize(ctx context.Context, model MaterializedViewModel) (*MaterializedViewModel, error)
+}
+
+var _ MaterializedViewRepository = &materializedViewSQL{}
+
+func NewMaterializedViewRepository(db *sql.DB) MaterializedViewRepository {
+	return &materializedViewSQL{
+		db: db,
+	}
+}
+
+func (m *materializedViewSQL) Create(ctx context.Context, params MaterializedViewModel) error {
+	params.Indexes = materializedViewIndexes(params)
+
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, m.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateMaterializedView, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	name := pgQualifiedName(params.Schema, params.Name)
+
+	createQuery := fmt.Sprintf("CREATE MATERIALIZED VIEW %s", name)
+	if len(params.Columns) > 0 {
+		createQuery += fmt.Sprintf(" (%s)", pgQuoteListOfIdentifiers(params.Columns))
+	}
+	createQuery += fmt.Sprintf(" AS %s", strings.TrimRight(strings.TrimSpace(params.Query), ";"))
+	if params.WithNoData {
+		createQuery += " WITH NO DATA;"
+	} else {
+		createQuery += " WITH DATA;"
+	}
+
+	statements := []string{createQuery}
+	if params.Owner != "" && params.Owner != GetAssumeRoleFromCtx(ctx) {
+		statements = append(statements, fmt.Sprintf("ALTER MATERIALIZED VIEW %s OWNER TO %s;", name, pq.QuoteIdentifier(params.Owner)))
+	}
+	for _, index := range params.Indexes {
+		statements = append(statements, indexCreateQuery(index, false))
+	}
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateMaterializedView)
+		}
+	}
+
+	if params.Comment != "" {
+		if err = CreateComment(ctx, txn, materializedViewObjectType, name, params.Comment); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateMaterializedView)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateMaterializedView, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (m *materializedViewSQL) Drop(ctx context.Context, schema, name string) error {
+	txn, err := BeginTxWithRole(ctx, m.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropMaterializedView, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	err = DropObject(ctx, txn, materializedViewObjectType, pgQualifiedName(schema, name))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropMaterializedView)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropMaterializedView, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (m *materializedViewSQL) Get(ctx context.Context, schema, name string) (*MaterializedViewModel, error) {
+	qualifiedName := pgQualifiedName(schema, name)
+
+	view, err := readView(ctx, m.db, qualifiedName, relKindMaterializedView)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetMaterializedView)
+	}
+
+	model := &MaterializedViewModel{
+		Schema:    view.Schema,
+		Name:      view.Name,
+		Database:  view.Database,
+		Query:     view.Query,
+		Columns:   view.Columns,
+		Owner:     view.Owner,
+		Comment:   view.Comment,
+		Populated: view.Populated,
+	}
+
+	indexesQuery := `
+		SELECT i.indexrelid::regclass::text
+		FROM pg_catalog.pg_index i
+				 JOIN pg_catalog.pg_class c ON c.oid = i.indexrelid
+		WHERE i.indrelid = pg_catalog.to_regclass(%s)
+		ORDER BY c.relname;`
+
+	rows, err := m.db.QueryContext(ctx, fmt.Sprintf(indexesQuery, pq.QuoteLiteral(qualifiedName)))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetMaterializedView, "pg_cmd", opQuery)
+	}
+	defer rows.Close()
+
+	var indexes []string
+	for rows.Next() {
+		var index string
+		if err = rows.Scan(&index); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "materialized_view_index")
+		}
+		indexes = append(indexes, index)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetMaterializedView, "pg_cmd", opQuery)
+	}
+
+	for _, index := range indexes {
+		relation := fmt.Sprintf("pg_catalog.to_regclass(%s)", pq.QuoteLiteral(index))
+		indexModel, err := readIndex(ctx, m.db, relation)
+		if err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opGetMaterializedView)
+		}
+		model.Indexes = append(model.Indexes, *indexModel)
+	}
+
+	return model, nil
+}
+
+// Update renames the materialized view and changes its owner, comment and indexes in place.
+// A new query or column list requires the materialized view to be recreated.
+func (m *materializedViewSQL) Update(ctx context.Context, params MaterializedViewUpdateParams) (*MaterializedViewModel, error) {
+	current, desired := params.Current, params.Desired
+	current.Indexes = materializedViewIndexes(MaterializedViewModel{Schema: desired.Schema, Name: desired.Name, Indexes: current.Indexes})
+	desired.Indexes = materializedViewIndexes(desired)
+
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(desired); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	statements := relationRenameStatements(materializedViewObjectType, current.Schema, current.Name, desired.Schema, desired.Name)
+	name := pgQualifiedName(desired.Schema, desired.Name)
+
+	if desired.Owner != "" && current.Owner != desired.Owner {
+		statements = append(statements, fmt.Sprintf("ALTER MATERIALIZED VIEW %s OWNER TO %s;", name, pq.QuoteIdentifier(desired.Owner)))
+	}
+	if current.Comment != desired.Comment {
+		statements = append(statements, fmt.Sprintf("COMMENT ON MATERIALIZED VIEW %s IS %s;", name, pq.QuoteLiteral(desired.Comment)))
+	}
+
+	// indexes are matched by name, a changed index is dropped and created again
+	for _, index := range current.Indexes {
+		idx := indexByName(desired.Indexes, index.Name, func(i IndexModel) string { return i.Name })
+		if idx < 0 || indexCreateQuery(desired.Indexes[idx], false) != indexCreateQuery(index, false) {
+			statements = append(statements, fmt.Sprintf("DROP INDEX %s;", pgQualifiedName(desired.Schema, index.Name)))
+		}
+	}
+	for _, index := range desired.Indexes {
+		idx := indexByName(current.Indexes, index.Name, func(i IndexModel) string { return i.Name })
+		if idx < 0 || indexCreateQuery(current.Indexes[idx], false) != indexCreateQuery(index, false) {
+			statements = append(statements, indexCreateQuery(index, false))
+		}
+	}
+
+	if params.Refresh {
+		statements = append(statements, fmt.Sprintf("REFRESH MATERIALIZED VIEW %s;", name))
+	}
+
+	txn, err := BeginTxWithRole(ctx, m.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateMaterializedView, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateMaterializedView)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateMaterializedView, "pg_cmd", opCommitTransaction)
+	}
+
+	return m.Get(ctx, desired.Schema, desired.Name)
+}
+
+func (m *materializedViewSQL) Exists(ctx context.Context, schema, name string) (bool, error) {
+	exists, err := relationExists(ctx, m.db, pgQualifiedName(schema, name), relKindMaterializedView)
+	if err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsMaterializedView)
+	}
+	return exists, nil
+}
+
+// Normalize returns the materialized view with the query, columns and indexes as PostgreSQL would
+// store them. The indexes are normalized against the existing materialized view.
+func (m *materializedViewSQL) Normalize(ctx context.Context, model MaterializedViewModel) (*MaterializedViewModel, error) {
+	query, columns, err := normalizeViewQuery(ctx, m.db, model.Query, model.Columns)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeMaterializedView)
+	}
+
+	normalized := model
+	normalized.Query = query
+	normalized.Columns = columns
+	normalized.Indexes = nil
+
+	indexRepository := NewIndexRepository(m.db)
+	for _, index := range materializedViewIndexes(model) {
+		normalizedIndex, err := indexRepository.Normalize(ctx, index)
+		if err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opNormalizeMaterializedView)
+		}
+		normalized.Indexes = append(normalized.Indexes, *normalizedIndex)
+	}
+
+	return &normalized, nil
+}
+
+// PreserveMaterializedViewSpelling returns the actual materialized view, but keeping the spelling of
+// the prior definition for the query, columns and indexes whose normalized form
+// (see MaterializedViewRepository.Normalize) matches the actual one.
+func PreserveMaterializedViewSpelling(prior, normalizedPrior, actual MaterializedViewModel) MaterializedViewModel {
+	result := actual
+	result.Query, result.Columns = preserveViewQuery(
+		prior.Query, prior.Columns,
+		normalizedPrior.Query, normalizedPrior.Columns,
+		actual.Query, actual.Columns,
+	)
+
+	nameFn := func(i IndexModel) string { return i.Name }
+	result.Indexes = orderLike(actual.Indexes, prior.Indexes, nameFn)
+	for i, index := range result.Indexes {
+		priorIdx := indexByName(prior.Indexes, index.Name, nameFn)
+		normIdx := indexByName(normalizedPrior.Indexes, index.Name, nameFn)
+		if priorIdx >= 0 && normIdx >= 0 {
+			result.Indexes[i] = PreserveIndexSpelling(prior.Indexes[priorIdx], normalizedPrior.Indexes[normIdx], index)
+		}
+	}
+
+	return result
+}
+
+// materializedViewIndexes returns the indexes of the materialized view with their schema and table filled.
+func materializedViewIndexes(model MaterializedViewModel) []IndexModel {
+	if model.Indexes == nil {
+		return nil
+	}
+	indexes := make([]IndexModel, len(model.Indexes))
+	for i, index := range model.Indexes {
+		indexes[i] = index
+		indexes[i].Schema = model.Schema
+		indexes[i].Table = model.Name
+	}
+	return indexes
+}
@@ -0,0 +1,79 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func mockMaterializedViewModel(t *testing.T) MaterializedViewModel {
+	t.Helper()
+	return MaterializedViewModel{
+		Schema:     "public",
+		Name:       "test_materialized_view",
+		Query:      "select active, count(*) as total from test_view_table group by active",
+		Comment:    "test comment",
+		WithNoData: true,
+		Indexes: []IndexModel{
+			{Name: "test_materialized_view_active_key", Keys: []IndexKey{{Column: "active"}}, Unique: true},
+		},
+	}
+}
+
+func TestMaterializedViewSQL_CreateAndGet(t *testing.T) {
+	ctx, db := testPrepareViewTestCase(t)
+	defer db.Close()
+
+	matViewRepo := NewMaterializedViewRepository(db)
+	model := mockMaterializedViewModel(t)
+	assert.NoError(t, matViewRepo.Create(ctx, model))
+
+	got, err := matViewRepo.Get(ctx, model.Schema, model.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, testViewDb, got.Database)
+	assert.Equal(t, testViewUser, got.Owner)
+	assert.Equal(t, []string{"active", "total"}, got.Columns)
+	assert.Equal(t, model.Comment, got.Comment)
+	assert.False(t, got.Populated)
+	if assert.Len(t, got.Indexes, 1) {
+		assert.Equal(t, model.Indexes[0].Name, got.Indexes[0].Name)
+		assert.Equal(t, model.Name, got.Indexes[0].Table)
+		assert.True(t, got.Indexes[0].Unique)
+	}
+
+	normalized, err := matViewRepo.Normalize(ctx, model)
+	assert.NoError(t, err)
+	result := PreserveMaterializedViewSpelling(model, *normalized, *got)
+	assert.Equal(t, model.Query, result.Query)
+	assert.Nil(t, result.Columns)
+
+	assert.NoError(t, matViewRepo.Drop(ctx, model.Schema, model.Name))
+	exists, err := matViewRepo.Exists(ctx, model.Schema, model.Name)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestMaterializedViewSQL_Update(t *testing.T) {
+	ctx, db := testPrepareViewTestCase(t)
+	defer db.Close()
+
+	matViewRepo := NewMaterializedViewRepository(db)
+	current := mockMaterializedViewModel(t)
+	assert.NoError(t, matViewRepo.Create(ctx, current))
+
+	desired := mockMaterializedViewModel(t)
+	desired.Name = "test_materialized_view_renamed"
+	desired.Comment = "test comment modified"
+	desired.Indexes = []IndexModel{
+		{Name: "test_materialized_view_total_idx", Keys: []IndexKey{{Column: "total", Order: "DESC"}}, Method: "btree"},
+	}
+
+	got, err := matViewRepo.Update(ctx, MaterializedViewUpdateParams{Current: current, Desired: desired, Refresh: true})
+	assert.NoError(t, err)
+	assert.Equal(t, desired.Name, got.Name)
+	assert.Equal(t, desired.Comment, got.Comment)
+	assert.True(t, got.Populated)
+	if assert.Len(t, got.Indexes, 1) {
+		assert.Equal(t, "test_materialized_view_total_idx", got.Indexes[0].Name)
+		assert.Equal(t, []IndexKey{{Column: "total", Order: "DESC"}}, got.Indexes[0].Keys)
+	}
+}
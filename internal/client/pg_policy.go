@@ -0,0 +1,351 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+const (
+	policyObjectType = "POLICY"
+
+	PolicyCommandAll    = "ALL"
+	PolicyCommandSelect = "SELECT"
+	PolicyCommandInsert = "INSERT"
+	PolicyCommandUpdate = "UPDATE"
+	PolicyCommandDelete = "DELETE"
+
+	// PolicyRolePublic applies the policy to every role.
+	PolicyRolePublic = "public"
+)
+
+var (
+	PolicyCommands = []string{PolicyCommandAll, PolicyCommandSelect, PolicyCommandInsert, PolicyCommandUpdate, PolicyCommandDelete}
+
+	// pg_policy.polcmd
+	policyCommandCodes = map[string]string{
+		"*": PolicyCommandAll,
+		"r": PolicyCommandSelect,
+		"a": PolicyCommandInsert,
+		"w": PolicyCommandUpdate,
+		"d": PolicyCommandDelete,
+	}
+)
+
+type policySQL struct {
+	db *sql.DB
+}
+
+// PolicyModel describes a row level security policy of a table.
+type PolicyModel struct {
+	Schema     string `json:"schema" validate:"required"`
+	Table      string `json:"table" validate:"required"`
+	Name       string `json:"name" validate:"required"`
+	Database   string `json:"database"`
+	Permissive bool   `json:"permissive"`
+	Command    string `json:"command" validate:"required,oneof=ALL SELECT INSERT UPDATE DELETE"`
+	// Roles the policy applies to, PolicyRolePublic for every role. Empty means PolicyRolePublic.
+	Roles     []string `json:"roles" validate:"unique"`
+	Using     string   `json:"using"`
+	WithCheck string   `json:"with_check"`
+	Comment   string   `json:"comment"`
+}
+
+type PolicyUpdateParams struct {
+	Current PolicyModel
+	Desired PolicyModel `validate:"required"`
+}
+
+type PolicyRepository interface {
+	Create(ctx context.Context, params PolicyModel) error
+	Drop(ctx context.Context, schema, table, name string) error
+	Get(ctx context.Context, schema, table, name string) (*PolicyModel, error)
+	Update(ctx context.Context, params PolicyUpdateParams) (*PolicyModel, error)
+	Exists(ctx context.Context, schema, table, name string) (bool, error)
+	Normalize(ctx context.Context, model PolicyModel) (*PolicyModel, error)
+}
+
+var _ PolicyRepository = &policySQL{}
+
+func NewPolicyRepository(db *sql.DB) PolicyRepository {
+	return &policySQL{
+		db: db,
+	}
+}
+
+func (p *policySQL) Create(ctx context.Context, params PolicyModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, p.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreatePolicy, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, policyCreateQuery(params))); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreatePolicy)
+	}
+
+	if params.Comment != "" {
+		if err = CreateComment(ctx, txn, policyObjectType, policyObjectName(params.Schema, params.Table, params.Name), params.Comment); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreatePolicy)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreatePolicy, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (p *policySQL) Drop(ctx context.Context, schema, table, name string) error {
+	txn, err := BeginTxWithRole(ctx, p.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropPolicy, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	err = DropObject(ctx, txn, policyObjectType, policyObjectName(schema, table, name))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropPolicy)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropPolicy, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (p *policySQL) Get(ctx context.Context, schema, table, name string) (*PolicyModel, error) {
+	model, err := readPolicy(ctx, p.db, pgQualifiedName(schema, table), name)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetPolicy)
+	}
+	return model, nil
+}
+
+// Update renames the policy and changes its roles, expressions and comment with ALTER POLICY.
+// The command and the permissive flag can't be changed, nor an expression removed.
+func (p *policySQL) Update(ctx context.Context, params PolicyUpdateParams) (*PolicyModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	current, desired := params.Current, params.Desired
+	table := pgQualifiedName(desired.Schema, desired.Table)
+
+	var statements []string
+	if current.Name != desired.Name {
+		statements = append(statements, fmt.Sprintf("ALTER POLICY %s ON %s RENAME TO %s;", pq.QuoteIdentifier(current.Name), table, pq.QuoteIdentifier(desired.Name)))
+	}
+
+	roles := desired.Roles
+	if len(roles) == 0 {
+		roles = []string{PolicyRolePublic}
+	}
+
+	var clauses []string
+	if !sameElements(current.Roles, roles) {
+		clauses = append(clauses, fmt.Sprintf("TO %s", policyRoles(roles)))
+	}
+	if desired.Using != "" && current.Using != desired.Using {
+		clauses = append(clauses, fmt.Sprintf("USING (%s)", desired.Using))
+	}
+	if desired.WithCheck != "" && current.WithCheck != desired.WithCheck {
+		clauses = append(clauses, fmt.Sprintf("WITH CHECK (%s)", desired.WithCheck))
+	}
+	if len(clauses) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER POLICY %s ON %s %s;", pq.QuoteIdentifier(desired.Name), table, strings.Join(clauses, " ")))
+	}
+	if current.Comment != desired.Comment {
+		statements = append(statements, fmt.Sprintf("COMMENT ON POLICY %s IS %s;", policyObjectName(desired.Schema, desired.Table, desired.Name), pq.QuoteLiteral(desired.Comment)))
+	}
+
+	txn, err := BeginTxWithRole(ctx, p.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdatePolicy, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdatePolicy)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdatePolicy, "pg_cmd", opCommitTransaction)
+	}
+
+	return p.Get(ctx, desired.Schema, desired.Table, desired.Name)
+}
+
+func (p *policySQL) Exists(ctx context.Context, schema, table, name string) (bool, error) {
+	existsQuery := `
+		SELECT EXISTS (SELECT 1
+					   FROM pg_catalog.pg_policy p
+					   WHERE p.polrelid = pg_catalog.to_regclass(%s)
+						 AND p.polname = %s);`
+
+	var exists bool
+	row := p.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(pgQualifiedName(schema, table)), pq.QuoteLiteral(name)))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsPolicy, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
+
+// Normalize returns the policy as PostgreSQL would store it, i.e. with the expressions through
+// pg_get_expr. The policy is created on a temporary copy of its table inside a transaction that is
+// always rolled back.
+func (p *policySQL) Normalize(ctx context.Context, model PolicyModel) (*PolicyModel, error) {
+	txn, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizePolicy, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	temporary := model
+	temporary.Schema = "pg_temp"
+	temporary.Table = "tf_normalize_" + model.Table
+
+	statements := []string{
+		fmt.Sprintf("CREATE TEMPORARY TABLE %s (LIKE %s);", pgQualifiedName(temporary.Schema, temporary.Table), pgQualifiedName(model.Schema, model.Table)),
+		policyCreateQuery(temporary),
+	}
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opNormalizePolicy)
+		}
+	}
+
+	normalized, err := readPolicy(ctx, txn, pgQualifiedName(temporary.Schema, temporary.Table), temporary.Name)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizePolicy)
+	}
+	normalized.Schema, normalized.Table, normalized.Database = model.Schema, model.Table, model.Database
+	normalized.Comment = model.Comment
+
+	return normalized, nil
+}
+
+// PreservePolicySpelling returns the actual policy, but keeping the spelling of the prior expressions
+// when their normalized form (see PolicyRepository.Normalize) matches the actual one.
+func PreservePolicySpelling(prior, normalizedPrior, actual PolicyModel) PolicyModel {
+	result := actual
+	if normalizedPrior.Using == actual.Using {
+		result.Using = prior.Using
+	}
+	if normalizedPrior.WithCheck == actual.WithCheck {
+		result.WithCheck = prior.WithCheck
+	}
+	return result
+}
+
+func policyCreateQuery(model PolicyModel) string {
+	kind := "RESTRICTIVE"
+	if model.Permissive {
+		kind = "PERMISSIVE"
+	}
+	roles := model.Roles
+	if len(roles) == 0 {
+		roles = []string{PolicyRolePublic}
+	}
+
+	query := fmt.Sprintf("CREATE POLICY %s ON %s AS %s FOR %s TO %s",
+		pq.QuoteIdentifier(model.Name), pgQualifiedName(model.Schema, model.Table), kind, model.Command, policyRoles(roles))
+	if model.Using != "" {
+		query += fmt.Sprintf(" USING (%s)", model.Using)
+	}
+	if model.WithCheck != "" {
+		query += fmt.Sprintf(" WITH CHECK (%s)", model.WithCheck)
+	}
+	return query + ";"
+}
+
+// policyRoles quotes the roles of a policy, but for the public pseudo-role which is a keyword.
+func policyRoles(roles []string) string {
+	quoted := make([]string, len(roles))
+	for i, role := range roles {
+		if role == PolicyRolePublic {
+			quoted[i] = "PUBLIC"
+		} else {
+			quoted[i] = pq.QuoteIdentifier(role)
+		}
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// policyObjectName returns the name of a policy in the COMMENT ON and DROP statements, i.e. 'name ON table'.
+func policyObjectName(schema, table, name string) string {
+	return fmt.Sprintf("%s ON %s", pq.QuoteIdentifier(name), pgQualifiedName(schema, table))
+}
+
+// sameElements reports whether both slices hold the same elements, regardless of their order.
+func sameElements(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[string]int, len(a))
+	for _, item := range a {
+		counts[item]++
+	}
+	for _, item := range b {
+		if counts[item] == 0 {
+			return false
+		}
+		counts[item]--
+	}
+	return true
+}
+
+// readPolicy reads the policy with the given name on the table with the given qualified name.
+func readPolicy(ctx context.Context, q pgQueryer, qualifiedTable, name string) (*PolicyModel, error) {
+	var model PolicyModel
+	var commandCode string
+
+	policyQuery := `
+		SELECT n.nspname                                                      as "schema",
+			   c.relname                                                      as "table",
+			   p.polname                                                      as "name",
+			   pg_catalog.current_database()                                  as "database",
+			   p.polpermissive                                                as "permissive",
+			   p.polcmd                                                       as "command",
+			   ARRAY(SELECT CASE WHEN r.oid = 0 THEN 'public' ELSE pg_catalog.pg_get_userbyid(r.oid) END
+					 FROM unnest(p.polroles) r(oid)
+					 ORDER BY 1)                                              as "roles",
+			   COALESCE(pg_catalog.pg_get_expr(p.polqual, p.polrelid), '')     as "using",
+			   COALESCE(pg_catalog.pg_get_expr(p.polwithcheck, p.polrelid), '') as "with_check",
+			   COALESCE(pg_catalog.obj_description(p.oid, 'pg_policy'), '')    as "comment"
+		FROM pg_catalog.pg_policy p
+				 JOIN pg_catalog.pg_class c ON c.oid = p.polrelid
+				 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE p.polrelid = pg_catalog.to_regclass(%s)
+		  AND p.polname = %s;`
+
+	row := q.QueryRowContext(ctx, fmt.Sprintf(policyQuery, pq.QuoteLiteral(qualifiedTable), pq.QuoteLiteral(name)))
+	err := row.Scan(
+		&model.Schema,
+		&model.Table,
+		&model.Name,
+		&model.Database,
+		&model.Permissive,
+		&commandCode,
+		(*pq.StringArray)(&model.Roles),
+		&model.Using,
+		&model.WithCheck,
+		&model.Comment,
+	)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "policy")
+	}
+	model.Command = policyCommandCodes[commandCode]
+
+	return &model, nil
+}
@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+const (
+	testPolicyDb   = "test_policy_db"
+	testPolicyUser = "test_policy_user"
+)
+
+func testPreparePolicyTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: testPolicyDb,
+		Username: testPolicyUser,
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE public.test_policy_table (id int, tenant text, owner_name text);
+		CREATE ROLE test_policy_reader;`)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func mockPolicyModel(t *testing.T) PolicyModel {
+	t.Helper()
+	return PolicyModel{
+		Schema:     "public",
+		Table:      "test_policy_table",
+		Name:       "test_policy",
+		Permissive: true,
+		Command:    PolicyCommandUpdate,
+		Roles:      []string{"test_policy_reader"},
+		Using:      "owner_name = current_user",
+		WithCheck:  "tenant IS NOT NULL",
+		Comment:    "test comment",
+	}
+}
+
+func TestPolicyCreateQuery(t *testing.T) {
+	model := mockPolicyModel(t)
+	assert.Equal(t,
+		`CREATE POLICY "test_policy" ON "public"."test_policy_table" AS PERMISSIVE FOR UPDATE TO "test_policy_reader" USING (owner_name = current_user) WITH CHECK (tenant IS NOT NULL);`,
+		policyCreateQuery(model),
+	)
+
+	model = PolicyModel{Schema: "public", Table: "t", Name: "p", Command: PolicyCommandInsert, WithCheck: "true"}
+	assert.Equal(t,
+		`CREATE POLICY "p" ON "public"."t" AS RESTRICTIVE FOR INSERT TO PUBLIC WITH CHECK (true);`,
+		policyCreateQuery(model),
+	)
+}
+
+func TestSameElements(t *testing.T) {
+	assert.True(t, sameElements(nil, []string{}))
+	assert.True(t, sameElements([]string{"a", "b"}, []string{"b", "a"}))
+	assert.False(t, sameElements([]string{"a", "a"}, []string{"a", "b"}))
+	assert.False(t, sameElements([]string{"a"}, []string{"a", "b"}))
+}
+
+func TestPolicySQL_CreateAndGet(t *testing.T) {
+	ctx, db := testPreparePolicyTestCase(t)
+	defer db.Close()
+
+	policyRepo := NewPolicyRepository(db)
+	model := mockPolicyModel(t)
+	assert.NoError(t, policyRepo.Create(ctx, model))
+
+	got, err := policyRepo.Get(ctx, model.Schema, model.Table, model.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, testPolicyDb, got.Database)
+	assert.True(t, got.Permissive)
+	assert.Equal(t, model.Command, got.Command)
+	assert.Equal(t, model.Roles, got.Roles)
+	assert.Equal(t, "(owner_name = CURRENT_USER)", got.Using)
+	assert.Equal(t, "(tenant IS NOT NULL)", got.WithCheck)
+	assert.Equal(t, model.Comment, got.Comment)
+
+	normalized, err := policyRepo.Normalize(ctx, model)
+	assert.NoError(t, err)
+	preserved := PreservePolicySpelling(model, *normalized, *got)
+	assert.Equal(t, model.Using, preserved.Using)
+	assert.Equal(t, model.WithCheck, preserved.WithCheck)
+
+	assert.NoError(t, policyRepo.Drop(ctx, model.Schema, model.Table, model.Name))
+	exists, err := policyRepo.Exists(ctx, model.Schema, model.Table, model.Name)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestPolicySQL_Update(t *testing.T) {
+	ctx, db := testPreparePolicyTestCase(t)
+	defer db.Close()
+
+	policyRepo := NewPolicyRepository(db)
+	current := mockPolicyModel(t)
+	assert.NoError(t, policyRepo.Create(ctx, current))
+
+	desired := current
+	desired.Name = "test_policy_renamed"
+	// no roles stands for every role
+	desired.Roles = nil
+	desired.Using = "tenant = 'a'"
+	desired.Comment = ""
+
+	got, err := policyRepo.Update(ctx, PolicyUpdateParams{Current: current, Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, desired.Name, got.Name)
+	assert.Equal(t, []string{PolicyRolePublic}, got.Roles)
+	assert.Equal(t, "(tenant = 'a'::text)", got.Using)
+	assert.Equal(t, "(tenant IS NOT NULL)", got.WithCheck)
+	assert.Empty(t, got.Comment)
+}
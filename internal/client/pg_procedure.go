@@ -0,0 +1,291 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+const procedureObjectType = "PROCEDURE"
+
+type procedureSQL struct {
+	db *sql.DB
+}
+
+// ProcedureModel describes a stored procedure, a routine without result invoked with CALL.
+// A procedure is identified by its schema, name and the types of its arguments.
+type ProcedureModel struct {
+	Schema   string       `json:"schema" validate:"required"`
+	Name     string       `json:"name" validate:"required"`
+	Database string       `json:"database"`
+	Args     []RoutineArg `json:"args" validate:"dive"`
+	Language string       `json:"language" validate:"required"`
+	Body     string       `json:"body" validate:"required"`
+	// SecurityDefiner runs the procedure with the privileges of its owner. Such a procedure can't
+	// execute transaction control statements (COMMIT, ROLLBACK).
+	SecurityDefiner bool   `json:"security_definer"`
+	Owner           string `json:"owner"`
+	Comment         string `json:"comment"`
+}
+
+type ProcedureUpdateParams struct {
+	Current ProcedureModel
+	Desired ProcedureModel `validate:"required"`
+}
+
+type ProcedureRepository interface {
+	Create(ctx context.Context, params ProcedureModel) error
+	Drop(ctx context.Context, schema, name string, argTypes []string) error
+	Get(ctx context.Context, schema, name string, argTypes []string) (*ProcedureModel, error)
+	Update(ctx context.Context, params ProcedureUpdateParams) (*ProcedureModel, error)
+	Exists(ctx context.Context, schema, name string, argTypes []string) (bool, error)
+	Normalize(ctx context.Context, model ProcedureModel) (*ProcedureModel, error)
+	Call(ctx context.Context, schema, name string, args ...string) error
+}
+
+var _ ProcedureRepository = &procedureSQL{}
+
+func NewProcedureRepository(db *sql.DB) ProcedureRepository {
+	return &procedureSQL{
+		db: db,
+	}
+}
+
+func (p *procedureSQL) Create(ctx context.Context, params ProcedureModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, p.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateProcedure, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, procedureCreateQuery(params, false))); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateProcedure)
+	}
+
+	if (params.Owner != "" && params.Owner != GetAssumeRoleFromCtx(ctx)) || params.Comment != "" {
+		identityArgs, err := routineIdentityArgs(ctx, txn, routineOid(params.Schema, params.Name, RoutineArgTypes(params.Args)))
+		if err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateProcedure)
+		}
+		name := routineName(params.Schema, params.Name, identityArgs)
+
+		if params.Owner != "" && params.Owner != GetAssumeRoleFromCtx(ctx) {
+			statement := fmt.Sprintf("ALTER %s %s OWNER TO %s;", procedureObjectType, name, pq.QuoteIdentifier(params.Owner))
+			if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+				return PgErrWithMetadata(err, "operation", opCreateProcedure)
+			}
+		}
+		if params.Comment != "" {
+			if err = CreateComment(ctx, txn, procedureObjectType, name, params.Comment); err != nil {
+				return PgErrWithMetadata(err, "operation", opCreateProcedure)
+			}
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateProcedure, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (p *procedureSQL) Drop(ctx context.Context, schema, name string, argTypes []string) error {
+	txn, err := BeginTxWithRole(ctx, p.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropProcedure, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	identityArgs, err := routineIdentityArgs(ctx, txn, routineOid(schema, name, argTypes))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropProcedure)
+	}
+
+	if err = DropObject(ctx, txn, procedureObjectType, routineName(schema, name, identityArgs)); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropProcedure)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropProcedure, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (p *procedureSQL) Get(ctx context.Context, schema, name string, argTypes []string) (*ProcedureModel, error) {
+	model, err := readProcedure(ctx, p.db, routineOid(schema, name, argTypes))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetProcedure)
+	}
+	return model, nil
+}
+
+// Update renames the procedure and replaces its definition with CREATE OR REPLACE when the language,
+// the body or the security changed. The arguments can't be changed: that is another procedure.
+func (p *procedureSQL) Update(ctx context.Context, params ProcedureUpdateParams) (*ProcedureModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, p.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateProcedure, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	current := params.Current
+	identityArgs, err := routineIdentityArgs(ctx, txn, routineOid(current.Schema, current.Name, RoutineArgTypes(current.Args)))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateProcedure)
+	}
+
+	for _, statement := range procedureUpdateStatements(current, params.Desired, identityArgs) {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateProcedure)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateProcedure, "pg_cmd", opCommitTransaction)
+	}
+
+	return p.Get(ctx, params.Desired.Schema, params.Desired.Name, RoutineArgTypes(params.Desired.Args))
+}
+
+func (p *procedureSQL) Exists(ctx context.Context, schema, name string, argTypes []string) (bool, error) {
+	existsQuery := `
+		SELECT EXISTS (SELECT 1
+					   FROM pg_catalog.pg_proc p
+					   WHERE p.oid = %s
+						 AND p.prokind = 'p');`
+
+	var exists bool
+	row := p.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, routineOid(schema, name, argTypes)))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsProcedure, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
+
+// Normalize returns the definition as PostgreSQL would store it, with the argument types through
+// format_type. The definition is created as a temporary procedure inside a transaction that is
+// always rolled back.
+func (p *procedureSQL) Normalize(ctx context.Context, model ProcedureModel) (*ProcedureModel, error) {
+	txn, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeProcedure, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	temporary := model
+	temporary.Schema = "pg_temp"
+	temporary.Name = "tf_normalize_" + model.Name
+
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, procedureCreateQuery(temporary, false))); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeProcedure)
+	}
+
+	normalized, err := readProcedure(ctx, txn, routineOid(temporary.Schema, temporary.Name, RoutineArgTypes(temporary.Args)))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeProcedure)
+	}
+	normalized.Schema, normalized.Name, normalized.Database = model.Schema, model.Name, model.Database
+	normalized.Owner, normalized.Comment = model.Owner, model.Comment
+
+	return normalized, nil
+}
+
+// Call invokes the procedure with the given SQL expressions as arguments. The CALL runs outside
+// of a transaction block, so the procedure is free to COMMIT or ROLLBACK its work.
+func (p *procedureSQL) Call(ctx context.Context, schema, name string, args ...string) error {
+	statement := fmt.Sprintf("CALL %s(%s);", pgQualifiedName(schema, name), strings.Join(args, ", "))
+	if err := ExecWithRole(ctx, p.db, statement); err != nil {
+		return PgErrWithMetadata(err, "operation", opCallProcedure)
+	}
+	return nil
+}
+
+// PreserveProcedureSpelling returns the actual procedure, but keeping the spelling of the prior
+// argument types whose normalized form (see ProcedureRepository.Normalize) matches the actual one.
+func PreserveProcedureSpelling(prior, normalizedPrior, actual ProcedureModel) ProcedureModel {
+	result := actual
+	result.Args = PreserveRoutineArgsSpelling(prior.Args, normalizedPrior.Args, actual.Args)
+	return result
+}
+
+func procedureCreateQuery(model ProcedureModel, replace bool) string {
+	var orReplace, security string
+	if replace {
+		orReplace = " OR REPLACE"
+	}
+	if model.SecurityDefiner {
+		security = " SECURITY DEFINER"
+	}
+
+	return fmt.Sprintf("CREATE%s PROCEDURE %s(%s) LANGUAGE %s%s AS %s;",
+		orReplace,
+		pgQualifiedName(model.Schema, model.Name),
+		routineArgsDefinition(model.Args),
+		pq.QuoteIdentifier(model.Language),
+		security,
+		pgDollarQuote(model.Body),
+	)
+}
+
+// procedureUpdateStatements returns the statements turning the current procedure into the desired
+// one, given the identity arguments of the current procedure.
+func procedureUpdateStatements(current, desired ProcedureModel, identityArgs string) []string {
+	statements := routineRenameStatements(procedureObjectType, identityArgs, current.Schema, current.Name, desired.Schema, desired.Name)
+	name := routineName(desired.Schema, desired.Name, identityArgs)
+
+	if current.Language != desired.Language || current.Body != desired.Body || current.SecurityDefiner != desired.SecurityDefiner {
+		statements = append(statements, procedureCreateQuery(desired, true))
+	}
+	if desired.Owner != "" && current.Owner != desired.Owner {
+		statements = append(statements, fmt.Sprintf("ALTER %s %s OWNER TO %s;", procedureObjectType, name, pq.QuoteIdentifier(desired.Owner)))
+	}
+	if current.Comment != desired.Comment {
+		statements = append(statements, fmt.Sprintf("COMMENT ON %s %s IS %s;", procedureObjectType, name, pq.QuoteLiteral(desired.Comment)))
+	}
+	return statements
+}
+
+// readProcedure reads the definition of the procedure whose oid is returned by the SQL expression.
+func readProcedure(ctx context.Context, q pgQueryer, oid string) (*ProcedureModel, error) {
+	procedureQuery := `
+		SELECT n.nspname                                                 as "schema",
+			   p.proname                                                 as "name",
+			   pg_catalog.current_database()                             as "database",
+			   l.lanname                                                 as "language",
+			   p.prosrc                                                  as "body",
+			   p.prosecdef                                               as "security_definer",
+			   pg_catalog.pg_get_userbyid(p.proowner)                    as "owner",
+			   COALESCE(pg_catalog.obj_description(p.oid, 'pg_proc'), '') as "comment"
+		FROM pg_catalog.pg_proc p
+				 JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace
+				 JOIN pg_catalog.pg_language l ON l.oid = p.prolang
+		WHERE p.oid = %s
+		  AND p.prokind = 'p';`
+
+	var model ProcedureModel
+	row := q.QueryRowContext(ctx, fmt.Sprintf(procedureQuery, oid))
+	err := row.Scan(&model.Schema, &model.Name, &model.Database, &model.Language, &model.Body, &model.SecurityDefiner, &model.Owner, &model.Comment)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opScanRowResult, "model", "procedure")
+	}
+
+	if model.Args, err = readRoutineArgs(ctx, q, oid); err != nil {
+		return nil, err
+	}
+	return &model, nil
+}
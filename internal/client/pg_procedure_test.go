@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func testPrepareProcedureTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_procedure_db",
+		Username: "test_procedure_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `
+		CREATE ROLE test_procedure_owner;
+		CREATE SCHEMA app;
+		CREATE TABLE public.test_audit (id serial PRIMARY KEY, note text);`)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func mockProcedureModel() ProcedureModel {
+	return ProcedureModel{
+		Schema:   "public",
+		Name:     "test_insert_audit",
+		Args:     []RoutineArg{{Name: "note", Type: "text"}, {Name: "times", Type: "int", Mode: "IN"}},
+		Language: "plpgsql",
+		Body: `
+BEGIN
+	FOR i IN 1..times LOOP
+		INSERT INTO public.test_audit (note) VALUES (note);
+		COMMIT;
+	END LOOP;
+END;`,
+	}
+}
+
+func TestProcedureCreateQuery(t *testing.T) {
+	model := ProcedureModel{
+		Schema:          "app",
+		Name:            "archive",
+		Args:            []RoutineArg{{Name: "days", Type: "int"}, {Name: "moved", Type: "bigint", Mode: "INOUT"}},
+		Language:        "sql",
+		Body:            "DELETE FROM app.events",
+		SecurityDefiner: true,
+	}
+
+	assert.Equal(t,
+		`CREATE PROCEDURE "app"."archive"("days" int, INOUT "moved" bigint) LANGUAGE "sql" SECURITY DEFINER AS $body$DELETE FROM app.events$body$;`,
+		procedureCreateQuery(model, false))
+
+	model.Args, model.SecurityDefiner = nil, false
+	assert.Equal(t,
+		`CREATE OR REPLACE PROCEDURE "app"."archive"() LANGUAGE "sql" AS $body$DELETE FROM app.events$body$;`,
+		procedureCreateQuery(model, true))
+}
+
+func TestProcedureUpdateStatements(t *testing.T) {
+	current := ProcedureModel{Schema: "public", Name: "archive", Language: "sql", Body: "SELECT 1", Owner: "dba"}
+
+	desired := current
+	desired.Schema, desired.Name, desired.Owner, desired.Comment = "app", "purge", "admin", "nightly"
+	assert.Equal(t, []string{
+		`ALTER PROCEDURE "public"."archive"(days integer) RENAME TO "purge";`,
+		`ALTER PROCEDURE "public"."purge"(days integer) SET SCHEMA "app";`,
+		`ALTER PROCEDURE "app"."purge"(days integer) OWNER TO "admin";`,
+		`COMMENT ON PROCEDURE "app"."purge"(days integer) IS 'nightly';`,
+	}, procedureUpdateStatements(current, desired, "days integer"))
+
+	desired = current
+	desired.Body = "SELECT 2"
+	assert.Equal(t, []string{procedureCreateQuery(desired, true)}, procedureUpdateStatements(current, desired, ""))
+
+	assert.Empty(t, procedureUpdateStatements(current, current, ""))
+}
+
+func TestProcedureSQL_CreateCallAndUpdate(t *testing.T) {
+	ctx, db := testPrepareProcedureTestCase(t)
+	defer db.Close()
+
+	repo := NewProcedureRepository(db)
+	model := mockProcedureModel()
+	model.Comment = "test comment"
+	require.NoError(t, repo.Create(ctx, model))
+
+	got, err := repo.Get(ctx, "public", "test_insert_audit", []string{"text", "int"})
+	require.NoError(t, err)
+	assert.Equal(t, []RoutineArg{{Name: "note", Type: "text", Mode: "IN"}, {Name: "times", Type: "integer", Mode: "IN"}}, got.Args)
+	assert.Equal(t, "plpgsql", got.Language)
+	assert.Equal(t, model.Body, got.Body)
+	assert.False(t, got.SecurityDefiner)
+	assert.Equal(t, "test_procedure_user", got.Owner)
+	assert.Equal(t, "test comment", got.Comment)
+
+	// the procedure commits every row, which is only allowed outside a transaction block
+	require.NoError(t, repo.Call(ctx, "public", "test_insert_audit", "'called'", "3"))
+	var count int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT count(*) FROM public.test_audit WHERE note = 'called';`).Scan(&count))
+	assert.Equal(t, 3, count)
+
+	desired := *got
+	desired.Schema = "app"
+	desired.Name = "test_audit_notes"
+	desired.SecurityDefiner = true
+	desired.Body = `
+BEGIN
+	INSERT INTO public.test_audit (note) SELECT note FROM generate_series(1, times);
+END;`
+	desired.Owner = "test_procedure_owner"
+	desired.Comment = ""
+	got, err = repo.Update(ctx, ProcedureUpdateParams{Current: *got, Desired: desired})
+	require.NoError(t, err)
+	assert.Equal(t, desired, *got)
+
+	exists, err := repo.Exists(ctx, "public", "test_insert_audit", []string{"text", "integer"})
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// a security definer procedure can't commit, but the insert above doesn't
+	require.NoError(t, repo.Call(ctx, "app", "test_audit_notes", "'definer'", "2"))
+
+	require.NoError(t, repo.Drop(ctx, "app", "test_audit_notes", []string{"text", "integer"}))
+	exists, err = repo.Exists(ctx, "app", "test_audit_notes", []string{"text", "integer"})
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// dropping a missing procedure is not an error
+	assert.NoError(t, repo.Drop(ctx, "app", "test_audit_notes", []string{"text", "integer"}))
+}
+
+func TestProcedureSQL_NormalizeAndPreserveSpelling(t *testing.T) {
+	ctx, db := testPrepareProcedureTestCase(t)
+	defer db.Close()
+
+	repo := NewProcedureRepository(db)
+	prior := mockProcedureModel()
+	prior.Args = []RoutineArg{{Name: "note", Type: "varchar", Mode: "IN"}, {Name: "times", Type: "int4", Mode: "INOUT"}}
+	require.NoError(t, repo.Create(ctx, prior))
+
+	normalized, err := repo.Normalize(ctx, prior)
+	require.NoError(t, err)
+	assert.Equal(t, []RoutineArg{{Name: "note", Type: "character varying", Mode: "IN"}, {Name: "times", Type: "integer", Mode: "INOUT"}}, normalized.Args)
+	assert.Equal(t, prior.Name, normalized.Name)
+
+	actual, err := repo.Get(ctx, "public", prior.Name, []string{"varchar", "int4"})
+	require.NoError(t, err)
+	assert.Equal(t, prior.Args, PreserveProcedureSpelling(prior, *normalized, *actual).Args)
+
+	exists, err := repo.Exists(ctx, "pg_temp", "tf_normalize_"+prior.Name, []string{"varchar", "int4"})
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,451 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+const (
+	publicationObjectType = "PUBLICATION"
+
+	PublicationOperationInsert   = "insert"
+	PublicationOperationUpdate   = "update"
+	PublicationOperationDelete   = "delete"
+	PublicationOperationTruncate = "truncate"
+)
+
+var PublicationOperations = []string{PublicationOperationInsert, PublicationOperationUpdate, PublicationOperationDelete, PublicationOperationTruncate}
+
+type publicationSQL struct {
+	db *sql.DB
+}
+
+// PublicationModel describes a publication, the set of changes a logical replication publisher sends.
+type PublicationModel struct {
+	Name     string `json:"name" validate:"required"`
+	Database string `json:"database"`
+	// AllTables publishes every table of the database, including the future ones.
+	AllTables bool               `json:"all_tables"`
+	Tables    []PublicationTable `json:"tables" validate:"dive"`
+	// Schemas publishes every table of the schemas (TABLES IN SCHEMA), including the future ones.
+	Schemas []string `json:"schemas" validate:"unique"`
+	// Publish lists the published operations, every operation when empty.
+	Publish                 []string `json:"publish" validate:"unique,dive,oneof=insert update delete truncate"`
+	PublishViaPartitionRoot bool     `json:"publish_via_partition_root"`
+	Owner                   string   `json:"owner"`
+	Comment                 string   `json:"comment"`
+}
+
+// PublicationTable is a table of a publication, optionally limited to some columns and rows.
+type PublicationTable struct {
+	Schema    string   `json:"schema" validate:"required"`
+	Name      string   `json:"name" validate:"required"`
+	Columns   []string `json:"columns" validate:"unique"`
+	RowFilter string   `json:"row_filter"`
+}
+
+type PublicationUpdateParams struct {
+	Current PublicationModel
+	Desired PublicationModel `validate:"required"`
+}
+
+type PublicationRepository interface {
+	Create(ctx context.Context, params PublicationModel) error
+	Drop(ctx context.Context, name string) error
+	Get(ctx context.Context, name string) (*PublicationModel, error)
+	Update(ctx context.Context, params PublicationUpdateParams) (*PublicationModel, error)
+	Exists(ctx context.Context, name string) (bool, error)
+	Normalize(ctx context.Context, model PublicationModel) (*PublicationModel, error)
+}
+
+var _ PublicationRepository = &publicationSQL{}
+
+func NewPublicationRepository(db *sql.DB) PublicationRepository {
+	return &publicationSQL{
+		db: db,
+	}
+}
+
+func (p *publicationSQL) Create(ctx context.Context, params PublicationModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, p.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreatePublication, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	statements := []string{publicationCreateQuery(params)}
+	if params.Owner != "" && params.Owner != GetAssumeRoleFromCtx(ctx) {
+		statements = append(statements, fmt.Sprintf("ALTER PUBLICATION %s OWNER TO %s;", pq.QuoteIdentifier(params.Name), pq.QuoteIdentifier(params.Owner)))
+	}
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreatePublication)
+		}
+	}
+
+	if params.Comment != "" {
+		if err = CreateComment(ctx, txn, publicationObjectType, pq.QuoteIdentifier(params.Name), params.Comment); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreatePublication)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreatePublication, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (p *publicationSQL) Drop(ctx context.Context, name string) error {
+	txn, err := BeginTxWithRole(ctx, p.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropPublication, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	err = DropObject(ctx, txn, publicationObjectType, pq.QuoteIdentifier(name))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropPublication)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropPublication, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (p *publicationSQL) Get(ctx context.Context, name string) (*PublicationModel, error) {
+	model, err := readPublication(ctx, p.db, name)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetPublication)
+	}
+	return model, nil
+}
+
+// Update applies the changes with ALTER PUBLICATION. The published tables and schemas are replaced
+// as a whole (SET), or dropped when none is left. Switching from or to all tables is not possible.
+func (p *publicationSQL) Update(ctx context.Context, params PublicationUpdateParams) (*PublicationModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	current, desired := params.Current, params.Desired
+	name := pq.QuoteIdentifier(desired.Name)
+
+	var statements []string
+	if current.Name != desired.Name {
+		statements = append(statements, fmt.Sprintf("ALTER PUBLICATION %s RENAME TO %s;", pq.QuoteIdentifier(current.Name), name))
+	}
+	if !desired.AllTables {
+		currentObjects, desiredObjects := publicationObjects(current, true), publicationObjects(desired, true)
+		switch {
+		case currentObjects == desiredObjects:
+		case desiredObjects != "":
+			statements = append(statements, fmt.Sprintf("ALTER PUBLICATION %s SET %s;", name, desiredObjects))
+		default:
+			// SET needs at least one object, the remaining ones are dropped by name
+			statements = append(statements, fmt.Sprintf("ALTER PUBLICATION %s DROP %s;", name, publicationObjects(current, false)))
+		}
+	}
+	var options []string
+	if !sameElements(publicationOperations(current.Publish), publicationOperations(desired.Publish)) {
+		options = append(options, fmt.Sprintf("publish = %s", pq.QuoteLiteral(strings.Join(publicationOperations(desired.Publish), ", "))))
+	}
+	if current.PublishViaPartitionRoot != desired.PublishViaPartitionRoot {
+		options = append(options, fmt.Sprintf("publish_via_partition_root = %t", desired.PublishViaPartitionRoot))
+	}
+	if len(options) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER PUBLICATION %s SET (%s);", name, strings.Join(options, ", ")))
+	}
+	if desired.Owner != "" && current.Owner != desired.Owner {
+		statements = append(statements, fmt.Sprintf("ALTER PUBLICATION %s OWNER TO %s;", name, pq.QuoteIdentifier(desired.Owner)))
+	}
+	if current.Comment != desired.Comment {
+		statements = append(statements, fmt.Sprintf("COMMENT ON PUBLICATION %s IS %s;", name, pq.QuoteLiteral(desired.Comment)))
+	}
+
+	txn, err := BeginTxWithRole(ctx, p.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdatePublication, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdatePublication)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdatePublication, "pg_cmd", opCommitTransaction)
+	}
+
+	return p.Get(ctx, desired.Name)
+}
+
+func (p *publicationSQL) Exists(ctx context.Context, name string) (bool, error) {
+	existsQuery := `SELECT EXISTS (SELECT 1 FROM pg_catalog.pg_publication p WHERE p.pubname = %s);`
+
+	var exists bool
+	row := p.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(name)))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsPublication, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
+
+// Normalize returns the publication as PostgreSQL would store it, i.e. with the row filters through
+// pg_get_expr. A copy of the publication is created inside a transaction that is always rolled back.
+func (p *publicationSQL) Normalize(ctx context.Context, model PublicationModel) (*PublicationModel, error) {
+	txn, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizePublication, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	temporary := model
+	temporary.Name = "tf_normalize_" + model.Name
+
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, publicationCreateQuery(temporary))); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizePublication)
+	}
+
+	normalized, err := readPublication(ctx, txn, temporary.Name)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizePublication)
+	}
+	normalized.Name, normalized.Database = model.Name, model.Database
+	normalized.Owner, normalized.Comment = model.Owner, model.Comment
+
+	return normalized, nil
+}
+
+// PreservePublicationSpelling returns the actual publication, but keeping the order of the prior
+// tables, columns, schemas and operations, and the spelling of the prior row filters when their
+// normalized form (see PublicationRepository.Normalize) matches the actual one.
+func PreservePublicationSpelling(prior, normalizedPrior, actual PublicationModel) PublicationModel {
+	result := actual
+	tableName := func(table PublicationTable) string {
+		return pgQualifiedName(table.Schema, table.Name)
+	}
+
+	result.Tables = orderLike(actual.Tables, prior.Tables, tableName)
+	for i, table := range result.Tables {
+		priorIdx := indexByName(prior.Tables, tableName(table), tableName)
+		normalizedIdx := indexByName(normalizedPrior.Tables, tableName(table), tableName)
+		if priorIdx < 0 || normalizedIdx < 0 {
+			continue
+		}
+		priorTable, normalizedTable := prior.Tables[priorIdx], normalizedPrior.Tables[normalizedIdx]
+		if sameElements(priorTable.Columns, table.Columns) {
+			result.Tables[i].Columns = priorTable.Columns
+		}
+		if normalizedTable.RowFilter == table.RowFilter {
+			result.Tables[i].RowFilter = priorTable.RowFilter
+		}
+	}
+
+	identity := func(item string) string { return item }
+	result.Schemas = orderLike(actual.Schemas, prior.Schemas, identity)
+	result.Publish = orderLike(actual.Publish, prior.Publish, identity)
+	return result
+}
+
+func publicationCreateQuery(model PublicationModel) string {
+	query := fmt.Sprintf("CREATE PUBLICATION %s", pq.QuoteIdentifier(model.Name))
+	if model.AllTables {
+		query += " FOR ALL TABLES"
+	} else if objects := publicationObjects(model, true); objects != "" {
+		query += " FOR " + objects
+	}
+
+	var options []string
+	if len(model.Publish) > 0 {
+		options = append(options, fmt.Sprintf("publish = %s", pq.QuoteLiteral(strings.Join(model.Publish, ", "))))
+	}
+	if model.PublishViaPartitionRoot {
+		options = append(options, "publish_via_partition_root = true")
+	}
+	if len(options) > 0 {
+		query += fmt.Sprintf(" WITH (%s)", strings.Join(options, ", "))
+	}
+	return query + ";"
+}
+
+// publicationObjects returns the tables and schemas of a publication, as in FOR, SET and DROP,
+// e.g. 'TABLE "public"."a" ("id") WHERE (id > 0), TABLES IN SCHEMA "b"'. The column lists and row
+// filters are only included when detailed, DROP refuses them.
+func publicationObjects(model PublicationModel, detailed bool) string {
+	var objects []string
+	if len(model.Tables) > 0 {
+		tables := make([]string, len(model.Tables))
+		for i, table := range model.Tables {
+			tables[i] = pgQualifiedName(table.Schema, table.Name)
+			if detailed && len(table.Columns) > 0 {
+				tables[i] += fmt.Sprintf(" (%s)", pgQuoteListOfIdentifiers(table.Columns))
+			}
+			if detailed && table.RowFilter != "" {
+				tables[i] += fmt.Sprintf(" WHERE (%s)", table.RowFilter)
+			}
+		}
+		objects = append(objects, "TABLE "+strings.Join(tables, ", "))
+	}
+	if len(model.Schemas) > 0 {
+		objects = append(objects, "TABLES IN SCHEMA "+pgQuoteListOfIdentifiers(model.Schemas))
+	}
+	return strings.Join(objects, ", ")
+}
+
+// publicationOperations returns the published operations, every operation when none is given.
+func publicationOperations(publish []string) []string {
+	if len(publish) == 0 {
+		return PublicationOperations
+	}
+	return publish
+}
+
+// readPublication reads the publication with its tables and schemas. The column lists, row filters
+// and schemas are only read from PostgreSQL 15, and publish_via_partition_root from PostgreSQL 13.
+func readPublication(ctx context.Context, q pgQueryer, name string) (*PublicationModel, error) {
+	versionNum, err := serverVersionNum(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	var model PublicationModel
+	var insert, update, del, truncate bool
+
+	viaRoot := "false"
+	if versionNum >= 130000 {
+		viaRoot = "p.pubviaroot"
+	}
+
+	publicationQuery := `
+		SELECT p.pubname                                                         as "name",
+			   pg_catalog.current_database()                                     as "database",
+			   p.puballtables                                                    as "all_tables",
+			   p.pubinsert                                                       as "insert",
+			   p.pubupdate                                                       as "update",
+			   p.pubdelete                                                       as "delete",
+			   p.pubtruncate                                                     as "truncate",
+			   %s                                                                as "publish_via_partition_root",
+			   pg_catalog.pg_get_userbyid(p.pubowner)                            as "owner",
+			   COALESCE(pg_catalog.obj_description(p.oid, 'pg_publication'), '') as "comment"
+		FROM pg_catalog.pg_publication p
+		WHERE p.pubname = %s;`
+
+	row := q.QueryRowContext(ctx, fmt.Sprintf(publicationQuery, viaRoot, pq.QuoteLiteral(name)))
+	err = row.Scan(
+		&model.Name,
+		&model.Database,
+		&model.AllTables,
+		&insert,
+		&update,
+		&del,
+		&truncate,
+		&model.PublishViaPartitionRoot,
+		&model.Owner,
+		&model.Comment,
+	)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "publication")
+	}
+	for i, published := range []bool{insert, update, del, truncate} {
+		if published {
+			model.Publish = append(model.Publish, PublicationOperations[i])
+		}
+	}
+
+	if model.Tables, err = readPublicationTables(ctx, q, name, versionNum); err != nil {
+		return nil, err
+	}
+	if versionNum >= 150000 {
+		if model.Schemas, err = readPublicationSchemas(ctx, q, name); err != nil {
+			return nil, err
+		}
+	}
+
+	return &model, nil
+}
+
+func readPublicationTables(ctx context.Context, q pgQueryer, name string, versionNum int) ([]PublicationTable, error) {
+	columns, rowFilter := "'{}'::text[]", "''"
+	if versionNum >= 150000 {
+		columns = `ARRAY(SELECT a.attname
+						 FROM unnest(pr.prattrs::int2[]) k(attnum)
+								  JOIN pg_catalog.pg_attribute a ON a.attrelid = pr.prrelid AND a.attnum = k.attnum
+						 ORDER BY k.attnum)`
+		rowFilter = "COALESCE(pg_catalog.pg_get_expr(pr.prqual, pr.prrelid), '')"
+	}
+
+	tablesQuery := `
+		SELECT n.nspname as "schema",
+			   c.relname as "name",
+			   %s        as "columns",
+			   %s        as "row_filter"
+		FROM pg_catalog.pg_publication_rel pr
+				 JOIN pg_catalog.pg_publication p ON p.oid = pr.prpubid
+				 JOIN pg_catalog.pg_class c ON c.oid = pr.prrelid
+				 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE p.pubname = %s
+		ORDER BY n.nspname, c.relname;`
+
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(tablesQuery, columns, rowFilter, pq.QuoteLiteral(name)))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery)
+	}
+	defer rows.Close()
+
+	var tables []PublicationTable
+	for rows.Next() {
+		var table PublicationTable
+		if err = rows.Scan(&table.Schema, &table.Name, (*pq.StringArray)(&table.Columns), &table.RowFilter); err != nil {
+			return nil, PgErrWithMetadata(err, "pg_cmd", opScanRowResult, "model", "publication_table")
+		}
+		if len(table.Columns) == 0 {
+			table.Columns = nil
+		}
+		tables = append(tables, table)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery)
+	}
+	return tables, nil
+}
+
+func readPublicationSchemas(ctx context.Context, q pgQueryer, name string) ([]string, error) {
+	schemasQuery := `
+		SELECT n.nspname
+		FROM pg_catalog.pg_publication_namespace pn
+				 JOIN pg_catalog.pg_publication p ON p.oid = pn.pnpubid
+				 JOIN pg_catalog.pg_namespace n ON n.oid = pn.pnnspid
+		WHERE p.pubname = %s
+		ORDER BY n.nspname;`
+
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(schemasQuery, pq.QuoteLiteral(name)))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery)
+	}
+	defer rows.Close()
+
+	var schemas []string
+	for rows.Next() {
+		var schema string
+		if err = rows.Scan(&schema); err != nil {
+			return nil, PgErrWithMetadata(err, "pg_cmd", opScanRowResult, "model", "publication_schema")
+		}
+		schemas = append(schemas, schema)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery)
+	}
+	return schemas, nil
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+const (
+	testPublicationDb   = "test_publication_db"
+	testPublicationUser = "test_publication_user"
+)
+
+func testPreparePublicationTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: testPublicationDb,
+		Username: testPublicationUser,
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE public.test_publication_orders (id int PRIMARY KEY, status text, total numeric);
+		CREATE TABLE public.test_publication_items (id int PRIMARY KEY, name text);
+		CREATE SCHEMA test_publication_audit;`)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func mockPublicationModel(t *testing.T) PublicationModel {
+	t.Helper()
+	return PublicationModel{
+		Name: "test_publication",
+		Tables: []PublicationTable{
+			{Schema: "public", Name: "test_publication_orders", Columns: []string{"status", "id"}, RowFilter: "id > 10"},
+			{Schema: "public", Name: "test_publication_items"},
+		},
+		Schemas: []string{"test_publication_audit"},
+		Publish: []string{PublicationOperationInsert, PublicationOperationUpdate},
+		Comment: "test comment",
+	}
+}
+
+func TestPublicationCreateQuery(t *testing.T) {
+	model := mockPublicationModel(t)
+	assert.Equal(t,
+		`CREATE PUBLICATION "test_publication" FOR TABLE "public"."test_publication_orders" ("status", "id") WHERE (id > 10), "public"."test_publication_items", TABLES IN SCHEMA "test_publication_audit" WITH (publish = 'insert, update');`,
+		publicationCreateQuery(model),
+	)
+	assert.Equal(t,
+		`TABLE "public"."test_publication_orders", "public"."test_publication_items", TABLES IN SCHEMA "test_publication_audit"`,
+		publicationObjects(model, false),
+	)
+
+	model = PublicationModel{Name: "all", AllTables: true, PublishViaPartitionRoot: true}
+	assert.Equal(t,
+		`CREATE PUBLICATION "all" FOR ALL TABLES WITH (publish_via_partition_root = true);`,
+		publicationCreateQuery(model),
+	)
+}
+
+func TestPreservePublicationSpelling(t *testing.T) {
+	prior := mockPublicationModel(t)
+	normalized := prior
+	normalized.Tables = []PublicationTable{
+		{Schema: "public", Name: "test_publication_items"},
+		{Schema: "public", Name: "test_publication_orders", Columns: []string{"id", "status"}, RowFilter: "(id > 10)"},
+	}
+	actual := normalized
+	actual.Publish = []string{PublicationOperationInsert, PublicationOperationUpdate}
+
+	preserved := PreservePublicationSpelling(prior, normalized, actual)
+	assert.Equal(t, prior.Tables, preserved.Tables)
+
+	actual.Tables = []PublicationTable{{Schema: "public", Name: "test_publication_orders", Columns: []string{"id"}, RowFilter: "(id > 20)"}}
+	preserved = PreservePublicationSpelling(prior, normalized, actual)
+	assert.Equal(t, actual.Tables, preserved.Tables)
+}
+
+func TestPublicationSQL_CreateAndGet(t *testing.T) {
+	// the column lists, row filters and schemas of publications come with PostgreSQL 15
+	test.SkipBelowPostgres(t, 15)
+	ctx, db := testPreparePublicationTestCase(t)
+	defer db.Close()
+
+	publicationRepo := NewPublicationRepository(db)
+	model := mockPublicationModel(t)
+	assert.NoError(t, publicationRepo.Create(ctx, model))
+
+	got, err := publicationRepo.Get(ctx, model.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, testPublicationDb, got.Database)
+	assert.False(t, got.AllTables)
+	assert.Len(t, got.Tables, 2)
+	assert.Equal(t, "test_publication_items", got.Tables[0].Name)
+	assert.Equal(t, []string{"id", "status"}, got.Tables[1].Columns)
+	assert.Equal(t, "(id > 10)", got.Tables[1].RowFilter)
+	assert.Equal(t, model.Schemas, got.Schemas)
+	assert.Equal(t, model.Publish, got.Publish)
+	assert.Equal(t, testPublicationUser, got.Owner)
+	assert.Equal(t, model.Comment, got.Comment)
+
+	normalized, err := publicationRepo.Normalize(ctx, model)
+	assert.NoError(t, err)
+	preserved := PreservePublicationSpelling(model, *normalized, *got)
+	assert.Equal(t, model.Tables, preserved.Tables)
+
+	assert.NoError(t, publicationRepo.Drop(ctx, model.Name))
+	exists, err := publicationRepo.Exists(ctx, model.Name)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestPublicationSQL_Update(t *testing.T) {
+	test.SkipBelowPostgres(t, 15)
+	ctx, db := testPreparePublicationTestCase(t)
+	defer db.Close()
+
+	publicationRepo := NewPublicationRepository(db)
+	current := mockPublicationModel(t)
+	assert.NoError(t, publicationRepo.Create(ctx, current))
+
+	desired := current
+	desired.Name = "test_publication_renamed"
+	desired.Tables = []PublicationTable{{Schema: "public", Name: "test_publication_items"}}
+	desired.Schemas = nil
+	desired.Publish = nil
+	desired.Comment = ""
+
+	got, err := publicationRepo.Update(ctx, PublicationUpdateParams{Current: current, Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, desired.Name, got.Name)
+	assert.Equal(t, desired.Tables, got.Tables)
+	assert.Empty(t, got.Schemas)
+	assert.Equal(t, PublicationOperations, got.Publish)
+	assert.Empty(t, got.Comment)
+
+	current, desired = *got, *got
+	desired.Tables = nil
+	got, err = publicationRepo.Update(ctx, PublicationUpdateParams{Current: current, Desired: desired})
+	assert.NoError(t, err)
+	assert.Empty(t, got.Tables)
+}
@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	errQueryNotReadOnly     = errors.New("the query is not read-only")
+	errQueryRowLimitReached = errors.New("the query returned too many rows")
+)
+
+// queryReadOnlyKeywords are the leading keywords of the statements that only read data. The
+// statements still run in a READ ONLY transaction, which refuses the data-modifying CTEs.
+var queryReadOnlyKeywords = []string{"select", "with", "values", "table", "show", "explain"}
+
+// queryLeadingCommentsRegexp matches the whitespace and the comments at the start of a statement.
+var queryLeadingCommentsRegexp = regexp.MustCompile(`^(\s+|--[^\n]*(\n|$)|/\*(?s:.*?)\*/)+`)
+
+type querySQL struct {
+	db *sql.DB
+}
+
+// QueryParams describes a read-only query, with its arguments bound to the placeholders $1, $2...
+type QueryParams struct {
+	Query string   `json:"query" validate:"required"`
+	Args  []string `json:"args"`
+	// RowLimit is the maximum number of rows, the query fails when it returns more.
+	RowLimit int `json:"row_limit" validate:"min=1"`
+	// StatementTimeout aborts the query when it runs longer, e.g. '30s', no timeout when empty.
+	StatementTimeout string `json:"statement_timeout"`
+}
+
+// QueryResult holds the rows of a query as text, the NULL values being nil.
+type QueryResult struct {
+	Columns []string             `json:"columns"`
+	Rows    []map[string]*string `json:"rows"`
+}
+
+type QueryRepository interface {
+	Query(ctx context.Context, params QueryParams) (*QueryResult, error)
+}
+
+var _ QueryRepository = &querySQL{}
+
+func NewQueryRepository(db *sql.DB) QueryRepository {
+	return &querySQL{
+		db: db,
+	}
+}
+
+// Query runs the query in a READ ONLY transaction, rolled back once the rows are read. The query
+// is prepared, so PostgreSQL refuses the strings holding several statements.
+func (q *querySQL) Query(ctx context.Context, params QueryParams) (*QueryResult, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+	if err := checkQueryReadOnly(params.Query); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opRunQuery)
+	}
+
+	txn, err := BeginTxWithRole(ctx, q.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opRunQuery, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	statements := []string{"SET TRANSACTION READ ONLY;"}
+	if params.StatementTimeout != "" {
+		statements = append(statements, fmt.Sprintf("SET LOCAL statement_timeout = %s;", pq.QuoteLiteral(params.StatementTimeout)))
+	}
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opRunQuery)
+		}
+	}
+
+	stmt, err := txn.PrepareContext(ctx, params.Query)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opRunQuery, "pg_cmd", opPrepareStatement)
+	}
+	defer stmt.Close()
+
+	args := make([]any, len(params.Args))
+	for i, arg := range params.Args {
+		args[i] = arg
+	}
+	rows, err := stmt.QueryContext(ctx, args...)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opRunQuery, "pg_cmd", opQuery)
+	}
+	defer rows.Close()
+
+	result, err := readQueryRows(rows, params.RowLimit)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opRunQuery, "pg_cmd", opScanRowResult)
+	}
+	return result, nil
+}
+
+// checkQueryReadOnly refuses the statements that don't start with a read-only keyword, e.g. an UPDATE.
+func checkQueryReadOnly(query string) error {
+	statement := queryLeadingCommentsRegexp.ReplaceAllString(query, "")
+	keyword := strings.ToLower(strings.TrimLeft(statement, "( \t\r\n"))
+	if end := strings.IndexFunc(keyword, func(r rune) bool { return !('a' <= r && r <= 'z') }); end >= 0 {
+		keyword = keyword[:end]
+	}
+	if !slices.Contains(queryReadOnlyKeywords, keyword) {
+		return fmt.Errorf("%w: statements must start with one of %s, got '%s'", errQueryNotReadOnly,
+			strings.ToUpper(strings.Join(queryReadOnlyKeywords, ", ")), keyword)
+	}
+	return nil
+}
+
+func readQueryRows(rows *sql.Rows, rowLimit int) (*QueryResult, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := QueryResult{Columns: columns, Rows: make([]map[string]*string, 0)}
+	values := make([]sql.NullString, len(columns))
+	targets := make([]any, len(columns))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+
+	for rows.Next() {
+		if len(result.Rows) == rowLimit {
+			return nil, fmt.Errorf("%w: the limit is %d rows", errQueryRowLimitReached, rowLimit)
+		}
+		if err = rows.Scan(targets...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]*string, len(columns))
+		for i, column := range columns {
+			if values[i].Valid {
+				value := values[i].String
+				row[column] = &value
+			} else {
+				row[column] = nil
+			}
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestCheckQueryReadOnly(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		readOnly bool
+	}{
+		{name: "Select", query: "SELECT 1", readOnly: true},
+		{name: "LowercaseWith", query: "with t as (select 1) select * from t", readOnly: true},
+		{name: "Parenthesized", query: "( SELECT 1) UNION (SELECT 2)", readOnly: true},
+		{name: "LeadingComments", query: "-- count the rows\n/* of the table */\n  SELECT count(*) FROM t", readOnly: true},
+		{name: "Show", query: "SHOW work_mem", readOnly: true},
+		{name: "Update", query: "UPDATE t SET a = 1"},
+		{name: "CommentedSelect", query: "-- SELECT\nDELETE FROM t"},
+		{name: "Empty", query: "  "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkQueryReadOnly(tt.query)
+			if tt.readOnly {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, errQueryNotReadOnly)
+			}
+		})
+	}
+}
+
+func TestQuerySQL_Query(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_query_db",
+		Username: "test_query_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE public.test_query_table (id int, label text);
+		INSERT INTO public.test_query_table VALUES (1, 'one'), (2, NULL), (3, 'three');`)
+	require.NoError(t, err)
+
+	repo := NewQueryRepository(db)
+	result, err := repo.Query(ctx, QueryParams{
+		Query:    "SELECT id, label FROM public.test_query_table WHERE id <= $1::int ORDER BY id",
+		Args:     []string{"2"},
+		RowLimit: 10,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "label"}, result.Columns)
+	require.Len(t, result.Rows, 2)
+	assert.Equal(t, "1", *result.Rows[0]["id"])
+	assert.Equal(t, "one", *result.Rows[0]["label"])
+	assert.Nil(t, result.Rows[1]["label"])
+
+	_, err = repo.Query(ctx, QueryParams{Query: "SELECT * FROM public.test_query_table", RowLimit: 2})
+	assert.ErrorIs(t, err, errQueryRowLimitReached)
+
+	_, err = repo.Query(ctx, QueryParams{Query: "DELETE FROM public.test_query_table", RowLimit: 10})
+	assert.ErrorIs(t, err, errQueryNotReadOnly)
+
+	// refused by the READ ONLY transaction
+	_, err = repo.Query(ctx, QueryParams{Query: "WITH d AS (DELETE FROM public.test_query_table RETURNING id) SELECT * FROM d", RowLimit: 10})
+	assert.ErrorContains(t, err, "cannot execute DELETE in a read-only transaction")
+
+	// refused by the prepared statement
+	_, err = repo.Query(ctx, QueryParams{Query: "SELECT 1; DELETE FROM public.test_query_table", RowLimit: 10})
+	assert.ErrorContains(t, err, "cannot insert multiple commands into a prepared statement")
+
+	_, err = repo.Query(ctx, QueryParams{Query: "SELECT pg_sleep(1)", RowLimit: 10, StatementTimeout: "100ms"})
+	assert.ErrorContains(t, err, "canceling statement due to statement timeout")
+
+	var count int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT count(*) FROM public.test_query_table;`).Scan(&count))
+	assert.Equal(t, 3, count)
+}
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"time"
+)
+
+const (
+	ReplicationSlotLogical  = "logical"
+	ReplicationSlotPhysical = "physical"
+
+	// ReplicationSlotDefaultPlugin is the output plugin of the logical slots when none is given,
+	// the one of the built-in logical replication and of most CDC tools.
+	ReplicationSlotDefaultPlugin = "pgoutput"
+)
+
+var ReplicationSlotTypes = []string{ReplicationSlotLogical, ReplicationSlotPhysical}
+
+var errReplicationSlotActive = errors.New("the replication slot is active")
+
+// replicationSlotReleaseChecks and replicationSlotReleaseInterval bound the wait for a terminated
+// walsender to release its slot.
+const (
+	replicationSlotReleaseChecks   = 20
+	replicationSlotReleaseInterval = 250 * time.Millisecond
+)
+
+type replicationSlotSQL struct {
+	db *sql.DB
+}
+
+// ReplicationSlotModel describes a replication slot. Slots are global to the server, logical slots
+// decode the changes of a single database.
+type ReplicationSlotModel struct {
+	Name     string `json:"name" validate:"required"`
+	Database string `json:"database"`
+	Type     string `json:"type" validate:"required,oneof=logical physical"`
+	// Plugin is the output plugin of a logical slot, ReplicationSlotDefaultPlugin when empty.
+	Plugin string `json:"plugin" validate:"excluded_if=Type physical"`
+	// Temporary slots are released when the session that created them ends.
+	Temporary bool `json:"temporary"`
+	// TwoPhase decodes the prepared transactions of a logical slot when they're prepared.
+	TwoPhase bool `json:"two_phase" validate:"excluded_if=Type physical"`
+
+	// the state of the slot, read only
+	Active            bool   `json:"active"`
+	ActivePid         int64  `json:"active_pid"`
+	RestartLsn        string `json:"restart_lsn"`
+	ConfirmedFlushLsn string `json:"confirmed_flush_lsn"`
+	WalStatus         string `json:"wal_status"`
+	// LagBytes is the amount of WAL between the current position of the server and the last
+	// position confirmed by the consumer of the slot, or its restart position for physical slots.
+	LagBytes int64 `json:"lag_bytes"`
+}
+
+type ReplicationSlotRepository interface {
+	Create(ctx context.Context, params ReplicationSlotModel) error
+	Drop(ctx context.Context, name string, terminateActive bool) error
+	Get(ctx context.Context, name string) (*ReplicationSlotModel, error)
+	Exists(ctx context.Context, name string) (bool, error)
+}
+
+var _ ReplicationSlotRepository = &replicationSlotSQL{}
+
+func NewReplicationSlotRepository(db *sql.DB) ReplicationSlotRepository {
+	return &replicationSlotSQL{
+		db: db,
+	}
+}
+
+// Create creates the slot with the replication functions. They run outside a transaction, a logical
+// slot can't be created by a transaction that already wrote.
+func (r *replicationSlotSQL) Create(ctx context.Context, params ReplicationSlotModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	if err := ExecWithRole(ctx, r.db, replicationSlotCreateQuery(params)); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateReplicationSlot)
+	}
+	return nil
+}
+
+// Drop drops the slot. An active slot is only dropped when terminateActive is set: the walsender
+// using it is terminated first, otherwise errReplicationSlotActive is returned.
+func (r *replicationSlotSQL) Drop(ctx context.Context, name string, terminateActive bool) error {
+	slot, err := r.Get(ctx, name)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return nil
+	case err != nil:
+		return PgErrWithMetadata(err, "operation", opDropReplicationSlot)
+	}
+
+	if slot.Active {
+		if !terminateActive {
+			err = fmt.Errorf("%w: '%s' is used by the process %d, stop its consumer or allow the termination of the active connection",
+				errReplicationSlotActive, name, slot.ActivePid)
+			return PgErrWithMetadata(err, "operation", opDropReplicationSlot)
+		}
+		if err = r.terminateActive(ctx, name); err != nil {
+			return PgErrWithMetadata(err, "operation", opDropReplicationSlot)
+		}
+	}
+
+	// a temporary slot is already gone once the session holding it is terminated
+	dropQuery := `
+		SELECT pg_catalog.pg_drop_replication_slot(s.slot_name)
+		FROM pg_catalog.pg_replication_slots s
+		WHERE s.slot_name = %s;`
+
+	if err = ExecWithRole(ctx, r.db, fmt.Sprintf(dropQuery, pq.QuoteLiteral(name))); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropReplicationSlot)
+	}
+	return nil
+}
+
+func (r *replicationSlotSQL) Get(ctx context.Context, name string) (*ReplicationSlotModel, error) {
+	versionNum, err := serverVersionNum(ctx, r.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetReplicationSlot)
+	}
+
+	walStatus, twoPhase := "''", "false"
+	if versionNum >= 130000 {
+		walStatus = "COALESCE(s.wal_status, '')"
+	}
+	if versionNum >= 140000 {
+		twoPhase = "s.two_phase"
+	}
+
+	// the lag is measured from the last received position on standby servers
+	readQuery := `
+		SELECT s.slot_name                                                        as "name",
+			   COALESCE(s.database, pg_catalog.current_database())                as "database",
+			   s.slot_type                                                        as "type",
+			   COALESCE(s.plugin, '')                                             as "plugin",
+			   s.temporary                                                        as "temporary",
+			   %s                                                                 as "two_phase",
+			   s.active                                                           as "active",
+			   COALESCE(s.active_pid, 0)                                          as "active_pid",
+			   COALESCE(s.restart_lsn::text, '')                                  as "restart_lsn",
+			   COALESCE(s.confirmed_flush_lsn::text, '')                          as "confirmed_flush_lsn",
+			   %s                                                                 as "wal_status",
+			   COALESCE(pg_catalog.pg_wal_lsn_diff(
+							CASE
+								WHEN pg_catalog.pg_is_in_recovery() THEN pg_catalog.pg_last_wal_receive_lsn()
+								ELSE pg_catalog.pg_current_wal_lsn() END,
+							COALESCE(s.confirmed_flush_lsn, s.restart_lsn)), 0)::bigint as "lag_bytes"
+		FROM pg_catalog.pg_replication_slots s
+		WHERE s.slot_name = %s;`
+
+	var model ReplicationSlotModel
+	row := r.db.QueryRowContext(ctx, fmt.Sprintf(readQuery, twoPhase, walStatus, pq.QuoteLiteral(name)))
+	err = row.Scan(
+		&model.Name,
+		&model.Database,
+		&model.Type,
+		&model.Plugin,
+		&model.Temporary,
+		&model.TwoPhase,
+		&model.Active,
+		&model.ActivePid,
+		&model.RestartLsn,
+		&model.ConfirmedFlushLsn,
+		&model.WalStatus,
+		&model.LagBytes,
+	)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetReplicationSlot, "pg_cmd", opScanRowResult)
+	}
+	return &model, nil
+}
+
+func (r *replicationSlotSQL) Exists(ctx context.Context, name string) (bool, error) {
+	existsQuery := `SELECT EXISTS (SELECT 1 FROM pg_catalog.pg_replication_slots s WHERE s.slot_name = %s);`
+
+	var exists bool
+	row := r.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(name)))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsReplicationSlot, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
+
+// terminateActive terminates the walsender using the slot, then waits for the slot to be released.
+func (r *replicationSlotSQL) terminateActive(ctx context.Context, name string) error {
+	terminateQuery := `
+		SELECT pg_catalog.pg_terminate_backend(s.active_pid)
+		FROM pg_catalog.pg_replication_slots s
+		WHERE s.slot_name = %s
+		  AND s.active;`
+
+	if err := ExecWithRole(ctx, r.db, fmt.Sprintf(terminateQuery, pq.QuoteLiteral(name))); err != nil {
+		return err
+	}
+
+	activeQuery := `SELECT COALESCE((SELECT s.active FROM pg_catalog.pg_replication_slots s WHERE s.slot_name = %s), false);`
+	for i := 0; i < replicationSlotReleaseChecks; i++ {
+		var active bool
+		if err := r.db.QueryRowContext(ctx, fmt.Sprintf(activeQuery, pq.QuoteLiteral(name))).Scan(&active); err != nil {
+			return PgErrWithMetadata(err, "pg_cmd", opQueryRow)
+		}
+		if !active {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(replicationSlotReleaseInterval):
+		}
+	}
+	return fmt.Errorf("%w: '%s' is still used after the termination of its process", errReplicationSlotActive, name)
+}
+
+func replicationSlotCreateQuery(model ReplicationSlotModel) string {
+	name := pq.QuoteLiteral(model.Name)
+	if model.Type == ReplicationSlotPhysical {
+		// the WAL is reserved right away, so the slot retains it before its consumer connects
+		return fmt.Sprintf("SELECT pg_catalog.pg_create_physical_replication_slot(%s, true, %t);", name, model.Temporary)
+	}
+
+	plugin := model.Plugin
+	if plugin == "" {
+		plugin = ReplicationSlotDefaultPlugin
+	}
+	// two_phase is only given when set, the argument is missing before PostgreSQL 14
+	if model.TwoPhase {
+		return fmt.Sprintf("SELECT pg_catalog.pg_create_logical_replication_slot(%s, %s, %t, true);", name, pq.QuoteLiteral(plugin), model.Temporary)
+	}
+	return fmt.Sprintf("SELECT pg_catalog.pg_create_logical_replication_slot(%s, %s, %t);", name, pq.QuoteLiteral(plugin), model.Temporary)
+}
@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+const testReplicationSlotDb = "test_replication_slot_db"
+
+func testPrepareReplicationSlotTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: testReplicationSlotDb,
+		Username: "test_replication_slot_user",
+		Settings: map[string]string{"wal_level": "logical"},
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func TestReplicationSlotCreateQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		model    ReplicationSlotModel
+		expected string
+	}{
+		{
+			name:     "LogicalDefaultPlugin",
+			model:    ReplicationSlotModel{Name: "cdc", Type: ReplicationSlotLogical},
+			expected: `SELECT pg_catalog.pg_create_logical_replication_slot('cdc', 'pgoutput', false);`,
+		},
+		{
+			name:     "LogicalTwoPhase",
+			model:    ReplicationSlotModel{Name: "cdc", Type: ReplicationSlotLogical, Plugin: "wal2json", Temporary: true, TwoPhase: true},
+			expected: `SELECT pg_catalog.pg_create_logical_replication_slot('cdc', 'wal2json', true, true);`,
+		},
+		{
+			name:     "Physical",
+			model:    ReplicationSlotModel{Name: "standby", Type: ReplicationSlotPhysical},
+			expected: `SELECT pg_catalog.pg_create_physical_replication_slot('standby', true, false);`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, replicationSlotCreateQuery(tt.model))
+		})
+	}
+}
+
+func TestReplicationSlotSQL_CreateAndGet(t *testing.T) {
+	ctx, db := testPrepareReplicationSlotTestCase(t)
+	defer db.Close()
+
+	repo := NewReplicationSlotRepository(db)
+	require.NoError(t, repo.Create(ctx, ReplicationSlotModel{Name: "test_logical_slot", Type: ReplicationSlotLogical}))
+	require.NoError(t, repo.Create(ctx, ReplicationSlotModel{Name: "test_physical_slot", Type: ReplicationSlotPhysical}))
+
+	got, err := repo.Get(ctx, "test_logical_slot")
+	assert.NoError(t, err)
+	assert.Equal(t, testReplicationSlotDb, got.Database)
+	assert.Equal(t, ReplicationSlotLogical, got.Type)
+	assert.Equal(t, ReplicationSlotDefaultPlugin, got.Plugin)
+	assert.False(t, got.Active)
+	assert.NotEmpty(t, got.ConfirmedFlushLsn)
+
+	got, err = repo.Get(ctx, "test_physical_slot")
+	assert.NoError(t, err)
+	assert.Equal(t, ReplicationSlotPhysical, got.Type)
+	assert.Empty(t, got.Plugin)
+	assert.NotEmpty(t, got.RestartLsn)
+
+	assert.NoError(t, repo.Drop(ctx, "test_logical_slot", false))
+	assert.NoError(t, repo.Drop(ctx, "test_physical_slot", false))
+
+	exists, err := repo.Exists(ctx, "test_logical_slot")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestReplicationSlotSQL_DropActive(t *testing.T) {
+	ctx, db := testPrepareReplicationSlotTestCase(t)
+	defer db.Close()
+
+	// a temporary slot stays active as long as the session that created it
+	holder, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer holder.Close()
+	_, err = holder.ExecContext(ctx, `SELECT pg_catalog.pg_create_logical_replication_slot('test_active_slot', 'test_decoding', true);`)
+	require.NoError(t, err)
+
+	repo := NewReplicationSlotRepository(db)
+	got, err := repo.Get(ctx, "test_active_slot")
+	assert.NoError(t, err)
+	assert.True(t, got.Active)
+	assert.True(t, got.Temporary)
+	assert.NotZero(t, got.ActivePid)
+
+	err = repo.Drop(ctx, "test_active_slot", false)
+	assert.ErrorIs(t, err, errReplicationSlotActive)
+
+	assert.NoError(t, repo.Drop(ctx, "test_active_slot", true))
+	exists, err := repo.Exists(ctx, "test_active_slot")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
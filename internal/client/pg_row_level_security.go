@@ -0,0 +1,95 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+)
+
+type rowLevelSecuritySQL struct {
+	db *sql.DB
+}
+
+// RowLevelSecurityModel describes the row level security settings of a table.
+type RowLevelSecurityModel struct {
+	Schema   string `json:"schema" validate:"required"`
+	Table    string `json:"table" validate:"required"`
+	Database string `json:"database"`
+	// Enabled makes the policies of the table apply to every role but its owner and the superusers.
+	Enabled bool `json:"enabled"`
+	// Forced makes the policies of the table apply to its owner as well.
+	Forced bool `json:"forced"`
+}
+
+type RowLevelSecurityRepository interface {
+	Get(ctx context.Context, schema, table string) (*RowLevelSecurityModel, error)
+	Set(ctx context.Context, params RowLevelSecurityModel) (*RowLevelSecurityModel, error)
+}
+
+var _ RowLevelSecurityRepository = &rowLevelSecuritySQL{}
+
+func NewRowLevelSecurityRepository(db *sql.DB) RowLevelSecurityRepository {
+	return &rowLevelSecuritySQL{
+		db: db,
+	}
+}
+
+func (r *rowLevelSecuritySQL) Get(ctx context.Context, schema, table string) (*RowLevelSecurityModel, error) {
+	readQuery := `
+		SELECT n.nspname                     as "schema",
+			   c.relname                     as "table",
+			   pg_catalog.current_database() as "database",
+			   c.relrowsecurity              as "enabled",
+			   c.relforcerowsecurity         as "forced"
+		FROM pg_catalog.pg_class c
+				 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.oid = pg_catalog.to_regclass(%s)
+		  AND c.relkind IN ('r', 'p');`
+
+	var model RowLevelSecurityModel
+	row := r.db.QueryRowContext(ctx, fmt.Sprintf(readQuery, pq.QuoteLiteral(pgQualifiedName(schema, table))))
+	err := row.Scan(&model.Schema, &model.Table, &model.Database, &model.Enabled, &model.Forced)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetRowLevelSecurity, "pg_cmd", opScanRowResult)
+	}
+	return &model, nil
+}
+
+// Set enables or disables, and forces or not, the row level security of the table.
+// Both settings are always applied since the statements are idempotent.
+func (r *rowLevelSecuritySQL) Set(ctx context.Context, params RowLevelSecurityModel) (*RowLevelSecurityModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, r.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateRowLevelSecurity, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	err = WithQueryExecHandler(txn.ExecContext(ctx, rowLevelSecurityQuery(params)))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateRowLevelSecurity)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateRowLevelSecurity, "pg_cmd", opCommitTransaction)
+	}
+
+	return r.Get(ctx, params.Schema, params.Table)
+}
+
+func rowLevelSecurityQuery(model RowLevelSecurityModel) string {
+	enable, force := "DISABLE", "NO FORCE"
+	if model.Enabled {
+		enable = "ENABLE"
+	}
+	if model.Forced {
+		force = "FORCE"
+	}
+	return fmt.Sprintf("ALTER TABLE %s %s ROW LEVEL SECURITY, %s ROW LEVEL SECURITY;",
+		pgQualifiedName(model.Schema, model.Table), enable, force)
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRowLevelSecurityQuery(t *testing.T) {
+	model := RowLevelSecurityModel{Schema: "public", Table: "t", Enabled: true}
+	assert.Equal(t,
+		`ALTER TABLE "public"."t" ENABLE ROW LEVEL SECURITY, NO FORCE ROW LEVEL SECURITY;`,
+		rowLevelSecurityQuery(model),
+	)
+
+	model.Enabled, model.Forced = false, true
+	assert.Equal(t,
+		`ALTER TABLE "public"."t" DISABLE ROW LEVEL SECURITY, FORCE ROW LEVEL SECURITY;`,
+		rowLevelSecurityQuery(model),
+	)
+}
+
+func TestRowLevelSecuritySQL_SetAndGet(t *testing.T) {
+	ctx, db := testPreparePolicyTestCase(t)
+	defer db.Close()
+
+	rlsRepo := NewRowLevelSecurityRepository(db)
+	got, err := rlsRepo.Get(ctx, "public", "test_policy_table")
+	assert.NoError(t, err)
+	assert.False(t, got.Enabled)
+	assert.False(t, got.Forced)
+
+	got, err = rlsRepo.Set(ctx, RowLevelSecurityModel{Schema: "public", Table: "test_policy_table", Enabled: true, Forced: true})
+	assert.NoError(t, err)
+	assert.Equal(t, testPolicyDb, got.Database)
+	assert.True(t, got.Enabled)
+	assert.True(t, got.Forced)
+
+	got, err = rlsRepo.Set(ctx, RowLevelSecurityModel{Schema: "public", Table: "test_policy_table"})
+	assert.NoError(t, err)
+	assert.False(t, got.Enabled)
+	assert.False(t, got.Forced)
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+)
+
+type scriptSQL struct {
+	db *sql.DB
+}
+
+// ScriptRepository runs raw SQL scripts, for the objects the provider doesn't model.
+type ScriptRepository interface {
+	// Exec runs the statements in a single transaction, or one by one outside of a transaction
+	// for the commands that refuse to run inside one, e.g. CREATE INDEX CONCURRENTLY.
+	Exec(ctx context.Context, statements []string, transaction bool) error
+	// Replace runs the destroy statements, then the create ones, in a single transaction unless
+	// transaction is false.
+	Replace(ctx context.Context, destroy, create []string, transaction bool) error
+	// Check runs the query in a READ ONLY transaction, true when it returns at least one row.
+	Check(ctx context.Context, query string) (bool, error)
+}
+
+var _ ScriptRepository = &scriptSQL{}
+
+func NewScriptRepository(db *sql.DB) ScriptRepository {
+	return &scriptSQL{
+		db: db,
+	}
+}
+
+func (s *scriptSQL) Exec(ctx context.Context, statements []string, transaction bool) error {
+	if err := s.exec(ctx, statements, transaction); err != nil {
+		return PgErrWithMetadata(err, "operation", opExecScript)
+	}
+	return nil
+}
+
+func (s *scriptSQL) Replace(ctx context.Context, destroy, create []string, transaction bool) error {
+	statements := append(append([]string{}, destroy...), create...)
+	if err := s.exec(ctx, statements, transaction); err != nil {
+		return PgErrWithMetadata(err, "operation", opReplaceScript)
+	}
+	return nil
+}
+
+func (s *scriptSQL) Check(ctx context.Context, query string) (bool, error) {
+	txn, err := BeginTxWithRole(ctx, s.db)
+	if err != nil {
+		return false, PgErrWithMetadata(err, "operation", opCheckScript, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, "SET TRANSACTION READ ONLY;")); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opCheckScript)
+	}
+
+	rows, err := txn.QueryContext(ctx, query)
+	if err != nil {
+		return false, PgErrWithMetadata(err, "operation", opCheckScript, "pg_cmd", opQuery)
+	}
+	defer rows.Close()
+
+	found := rows.Next()
+	if err = rows.Err(); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opCheckScript, "pg_cmd", opQuery)
+	}
+	return found, nil
+}
+
+func (s *scriptSQL) exec(ctx context.Context, statements []string, transaction bool) error {
+	if !transaction {
+		return ExecWithRole(ctx, s.db, statements...)
+	}
+
+	txn, err := BeginTxWithRole(ctx, s.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
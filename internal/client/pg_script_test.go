@@ -0,0 +1,80 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func testPrepareScriptTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_script_db",
+		Username: "test_script_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	require.NoError(t, err)
+	return ctx, db
+}
+
+func TestScriptSQL_ExecAndCheck(t *testing.T) {
+	ctx, db := testPrepareScriptTestCase(t)
+	defer db.Close()
+
+	repo := NewScriptRepository(db)
+	exists := "SELECT 1 FROM pg_catalog.pg_class WHERE relname = 'test_script_table'"
+
+	require.NoError(t, repo.Exec(ctx, []string{"CREATE TABLE public.test_script_table (id int); INSERT INTO public.test_script_table VALUES (1);"}, true))
+	found, err := repo.Check(ctx, exists)
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	// a failing statement rolls back the whole transaction
+	err = repo.Exec(ctx, []string{"DROP TABLE public.test_script_table;", "SELECT 1/0;"}, true)
+	assert.ErrorContains(t, err, "division by zero")
+	found, err = repo.Check(ctx, exists)
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	// the commands refusing transactions run one by one
+	require.NoError(t, repo.Exec(ctx, []string{"CREATE INDEX CONCURRENTLY test_script_idx ON public.test_script_table (id);"}, false))
+	found, err = repo.Check(ctx, "SELECT 1 FROM pg_catalog.pg_class WHERE relname = 'test_script_idx'")
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	// the checks are read-only
+	_, err = repo.Check(ctx, "DELETE FROM public.test_script_table RETURNING id")
+	assert.ErrorContains(t, err, "cannot execute DELETE in a read-only transaction")
+
+	require.NoError(t, repo.Exec(ctx, []string{"DROP TABLE public.test_script_table;"}, true))
+	found, err = repo.Check(ctx, exists)
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestScriptSQL_Replace(t *testing.T) {
+	ctx, db := testPrepareScriptTestCase(t)
+	defer db.Close()
+
+	repo := NewScriptRepository(db)
+	require.NoError(t, repo.Exec(ctx, []string{"CREATE VIEW public.test_script_view AS SELECT 1 AS a;"}, true))
+
+	// a failing create keeps the objects of the previous script
+	err := repo.Replace(ctx, []string{"DROP VIEW public.test_script_view;"}, []string{"CREATE VIEW public.test_script_view AS SELECT missing;"}, true)
+	assert.Error(t, err)
+
+	var column string
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT attname FROM pg_catalog.pg_attribute WHERE attrelid = 'public.test_script_view'::regclass;`).Scan(&column))
+	assert.Equal(t, "a", column)
+
+	require.NoError(t, repo.Replace(ctx, []string{"DROP VIEW public.test_script_view;"}, []string{"CREATE VIEW public.test_script_view AS SELECT 2 AS b;"}, true))
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT attname FROM pg_catalog.pg_attribute WHERE attrelid = 'public.test_script_view'::regclass;`).Scan(&column))
+	assert.Equal(t, "b", column)
+}
@@ -0,0 +1,291 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+const sequenceObjectType = "SEQUENCE"
+
+var SequenceDataTypes = []string{"smallint", "integer", "bigint"}
+
+type sequenceSQL struct {
+	db *sql.DB
+}
+
+// SequenceModel describes a sequence. Nil bounds and start, and zero increment and cache, take the
+// PostgreSQL defaults on creation and are left untouched on updates.
+type SequenceModel struct {
+	Schema    string `json:"schema" validate:"required"`
+	Name      string `json:"name" validate:"required"`
+	Database  string `json:"database"`
+	DataType  string `json:"data_type" validate:"omitempty,oneof=smallint integer bigint"`
+	Increment int64  `json:"increment"`
+	MinValue  *int64 `json:"min_value"`
+	MaxValue  *int64 `json:"max_value"`
+	Start     *int64 `json:"start"`
+	Cache     int64  `json:"cache" validate:"gte=0"`
+	Cycle     bool   `json:"cycle"`
+	// OwnedBy is the column owning the sequence, in the format 'table_name.column_name'. The table
+	// must be in the same schema as the sequence.
+	OwnedBy string `json:"owned_by"`
+	Owner   string `json:"owner"`
+	Comment string `json:"comment"`
+	// LastValue is the last value returned by nextval, nil when it was never called. It's only read.
+	LastValue *int64 `json:"last_value"`
+}
+
+type SequenceUpdateParams struct {
+	Current SequenceModel
+	Desired SequenceModel `validate:"required"`
+}
+
+type SequenceRepository interface {
+	Create(ctx context.Context, params SequenceModel) error
+	Drop(ctx context.Context, schema, name string) error
+	Get(ctx context.Context, schema, name string) (*SequenceModel, error)
+	Update(ctx context.Context, params SequenceUpdateParams) (*SequenceModel, error)
+	Exists(ctx context.Context, schema, name string) (bool, error)
+}
+
+var _ SequenceRepository = &sequenceSQL{}
+
+func NewSequenceRepository(db *sql.DB) SequenceRepository {
+	return &sequenceSQL{
+		db: db,
+	}
+}
+
+func (s *sequenceSQL) Create(ctx context.Context, params SequenceModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, s.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateSequence, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	name := pgQualifiedName(params.Schema, params.Name)
+
+	createQuery := fmt.Sprintf("CREATE SEQUENCE %s", name)
+	if options := sequenceOptions(nil, params); len(options) > 0 {
+		createQuery += " " + strings.Join(options, " ")
+	}
+	statements := []string{createQuery + ";"}
+
+	// the owner goes first, a sequence and the table owning it must have the same owner
+	if params.Owner != "" && params.Owner != GetAssumeRoleFromCtx(ctx) {
+		statements = append(statements, fmt.Sprintf("ALTER SEQUENCE %s OWNER TO %s;", name, pq.QuoteIdentifier(params.Owner)))
+	}
+	if params.OwnedBy != "" {
+		statements = append(statements, fmt.Sprintf("ALTER SEQUENCE %s OWNED BY %s;", name, sequenceOwnedBy(params.Schema, params.OwnedBy)))
+	}
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateSequence)
+		}
+	}
+
+	if params.Comment != "" {
+		if err = CreateComment(ctx, txn, sequenceObjectType, name, params.Comment); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateSequence)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateSequence, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (s *sequenceSQL) Drop(ctx context.Context, schema, name string) error {
+	txn, err := BeginTxWithRole(ctx, s.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropSequence, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	err = DropObject(ctx, txn, sequenceObjectType, pgQualifiedName(schema, name))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropSequence)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropSequence, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (s *sequenceSQL) Get(ctx context.Context, schema, name string) (*SequenceModel, error) {
+	var model SequenceModel
+	var lastValue sql.NullInt64
+	var minValue, maxValue, start int64
+
+	sequenceQuery := `
+		SELECT s.schemaname                                                   as "schema",
+			   s.sequencename                                                 as "name",
+			   pg_catalog.current_database()                                  as "database",
+			   s.data_type::text                                              as "data_type",
+			   s.increment_by                                                 as "increment",
+			   s.min_value                                                    as "min_value",
+			   s.max_value                                                    as "max_value",
+			   s.start_value                                                  as "start",
+			   s.cache_size                                                   as "cache",
+			   s.cycle                                                        as "cycle",
+			   COALESCE((SELECT t.relname || '.' || a.attname
+						 FROM pg_catalog.pg_depend d
+								  JOIN pg_catalog.pg_class t ON t.oid = d.refobjid
+								  JOIN pg_catalog.pg_attribute a ON a.attrelid = d.refobjid AND a.attnum = d.refobjsubid
+						 WHERE d.classid = 'pg_catalog.pg_class'::regclass
+						   AND d.objid = c.oid
+						   AND d.refclassid = 'pg_catalog.pg_class'::regclass
+						   AND d.deptype = 'a'), '')                          as "owned_by",
+			   s.sequenceowner                                                as "owner",
+			   COALESCE(pg_catalog.obj_description(c.oid, 'pg_class'), '')    as "comment",
+			   s.last_value                                                   as "last_value"
+		FROM pg_catalog.pg_class c
+				 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+				 JOIN pg_catalog.pg_sequences s ON s.schemaname = n.nspname AND s.sequencename = c.relname
+		WHERE c.oid = pg_catalog.to_regclass(%s)
+		  AND c.relkind = %s;`
+
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf(sequenceQuery, pq.QuoteLiteral(pgQualifiedName(schema, name)), pq.QuoteLiteral(relKindSequence)))
+	err := row.Scan(
+		&model.Schema,
+		&model.Name,
+		&model.Database,
+		&model.DataType,
+		&model.Increment,
+		&minValue,
+		&maxValue,
+		&start,
+		&model.Cache,
+		&model.Cycle,
+		&model.OwnedBy,
+		&model.Owner,
+		&model.Comment,
+		&lastValue,
+	)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "sequence")
+	}
+
+	model.MinValue, model.MaxValue, model.Start = &minValue, &maxValue, &start
+	if lastValue.Valid {
+		model.LastValue = &lastValue.Int64
+	}
+	return &model, nil
+}
+
+// Update applies every change with ALTER SEQUENCE. A new start value is only recorded, the sequence
+// is not restarted and keeps returning values after its last one.
+func (s *sequenceSQL) Update(ctx context.Context, params SequenceUpdateParams) (*SequenceModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	current, desired := params.Current, params.Desired
+
+	// a sequence owned by a column can't change its owner or schema, release it first
+	var statements []string
+	ownedByChanged := current.OwnedBy != desired.OwnedBy || current.Schema != desired.Schema
+	if current.OwnedBy != "" && (ownedByChanged || (desired.Owner != "" && current.Owner != desired.Owner)) {
+		statements = append(statements, fmt.Sprintf("ALTER SEQUENCE %s OWNED BY NONE;", pgQualifiedName(current.Schema, current.Name)))
+		ownedByChanged = true
+	}
+
+	statements = append(statements, relationRenameStatements(sequenceObjectType, current.Schema, current.Name, desired.Schema, desired.Name)...)
+	name := pgQualifiedName(desired.Schema, desired.Name)
+
+	if options := sequenceOptions(&current, desired); len(options) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER SEQUENCE %s %s;", name, strings.Join(options, " ")))
+	}
+	if desired.Owner != "" && current.Owner != desired.Owner {
+		statements = append(statements, fmt.Sprintf("ALTER SEQUENCE %s OWNER TO %s;", name, pq.QuoteIdentifier(desired.Owner)))
+	}
+	if desired.OwnedBy != "" && ownedByChanged {
+		statements = append(statements, fmt.Sprintf("ALTER SEQUENCE %s OWNED BY %s;", name, sequenceOwnedBy(desired.Schema, desired.OwnedBy)))
+	}
+	if current.Comment != desired.Comment {
+		statements = append(statements, fmt.Sprintf("COMMENT ON SEQUENCE %s IS %s;", name, pq.QuoteLiteral(desired.Comment)))
+	}
+
+	txn, err := BeginTxWithRole(ctx, s.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateSequence, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateSequence)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateSequence, "pg_cmd", opCommitTransaction)
+	}
+
+	return s.Get(ctx, desired.Schema, desired.Name)
+}
+
+func (s *sequenceSQL) Exists(ctx context.Context, schema, name string) (bool, error) {
+	exists, err := relationExists(ctx, s.db, pgQualifiedName(schema, name), relKindSequence)
+	if err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsSequence)
+	}
+	return exists, nil
+}
+
+// sequenceOptions returns the options of CREATE SEQUENCE, or of ALTER SEQUENCE when there is a
+// current sequence. In that case only the options that differ from the current sequence are returned.
+func sequenceOptions(current *SequenceModel, desired SequenceModel) []string {
+	changed := func(cur, des *int64) bool {
+		return des != nil && (cur == nil || *cur != *des)
+	}
+	if current == nil {
+		current = &SequenceModel{}
+	}
+
+	var options []string
+	if desired.DataType != "" && desired.DataType != current.DataType {
+		options = append(options, fmt.Sprintf("AS %s", desired.DataType))
+	}
+	if desired.Increment != 0 && desired.Increment != current.Increment {
+		options = append(options, fmt.Sprintf("INCREMENT BY %d", desired.Increment))
+	}
+	if changed(current.MinValue, desired.MinValue) {
+		options = append(options, fmt.Sprintf("MINVALUE %d", *desired.MinValue))
+	}
+	if changed(current.MaxValue, desired.MaxValue) {
+		options = append(options, fmt.Sprintf("MAXVALUE %d", *desired.MaxValue))
+	}
+	if changed(current.Start, desired.Start) {
+		options = append(options, fmt.Sprintf("START WITH %d", *desired.Start))
+	}
+	if desired.Cache != 0 && desired.Cache != current.Cache {
+		options = append(options, fmt.Sprintf("CACHE %d", desired.Cache))
+	}
+	if desired.Cycle != current.Cycle {
+		if desired.Cycle {
+			options = append(options, "CYCLE")
+		} else {
+			options = append(options, "NO CYCLE")
+		}
+	}
+	return options
+}
+
+// sequenceOwnedBy quotes the 'table_name.column_name' owning a sequence, the table lives in the
+// schema of the sequence.
+func sequenceOwnedBy(schema, ownedBy string) string {
+	table, column, _ := strings.Cut(ownedBy, ".")
+	return fmt.Sprintf("%s.%s", pgQualifiedName(schema, table), pq.QuoteIdentifier(column))
+}
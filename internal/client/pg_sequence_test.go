@@ -0,0 +1,145 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+const (
+	testSequenceDb   = "test_sequence_db"
+	testSequenceUser = "test_sequence_user"
+)
+
+func testPrepareSequenceTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: testSequenceDb,
+		Username: testSequenceUser,
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `CREATE TABLE public.test_sequence_table (id int, code int);`)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func int64Ptr(v int64) *int64 {
+	return &v
+}
+
+func mockSequenceModel(t *testing.T) SequenceModel {
+	t.Helper()
+	return SequenceModel{
+		Schema:    "public",
+		Name:      "test_sequence",
+		DataType:  "integer",
+		Increment: 10,
+		MinValue:  int64Ptr(100),
+		MaxValue:  int64Ptr(100000),
+		Start:     int64Ptr(1000),
+		Cache:     5,
+		Cycle:     true,
+		OwnedBy:   "test_sequence_table.id",
+		Comment:   "test comment",
+	}
+}
+
+func TestSequenceOptions(t *testing.T) {
+	model := mockSequenceModel(t)
+	assert.Equal(t,
+		[]string{"AS integer", "INCREMENT BY 10", "MINVALUE 100", "MAXVALUE 100000", "START WITH 1000", "CACHE 5", "CYCLE"},
+		sequenceOptions(nil, model),
+	)
+	assert.Nil(t, sequenceOptions(&model, model))
+
+	desired := model
+	desired.MaxValue = int64Ptr(200000)
+	desired.MinValue = nil
+	desired.Cycle = false
+	assert.Equal(t, []string{"MAXVALUE 200000", "NO CYCLE"}, sequenceOptions(&model, desired))
+
+	assert.Equal(t, `"public"."test_sequence_table"."id"`, sequenceOwnedBy("public", model.OwnedBy))
+}
+
+func TestSequenceSQL_CreateAndGet(t *testing.T) {
+	ctx, db := testPrepareSequenceTestCase(t)
+	defer db.Close()
+
+	sequenceRepo := NewSequenceRepository(db)
+	model := mockSequenceModel(t)
+	assert.NoError(t, sequenceRepo.Create(ctx, model))
+
+	got, err := sequenceRepo.Get(ctx, model.Schema, model.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, testSequenceDb, got.Database)
+	assert.Equal(t, testSequenceUser, got.Owner)
+	assert.Equal(t, model.DataType, got.DataType)
+	assert.Equal(t, model.Increment, got.Increment)
+	assert.Equal(t, model.MinValue, got.MinValue)
+	assert.Equal(t, model.MaxValue, got.MaxValue)
+	assert.Equal(t, model.Start, got.Start)
+	assert.Equal(t, model.Cache, got.Cache)
+	assert.True(t, got.Cycle)
+	assert.Equal(t, model.OwnedBy, got.OwnedBy)
+	assert.Equal(t, model.Comment, got.Comment)
+	assert.Nil(t, got.LastValue)
+
+	_, err = db.ExecContext(ctx, `SELECT nextval('public.test_sequence');`)
+	assert.NoError(t, err)
+	got, err = sequenceRepo.Get(ctx, model.Schema, model.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, int64Ptr(1000), got.LastValue)
+
+	// dropping the owning table drops the sequence
+	_, err = db.ExecContext(ctx, `DROP TABLE public.test_sequence_table;`)
+	assert.NoError(t, err)
+	exists, err := sequenceRepo.Exists(ctx, model.Schema, model.Name)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestSequenceSQL_Update(t *testing.T) {
+	ctx, db := testPrepareSequenceTestCase(t)
+	defer db.Close()
+
+	_, err := db.ExecContext(ctx, `CREATE SCHEMA test_sequence_schema;`)
+	assert.NoError(t, err)
+
+	sequenceRepo := NewSequenceRepository(db)
+	current := mockSequenceModel(t)
+	assert.NoError(t, sequenceRepo.Create(ctx, current))
+
+	_, err = db.ExecContext(ctx, `SELECT nextval('public.test_sequence');`)
+	assert.NoError(t, err)
+
+	desired := mockSequenceModel(t)
+	desired.Schema = "test_sequence_schema"
+	desired.Name = "test_sequence_renamed"
+	desired.DataType = "bigint"
+	desired.Increment = 1
+	desired.Start = int64Ptr(500)
+	desired.Cycle = false
+	desired.OwnedBy = ""
+	desired.Comment = ""
+
+	got, err := sequenceRepo.Update(ctx, SequenceUpdateParams{Current: current, Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, desired.Schema, got.Schema)
+	assert.Equal(t, desired.Name, got.Name)
+	assert.Equal(t, "bigint", got.DataType)
+	assert.Equal(t, int64(1), got.Increment)
+	assert.Equal(t, int64Ptr(500), got.Start)
+	assert.False(t, got.Cycle)
+	assert.Empty(t, got.OwnedBy)
+	assert.Empty(t, got.Comment)
+	// the new start value does not restart the sequence
+	assert.Equal(t, int64Ptr(1000), got.LastValue)
+}
@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+)
+
+// PgCapability identifies a server feature that is not available in every supported PostgreSQL version.
+type PgCapability string
+
+const (
+	// CapabilityDropDatabaseForce is the `DROP DATABASE ... WITH (FORCE)` option (PostgreSQL 13+).
+	CapabilityDropDatabaseForce PgCapability = "drop_database_force"
+	// CapabilityICULocale is the `ICU_LOCALE` option of CREATE DATABASE (PostgreSQL 15+).
+	CapabilityICULocale PgCapability = "icu_locale"
+	// CapabilitySecurityInvokerView is the `security_invoker` option of views (PostgreSQL 15+).
+	CapabilitySecurityInvokerView PgCapability = "security_invoker_view"
+	// CapabilityMaintainPrivilege is the `MAINTAIN` privilege on relations (PostgreSQL 17+).
+	CapabilityMaintainPrivilege PgCapability = "maintain_privilege"
+	// CapabilityLoginEventTrigger is the `login` event for event triggers (PostgreSQL 17+).
+	CapabilityLoginEventTrigger PgCapability = "login_event_trigger"
+	// CapabilityPublicationFilters are the column lists, row filters and `TABLES IN SCHEMA` of publications (PostgreSQL 15+).
+	CapabilityPublicationFilters PgCapability = "publication_filters"
+	// CapabilitySubscriptionStreaming is the `streaming` option of subscriptions (PostgreSQL 14+).
+	CapabilitySubscriptionStreaming PgCapability = "subscription_streaming"
+	// CapabilitySubscriptionBinary is the `binary` option of subscriptions (PostgreSQL 14+).
+	CapabilitySubscriptionBinary PgCapability = "subscription_binary"
+	// CapabilityParallelStreaming is the `parallel` value of the `streaming` option of subscriptions (PostgreSQL 16+).
+	CapabilityParallelStreaming PgCapability = "parallel_streaming"
+	// CapabilityTwoPhaseSlot is the `two_phase` option of logical replication slots (PostgreSQL 14+).
+	CapabilityTwoPhaseSlot PgCapability = "two_phase_slot"
+	// CapabilityProcedureOutArgs are the `OUT` arguments of procedures (PostgreSQL 14+).
+	CapabilityProcedureOutArgs PgCapability = "procedure_out_args"
+	// CapabilityDropExpression is the `DROP EXPRESSION` action turning a generated column into a regular one (PostgreSQL 13+).
+	CapabilityDropExpression PgCapability = "drop_expression"
+)
+
+// capabilityMinVersion maps every capability to the first server_version_num that supports it.
+var capabilityMinVersion = map[PgCapability]int{
+	CapabilityDropDatabaseForce:     130000,
+	CapabilityICULocale:             150000,
+	CapabilitySecurityInvokerView:   150000,
+	CapabilityMaintainPrivilege:     170000,
+	CapabilityLoginEventTrigger:     170000,
+	CapabilityPublicationFilters:    150000,
+	CapabilitySubscriptionStreaming: 140000,
+	CapabilitySubscriptionBinary:    140000,
+	CapabilityParallelStreaming:     160000,
+	CapabilityTwoPhaseSlot:          140000,
+	CapabilityProcedureOutArgs:      140000,
+	CapabilityDropExpression:        130000,
+}
+
+// ServerInfo describes the PostgreSQL server behind a connection.
+type ServerInfo struct {
+	Version     string `json:"version"`
+	VersionNum  int    `json:"version_num"`
+	CurrentUser string `json:"current_user"`
+	IsSuperuser bool   `json:"is_superuser"`
+	// InRecovery is true on a standby server, replaying the WAL of its primary.
+	InRecovery     bool              `json:"in_recovery"`
+	DataChecksums  bool              `json:"data_checksums"`
+	MaxConnections int               `json:"max_connections"`
+	ServerEncoding string            `json:"server_encoding"`
+	Capabilities   []PgCapability    `json:"capabilities"`
+	Extensions     map[string]string `json:"extensions"`
+}
+
+// MajorVersion returns the major version of the server, e.g. 16 for 16.4.
+func (s *ServerInfo) MajorVersion() int {
+	return s.VersionNum / 10000
+}
+
+// Supports reports whether the server provides the given capability.
+func (s *ServerInfo) Supports(capability PgCapability) bool {
+	return slices.Contains(s.Capabilities, capability)
+}
+
+// HasExtension reports whether the given extension is installed in the connected database.
+func (s *ServerInfo) HasExtension(name string) bool {
+	_, ok := s.Extensions[name]
+	return ok
+}
+
+// String returns a short human-readable description of the server, used in diagnostics.
+func (s *ServerInfo) String() string {
+	return fmt.Sprintf("PostgreSQL %s", s.Version)
+}
+
+// CapabilityMinVersion returns the first major version of PostgreSQL providing the capability.
+func CapabilityMinVersion(capability PgCapability) int {
+	return capabilityMinVersion[capability] / 10000
+}
+
+func capabilitiesForVersion(versionNum int) []PgCapability {
+	capabilities := make([]PgCapability, 0, len(capabilityMinVersion))
+	for capability, minVersion := range capabilityMinVersion {
+		if versionNum >= minVersion {
+			capabilities = append(capabilities, capability)
+		}
+	}
+	slices.Sort(capabilities)
+	return capabilities
+}
+
+func getServerInfo(ctx context.Context, db *sql.DB) (*ServerInfo, error) {
+	info := &ServerInfo{
+		Extensions: map[string]string{},
+	}
+
+	serverQuery := `
+		SELECT pg_catalog.current_setting('server_version')              as "version",
+			   pg_catalog.current_setting('server_version_num')::integer as "version_num",
+			   current_user                                              as "current_user",
+			   pg_catalog.current_setting('is_superuser') = 'on'         as "is_superuser",
+			   pg_catalog.pg_is_in_recovery()                            as "in_recovery",
+			   pg_catalog.current_setting('data_checksums') = 'on'       as "data_checksums",
+			   pg_catalog.current_setting('max_connections')::integer    as "max_connections",
+			   pg_catalog.current_setting('server_encoding')             as "server_encoding";`
+
+	row := db.QueryRowContext(ctx, serverQuery)
+	err := row.Scan(&info.Version, &info.VersionNum, &info.CurrentUser, &info.IsSuperuser,
+		&info.InRecovery, &info.DataChecksums, &info.MaxConnections, &info.ServerEncoding)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetServerInfo, "pg_cmd", opQueryRow)
+	}
+
+	info.Capabilities = capabilitiesForVersion(info.VersionNum)
+
+	extensionsQuery := `SELECT e.extname, e.extversion FROM pg_catalog.pg_extension e;`
+
+	rows, err := db.QueryContext(ctx, extensionsQuery)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetServerInfo, "pg_cmd", opQuery)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name, version string
+		if err = rows.Scan(&name, &version); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opGetServerInfo, "pg_cmd", opScanRowResult)
+		}
+		info.Extensions[name] = version
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetServerInfo, "pg_cmd", opQuery)
+	}
+
+	return info, nil
+}
+
+// serverVersionNum returns the server_version_num of the server, for the catalog queries that
+// depend on the PostgreSQL version.
+func serverVersionNum(ctx context.Context, q pgQueryer) (int, error) {
+	var versionNum int
+	row := q.QueryRowContext(ctx, `SELECT pg_catalog.current_setting('server_version_num')::integer;`)
+	if err := row.Scan(&versionNum); err != nil {
+		return 0, PgErrWithMetadata(err, "pg_cmd", opQueryRow)
+	}
+	return versionNum, nil
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"strings"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestServerInfo_Supports(t *testing.T) {
+	tests := []struct {
+		name       string
+		capability PgCapability
+		versionNum int
+		expected   bool
+	}{
+		{name: "DropForceOnPG12", capability: CapabilityDropDatabaseForce, versionNum: 120020, expected: false},
+		{name: "DropForceOnPG13", capability: CapabilityDropDatabaseForce, versionNum: 130000, expected: true},
+		{name: "ICULocaleOnPG14", capability: CapabilityICULocale, versionNum: 140013, expected: false},
+		{name: "ICULocaleOnPG15", capability: CapabilityICULocale, versionNum: 150008, expected: true},
+		{name: "DropExpressionOnPG13", capability: CapabilityDropExpression, versionNum: 130000, expected: true},
+		{name: "SecurityInvokerViewOnPG14", capability: CapabilitySecurityInvokerView, versionNum: 140013, expected: false},
+		{name: "MaintainOnPG16", capability: CapabilityMaintainPrivilege, versionNum: 160004, expected: false},
+		{name: "LoginEventTriggerOnPG17", capability: CapabilityLoginEventTrigger, versionNum: 170000, expected: true},
+		{name: "PublicationFiltersOnPG14", capability: CapabilityPublicationFilters, versionNum: 140013, expected: false},
+		{name: "SubscriptionBinaryOnPG14", capability: CapabilitySubscriptionBinary, versionNum: 140000, expected: true},
+		{name: "ParallelStreamingOnPG15", capability: CapabilityParallelStreaming, versionNum: 150008, expected: false},
+		{name: "TwoPhaseSlotOnPG13", capability: CapabilityTwoPhaseSlot, versionNum: 130016, expected: false},
+		{name: "ProcedureOutArgsOnPG14", capability: CapabilityProcedureOutArgs, versionNum: 140005, expected: true},
+		{name: "DropExpressionOnPG12", capability: CapabilityDropExpression, versionNum: 120020, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info := &ServerInfo{VersionNum: tt.versionNum, Capabilities: capabilitiesForVersion(tt.versionNum)}
+			assert.Equal(t, tt.expected, info.Supports(tt.capability))
+		})
+	}
+}
+
+func TestPgConnection_ServerInfo(t *testing.T) {
+	for _, image := range test.SupportedPostgresImages {
+		t.Run(image, func(t *testing.T) {
+			runOpts := test.PostgresContainerRunOptions{Image: image, Database: "test_server_info"}
+			pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+			connString := test.GetPostgresConnectionString(t, pgContainer)
+			ctx := context.TODO()
+
+			db, err := postgres.Open(ctx, connString)
+			require.NoError(t, err)
+			defer db.Close()
+
+			conn, err := NewPgConnector(db)
+			require.NoError(t, err)
+
+			info, err := conn.ServerInfo(ctx)
+			require.NoError(t, err)
+
+			// image tags look like 'postgres:<major>-alpine'
+			major := strings.TrimSuffix(strings.TrimPrefix(image, "postgres:"), "-alpine")
+			assert.True(t, strings.HasPrefix(info.Version, major+"."), "unexpected version %s", info.Version)
+			assert.Equal(t, "tester", info.CurrentUser)
+			assert.True(t, info.IsSuperuser)
+			assert.False(t, info.InRecovery)
+			assert.Equal(t, 100, info.MaxConnections)
+			assert.Equal(t, "UTF8", info.ServerEncoding)
+			assert.True(t, info.HasExtension("plpgsql"))
+			assert.Equal(t, info.MajorVersion() >= 13, info.Supports(CapabilityDropDatabaseForce))
+			assert.Equal(t, info.MajorVersion() >= 13, info.Supports(CapabilityDropExpression))
+			assert.Equal(t, info.MajorVersion() >= 15, info.Supports(CapabilityICULocale))
+			assert.Equal(t, info.MajorVersion() >= 17, info.Supports(CapabilityLoginEventTrigger))
+
+			// the information is cached per connection
+			cached, err := conn.ServerInfo(ctx)
+			require.NoError(t, err)
+			assert.Same(t, info, cached)
+		})
+	}
+}
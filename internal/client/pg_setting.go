@@ -0,0 +1,373 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// the types of the configuration parameters, as reported by pg_settings.vartype.
+const (
+	SettingTypeBool    = "bool"
+	SettingTypeEnum    = "enum"
+	SettingTypeInteger = "integer"
+	SettingTypeReal    = "real"
+	SettingTypeString  = "string"
+)
+
+var errInvalidSettingValue = errors.New("invalid value for parameter")
+
+// settingQuotedLists are the list parameters whose elements are identifiers, e.g. the schemas of the
+// search_path. PostgreSQL quotes every element as an identifier, so they are passed one by one.
+var settingQuotedLists = []string{
+	"search_path",
+	"temp_tablespaces",
+	"local_preload_libraries",
+	"session_preload_libraries",
+	"shared_preload_libraries",
+}
+
+// settingUnitFactors are the units accepted in the values of memory parameters (in bytes) and of
+// time parameters (in milliseconds).
+var settingUnitFactors = map[string]map[string]float64{
+	"memory": {"B": 1, "kB": 1 << 10, "MB": 1 << 20, "GB": 1 << 30, "TB": 1 << 40},
+	"time":   {"us": 0.001, "ms": 1, "s": 1000, "min": 60 * 1000, "h": 60 * 60 * 1000, "d": 24 * 60 * 60 * 1000},
+}
+
+var (
+	settingNumericValueRegexp = regexp.MustCompile(`^\s*([-+]?(?:[0-9]+\.?[0-9]*|\.[0-9]+)(?:[eE][-+]?[0-9]+)?)\s*([a-zA-Z]*)\s*$`)
+	// settingIdentifierRegexp matches the identifiers that PostgreSQL doesn't quote.
+	settingIdentifierRegexp = regexp.MustCompile(`^[a-z_][a-z0-9_$]*$`)
+)
+
+type settingSQL struct {
+	db *sql.DB
+}
+
+// SettingModel is a row of pg_settings, the configuration parameters as seen by the current session.
+type SettingModel struct {
+	Name    string `json:"name"`
+	Setting string `json:"setting"`
+	Unit    string `json:"unit"`
+	// Category is the group of the parameter, e.g. 'Write-Ahead Log / Settings'.
+	Category string `json:"category"`
+	Context  string `json:"context"`
+	// Source tells where the value comes from, e.g. 'default', 'configuration file' or 'database'.
+	Source         string `json:"source"`
+	PendingRestart bool   `json:"pending_restart"`
+}
+
+// SettingFilter selects the rows of pg_settings, every row when empty.
+type SettingFilter struct {
+	// Names are the names of the parameters, case-insensitive.
+	Names []string
+	// Category is a category of parameters, including its subcategories, e.g. 'Write-Ahead Log'.
+	Category string
+}
+
+type SettingRepository interface {
+	List(ctx context.Context, filter SettingFilter) ([]SettingModel, error)
+}
+
+var _ SettingRepository = &settingSQL{}
+
+func NewSettingRepository(db *sql.DB) SettingRepository {
+	return &settingSQL{
+		db: db,
+	}
+}
+
+func (s *settingSQL) List(ctx context.Context, filter SettingFilter) ([]SettingModel, error) {
+	settingsQuery := `
+		SELECT s.name                 as "name",
+			   COALESCE(s.setting, '') as "setting",
+			   COALESCE(s.unit, '')    as "unit",
+			   s.category             as "category",
+			   s.context              as "context",
+			   s.source               as "source",
+			   s.pending_restart      as "pending_restart"
+		FROM pg_catalog.pg_settings s
+		WHERE %s
+		ORDER BY s.name;`
+
+	rows, err := s.db.QueryContext(ctx, fmt.Sprintf(settingsQuery, settingFilterCondition(filter)))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opListSettings, "pg_cmd", opQuery)
+	}
+	defer rows.Close()
+
+	settings := make([]SettingModel, 0)
+	for rows.Next() {
+		var setting SettingModel
+		err = rows.Scan(&setting.Name, &setting.Setting, &setting.Unit, &setting.Category, &setting.Context,
+			&setting.Source, &setting.PendingRestart)
+		if err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opListSettings, "pg_cmd", opScanRowResult)
+		}
+		settings = append(settings, setting)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opListSettings, "pg_cmd", opQuery)
+	}
+	return settings, nil
+}
+
+// settingFilterCondition returns the WHERE condition on pg_settings matching the filter.
+func settingFilterCondition(filter SettingFilter) string {
+	conditions := []string{"true"}
+	if len(filter.Names) > 0 {
+		names := make([]string, len(filter.Names))
+		for i, name := range filter.Names {
+			names[i] = pq.QuoteLiteral(strings.ToLower(name))
+		}
+		conditions = append(conditions, fmt.Sprintf("lower(s.name) IN (%s)", strings.Join(names, ", ")))
+	}
+	if filter.Category != "" {
+		category := pq.QuoteLiteral(filter.Category)
+		conditions = append(conditions, fmt.Sprintf("(s.category = %s OR starts_with(s.category, %s || ' / '))", category, category))
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+// SettingDefinition describes a configuration parameter of the server, read from pg_settings.
+type SettingDefinition struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Unit is the implicit unit of the numeric values, e.g. 'kB', '8kB' or 'ms', empty when unit-less.
+	Unit string `json:"unit"`
+	// Context tells when the parameter can be changed, e.g. 'user', 'superuser' or 'postmaster'.
+	Context    string   `json:"context"`
+	EnumValues []string `json:"enum_values"`
+	MinValue   *float64 `json:"min_value"`
+	MaxValue   *float64 `json:"max_value"`
+}
+
+// Validate checks that the value is valid for the parameter: a boolean, one of the values of an
+// enum, or a number within the bounds of the parameter, with an optional unit.
+func (d *SettingDefinition) Validate(value string) error {
+	switch d.Type {
+	case SettingTypeBool:
+		if _, ok := parseSettingBool(value); !ok {
+			return fmt.Errorf("%w '%s': '%s' is not a boolean", errInvalidSettingValue, d.Name, value)
+		}
+	case SettingTypeEnum:
+		if !slices.Contains(d.EnumValues, strings.ToLower(strings.TrimSpace(value))) {
+			return fmt.Errorf("%w '%s': '%s' is not one of %s", errInvalidSettingValue, d.Name, value, strings.Join(d.EnumValues, ", "))
+		}
+	case SettingTypeInteger, SettingTypeReal:
+		number, err := d.parseNumber(value)
+		if err != nil {
+			return err
+		}
+		if d.Type == SettingTypeInteger {
+			number = math.Round(number)
+		}
+		if (d.MinValue != nil && number < *d.MinValue) || (d.MaxValue != nil && number > *d.MaxValue) {
+			return fmt.Errorf("%w '%s': %s is outside the valid range [%s, %s]", errInvalidSettingValue, d.Name, value,
+				formatSettingNumber(d.MinValue), formatSettingNumber(d.MaxValue))
+		}
+	}
+	return nil
+}
+
+// Normalize returns the value in a canonical form: booleans as on/off, enums in lowercase and
+// numbers in the unit of the parameter, e.g. 1048576 for a work_mem of '1GB' or '1024MB'.
+// Values that can't be parsed are returned unchanged.
+func (d *SettingDefinition) Normalize(value string) string {
+	switch d.Type {
+	case SettingTypeBool:
+		if b, ok := parseSettingBool(value); ok {
+			if b {
+				return "on"
+			}
+			return "off"
+		}
+	case SettingTypeEnum:
+		return strings.ToLower(strings.TrimSpace(value))
+	case SettingTypeInteger, SettingTypeReal:
+		if number, err := d.parseNumber(value); err == nil {
+			if d.Type == SettingTypeInteger {
+				number = math.Round(number)
+			}
+			return strconv.FormatFloat(number, 'f', -1, 64)
+		}
+	case SettingTypeString:
+		return normalizeSettingString(d.Name, value)
+	}
+	return value
+}
+
+// parseNumber parses a numeric value, converting it to the unit of the parameter when it has one.
+func (d *SettingDefinition) parseNumber(value string) (float64, error) {
+	matches := settingNumericValueRegexp.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, fmt.Errorf("%w '%s': '%s' is not a number", errInvalidSettingValue, d.Name, value)
+	}
+	number, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w '%s': %s", errInvalidSettingValue, d.Name, err)
+	}
+	if matches[2] == "" {
+		return number, nil
+	}
+
+	category, baseFactor := settingUnitOf(d.Unit)
+	factor, ok := settingUnitFactors[category][matches[2]]
+	if !ok {
+		if category == "" {
+			return 0, fmt.Errorf("%w '%s': the parameter doesn't accept units", errInvalidSettingValue, d.Name)
+		}
+		units := make([]string, 0, len(settingUnitFactors[category]))
+		for unit := range settingUnitFactors[category] {
+			units = append(units, unit)
+		}
+		slices.Sort(units)
+		return 0, fmt.Errorf("%w '%s': unknown unit '%s', valid units are %s", errInvalidSettingValue, d.Name, matches[2], strings.Join(units, ", "))
+	}
+	return number * factor / baseFactor, nil
+}
+
+// settingUnitOf returns the category of the unit of a parameter and its factor, e.g. memory and 8192 for '8kB'.
+func settingUnitOf(unit string) (string, float64) {
+	multiplier, name := 1.0, strings.TrimLeft(unit, "0123456789")
+	if prefix := strings.TrimSuffix(unit, name); prefix != "" {
+		multiplier, _ = strconv.ParseFloat(prefix, 64)
+	}
+	for category, factors := range settingUnitFactors {
+		if factor, ok := factors[name]; ok {
+			return category, multiplier * factor
+		}
+	}
+	return "", 1
+}
+
+// parseSettingBool parses a boolean the way PostgreSQL does, accepting any unique prefix of
+// true, false, yes, no, as well as on, off, 1 and 0.
+func parseSettingBool(value string) (bool, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case value == "":
+		return false, false
+	case value == "1" || value == "on":
+		return true, true
+	case value == "0" || value == "of" || value == "off":
+		return false, true
+	case strings.HasPrefix("true", value) || strings.HasPrefix("yes", value):
+		return true, true
+	case strings.HasPrefix("false", value) || strings.HasPrefix("no", value):
+		return false, true
+	}
+	return false, false
+}
+
+func formatSettingNumber(number *float64) string {
+	if number == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*number, 'f', -1, 64)
+}
+
+// splitSettingList splits a list value on the commas outside of double quotes, unquoting the elements.
+func splitSettingList(value string) []string {
+	var elements []string
+	var current strings.Builder
+	quoted := false
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch {
+		case c == '"' && quoted && i+1 < len(value) && value[i+1] == '"':
+			current.WriteByte('"')
+			i++
+		case c == '"':
+			quoted = !quoted
+		case c == ',' && !quoted:
+			elements = append(elements, current.String())
+			current.Reset()
+		case (c == ' ' || c == '\t') && !quoted:
+			// whitespace is only meaningful inside quotes
+		default:
+			current.WriteByte(c)
+		}
+	}
+	return append(elements, current.String())
+}
+
+// normalizeSettingString returns the value of a string parameter as PostgreSQL stores it, which
+// only differs from the given one for the lists of identifiers, e.g. 'app,public' is stored as 'app, public'.
+func normalizeSettingString(name, value string) string {
+	if !slices.Contains(settingQuotedLists, strings.ToLower(name)) {
+		return value
+	}
+	elements := splitSettingList(value)
+	for i, element := range elements {
+		if !settingIdentifierRegexp.MatchString(element) {
+			element = pq.QuoteIdentifier(element)
+		}
+		elements[i] = element
+	}
+	return strings.Join(elements, ", ")
+}
+
+// settingValueClause returns the value of a SET clause. The elements of the lists of identifiers
+// are passed one by one, since PostgreSQL would otherwise quote the whole list as a single identifier.
+func settingValueClause(name, value string) string {
+	if !slices.Contains(settingQuotedLists, strings.ToLower(name)) {
+		return pq.QuoteLiteral(value)
+	}
+	elements := splitSettingList(value)
+	for i, element := range elements {
+		elements[i] = pq.QuoteLiteral(element)
+	}
+	return strings.Join(elements, ", ")
+}
+
+// settingNameClause returns the name of a parameter in a SET clause. The name of a custom
+// parameter is qualified by its prefix, e.g. 'app.tenant'.
+func settingNameClause(name string) string {
+	return pgQuoteQualifiedName(strings.ToLower(name))
+}
+
+// readSettingDefinition reads the definition of the parameter from pg_settings, nil when the server
+// doesn't know it, e.g. a custom parameter that was never set.
+func readSettingDefinition(ctx context.Context, q pgQueryer, name string) (*SettingDefinition, error) {
+	definitionQuery := `
+		SELECT s.name                      as "name",
+			   s.vartype                   as "type",
+			   COALESCE(s.unit, '')        as "unit",
+			   s.context                   as "context",
+			   COALESCE(s.enumvals, '{}')  as "enum_values",
+			   s.min_val::float8           as "min_value",
+			   s.max_val::float8           as "max_value"
+		FROM pg_catalog.pg_settings s
+		WHERE lower(s.name) = lower(%s);`
+
+	var definition SettingDefinition
+	var minValue, maxValue sql.NullFloat64
+	row := q.QueryRowContext(ctx, fmt.Sprintf(definitionQuery, pq.QuoteLiteral(name)))
+	err := row.Scan(&definition.Name, &definition.Type, &definition.Unit, &definition.Context,
+		(*pq.StringArray)(&definition.EnumValues), &minValue, &maxValue)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opScanRowResult, "model", "setting_definition")
+	}
+
+	for i, enumValue := range definition.EnumValues {
+		definition.EnumValues[i] = strings.ToLower(enumValue)
+	}
+	if minValue.Valid {
+		definition.MinValue = &minValue.Float64
+	}
+	if maxValue.Valid {
+		definition.MaxValue = &maxValue.Float64
+	}
+	return &definition, nil
+}
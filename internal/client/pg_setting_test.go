@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestSettingDefinition_Validate(t *testing.T) {
+	minMem, maxMem := 64.0, 2147483647.0
+	minTimeout, maxTimeout := 0.0, 2147483647.0
+	workMem := SettingDefinition{Name: "work_mem", Type: SettingTypeInteger, Unit: "kB", MinValue: &minMem, MaxValue: &maxMem}
+	timeout := SettingDefinition{Name: "statement_timeout", Type: SettingTypeInteger, Unit: "ms", MinValue: &minTimeout, MaxValue: &maxTimeout}
+	jit := SettingDefinition{Name: "jit", Type: SettingTypeBool}
+	logLevel := SettingDefinition{Name: "log_min_messages", Type: SettingTypeEnum, EnumValues: []string{"debug", "info", "warning"}}
+
+	tests := []struct {
+		name       string
+		definition SettingDefinition
+		value      string
+		errMsg     string
+	}{
+		{name: "MemoryWithUnit", definition: workMem, value: "64MB"},
+		{name: "MemoryWithoutUnit", definition: workMem, value: "4096"},
+		{name: "MemoryBelowMin", definition: workMem, value: "32kB", errMsg: "invalid value for parameter 'work_mem': 32kB is outside the valid range [64, 2147483647]"},
+		{name: "MemoryWithTimeUnit", definition: workMem, value: "5s", errMsg: "unknown unit 's', valid units are B, GB, MB, TB, kB"},
+		{name: "TimeWithUnit", definition: timeout, value: "1.5min"},
+		{name: "NotANumber", definition: timeout, value: "fast", errMsg: "'fast' is not a number"},
+		{name: "BoolPrefix", definition: jit, value: "Of"},
+		{name: "NotABool", definition: jit, value: "o", errMsg: "'o' is not a boolean"},
+		{name: "EnumCaseInsensitive", definition: logLevel, value: "WARNING"},
+		{name: "NotAnEnumValue", definition: logLevel, value: "verbose", errMsg: "'verbose' is not one of debug, info, warning"},
+		{name: "AnyString", definition: SettingDefinition{Name: "search_path", Type: SettingTypeString}, value: "app, public"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.definition.Validate(tt.value)
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, errInvalidSettingValue)
+			assert.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
+
+func TestSettingDefinition_Normalize(t *testing.T) {
+	workMem := SettingDefinition{Name: "work_mem", Type: SettingTypeInteger, Unit: "kB"}
+	sharedBuffers := SettingDefinition{Name: "shared_buffers", Type: SettingTypeInteger, Unit: "8kB"}
+	timeout := SettingDefinition{Name: "statement_timeout", Type: SettingTypeInteger, Unit: "ms"}
+	searchPath := SettingDefinition{Name: "search_path", Type: SettingTypeString}
+
+	assert.Equal(t, "1048576", workMem.Normalize("1GB"))
+	assert.Equal(t, "1048576", workMem.Normalize("1024MB"))
+	assert.Equal(t, "1048576", workMem.Normalize("1048576"))
+	assert.Equal(t, "16384", sharedBuffers.Normalize("128MB"))
+	assert.Equal(t, "30000", timeout.Normalize("30s"))
+	assert.Equal(t, "30000", timeout.Normalize("0.5 min"))
+	assert.Equal(t, "on", (&SettingDefinition{Type: SettingTypeBool}).Normalize("TRUE"))
+	assert.Equal(t, "app, public", searchPath.Normalize("app,public"))
+	assert.Equal(t, `"$user", "My Schema", public`, searchPath.Normalize(`"$user",  "My Schema",public`))
+	assert.Equal(t, "not a number", workMem.Normalize("not a number"))
+}
+
+func TestSettingValueClause(t *testing.T) {
+	assert.Equal(t, `'1GB'`, settingValueClause("work_mem", "1GB"))
+	assert.Equal(t, `'$user', 'app', 'My Schema'`, settingValueClause("search_path", `"$user", app, "My Schema"`))
+	assert.Equal(t, `'ISO, MDY'`, settingValueClause("DateStyle", "ISO, MDY"))
+	assert.Equal(t, `"app"."tenant"`, settingNameClause("app.tenant"))
+}
+
+func TestSettingFilterCondition(t *testing.T) {
+	assert.Equal(t, "true", settingFilterCondition(SettingFilter{}))
+	assert.Equal(t, "true AND lower(s.name) IN ('wal_level', 'work_mem')",
+		settingFilterCondition(SettingFilter{Names: []string{"WAL_Level", "work_mem"}}))
+	assert.Equal(t, "true AND (s.category = 'Write-Ahead Log' OR starts_with(s.category, 'Write-Ahead Log' || ' / '))",
+		settingFilterCondition(SettingFilter{Category: "Write-Ahead Log"}))
+}
+
+func TestSettingSQL_List(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_setting_db",
+		Username: "test_setting_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := NewSettingRepository(db)
+	settings, err := repo.List(ctx, SettingFilter{Names: []string{"WAL_LEVEL", "work_mem"}})
+	require.NoError(t, err)
+	require.Len(t, settings, 2)
+	assert.Equal(t, SettingModel{
+		Name:     "wal_level",
+		Setting:  "replica",
+		Category: "Write-Ahead Log / Settings",
+		Context:  "postmaster",
+		Source:   "default",
+	}, settings[0])
+	assert.Equal(t, "work_mem", settings[1].Name)
+	assert.Equal(t, "kB", settings[1].Unit)
+
+	settings, err = repo.List(ctx, SettingFilter{Category: "Write-Ahead Log"})
+	require.NoError(t, err)
+	for _, setting := range settings {
+		assert.Contains(t, setting.Category, "Write-Ahead Log")
+	}
+
+	settings, err = repo.List(ctx, SettingFilter{})
+	require.NoError(t, err)
+	assert.Greater(t, len(settings), 200)
+}
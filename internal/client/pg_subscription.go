@@ -0,0 +1,278 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+const (
+	subscriptionObjectType = "SUBSCRIPTION"
+
+	SubscriptionStreamingOff      = "off"
+	SubscriptionStreamingOn       = "on"
+	SubscriptionStreamingParallel = "parallel"
+)
+
+var SubscriptionStreamingModes = []string{SubscriptionStreamingOff, SubscriptionStreamingOn, SubscriptionStreamingParallel}
+
+type subscriptionSQL struct {
+	db *sql.DB
+}
+
+// SubscriptionModel describes a subscription, the logical replication of publications of another server.
+type SubscriptionModel struct {
+	Name     string `json:"name" validate:"required"`
+	Database string `json:"database"`
+	// ConnInfo is the libpq connection string of the publisher. It's never read back, only superusers can.
+	ConnInfo     string   `json:"conn_info" validate:"required"`
+	Publications []string `json:"publications" validate:"required,min=1,unique"`
+	// SlotName is the replication slot on the publisher, the name of the subscription when empty.
+	SlotName string `json:"slot_name"`
+	// CreateSlot creates the replication slot on the publisher. Only used on creation.
+	CreateSlot bool `json:"create_slot"`
+	// CopyData copies the existing data of the published tables, on creation and on changes of the publications.
+	CopyData  bool   `json:"copy_data"`
+	Enabled   bool   `json:"enabled"`
+	Streaming string `json:"streaming" validate:"omitempty,oneof=off on parallel"`
+	Binary    bool   `json:"binary"`
+	Owner     string `json:"owner"`
+	Comment   string `json:"comment"`
+}
+
+type SubscriptionUpdateParams struct {
+	Current SubscriptionModel
+	Desired SubscriptionModel `validate:"required"`
+}
+
+type SubscriptionRepository interface {
+	Create(ctx context.Context, params SubscriptionModel) error
+	Drop(ctx context.Context, name string) error
+	Get(ctx context.Context, name string) (*SubscriptionModel, error)
+	Update(ctx context.Context, params SubscriptionUpdateParams) (*SubscriptionModel, error)
+	Exists(ctx context.Context, name string) (bool, error)
+}
+
+var _ SubscriptionRepository = &subscriptionSQL{}
+
+func NewSubscriptionRepository(db *sql.DB) SubscriptionRepository {
+	return &subscriptionSQL{
+		db: db,
+	}
+}
+
+// Create creates the subscription. CREATE SUBSCRIPTION refuses to run inside a transaction when it
+// creates the replication slot, so the owner and comment are set by a transaction of their own, and
+// the subscription is dropped when that transaction fails.
+func (s *subscriptionSQL) Create(ctx context.Context, params SubscriptionModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	if err := ExecWithRole(ctx, s.db, subscriptionCreateQuery(params)); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateSubscription)
+	}
+
+	if err := s.completeCreate(ctx, params); err != nil {
+		if errDrop := s.Drop(ctx, params.Name); errDrop != nil {
+			return PgErrWithMetadata(err, "operation", opCreateSubscription, "cleanup", errDrop.Error())
+		}
+		return PgErrWithMetadata(err, "operation", opCreateSubscription, "cleanup", "subscription dropped")
+	}
+	return nil
+}
+
+// completeCreate sets the owner and comment of a new subscription, in a single transaction.
+func (s *subscriptionSQL) completeCreate(ctx context.Context, params SubscriptionModel) error {
+	name := pq.QuoteIdentifier(params.Name)
+
+	var statements []string
+	if params.Owner != "" && params.Owner != GetAssumeRoleFromCtx(ctx) {
+		statements = append(statements, fmt.Sprintf("ALTER SUBSCRIPTION %s OWNER TO %s;", name, pq.QuoteIdentifier(params.Owner)))
+	}
+	if params.Comment != "" {
+		statements = append(statements, fmt.Sprintf("COMMENT ON SUBSCRIPTION %s IS %s;", name, pq.QuoteLiteral(params.Comment)))
+	}
+	if len(statements) == 0 {
+		return nil
+	}
+
+	txn, err := BeginTxWithRole(ctx, s.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return err
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+// Drop drops the subscription along with its replication slot on the publisher.
+func (s *subscriptionSQL) Drop(ctx context.Context, name string) error {
+	err := ExecWithRole(ctx, s.db, fmt.Sprintf("DROP %s IF EXISTS %s;", subscriptionObjectType, pq.QuoteIdentifier(name)))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropSubscription)
+	}
+	return nil
+}
+
+func (s *subscriptionSQL) Get(ctx context.Context, name string) (*SubscriptionModel, error) {
+	versionNum, err := serverVersionNum(ctx, s.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetSubscription)
+	}
+
+	streaming, binary := "'off'", "false"
+	switch {
+	case versionNum >= 160000:
+		streaming = "CASE s.substream WHEN 'p' THEN 'parallel' WHEN 't' THEN 'on' ELSE 'off' END"
+		binary = "s.subbinary"
+	case versionNum >= 140000:
+		streaming = "CASE WHEN s.substream THEN 'on' ELSE 'off' END"
+		binary = "s.subbinary"
+	}
+
+	// subconninfo is left out, only superusers can read it
+	readQuery := `
+		SELECT s.subname                                                          as "name",
+			   pg_catalog.current_database()                                      as "database",
+			   s.subenabled                                                       as "enabled",
+			   COALESCE(s.subslotname, '')                                        as "slot_name",
+			   s.subpublications                                                  as "publications",
+			   %s                                                                 as "streaming",
+			   %s                                                                 as "binary",
+			   pg_catalog.pg_get_userbyid(s.subowner)                             as "owner",
+			   COALESCE(pg_catalog.obj_description(s.oid, 'pg_subscription'), '') as "comment"
+		FROM pg_catalog.pg_subscription s
+				 JOIN pg_catalog.pg_database d ON d.oid = s.subdbid
+		WHERE d.datname = pg_catalog.current_database()
+		  AND s.subname = %s;`
+
+	var model SubscriptionModel
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf(readQuery, streaming, binary, pq.QuoteLiteral(name)))
+	err = row.Scan(
+		&model.Name,
+		&model.Database,
+		&model.Enabled,
+		&model.SlotName,
+		(*pq.StringArray)(&model.Publications),
+		&model.Streaming,
+		&model.Binary,
+		&model.Owner,
+		&model.Comment,
+	)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetSubscription, "pg_cmd", opScanRowResult)
+	}
+	return &model, nil
+}
+
+// Update applies the changes with ALTER SUBSCRIPTION. The publications are refreshed when the
+// subscription is enabled, which can't run inside a transaction either.
+func (s *subscriptionSQL) Update(ctx context.Context, params SubscriptionUpdateParams) (*SubscriptionModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	if err := ExecWithRole(ctx, s.db, subscriptionUpdateStatements(params.Current, params.Desired)...); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateSubscription)
+	}
+
+	return s.Get(ctx, params.Desired.Name)
+}
+
+func (s *subscriptionSQL) Exists(ctx context.Context, name string) (bool, error) {
+	existsQuery := `
+		SELECT EXISTS (SELECT 1
+					   FROM pg_catalog.pg_subscription s
+								JOIN pg_catalog.pg_database d ON d.oid = s.subdbid
+					   WHERE d.datname = pg_catalog.current_database()
+						 AND s.subname = %s);`
+
+	var exists bool
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(name)))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsSubscription, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
+
+func subscriptionCreateQuery(model SubscriptionModel) string {
+	options := []string{
+		fmt.Sprintf("enabled = %t", model.Enabled),
+		fmt.Sprintf("create_slot = %t", model.CreateSlot),
+		fmt.Sprintf("copy_data = %t", model.CopyData),
+	}
+	if model.SlotName != "" {
+		options = append(options, fmt.Sprintf("slot_name = %s", pq.QuoteLiteral(model.SlotName)))
+	}
+	// the options missing from older servers are only given when they differ from the default
+	if model.Streaming != "" && model.Streaming != SubscriptionStreamingOff {
+		options = append(options, fmt.Sprintf("streaming = %s", model.Streaming))
+	}
+	if model.Binary {
+		options = append(options, "binary = true")
+	}
+
+	return fmt.Sprintf("CREATE SUBSCRIPTION %s CONNECTION %s PUBLICATION %s WITH (%s);",
+		pq.QuoteIdentifier(model.Name), pq.QuoteLiteral(model.ConnInfo), pgQuoteListOfIdentifiers(model.Publications), strings.Join(options, ", "))
+}
+
+// subscriptionUpdateStatements returns the statements turning the current subscription into the
+// desired one. A subscription being enabled is enabled first, so the publications can be refreshed,
+// and one being disabled is disabled last.
+func subscriptionUpdateStatements(current, desired SubscriptionModel) []string {
+	name := pq.QuoteIdentifier(desired.Name)
+
+	var statements []string
+	if current.Name != desired.Name {
+		statements = append(statements, fmt.Sprintf("ALTER SUBSCRIPTION %s RENAME TO %s;", pq.QuoteIdentifier(current.Name), name))
+	}
+	if desired.Enabled && !current.Enabled {
+		statements = append(statements, fmt.Sprintf("ALTER SUBSCRIPTION %s ENABLE;", name))
+	}
+	if current.ConnInfo != desired.ConnInfo {
+		statements = append(statements, fmt.Sprintf("ALTER SUBSCRIPTION %s CONNECTION %s;", name, pq.QuoteLiteral(desired.ConnInfo)))
+	}
+	if !sameElements(current.Publications, desired.Publications) {
+		refresh := fmt.Sprintf("refresh = %t", desired.Enabled)
+		if desired.Enabled {
+			refresh += fmt.Sprintf(", copy_data = %t", desired.CopyData)
+		}
+		statements = append(statements, fmt.Sprintf("ALTER SUBSCRIPTION %s SET PUBLICATION %s WITH (%s);", name, pgQuoteListOfIdentifiers(desired.Publications), refresh))
+	}
+
+	var options []string
+	if current.Streaming != desired.Streaming && desired.Streaming != "" {
+		options = append(options, fmt.Sprintf("streaming = %s", desired.Streaming))
+	}
+	if current.Binary != desired.Binary {
+		options = append(options, fmt.Sprintf("binary = %t", desired.Binary))
+	}
+	if len(options) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER SUBSCRIPTION %s SET (%s);", name, strings.Join(options, ", ")))
+	}
+
+	if !desired.Enabled && current.Enabled {
+		statements = append(statements, fmt.Sprintf("ALTER SUBSCRIPTION %s DISABLE;", name))
+	}
+	if desired.Owner != "" && current.Owner != desired.Owner {
+		statements = append(statements, fmt.Sprintf("ALTER SUBSCRIPTION %s OWNER TO %s;", name, pq.QuoteIdentifier(desired.Owner)))
+	}
+	if current.Comment != desired.Comment {
+		statements = append(statements, fmt.Sprintf("COMMENT ON SUBSCRIPTION %s IS %s;", name, pq.QuoteLiteral(desired.Comment)))
+	}
+	return statements
+}
@@ -0,0 +1,153 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+const (
+	testSubscriptionDb   = "test_subscription_db"
+	testSubscriptionUser = "test_subscription_user"
+)
+
+// testPrepareSubscriptionTestCase starts a publisher and a subscriber sharing a network, the
+// publisher publishes a table that also exists on the subscriber.
+func testPrepareSubscriptionTestCase(t *testing.T) (context.Context, *sql.DB, string) {
+	nw := test.NewTestNetwork(t)
+	publisherOpts := test.PostgresContainerRunOptions{
+		Database:     "test_publisher_db",
+		Username:     "test_publisher_user",
+		Settings:     map[string]string{"wal_level": "logical"},
+		Network:      nw,
+		NetworkAlias: "publisher",
+	}
+	subscriberOpts := test.PostgresContainerRunOptions{
+		Database: testSubscriptionDb,
+		Username: testSubscriptionUser,
+		Network:  nw,
+	}
+	ctx := context.TODO()
+
+	publisher, err := postgres.Open(ctx, test.GetPostgresConnectionString(t, test.LoadPostgresTestContainer(t, publisherOpts, false)))
+	assert.NoError(t, err)
+	defer publisher.Close()
+
+	subscriber, err := postgres.Open(ctx, test.GetPostgresConnectionString(t, test.LoadPostgresTestContainer(t, subscriberOpts, false)))
+	assert.NoError(t, err)
+
+	table := `CREATE TABLE public.test_subscription_table (id int PRIMARY KEY, name text);`
+	_, err = publisher.ExecContext(ctx, table+`
+		INSERT INTO public.test_subscription_table VALUES (1, 'a');
+		CREATE PUBLICATION test_publication FOR TABLE public.test_subscription_table;
+		CREATE PUBLICATION test_publication_other FOR TABLE public.test_subscription_table;`)
+	assert.NoError(t, err)
+	_, err = subscriber.ExecContext(ctx, table)
+	assert.NoError(t, err)
+
+	return ctx, subscriber, test.GetPostgresNetworkConnInfo(t, publisherOpts)
+}
+
+func mockSubscriptionModel(t *testing.T) SubscriptionModel {
+	t.Helper()
+	return SubscriptionModel{
+		Name:         "test_subscription",
+		ConnInfo:     "host=publisher dbname=app",
+		Publications: []string{"test_publication"},
+		CreateSlot:   true,
+		CopyData:     true,
+		Enabled:      true,
+		Streaming:    SubscriptionStreamingOn,
+		Comment:      "test comment",
+	}
+}
+
+func TestSubscriptionCreateQuery(t *testing.T) {
+	model := mockSubscriptionModel(t)
+	assert.Equal(t,
+		`CREATE SUBSCRIPTION "test_subscription" CONNECTION 'host=publisher dbname=app' PUBLICATION "test_publication" WITH (enabled = true, create_slot = true, copy_data = true, streaming = on);`,
+		subscriptionCreateQuery(model),
+	)
+
+	model = SubscriptionModel{Name: "s", ConnInfo: "host=p", Publications: []string{"a", "b"}, SlotName: "slot", Binary: true}
+	assert.Equal(t,
+		`CREATE SUBSCRIPTION "s" CONNECTION 'host=p' PUBLICATION "a", "b" WITH (enabled = false, create_slot = false, copy_data = false, slot_name = 'slot', binary = true);`,
+		subscriptionCreateQuery(model),
+	)
+}
+
+func TestSubscriptionUpdateStatements(t *testing.T) {
+	current := mockSubscriptionModel(t)
+	current.Enabled = false
+
+	desired := current
+	desired.Enabled = true
+	desired.Publications = []string{"test_publication", "other"}
+	desired.Streaming = SubscriptionStreamingOff
+	assert.Equal(t, []string{
+		`ALTER SUBSCRIPTION "test_subscription" ENABLE;`,
+		`ALTER SUBSCRIPTION "test_subscription" SET PUBLICATION "test_publication", "other" WITH (refresh = true, copy_data = true);`,
+		`ALTER SUBSCRIPTION "test_subscription" SET (streaming = off);`,
+	}, subscriptionUpdateStatements(current, desired))
+
+	current = desired
+	desired.Enabled = false
+	desired.Publications = []string{"other"}
+	desired.Comment = ""
+	assert.Equal(t, []string{
+		`ALTER SUBSCRIPTION "test_subscription" SET PUBLICATION "other" WITH (refresh = false);`,
+		`ALTER SUBSCRIPTION "test_subscription" DISABLE;`,
+		`COMMENT ON SUBSCRIPTION "test_subscription" IS '';`,
+	}, subscriptionUpdateStatements(current, desired))
+}
+
+func TestSubscriptionSQL_CreateGetAndUpdate(t *testing.T) {
+	// the streaming and binary options of subscriptions come with PostgreSQL 14
+	test.SkipBelowPostgres(t, 14)
+	ctx, db, connInfo := testPrepareSubscriptionTestCase(t)
+	defer db.Close()
+
+	subscriptionRepo := NewSubscriptionRepository(db)
+	current := mockSubscriptionModel(t)
+	current.ConnInfo = connInfo
+	assert.NoError(t, subscriptionRepo.Create(ctx, current))
+
+	got, err := subscriptionRepo.Get(ctx, current.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, testSubscriptionDb, got.Database)
+	assert.True(t, got.Enabled)
+	assert.Equal(t, current.Name, got.SlotName)
+	assert.Equal(t, current.Publications, got.Publications)
+	assert.Equal(t, SubscriptionStreamingOn, got.Streaming)
+	assert.False(t, got.Binary)
+	assert.Equal(t, testSubscriptionUser, got.Owner)
+	assert.Equal(t, current.Comment, got.Comment)
+
+	desired := current
+	desired.Name = "test_subscription_renamed"
+	desired.Publications = []string{"test_publication_other"}
+	desired.Enabled = false
+	desired.Binary = true
+
+	got, err = subscriptionRepo.Update(ctx, SubscriptionUpdateParams{Current: current, Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, desired.Name, got.Name)
+	assert.Equal(t, desired.Publications, got.Publications)
+	assert.False(t, got.Enabled)
+	assert.True(t, got.Binary)
+
+	assert.NoError(t, subscriptionRepo.Drop(ctx, desired.Name))
+	exists, err := subscriptionRepo.Exists(ctx, desired.Name)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	// the subscription isn't left behind when its owner can't be set
+	current.Owner = "test_subscription_missing_role"
+	assert.Error(t, subscriptionRepo.Create(ctx, current))
+	exists, err = subscriptionRepo.Exists(ctx, current.Name)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+	"time"
+)
+
+// systemSettingReloadChecks and systemSettingReloadInterval bound the wait for the server to apply
+// the reloaded configuration.
+const (
+	systemSettingReloadChecks   = 20
+	systemSettingReloadInterval = 250 * time.Millisecond
+)
+
+type systemSettingSQL struct {
+	db *sql.DB
+}
+
+// SystemSettingModel describes a configuration parameter of the server, written to
+// postgresql.auto.conf by ALTER SYSTEM.
+type SystemSettingModel struct {
+	Name  string `json:"name" validate:"required"`
+	Value string `json:"value"`
+	// PendingRestart tells that the value is only applied once the server restarts, read-only.
+	PendingRestart bool `json:"pending_restart"`
+}
+
+type SystemSettingUpdateParams struct {
+	Current SystemSettingModel
+	Desired SystemSettingModel `validate:"required"`
+}
+
+type SystemSettingRepository interface {
+	Create(ctx context.Context, params SystemSettingModel) error
+	Drop(ctx context.Context, name string) error
+	Get(ctx context.Context, name string) (*SystemSettingModel, error)
+	Update(ctx context.Context, params SystemSettingUpdateParams) (*SystemSettingModel, error)
+	Exists(ctx context.Context, name string) (bool, error)
+	// Definition returns the definition of the parameter, nil when the server doesn't know it.
+	Definition(ctx context.Context, name string) (*SettingDefinition, error)
+}
+
+var _ SystemSettingRepository = &systemSettingSQL{}
+
+func NewSystemSettingRepository(db *sql.DB) SystemSettingRepository {
+	return &systemSettingSQL{
+		db: db,
+	}
+}
+
+// Create writes the parameter to postgresql.auto.conf and reloads the configuration. ALTER SYSTEM
+// can't run inside a transaction block, so the statements run one by one.
+func (s *systemSettingSQL) Create(ctx context.Context, params SystemSettingModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	if err := s.execAndReload(ctx, systemSettingSetQuery(params)); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateSystemSetting)
+	}
+	return nil
+}
+
+func (s *systemSettingSQL) Drop(ctx context.Context, name string) error {
+	if err := s.execAndReload(ctx, systemSettingResetQuery(name)); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropSystemSetting)
+	}
+	return nil
+}
+
+func (s *systemSettingSQL) Get(ctx context.Context, name string) (*SystemSettingModel, error) {
+	// the last line of postgresql.auto.conf wins, pg_settings tells whether it is applied yet
+	settingQuery := `
+		SELECT f.setting                            as "value",
+			   COALESCE(s.pending_restart, false)   as "pending_restart"
+		FROM pg_catalog.pg_file_settings f
+				 LEFT JOIN pg_catalog.pg_settings s ON s.name = f.name
+		WHERE f.sourcefile LIKE '%%/postgresql.auto.conf'
+		  AND lower(f.name) = lower(%s)
+		ORDER BY f.seqno DESC
+		LIMIT 1;`
+
+	model := SystemSettingModel{Name: name}
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf(settingQuery, pq.QuoteLiteral(name)))
+	if err := row.Scan(&model.Value, &model.PendingRestart); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetSystemSetting, "pg_cmd", opScanRowResult)
+	}
+	return &model, nil
+}
+
+// Update writes the new value of the parameter and reloads the configuration. When the parameter
+// changed, the current one is reset first.
+func (s *systemSettingSQL) Update(ctx context.Context, params SystemSettingUpdateParams) (*SystemSettingModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	if statements := systemSettingUpdateStatements(params.Current, params.Desired); len(statements) > 0 {
+		if err := s.execAndReload(ctx, statements...); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateSystemSetting)
+		}
+	}
+	return s.Get(ctx, params.Desired.Name)
+}
+
+func (s *systemSettingSQL) Exists(ctx context.Context, name string) (bool, error) {
+	existsQuery := `
+		SELECT EXISTS (SELECT 1
+					   FROM pg_catalog.pg_file_settings f
+					   WHERE f.sourcefile LIKE '%%/postgresql.auto.conf'
+						 AND lower(f.name) = lower(%s));`
+
+	var exists bool
+	row := s.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(name)))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsSystemSetting, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
+
+func (s *systemSettingSQL) Definition(ctx context.Context, name string) (*SettingDefinition, error) {
+	definition, err := readSettingDefinition(ctx, s.db, name)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetSettingDefinition)
+	}
+	return definition, nil
+}
+
+// execAndReload runs the statements, reloads the configuration and waits for pg_settings to reflect
+// the reload: pg_reload_conf only signals the server, so pending_restart would be read too early.
+func (s *systemSettingSQL) execAndReload(ctx context.Context, statements ...string) error {
+	var loadedAt string
+	if err := s.db.QueryRowContext(ctx, systemSettingLoadTimeQuery).Scan(&loadedAt); err != nil {
+		return PgErrWithMetadata(err, "pg_cmd", opQueryRow)
+	}
+
+	if err := ExecWithRole(ctx, s.db, append(statements, systemSettingReloadQuery)...); err != nil {
+		return err
+	}
+
+	reloadedQuery := `SELECT pg_catalog.pg_conf_load_time() > %s::timestamptz;`
+	for i := 0; i < systemSettingReloadChecks; i++ {
+		var reloaded bool
+		if err := s.db.QueryRowContext(ctx, fmt.Sprintf(reloadedQuery, pq.QuoteLiteral(loadedAt))).Scan(&reloaded); err != nil {
+			return PgErrWithMetadata(err, "pg_cmd", opQueryRow)
+		}
+		if reloaded {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(systemSettingReloadInterval):
+		}
+	}
+	return fmt.Errorf("the server did not reload its configuration after %s", systemSettingReloadChecks*systemSettingReloadInterval)
+}
+
+// systemSettingLoadTimeQuery reads when the configuration was last loaded, as text so that the
+// precision of the timestamp is kept.
+const systemSettingLoadTimeQuery = "SELECT pg_catalog.pg_conf_load_time()::text;"
+
+// systemSettingReloadQuery signals the server to reload its configuration files, applying the
+// parameters that don't need a restart.
+const systemSettingReloadQuery = "SELECT pg_catalog.pg_reload_conf();"
+
+func systemSettingSetQuery(model SystemSettingModel) string {
+	return fmt.Sprintf("ALTER SYSTEM SET %s = %s;", settingNameClause(model.Name), settingValueClause(model.Name, model.Value))
+}
+
+func systemSettingResetQuery(name string) string {
+	return fmt.Sprintf("ALTER SYSTEM RESET %s;", settingNameClause(name))
+}
+
+func systemSettingUpdateStatements(current, desired SystemSettingModel) []string {
+	var statements []string
+	if !strings.EqualFold(current.Name, desired.Name) {
+		statements = append(statements, systemSettingResetQuery(current.Name))
+	} else if current.Value == desired.Value {
+		return nil
+	}
+	return append(statements, systemSettingSetQuery(desired))
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func testPrepareSystemSettingTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_system_setting_db",
+		Username: "test_system_setting_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func TestSystemSettingStatements(t *testing.T) {
+	assert.Equal(t, `ALTER SYSTEM SET "work_mem" = '64MB';`, systemSettingSetQuery(SystemSettingModel{Name: "work_mem", Value: "64MB"}))
+	assert.Equal(t, `ALTER SYSTEM SET "shared_preload_libraries" = 'pg_stat_statements', 'auto_explain';`,
+		systemSettingSetQuery(SystemSettingModel{Name: "shared_preload_libraries", Value: "pg_stat_statements, auto_explain"}))
+	assert.Equal(t, `ALTER SYSTEM RESET "log_min_duration_statement";`, systemSettingResetQuery("Log_Min_Duration_Statement"))
+
+	current := SystemSettingModel{Name: "work_mem", Value: "64MB"}
+	desired := current
+	assert.Empty(t, systemSettingUpdateStatements(current, desired))
+
+	desired.Value = "1GB"
+	assert.Equal(t, []string{`ALTER SYSTEM SET "work_mem" = '1GB';`}, systemSettingUpdateStatements(current, desired))
+
+	desired.Name = "maintenance_work_mem"
+	assert.Equal(t, []string{
+		`ALTER SYSTEM RESET "work_mem";`,
+		`ALTER SYSTEM SET "maintenance_work_mem" = '1GB';`,
+	}, systemSettingUpdateStatements(current, desired))
+}
+
+func TestSystemSettingSQL_CreateGetAndUpdate(t *testing.T) {
+	ctx, db := testPrepareSystemSettingTestCase(t)
+	defer db.Close()
+
+	repo := NewSystemSettingRepository(db)
+	setting := SystemSettingModel{Name: "work_mem", Value: "64MB"}
+	require.NoError(t, repo.Create(ctx, setting))
+
+	got, err := repo.Get(ctx, "work_mem")
+	assert.NoError(t, err)
+	assert.Equal(t, setting, *got)
+
+	// the reload applies the value to the new sessions
+	var workMem string
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT setting FROM pg_catalog.pg_settings WHERE name = 'work_mem';`).Scan(&workMem))
+	assert.Equal(t, "65536", workMem)
+
+	desired := setting
+	desired.Value = "1GB"
+	got, err = repo.Update(ctx, SystemSettingUpdateParams{Current: setting, Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, desired, *got)
+
+	require.NoError(t, repo.Drop(ctx, "work_mem"))
+	exists, err := repo.Exists(ctx, "work_mem")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestSystemSettingSQL_PendingRestart(t *testing.T) {
+	ctx, db := testPrepareSystemSettingTestCase(t)
+	defer db.Close()
+
+	repo := NewSystemSettingRepository(db)
+	require.NoError(t, repo.Create(ctx, SystemSettingModel{Name: "max_connections", Value: "150"}))
+
+	got, err := repo.Get(ctx, "max_connections")
+	assert.NoError(t, err)
+	assert.Equal(t, "150", got.Value)
+	assert.True(t, got.PendingRestart)
+
+	definition, err := repo.Definition(ctx, "max_connections")
+	require.NoError(t, err)
+	assert.Equal(t, "postmaster", definition.Context)
+
+	require.NoError(t, repo.Drop(ctx, "max_connections"))
+}
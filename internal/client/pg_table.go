@@ -0,0 +1,785 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"slices"
+	"strings"
+)
+
+const (
+	tableObjectType = "TABLE"
+
+	TableIdentityAlways    = "always"
+	TableIdentityByDefault = "by_default"
+)
+
+var (
+	ForeignKeyActions = []string{"NO ACTION", "RESTRICT", "CASCADE", "SET NULL", "SET DEFAULT"}
+
+	errTableDestructiveChange = errors.New("the table changes would destroy data")
+
+	tableIdentitySQL = map[string]string{
+		TableIdentityAlways:    "ALWAYS",
+		TableIdentityByDefault: "BY DEFAULT",
+	}
+	// pg_attribute.attidentity
+	tableIdentityCodes = map[string]string{
+		"a": TableIdentityAlways,
+		"d": TableIdentityByDefault,
+	}
+	// pg_constraint.confdeltype and pg_constraint.confupdtype
+	foreignKeyActionCodes = map[string]string{
+		"a": "NO ACTION",
+		"r": "RESTRICT",
+		"c": "CASCADE",
+		"n": "SET NULL",
+		"d": "SET DEFAULT",
+	}
+)
+
+type tableSQL struct {
+	db *sql.DB
+}
+
+type TableColumn struct {
+	Name      string `json:"name" validate:"required"`
+	Type      string `json:"type" validate:"required"`
+	Nullable  bool   `json:"nullable"`
+	Default   string `json:"default" validate:"excluded_with=Identity Generated"`
+	Identity  string `json:"identity" validate:"omitempty,oneof=always by_default,excluded_with=Generated"`
+	Generated string `json:"generated"`
+}
+
+type TablePrimaryKey struct {
+	Name    string   `json:"name"`
+	Columns []string `json:"columns" validate:"required,min=1,unique"`
+}
+
+type TableUniqueConstraint struct {
+	Name    string   `json:"name" validate:"required"`
+	Columns []string `json:"columns" validate:"required,min=1,unique"`
+}
+
+type TableCheckConstraint struct {
+	Name       string `json:"name" validate:"required"`
+	Expression string `json:"expression" validate:"required"`
+}
+
+type TableForeignKey struct {
+	Name              string   `json:"name" validate:"required"`
+	Columns           []string `json:"columns" validate:"required,min=1"`
+	ReferencesTable   string   `json:"references_table" validate:"required"`
+	ReferencesColumns []string `json:"references_columns" validate:"required,min=1"`
+	OnDelete          string   `json:"on_delete" validate:"omitempty,oneof='NO ACTION' RESTRICT CASCADE 'SET NULL' 'SET DEFAULT'"`
+	OnUpdate          string   `json:"on_update" validate:"omitempty,oneof='NO ACTION' RESTRICT CASCADE 'SET NULL' 'SET DEFAULT'"`
+}
+
+type TableModel struct {
+	Schema            string                  `json:"schema" validate:"required"`
+	Name              string                  `json:"name" validate:"required"`
+	Database          string                  `json:"database"`
+	Columns           []TableColumn           `json:"columns" validate:"required,min=1,dive"`
+	PrimaryKey        *TablePrimaryKey        `json:"primary_key" validate:"omitempty"`
+	UniqueConstraints []TableUniqueConstraint `json:"unique_constraints" validate:"dive"`
+	CheckConstraints  []TableCheckConstraint  `json:"check_constraints" validate:"dive"`
+	ForeignKeys       []TableForeignKey       `json:"foreign_keys" validate:"dive"`
+	PartitionBy       string                  `json:"partition_by"`
+	Unlogged          bool                    `json:"unlogged"`
+	Comment           string                  `json:"comment"`
+}
+
+// TableAlteration is a single statement of the plan that turns a table into another one.
+type TableAlteration struct {
+	Statement   string
+	Description string
+	// Destructive alterations may lose data, e.g. dropping a column or changing its type.
+	Destructive bool
+}
+
+type TableUpdateParams struct {
+	Current TableModel
+	Desired TableModel `validate:"required"`
+	// AllowDestructive must be set to apply alterations that may lose data.
+	AllowDestructive bool
+}
+
+type TableRepository interface {
+	Create(ctx context.Context, params TableModel) error
+	Drop(ctx context.Context, schema, name string) error
+	Get(ctx context.Context, schema, name string) (*TableModel, error)
+	Update(ctx context.Context, params TableUpdateParams) (*TableModel, error)
+	Exists(ctx context.Context, schema, name string) (bool, error)
+	Normalize(ctx context.Context, model TableModel) (*TableModel, error)
+	PlanAlterations(ctx context.Context, current, desired TableModel) ([]TableAlteration, error)
+}
+
+var _ TableRepository = &tableSQL{}
+
+// pgQueryer is implemented by both *sql.DB and *sql.Tx.
+type pgQueryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func NewTableRepository(db *sql.DB) TableRepository {
+	return &tableSQL{
+		db: db,
+	}
+}
+
+func (t *tableSQL) Create(ctx context.Context, params TableModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, t.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateTable, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	err = WithQueryExecHandler(txn.ExecContext(ctx, tableCreateQuery(params, false)))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateTable)
+	}
+
+	if params.Comment != "" {
+		err = CreateComment(ctx, txn, tableObjectType, pgQualifiedName(params.Schema, params.Name), params.Comment)
+		if err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateTable)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateTable, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (t *tableSQL) Drop(ctx context.Context, schema, name string) error {
+	txn, err := BeginTxWithRole(ctx, t.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropTable, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	err = DropObject(ctx, txn, tableObjectType, pgQualifiedName(schema, name))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropTable)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropTable, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (t *tableSQL) Get(ctx context.Context, schema, name string) (*TableModel, error) {
+	relation := fmt.Sprintf("pg_catalog.to_regclass(%s)", pq.QuoteLiteral(pgQualifiedName(schema, name)))
+
+	model, err := readTable(ctx, t.db, relation)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetTable)
+	}
+	return model, nil
+}
+
+func (t *tableSQL) Update(ctx context.Context, params TableUpdateParams) (*TableModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	alterations, err := t.PlanAlterations(ctx, params.Current, params.Desired)
+	if err != nil {
+		// the plan of the resource already warned about it, the types are compared as written
+		alterations = PlanTableAlterations(params.Current, params.Desired)
+	}
+	if !params.AllowDestructive {
+		var destructive []string
+		for _, alteration := range alterations {
+			if alteration.Destructive {
+				destructive = append(destructive, alteration.Description)
+			}
+		}
+		if len(destructive) > 0 {
+			err := fmt.Errorf("%w: %s", errTableDestructiveChange, strings.Join(destructive, "; "))
+			return nil, PgErrWithMetadata(err, "operation", opUpdateTable)
+		}
+	}
+
+	txn, err := BeginTxWithRole(ctx, t.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateTable, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, alteration := range alterations {
+		err = WithQueryExecHandler(txn.ExecContext(ctx, alteration.Statement))
+		if err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateTable, "alteration", alteration.Description)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateTable, "pg_cmd", opCommitTransaction)
+	}
+
+	return t.Get(ctx, params.Desired.Schema, params.Desired.Name)
+}
+
+func (t *tableSQL) Exists(ctx context.Context, schema, name string) (bool, error) {
+	existsQuery := `SELECT pg_catalog.to_regclass(%s) IS NOT NULL;`
+
+	var exists bool
+	row := t.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(pgQualifiedName(schema, name))))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsTable, "pg_cmd", opQueryRow)
+	}
+
+	return exists, nil
+}
+
+// Normalize returns the definition as PostgreSQL would store it: types through format_type and
+// expressions through pg_get_expr. The definition is created as a temporary table inside a
+// transaction that is always rolled back. Foreign keys only get their referenced table resolved,
+// since temporary tables can't reference permanent ones.
+func (t *tableSQL) Normalize(ctx context.Context, model TableModel) (*TableModel, error) {
+	txn, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeTable, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	temporary := model
+	temporary.Schema = "pg_temp"
+	temporary.Name = "tf_normalize_" + model.Name
+	temporary.ForeignKeys = nil
+	temporary.Unlogged = false
+
+	err = WithQueryExecHandler(txn.ExecContext(ctx, tableCreateQuery(temporary, true)))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeTable)
+	}
+
+	relation := fmt.Sprintf("pg_catalog.to_regclass(%s)", pq.QuoteLiteral(pgQualifiedName(temporary.Schema, temporary.Name)))
+	normalized, err := readTable(ctx, txn, relation)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeTable)
+	}
+
+	normalized.ForeignKeys = make([]TableForeignKey, len(model.ForeignKeys))
+	for i, fk := range model.ForeignKeys {
+		normalized.ForeignKeys[i] = fk
+		row := txn.QueryRowContext(ctx, fmt.Sprintf(`
+			SELECT n.nspname || '.' || c.relname
+			FROM pg_catalog.pg_class c
+					 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+			WHERE c.oid = pg_catalog.to_regclass(%s);`, pq.QuoteLiteral(fk.ReferencesTable)))
+		if err = row.Scan(&normalized.ForeignKeys[i].ReferencesTable); err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, PgErrWithMetadata(err, "operation", opNormalizeTable, "pg_cmd", opQueryRow)
+		}
+	}
+
+	normalized.Schema = model.Schema
+	normalized.Name = model.Name
+	normalized.Database = model.Database
+	normalized.Unlogged = model.Unlogged
+	normalized.Comment = model.Comment
+	if normalized.PrimaryKey != nil && model.PrimaryKey != nil {
+		normalized.PrimaryKey.Name = model.PrimaryKey.Name
+	}
+
+	return normalized, nil
+}
+
+// PlanAlterations returns the alterations of PlanTableAlterations, comparing the column types as
+// PostgreSQL stores them (see Normalize): a type only spelled differently, e.g. 'int' for 'integer',
+// is not altered.
+func (t *tableSQL) PlanAlterations(ctx context.Context, current, desired TableModel) ([]TableAlteration, error) {
+	// type changes are destructive, without any there is nothing to normalize
+	alterations := PlanTableAlterations(current, desired)
+	if !slices.ContainsFunc(alterations, func(a TableAlteration) bool { return a.Destructive }) {
+		return alterations, nil
+	}
+
+	normalizedCurrent, err := t.Normalize(ctx, current)
+	if err != nil {
+		return nil, err
+	}
+	normalizedDesired, err := t.Normalize(ctx, desired)
+	if err != nil {
+		return nil, err
+	}
+	return PlanTableAlterations(withColumnTypesOf(current, *normalizedCurrent), withColumnTypesOf(desired, *normalizedDesired)), nil
+}
+
+// withColumnTypesOf returns the model with the types of the columns of the same name in normalized.
+func withColumnTypesOf(model, normalized TableModel) TableModel {
+	model.Columns = slices.Clone(model.Columns)
+	for i, column := range model.Columns {
+		if idx := indexByName(normalized.Columns, column.Name, func(c TableColumn) string { return c.Name }); idx >= 0 {
+			model.Columns[i].Type = normalized.Columns[idx].Type
+		}
+	}
+	return model
+}
+
+// PreserveTableSpelling returns the actual table, but keeping the spelling of the prior definition
+// for every column attribute and constraint whose normalized form (see TableRepository.Normalize)
+// matches the actual one. This avoids perpetual diffs between e.g. 'int' and 'integer'.
+func PreserveTableSpelling(prior, normalizedPrior, actual TableModel) TableModel {
+	result := actual
+	// constraints are read back sorted by name, keep the order of the prior definition instead
+	result.UniqueConstraints = orderLike(actual.UniqueConstraints, prior.UniqueConstraints, func(c TableUniqueConstraint) string { return c.Name })
+	result.CheckConstraints = orderLike(actual.CheckConstraints, prior.CheckConstraints, func(c TableCheckConstraint) string { return c.Name })
+	result.ForeignKeys = orderLike(actual.ForeignKeys, prior.ForeignKeys, func(c TableForeignKey) string { return c.Name })
+	// columns are read back by position, but ADD COLUMN always appends: keep the prior order too
+	actualColumns := orderLike(actual.Columns, prior.Columns, func(c TableColumn) string { return c.Name })
+
+	result.Columns = make([]TableColumn, len(actualColumns))
+	for i, column := range actualColumns {
+		result.Columns[i] = column
+
+		priorIdx := indexByName(prior.Columns, column.Name, func(c TableColumn) string { return c.Name })
+		normIdx := indexByName(normalizedPrior.Columns, column.Name, func(c TableColumn) string { return c.Name })
+		if priorIdx < 0 || normIdx < 0 {
+			continue
+		}
+		priorColumn, normColumn := prior.Columns[priorIdx], normalizedPrior.Columns[normIdx]
+
+		if normColumn.Type == column.Type {
+			result.Columns[i].Type = priorColumn.Type
+		}
+		if normColumn.Default == column.Default {
+			result.Columns[i].Default = priorColumn.Default
+		}
+		if normColumn.Generated == column.Generated {
+			result.Columns[i].Generated = priorColumn.Generated
+		}
+	}
+
+	for i, check := range result.CheckConstraints {
+		priorIdx := indexByName(prior.CheckConstraints, check.Name, func(c TableCheckConstraint) string { return c.Name })
+		normIdx := indexByName(normalizedPrior.CheckConstraints, check.Name, func(c TableCheckConstraint) string { return c.Name })
+		if priorIdx >= 0 && normIdx >= 0 && normalizedPrior.CheckConstraints[normIdx].Expression == check.Expression {
+			result.CheckConstraints[i].Expression = prior.CheckConstraints[priorIdx].Expression
+		}
+	}
+
+	for i, fk := range result.ForeignKeys {
+		priorIdx := indexByName(prior.ForeignKeys, fk.Name, func(c TableForeignKey) string { return c.Name })
+		normIdx := indexByName(normalizedPrior.ForeignKeys, fk.Name, func(c TableForeignKey) string { return c.Name })
+		if priorIdx >= 0 && normIdx >= 0 && normalizedPrior.ForeignKeys[normIdx].ReferencesTable == fk.ReferencesTable {
+			result.ForeignKeys[i].ReferencesTable = prior.ForeignKeys[priorIdx].ReferencesTable
+		}
+	}
+
+	if normalizedPrior.PartitionBy == actual.PartitionBy {
+		result.PartitionBy = prior.PartitionBy
+	}
+
+	return result
+}
+
+// PlanTableAlterations returns the ALTER statements that turn the current table into the desired one.
+// Columns and constraints are matched by name: a renamed column is planned as a drop and an add.
+func PlanTableAlterations(current, desired TableModel) []TableAlteration {
+	var alterations []TableAlteration
+
+	currentName := pgQualifiedName(current.Schema, current.Name)
+	if current.Name != desired.Name {
+		alterations = append(alterations, TableAlteration{
+			Statement:   fmt.Sprintf("ALTER TABLE %s RENAME TO %s;", currentName, pq.QuoteIdentifier(desired.Name)),
+			Description: fmt.Sprintf("rename table to '%s'", desired.Name),
+		})
+		currentName = pgQualifiedName(current.Schema, desired.Name)
+	}
+	if current.Schema != desired.Schema {
+		alterations = append(alterations, TableAlteration{
+			Statement:   fmt.Sprintf("ALTER TABLE %s SET SCHEMA %s;", currentName, pq.QuoteIdentifier(desired.Schema)),
+			Description: fmt.Sprintf("move table to schema '%s'", desired.Schema),
+		})
+	}
+
+	name := pgQualifiedName(desired.Schema, desired.Name)
+	alter := func(description string, destructive bool, format string, args ...any) {
+		alterations = append(alterations, TableAlteration{
+			Statement:   fmt.Sprintf("ALTER TABLE %s %s;", name, fmt.Sprintf(format, args...)),
+			Description: description,
+			Destructive: destructive,
+		})
+	}
+
+	// constraints are dropped first, so they don't block the column changes
+	currentConstraints := tableConstraintDefinitions(current)
+	desiredConstraints := tableConstraintDefinitions(desired)
+	primaryKeyChanged := tablePrimaryKeyChanged(current.PrimaryKey, desired.PrimaryKey)
+
+	if current.PrimaryKey != nil && primaryKeyChanged {
+		pkName := current.PrimaryKey.Name
+		if pkName == "" {
+			pkName = current.Name + "_pkey"
+		}
+		alter("drop primary key", false, "DROP CONSTRAINT %s", pq.QuoteIdentifier(pkName))
+	}
+	for _, constraintName := range currentConstraints.names {
+		if desiredConstraints.byName[constraintName] != currentConstraints.byName[constraintName] {
+			alter(fmt.Sprintf("drop constraint '%s'", constraintName), false, "DROP CONSTRAINT %s", pq.QuoteIdentifier(constraintName))
+		}
+	}
+
+	for _, column := range current.Columns {
+		if indexByName(desired.Columns, column.Name, func(c TableColumn) string { return c.Name }) < 0 {
+			alter(fmt.Sprintf("drop column '%s'", column.Name), true, "DROP COLUMN %s", pq.QuoteIdentifier(column.Name))
+		}
+	}
+
+	for _, column := range desired.Columns {
+		idx := indexByName(current.Columns, column.Name, func(c TableColumn) string { return c.Name })
+		if idx < 0 {
+			alter(fmt.Sprintf("add column '%s'", column.Name), false, "ADD COLUMN %s", tableColumnDefinition(column))
+			continue
+		}
+		currentColumn := current.Columns[idx]
+		quotedName := pq.QuoteIdentifier(column.Name)
+
+		// a stored generated column can't be altered, only turned into a regular one
+		if currentColumn.Generated != column.Generated {
+			if column.Generated == "" {
+				alter(fmt.Sprintf("drop expression of column '%s'", column.Name), false, "ALTER COLUMN %s DROP EXPRESSION", quotedName)
+			} else {
+				alter(fmt.Sprintf("recreate generated column '%s'", column.Name), true, "DROP COLUMN %s", quotedName)
+				alter(fmt.Sprintf("recreate generated column '%s'", column.Name), true, "ADD COLUMN %s", tableColumnDefinition(column))
+				continue
+			}
+		}
+		if !strings.EqualFold(strings.Join(strings.Fields(currentColumn.Type), " "), strings.Join(strings.Fields(column.Type), " ")) {
+			alter(fmt.Sprintf("change type of column '%s' to '%s'", column.Name, column.Type), true,
+				"ALTER COLUMN %s TYPE %s", quotedName, column.Type)
+		}
+		// an identity column can't have a default: the default goes before the identity is added,
+		// and the new one comes after it is dropped
+		if currentColumn.Default != column.Default && column.Default == "" {
+			alter(fmt.Sprintf("drop default of column '%s'", column.Name), false, "ALTER COLUMN %s DROP DEFAULT", quotedName)
+		}
+		if currentColumn.Identity != column.Identity {
+			switch {
+			case currentColumn.Identity == "":
+				alter(fmt.Sprintf("add identity to column '%s'", column.Name), false,
+					"ALTER COLUMN %s ADD GENERATED %s AS IDENTITY", quotedName, tableIdentitySQL[column.Identity])
+			case column.Identity == "":
+				alter(fmt.Sprintf("drop identity of column '%s'", column.Name), false, "ALTER COLUMN %s DROP IDENTITY", quotedName)
+			default:
+				alter(fmt.Sprintf("change identity of column '%s'", column.Name), false,
+					"ALTER COLUMN %s SET GENERATED %s", quotedName, tableIdentitySQL[column.Identity])
+			}
+		}
+		if currentColumn.Default != column.Default && column.Default != "" {
+			alter(fmt.Sprintf("set default of column '%s'", column.Name), false, "ALTER COLUMN %s SET DEFAULT %s", quotedName, column.Default)
+		}
+		if currentColumn.Nullable != column.Nullable {
+			if column.Nullable {
+				alter(fmt.Sprintf("drop not null of column '%s'", column.Name), false, "ALTER COLUMN %s DROP NOT NULL", quotedName)
+			} else {
+				alter(fmt.Sprintf("set not null of column '%s'", column.Name), false, "ALTER COLUMN %s SET NOT NULL", quotedName)
+			}
+		}
+	}
+
+	if desired.PrimaryKey != nil && primaryKeyChanged {
+		alter("add primary key", false, "ADD %s", tablePrimaryKeyDefinition(*desired.PrimaryKey))
+	}
+	for _, constraintName := range desiredConstraints.names {
+		definition := desiredConstraints.byName[constraintName]
+		if currentConstraints.byName[constraintName] != definition {
+			alter(fmt.Sprintf("add constraint '%s'", constraintName), false, "ADD %s", definition)
+		}
+	}
+
+	if current.Unlogged != desired.Unlogged {
+		persistence := "LOGGED"
+		if desired.Unlogged {
+			persistence = "UNLOGGED"
+		}
+		alter(fmt.Sprintf("set table %s", strings.ToLower(persistence)), false, "SET %s", persistence)
+	}
+
+	if current.Comment != desired.Comment {
+		alterations = append(alterations, TableAlteration{
+			Statement:   fmt.Sprintf("COMMENT ON TABLE %s IS %s;", name, pq.QuoteLiteral(desired.Comment)),
+			Description: "update comment",
+		})
+	}
+
+	return alterations
+}
+
+type tableConstraints struct {
+	names  []string
+	byName map[string]string
+}
+
+// tableConstraintDefinitions returns the definition of every named constraint, the primary key excluded.
+func tableConstraintDefinitions(model TableModel) tableConstraints {
+	constraints := tableConstraints{byName: map[string]string{}}
+	add := func(name, definition string) {
+		constraints.names = append(constraints.names, name)
+		constraints.byName[name] = fmt.Sprintf("CONSTRAINT %s %s", pq.QuoteIdentifier(name), definition)
+	}
+
+	for _, unique := range model.UniqueConstraints {
+		add(unique.Name, fmt.Sprintf("UNIQUE (%s)", pgQuoteListOfIdentifiers(unique.Columns)))
+	}
+	for _, check := range model.CheckConstraints {
+		add(check.Name, fmt.Sprintf("CHECK (%s)", check.Expression))
+	}
+	for _, fk := range model.ForeignKeys {
+		definition := fmt.Sprintf("FOREIGN KEY (%s) REFERENCES %s (%s)",
+			pgQuoteListOfIdentifiers(fk.Columns),
+			pgQuoteQualifiedName(fk.ReferencesTable),
+			pgQuoteListOfIdentifiers(fk.ReferencesColumns),
+		)
+		if fk.OnDelete != "" {
+			definition += " ON DELETE " + fk.OnDelete
+		}
+		if fk.OnUpdate != "" {
+			definition += " ON UPDATE " + fk.OnUpdate
+		}
+		add(fk.Name, definition)
+	}
+
+	return constraints
+}
+
+// tablePrimaryKeyDefinition returns the primary key definition, unnamed keys get the default name from PostgreSQL.
+func tablePrimaryKeyDefinition(pk TablePrimaryKey) string {
+	definition := fmt.Sprintf("PRIMARY KEY (%s)", pgQuoteListOfIdentifiers(pk.Columns))
+	if pk.Name == "" {
+		return definition
+	}
+	return fmt.Sprintf("CONSTRAINT %s %s", pq.QuoteIdentifier(pk.Name), definition)
+}
+
+// tablePrimaryKeyChanged reports whether the primary key must be recreated. An empty
+// desired name keeps the current one.
+func tablePrimaryKeyChanged(current, desired *TablePrimaryKey) bool {
+	switch {
+	case current == nil || desired == nil:
+		return current != desired
+	case desired.Name != "" && desired.Name != current.Name:
+		return true
+	default:
+		return !slices.Equal(current.Columns, desired.Columns)
+	}
+}
+
+func tableColumnDefinition(column TableColumn) string {
+	definition := fmt.Sprintf("%s %s", pq.QuoteIdentifier(column.Name), column.Type)
+
+	switch {
+	case column.Generated != "":
+		definition += fmt.Sprintf(" GENERATED ALWAYS AS (%s) STORED", column.Generated)
+	case column.Identity != "":
+		definition += fmt.Sprintf(" GENERATED %s AS IDENTITY", tableIdentitySQL[column.Identity])
+	case column.Default != "":
+		definition += fmt.Sprintf(" DEFAULT %s", column.Default)
+	}
+	if !column.Nullable {
+		definition += " NOT NULL"
+	}
+	return definition
+}
+
+func tableCreateQuery(model TableModel, temporary bool) string {
+	definitions := make([]string, 0, len(model.Columns))
+	for _, column := range model.Columns {
+		definitions = append(definitions, tableColumnDefinition(column))
+	}
+
+	if model.PrimaryKey != nil {
+		definitions = append(definitions, tablePrimaryKeyDefinition(*model.PrimaryKey))
+	}
+	constraints := tableConstraintDefinitions(model)
+	for _, name := range constraints.names {
+		definitions = append(definitions, constraints.byName[name])
+	}
+
+	var persistence, partitionBy string
+	switch {
+	case temporary:
+		persistence = "TEMPORARY"
+	case model.Unlogged:
+		persistence = "UNLOGGED"
+	}
+	if model.PartitionBy != "" {
+		partitionBy = fmt.Sprintf("PARTITION BY %s", model.PartitionBy)
+	}
+
+	createQuery := `
+		CREATE %s TABLE %s (
+			%s
+		) %s;`
+
+	return fmt.Sprintf(createQuery, persistence, pgQualifiedName(model.Schema, model.Name), strings.Join(definitions, ",\n\t\t\t"), partitionBy)
+}
+
+// readTable reads the definition of the table whose oid is returned by the relation SQL expression.
+func readTable(ctx context.Context, q pgQueryer, relation string) (*TableModel, error) {
+	var model TableModel
+
+	tableQuery := `
+		SELECT n.nspname                                                      as "schema",
+			   c.relname                                                      as "name",
+			   pg_catalog.current_database()                                  as "database",
+			   c.relpersistence = 'u'                                         as "unlogged",
+			   COALESCE(pg_catalog.pg_get_partkeydef(c.oid), '')              as "partition_by",
+			   COALESCE(pg_catalog.obj_description(c.oid, 'pg_class'), '')    as "comment"
+		FROM pg_catalog.pg_class c
+				 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.oid = %s
+		  AND c.relkind IN ('r', 'p');`
+
+	row := q.QueryRowContext(ctx, fmt.Sprintf(tableQuery, relation))
+	err := row.Scan(&model.Schema, &model.Name, &model.Database, &model.Unlogged, &model.PartitionBy, &model.Comment)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "table")
+	}
+
+	columnsQuery := `
+		SELECT a.attname                                          as "name",
+			   pg_catalog.format_type(a.atttypid, a.atttypmod)    as "type",
+			   NOT a.attnotnull                                   as "nullable",
+			   CASE WHEN a.attgenerated = '' THEN COALESCE(pg_catalog.pg_get_expr(d.adbin, d.adrelid), '') ELSE '' END as "default",
+			   a.attidentity::text                                as "identity",
+			   CASE WHEN a.attgenerated = 's' THEN pg_catalog.pg_get_expr(d.adbin, d.adrelid) ELSE '' END as "generated"
+		FROM pg_catalog.pg_attribute a
+				 LEFT JOIN pg_catalog.pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+		WHERE a.attrelid = %s
+		  AND a.attnum > 0
+		  AND NOT a.attisdropped
+		ORDER BY a.attnum;`
+
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(columnsQuery, relation))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery, "model", "table_column")
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var column TableColumn
+		if err = rows.Scan(&column.Name, &column.Type, &column.Nullable, &column.Default, &column.Identity, &column.Generated); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "table_column")
+		}
+		column.Identity = tableIdentityCodes[column.Identity]
+		model.Columns = append(model.Columns, column)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery, "model", "table_column")
+	}
+
+	constraintsQuery := `
+		SELECT c.conname                                                            as "name",
+			   c.contype::text                                                      as "type",
+			   ARRAY(SELECT a.attname
+					 FROM pg_catalog.unnest(c.conkey) WITH ORDINALITY k(attnum, ord)
+							  JOIN pg_catalog.pg_attribute a ON a.attrelid = c.conrelid AND a.attnum = k.attnum
+					 ORDER BY k.ord)                                                as "columns",
+			   CASE WHEN c.contype = 'c' THEN pg_catalog.pg_get_expr(c.conbin, c.conrelid) ELSE '' END as "expression",
+			   COALESCE(rn.nspname || '.' || r.relname, '')                         as "references_table",
+			   ARRAY(SELECT a.attname
+					 FROM pg_catalog.unnest(c.confkey) WITH ORDINALITY k(attnum, ord)
+							  JOIN pg_catalog.pg_attribute a ON a.attrelid = c.confrelid AND a.attnum = k.attnum
+					 ORDER BY k.ord)                                                as "references_columns",
+			   c.confdeltype::text                                                  as "on_delete",
+			   c.confupdtype::text                                                  as "on_update"
+		FROM pg_catalog.pg_constraint c
+				 LEFT JOIN pg_catalog.pg_class r ON r.oid = c.confrelid
+				 LEFT JOIN pg_catalog.pg_namespace rn ON rn.oid = r.relnamespace
+		WHERE c.conrelid = %s
+		  AND c.contype IN ('p', 'u', 'c', 'f')
+		ORDER BY c.conname;`
+
+	constraintRows, err := q.QueryContext(ctx, fmt.Sprintf(constraintsQuery, relation))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery, "model", "table_constraint")
+	}
+	defer constraintRows.Close()
+
+	for constraintRows.Next() {
+		var name, conType, expression, refTable, onDelete, onUpdate string
+		var columns, refColumns []string
+
+		err = constraintRows.Scan(&name, &conType, (*pq.StringArray)(&columns), &expression, &refTable, (*pq.StringArray)(&refColumns), &onDelete, &onUpdate)
+		if err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "table_constraint")
+		}
+
+		switch conType {
+		case "p":
+			model.PrimaryKey = &TablePrimaryKey{Name: name, Columns: columns}
+		case "u":
+			model.UniqueConstraints = append(model.UniqueConstraints, TableUniqueConstraint{Name: name, Columns: columns})
+		case "c":
+			model.CheckConstraints = append(model.CheckConstraints, TableCheckConstraint{Name: name, Expression: expression})
+		case "f":
+			model.ForeignKeys = append(model.ForeignKeys, TableForeignKey{
+				Name:              name,
+				Columns:           columns,
+				ReferencesTable:   refTable,
+				ReferencesColumns: refColumns,
+				OnDelete:          foreignKeyActionCodes[onDelete],
+				OnUpdate:          foreignKeyActionCodes[onUpdate],
+			})
+		}
+	}
+	if err = constraintRows.Err(); err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery, "model", "table_constraint")
+	}
+
+	return &model, nil
+}
+
+// pgQuoteQualifiedName quotes every part of a possibly schema qualified name, e.g. 'public.users'.
+func pgQuoteQualifiedName(name string) string {
+	parts := strings.SplitN(name, ".", 2)
+	if len(parts) == 1 {
+		return pq.QuoteIdentifier(parts[0])
+	}
+	return pgQualifiedName(parts[0], parts[1])
+}
+
+func indexByName[T any](items []T, name string, nameFn func(T) string) int {
+	for i, item := range items {
+		if nameFn(item) == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// orderLike returns the items sorted as in prior, items missing from prior are kept at the end.
+func orderLike[T any](items, prior []T, nameFn func(T) string) []T {
+	if items == nil {
+		return nil
+	}
+	result := make([]T, 0, len(items))
+	used := make([]bool, len(items))
+	for _, priorItem := range prior {
+		if idx := indexByName(items, nameFn(priorItem), nameFn); idx >= 0 && !used[idx] {
+			result = append(result, items[idx])
+			used[idx] = true
+		}
+	}
+	for i, item := range items {
+		if !used[i] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
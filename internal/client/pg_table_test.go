@@ -0,0 +1,350 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+const (
+	testTableDb   = "test_table_db"
+	testTableUser = "test_table_user"
+)
+
+func testPrepareTableTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: testTableDb,
+		Username: testTableUser,
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func mockTableModel(t *testing.T) TableModel {
+	t.Helper()
+	return TableModel{
+		Schema: "public",
+		Name:   "test_table",
+		Columns: []TableColumn{
+			{Name: "id", Type: "bigint", Identity: TableIdentityAlways},
+			{Name: "code", Type: "varchar(10)"},
+			{Name: "price", Type: "numeric(10,2)", Nullable: true, Default: "0"},
+		},
+		PrimaryKey:        &TablePrimaryKey{Columns: []string{"id"}},
+		UniqueConstraints: []TableUniqueConstraint{{Name: "test_table_code_key", Columns: []string{"code"}}},
+		CheckConstraints:  []TableCheckConstraint{{Name: "test_table_price_check", Expression: "price >= 0"}},
+		Comment:           "test comment",
+	}
+}
+
+func TestPlanTableAlterations(t *testing.T) {
+	tests := []struct {
+		name        string
+		change      func(model *TableModel)
+		statements  []string
+		destructive bool
+	}{
+		{
+			name:   "NoChanges",
+			change: func(_ *TableModel) {},
+		},
+		{
+			name: "RenameAndMove",
+			change: func(model *TableModel) {
+				model.Name = "test_table_renamed"
+				model.Schema = "other"
+			},
+			statements: []string{
+				`ALTER TABLE "public"."test_table" RENAME TO "test_table_renamed";`,
+				`ALTER TABLE "public"."test_table_renamed" SET SCHEMA "other";`,
+			},
+		},
+		{
+			name: "AddColumn",
+			change: func(model *TableModel) {
+				model.Columns = append(model.Columns, TableColumn{Name: "label", Type: "text", Default: "'none'"})
+			},
+			statements: []string{
+				`ALTER TABLE "public"."test_table" ADD COLUMN "label" text DEFAULT 'none' NOT NULL;`,
+			},
+		},
+		{
+			name: "DropColumn",
+			change: func(model *TableModel) {
+				model.Columns = model.Columns[:2]
+				model.CheckConstraints = nil
+			},
+			statements: []string{
+				`ALTER TABLE "public"."test_table" DROP CONSTRAINT "test_table_price_check";`,
+				`ALTER TABLE "public"."test_table" DROP COLUMN "price";`,
+			},
+			destructive: true,
+		},
+		{
+			name: "AlterColumn",
+			change: func(model *TableModel) {
+				model.Columns[1].Nullable = true
+				model.Columns[2].Default = ""
+				model.Columns[0].Identity = TableIdentityByDefault
+			},
+			statements: []string{
+				`ALTER TABLE "public"."test_table" ALTER COLUMN "id" SET GENERATED BY DEFAULT;`,
+				`ALTER TABLE "public"."test_table" ALTER COLUMN "code" DROP NOT NULL;`,
+				`ALTER TABLE "public"."test_table" ALTER COLUMN "price" DROP DEFAULT;`,
+			},
+		},
+		{
+			name: "AddIdentityOverDefault",
+			change: func(model *TableModel) {
+				model.Columns[2].Default = ""
+				model.Columns[2].Identity = TableIdentityByDefault
+			},
+			statements: []string{
+				`ALTER TABLE "public"."test_table" ALTER COLUMN "price" DROP DEFAULT;`,
+				`ALTER TABLE "public"."test_table" ALTER COLUMN "price" ADD GENERATED BY DEFAULT AS IDENTITY;`,
+			},
+		},
+		{
+			name: "DropIdentityForDefault",
+			change: func(model *TableModel) {
+				model.Columns[0].Identity = ""
+				model.Columns[0].Default = "1"
+			},
+			statements: []string{
+				`ALTER TABLE "public"."test_table" ALTER COLUMN "id" DROP IDENTITY;`,
+				`ALTER TABLE "public"."test_table" ALTER COLUMN "id" SET DEFAULT 1;`,
+			},
+		},
+		{
+			name: "ChangeColumnType",
+			change: func(model *TableModel) {
+				model.Columns[1].Type = "text"
+			},
+			statements: []string{
+				`ALTER TABLE "public"."test_table" ALTER COLUMN "code" TYPE text;`,
+			},
+			destructive: true,
+		},
+		{
+			name: "ChangeConstraints",
+			change: func(model *TableModel) {
+				model.CheckConstraints[0].Expression = "price > 0"
+				model.PrimaryKey.Columns = []string{"id", "code"}
+			},
+			statements: []string{
+				`ALTER TABLE "public"."test_table" DROP CONSTRAINT "test_table_pkey";`,
+				`ALTER TABLE "public"."test_table" DROP CONSTRAINT "test_table_price_check";`,
+				`ALTER TABLE "public"."test_table" ADD PRIMARY KEY ("id", "code");`,
+				`ALTER TABLE "public"."test_table" ADD CONSTRAINT "test_table_price_check" CHECK (price > 0);`,
+			},
+		},
+		{
+			name: "UnloggedAndComment",
+			change: func(model *TableModel) {
+				model.Unlogged = true
+				model.Comment = "test comment modified"
+			},
+			statements: []string{
+				`ALTER TABLE "public"."test_table" SET UNLOGGED;`,
+				`COMMENT ON TABLE "public"."test_table" IS 'test comment modified';`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := mockTableModel(t)
+			desired := mockTableModel(t)
+			tt.change(&desired)
+
+			alterations := PlanTableAlterations(current, desired)
+
+			var statements []string
+			destructive := false
+			for _, alteration := range alterations {
+				statements = append(statements, alteration.Statement)
+				destructive = destructive || alteration.Destructive
+			}
+			assert.Equal(t, tt.statements, statements)
+			assert.Equal(t, tt.destructive, destructive)
+		})
+	}
+}
+
+func TestPreserveTableSpelling(t *testing.T) {
+	prior := mockTableModel(t)
+	prior.Columns[0].Type = "int8"
+	prior.CheckConstraints = append(prior.CheckConstraints, TableCheckConstraint{Name: "a_check", Expression: "code <> ''"})
+
+	normalized := mockTableModel(t)
+	normalized.CheckConstraints = []TableCheckConstraint{
+		{Name: "a_check", Expression: "((code)::text <> ''::text)"},
+		{Name: "test_table_price_check", Expression: "(price >= (0)::numeric)"},
+	}
+	normalized.Columns[1].Type = "character varying(10)"
+	normalized.Columns[2].Type = "numeric(10,2)"
+
+	// the code column was added after the others
+	actual := normalized
+	actual.Columns = []TableColumn{normalized.Columns[0], normalized.Columns[2], normalized.Columns[1]}
+	actual.Columns[1].Default = "1"
+
+	result := PreserveTableSpelling(prior, normalized, actual)
+
+	assert.Equal(t, []string{"id", "code", "price"}, []string{result.Columns[0].Name, result.Columns[1].Name, result.Columns[2].Name},
+		"columns keep the prior order")
+	assert.Equal(t, "int8", result.Columns[0].Type)
+	assert.Equal(t, "varchar(10)", result.Columns[1].Type)
+	assert.Equal(t, "1", result.Columns[2].Default, "drift must be reported")
+	assert.Equal(t, []TableCheckConstraint{
+		{Name: "test_table_price_check", Expression: "price >= 0"},
+		{Name: "a_check", Expression: "code <> ''"},
+	}, result.CheckConstraints, "constraints keep the prior order and spelling")
+}
+
+func TestWithColumnTypesOf(t *testing.T) {
+	model := mockTableModel(t)
+	model.Columns[0].Type = "int8"
+
+	normalized := mockTableModel(t)
+	normalized.Columns = normalized.Columns[:2]
+	normalized.Columns[1].Type = "character varying(10)"
+
+	result := withColumnTypesOf(model, normalized)
+	assert.Equal(t, []string{"bigint", "character varying(10)", "numeric(10,2)"},
+		[]string{result.Columns[0].Type, result.Columns[1].Type, result.Columns[2].Type})
+	assert.Equal(t, "int8", model.Columns[0].Type, "the model must not be modified")
+}
+
+func TestTableSQL_CreateAndGet(t *testing.T) {
+	ctx, db := testPrepareTableTestCase(t)
+	defer db.Close()
+
+	tableRepo := NewTableRepository(db)
+
+	parent := TableModel{
+		Schema:     "public",
+		Name:       "test_table_parent",
+		Columns:    []TableColumn{{Name: "id", Type: "int"}},
+		PrimaryKey: &TablePrimaryKey{Columns: []string{"id"}},
+	}
+	assert.NoError(t, tableRepo.Create(ctx, parent))
+
+	model := mockTableModel(t)
+	model.Columns = append(model.Columns,
+		TableColumn{Name: "parent_id", Type: "int", Nullable: true},
+		TableColumn{Name: "total", Type: "numeric", Nullable: true, Generated: "price * 2"},
+	)
+	model.ForeignKeys = []TableForeignKey{{
+		Name:              "test_table_parent_fkey",
+		Columns:           []string{"parent_id"},
+		ReferencesTable:   "test_table_parent",
+		ReferencesColumns: []string{"id"},
+		OnDelete:          "CASCADE",
+	}}
+	assert.NoError(t, tableRepo.Create(ctx, model))
+
+	exists, err := tableRepo.Exists(ctx, model.Schema, model.Name)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	got, err := tableRepo.Get(ctx, model.Schema, model.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, testTableDb, got.Database)
+	assert.Equal(t, model.Comment, got.Comment)
+	assert.Len(t, got.Columns, 5)
+	assert.Equal(t, TableColumn{Name: "id", Type: "bigint", Identity: TableIdentityAlways}, got.Columns[0])
+	assert.Equal(t, "character varying(10)", got.Columns[1].Type)
+	assert.Equal(t, "(price * (2)::numeric)", got.Columns[4].Generated)
+	assert.Equal(t, &TablePrimaryKey{Name: "test_table_pkey", Columns: []string{"id"}}, got.PrimaryKey)
+	assert.Equal(t, model.UniqueConstraints, got.UniqueConstraints)
+	assert.Len(t, got.ForeignKeys, 1)
+	assert.Equal(t, "public.test_table_parent", got.ForeignKeys[0].ReferencesTable)
+	assert.Equal(t, "CASCADE", got.ForeignKeys[0].OnDelete)
+	assert.Equal(t, "NO ACTION", got.ForeignKeys[0].OnUpdate)
+
+	normalized, err := tableRepo.Normalize(ctx, model)
+	assert.NoError(t, err)
+	result := PreserveTableSpelling(model, *normalized, *got)
+	assert.Empty(t, PlanTableAlterations(result, model), "the normalized definition must not drift")
+
+	assert.NoError(t, tableRepo.Drop(ctx, model.Schema, model.Name))
+	exists, err = tableRepo.Exists(ctx, model.Schema, model.Name)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestTableSQL_PlanAlterations(t *testing.T) {
+	ctx, db := testPrepareTableTestCase(t)
+	defer db.Close()
+
+	tableRepo := NewTableRepository(db)
+	current := mockTableModel(t)
+	assert.NoError(t, tableRepo.Create(ctx, current))
+
+	t.Run("SpellingOnly", func(t *testing.T) {
+		desired := mockTableModel(t)
+		desired.Columns[0].Type = "int8"
+		desired.Columns[1].Type = "character varying(10)"
+
+		alterations, err := tableRepo.PlanAlterations(ctx, current, desired)
+		assert.NoError(t, err)
+		assert.Empty(t, alterations)
+
+		_, err = tableRepo.Update(ctx, TableUpdateParams{Current: current, Desired: desired})
+		assert.NoError(t, err)
+	})
+
+	t.Run("TypeChange", func(t *testing.T) {
+		desired := mockTableModel(t)
+		desired.Columns[1].Type = "varchar(20)"
+
+		alterations, err := tableRepo.PlanAlterations(ctx, current, desired)
+		assert.NoError(t, err)
+		require.Len(t, alterations, 1)
+		assert.True(t, alterations[0].Destructive)
+	})
+
+	assert.NoError(t, tableRepo.Drop(ctx, current.Schema, current.Name))
+}
+
+func TestTableSQL_Update(t *testing.T) {
+	ctx, db := testPrepareTableTestCase(t)
+	defer db.Close()
+
+	tableRepo := NewTableRepository(db)
+	current := mockTableModel(t)
+	assert.NoError(t, tableRepo.Create(ctx, current))
+
+	_, err := db.ExecContext(ctx, `INSERT INTO "public"."test_table" (code, price) VALUES ('a', 1);`)
+	assert.NoError(t, err)
+
+	desired := mockTableModel(t)
+	desired.Name = "test_table_renamed"
+	desired.Columns = append(desired.Columns[:2], TableColumn{Name: "label", Type: "text", Nullable: true})
+	desired.CheckConstraints = nil
+	desired.Unlogged = true
+
+	_, err = tableRepo.Update(ctx, TableUpdateParams{Current: current, Desired: desired})
+	assert.ErrorIs(t, err, errTableDestructiveChange)
+
+	got, err := tableRepo.Update(ctx, TableUpdateParams{Current: current, Desired: desired, AllowDestructive: true})
+	assert.NoError(t, err)
+	assert.Equal(t, desired.Name, got.Name)
+	assert.True(t, got.Unlogged)
+	assert.Empty(t, got.CheckConstraints)
+	assert.Equal(t, []string{"id", "code", "label"}, []string{got.Columns[0].Name, got.Columns[1].Name, got.Columns[2].Name})
+
+	var count int
+	assert.NoError(t, db.QueryRowContext(ctx, `SELECT count(*) FROM "public"."test_table_renamed";`).Scan(&count))
+	assert.Equal(t, 1, count, "rows must be kept")
+}
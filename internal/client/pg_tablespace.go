@@ -0,0 +1,299 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"strconv"
+	"strings"
+)
+
+const tablespaceObjectType = "TABLESPACE"
+
+// the options of a tablespace, overriding the server settings of the same name for its relations.
+const (
+	tablespaceOptSeqPageCost            = "seq_page_cost"
+	tablespaceOptRandomPageCost         = "random_page_cost"
+	tablespaceOptEffectiveIoConcurrency = "effective_io_concurrency"
+)
+
+var errTablespaceNotEmpty = errors.New("the tablespace is not empty")
+
+type tablespaceSQL struct {
+	db *sql.DB
+}
+
+// TablespaceModel describes a tablespace, a directory of the server where the files of the
+// relations placed in it are stored. Tablespaces are global to the server.
+type TablespaceModel struct {
+	Name string `json:"name" validate:"required"`
+	// Location is the absolute path of the directory, it must exist and be owned by the server user.
+	Location string `json:"location" validate:"required"`
+	Owner    string `json:"owner"`
+	// SeqPageCost, RandomPageCost and EffectiveIoConcurrency override the server settings of the
+	// same name for the relations of the tablespace, nil when not set.
+	SeqPageCost            *float64 `json:"seq_page_cost"`
+	RandomPageCost         *float64 `json:"random_page_cost"`
+	EffectiveIoConcurrency *int64   `json:"effective_io_concurrency"`
+	Comment                string   `json:"comment"`
+}
+
+type TablespaceUpdateParams struct {
+	Current TablespaceModel
+	Desired TablespaceModel `validate:"required"`
+}
+
+type TablespaceRepository interface {
+	Create(ctx context.Context, params TablespaceModel) error
+	Drop(ctx context.Context, name string) error
+	Get(ctx context.Context, name string) (*TablespaceModel, error)
+	Update(ctx context.Context, params TablespaceUpdateParams) (*TablespaceModel, error)
+	Exists(ctx context.Context, name string) (bool, error)
+}
+
+var _ TablespaceRepository = &tablespaceSQL{}
+
+func NewTablespaceRepository(db *sql.DB) TablespaceRepository {
+	return &tablespaceSQL{
+		db: db,
+	}
+}
+
+// Create creates the tablespace. CREATE TABLESPACE can't run inside a transaction, so the
+// statements run one after the other (see ExecWithRole).
+func (t *tablespaceSQL) Create(ctx context.Context, params TablespaceModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	statements := []string{tablespaceCreateQuery(params, GetAssumeRoleFromCtx(ctx))}
+	if params.Comment != "" {
+		statements = append(statements, fmt.Sprintf("COMMENT ON %s %s IS %s;", tablespaceObjectType, pq.QuoteIdentifier(params.Name), pq.QuoteLiteral(params.Comment)))
+	}
+	if err := ExecWithRole(ctx, t.db, statements...); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateTablespace)
+	}
+	return nil
+}
+
+// Drop drops the tablespace. It refuses, with errTablespaceNotEmpty, while a database of the server
+// uses it as default tablespace or a relation of the current database is stored in it. The relations
+// of the other databases are only detected by the server when dropping.
+func (t *tablespaceSQL) Drop(ctx context.Context, name string) error {
+	objects, err := tablespaceObjects(ctx, t.db, name)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropTablespace)
+	}
+	if len(objects) > 0 {
+		return PgErrWithMetadata(
+			fmt.Errorf("%w: '%s' still holds %s", errTablespaceNotEmpty, name, strings.Join(objects, ", ")),
+			"operation", opDropTablespace,
+		)
+	}
+
+	dropQuery := fmt.Sprintf("DROP %s IF EXISTS %s;", tablespaceObjectType, pq.QuoteIdentifier(name))
+	if err = ExecWithRole(ctx, t.db, dropQuery); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropTablespace)
+	}
+	return nil
+}
+
+func (t *tablespaceSQL) Get(ctx context.Context, name string) (*TablespaceModel, error) {
+	readQuery := `
+		SELECT ts.spcname                                                             as "name",
+			   pg_catalog.pg_tablespace_location(ts.oid)                              as "location",
+			   pg_catalog.pg_get_userbyid(ts.spcowner)                                as "owner",
+			   COALESCE(ts.spcoptions, '{}')                                          as "options",
+			   COALESCE(pg_catalog.shobj_description(ts.oid, 'pg_tablespace'), '')    as "comment"
+		FROM pg_catalog.pg_tablespace ts
+		WHERE ts.spcname = %s;`
+
+	var model TablespaceModel
+	var options []string
+	row := t.db.QueryRowContext(ctx, fmt.Sprintf(readQuery, pq.QuoteLiteral(name)))
+	err := row.Scan(&model.Name, &model.Location, &model.Owner, (*pq.StringArray)(&options), &model.Comment)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetTablespace, "pg_cmd", opScanRowResult)
+	}
+
+	if err = model.setOptions(parseRelOptions(options)); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetTablespace)
+	}
+	return &model, nil
+}
+
+// Update applies the changes with ALTER TABLESPACE, the location of a tablespace can't be changed.
+func (t *tablespaceSQL) Update(ctx context.Context, params TablespaceUpdateParams) (*TablespaceModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, t.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateTablespace, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range tablespaceUpdateStatements(params.Current, params.Desired) {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateTablespace)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateTablespace, "pg_cmd", opCommitTransaction)
+	}
+
+	return t.Get(ctx, params.Desired.Name)
+}
+
+func (t *tablespaceSQL) Exists(ctx context.Context, name string) (bool, error) {
+	existsQuery := `SELECT EXISTS (SELECT 1 FROM pg_catalog.pg_tablespace ts WHERE ts.spcname = %s);`
+
+	var exists bool
+	row := t.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(name)))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsTablespace, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
+
+// options returns the options of the tablespace that are set, formatted as the server stores them.
+func (m TablespaceModel) options() map[string]string {
+	options := map[string]string{}
+	if m.SeqPageCost != nil {
+		options[tablespaceOptSeqPageCost] = strconv.FormatFloat(*m.SeqPageCost, 'f', -1, 64)
+	}
+	if m.RandomPageCost != nil {
+		options[tablespaceOptRandomPageCost] = strconv.FormatFloat(*m.RandomPageCost, 'f', -1, 64)
+	}
+	if m.EffectiveIoConcurrency != nil {
+		options[tablespaceOptEffectiveIoConcurrency] = strconv.FormatInt(*m.EffectiveIoConcurrency, 10)
+	}
+	return options
+}
+
+// setOptions parses the options read from the server, the unknown ones are ignored.
+func (m *TablespaceModel) setOptions(options map[string]string) error {
+	for name, value := range options {
+		switch name {
+		case tablespaceOptSeqPageCost, tablespaceOptRandomPageCost:
+			cost, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s option '%s': %w", name, value, err)
+			}
+			if name == tablespaceOptSeqPageCost {
+				m.SeqPageCost = &cost
+			} else {
+				m.RandomPageCost = &cost
+			}
+		case tablespaceOptEffectiveIoConcurrency:
+			concurrency, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s option '%s': %w", name, value, err)
+			}
+			m.EffectiveIoConcurrency = &concurrency
+		}
+	}
+	return nil
+}
+
+// tablespaceCreateQuery returns the CREATE TABLESPACE statement, the owner is omitted when it's
+// the role creating the tablespace.
+func tablespaceCreateQuery(model TablespaceModel, role string) string {
+	query := fmt.Sprintf("CREATE TABLESPACE %s", pq.QuoteIdentifier(model.Name))
+	if model.Owner != "" && model.Owner != role {
+		query += fmt.Sprintf(" OWNER %s", pq.QuoteIdentifier(model.Owner))
+	}
+	query += fmt.Sprintf(" LOCATION %s", pq.QuoteLiteral(model.Location))
+	if options := model.options(); len(options) > 0 {
+		settings := make([]string, 0, len(options))
+		for _, name := range sortedOptionNames(options) {
+			settings = append(settings, fmt.Sprintf("%s = %s", name, options[name]))
+		}
+		query += fmt.Sprintf(" WITH (%s)", strings.Join(settings, ", "))
+	}
+	return query + ";"
+}
+
+// tablespaceUpdateStatements returns the statements turning the current tablespace into the desired one.
+func tablespaceUpdateStatements(current, desired TablespaceModel) []string {
+	name := pq.QuoteIdentifier(desired.Name)
+
+	var statements []string
+	if current.Name != desired.Name {
+		statements = append(statements, fmt.Sprintf("ALTER TABLESPACE %s RENAME TO %s;", pq.QuoteIdentifier(current.Name), name))
+	}
+	if desired.Owner != "" && current.Owner != desired.Owner {
+		statements = append(statements, fmt.Sprintf("ALTER TABLESPACE %s OWNER TO %s;", name, pq.QuoteIdentifier(desired.Owner)))
+	}
+
+	currentOptions, desiredOptions := current.options(), desired.options()
+	var set, reset []string
+	for _, option := range sortedOptionNames(desiredOptions) {
+		if value, ok := currentOptions[option]; !ok || value != desiredOptions[option] {
+			set = append(set, fmt.Sprintf("%s = %s", option, desiredOptions[option]))
+		}
+	}
+	for _, option := range sortedOptionNames(currentOptions) {
+		if _, ok := desiredOptions[option]; !ok {
+			reset = append(reset, option)
+		}
+	}
+	if len(reset) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER TABLESPACE %s RESET (%s);", name, strings.Join(reset, ", ")))
+	}
+	if len(set) > 0 {
+		statements = append(statements, fmt.Sprintf("ALTER TABLESPACE %s SET (%s);", name, strings.Join(set, ", ")))
+	}
+
+	if current.Comment != desired.Comment {
+		statements = append(statements, fmt.Sprintf("COMMENT ON TABLESPACE %s IS %s;", name, pq.QuoteLiteral(desired.Comment)))
+	}
+	return statements
+}
+
+// tablespaceObjects returns the objects still using the tablespace: the databases using it as default
+// tablespace and the relations of the current database stored in it.
+func tablespaceObjects(ctx context.Context, q pgQueryer, name string) ([]string, error) {
+	objectsQuery := `
+		SELECT 'database ' || pg_catalog.quote_ident(d.datname)
+		FROM pg_catalog.pg_database d
+				 JOIN pg_catalog.pg_tablespace ts ON ts.oid = d.dattablespace
+		WHERE ts.spcname = %[1]s
+		UNION ALL
+		SELECT CASE
+				   WHEN c.relkind IN ('i', 'I') THEN 'index '
+				   WHEN c.relkind = 'm' THEN 'materialized view '
+				   ELSE 'table '
+				   END || pg_catalog.quote_ident(n.nspname) || '.' || pg_catalog.quote_ident(c.relname)
+		FROM pg_catalog.pg_class c
+				 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+				 JOIN pg_catalog.pg_tablespace ts ON ts.oid = c.reltablespace
+		WHERE ts.spcname = %[1]s
+		  AND c.relkind <> 't'
+		ORDER BY 1;`
+
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(objectsQuery, pq.QuoteLiteral(name)))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery)
+	}
+	defer rows.Close()
+
+	objects := make([]string, 0)
+	for rows.Next() {
+		var object string
+		if err = rows.Scan(&object); err != nil {
+			return nil, PgErrWithMetadata(err, "pg_cmd", opScanRowResult)
+		}
+		objects = append(objects, object)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery)
+	}
+	return objects, nil
+}
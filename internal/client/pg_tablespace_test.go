@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+const (
+	testTablespaceDirHot  = "/mnt/tablespaces/hot"
+	testTablespaceDirCold = "/mnt/tablespaces/cold"
+)
+
+func testPrepareTablespaceTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database:     "test_tablespace_db",
+		Username:     "test_tablespace_user",
+		WritableDirs: []string{testTablespaceDirHot, testTablespaceDirCold},
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `CREATE ROLE test_tablespace_owner;`)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func TestTablespaceCreateQuery(t *testing.T) {
+	cost, concurrency := 1.5, int64(200)
+	tests := []struct {
+		name     string
+		model    TablespaceModel
+		role     string
+		expected string
+	}{
+		{
+			name:     "Minimal",
+			model:    TablespaceModel{Name: "hot", Location: "/mnt/hot"},
+			expected: `CREATE TABLESPACE "hot" LOCATION '/mnt/hot';`,
+		},
+		{
+			name:     "OwnerAndOptions",
+			model:    TablespaceModel{Name: "hot", Location: "/mnt/hot", Owner: "dba", RandomPageCost: &cost, EffectiveIoConcurrency: &concurrency},
+			expected: `CREATE TABLESPACE "hot" OWNER "dba" LOCATION '/mnt/hot' WITH (effective_io_concurrency = 200, random_page_cost = 1.5);`,
+		},
+		{
+			name:     "OwnerIsAssumedRole",
+			model:    TablespaceModel{Name: "hot", Location: "/mnt/hot", Owner: "dba"},
+			role:     "dba",
+			expected: `CREATE TABLESPACE "hot" LOCATION '/mnt/hot';`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tablespaceCreateQuery(tt.model, tt.role))
+		})
+	}
+}
+
+func TestTablespaceUpdateStatements(t *testing.T) {
+	seqCost, randomCost, newRandomCost := 1.0, 4.0, 1.1
+	current := TablespaceModel{Name: "hot", Location: "/mnt/hot", Owner: "dba", SeqPageCost: &seqCost, RandomPageCost: &randomCost}
+	desired := TablespaceModel{Name: "fast", Location: "/mnt/hot", Owner: "admin", RandomPageCost: &newRandomCost, Comment: "SSD"}
+
+	assert.Equal(t, []string{
+		`ALTER TABLESPACE "hot" RENAME TO "fast";`,
+		`ALTER TABLESPACE "fast" OWNER TO "admin";`,
+		`ALTER TABLESPACE "fast" RESET (seq_page_cost);`,
+		`ALTER TABLESPACE "fast" SET (random_page_cost = 1.1);`,
+		`COMMENT ON TABLESPACE "fast" IS 'SSD';`,
+	}, tablespaceUpdateStatements(current, desired))
+
+	assert.Empty(t, tablespaceUpdateStatements(current, current))
+}
+
+func TestTablespaceSQL_CreateGetAndUpdate(t *testing.T) {
+	ctx, db := testPrepareTablespaceTestCase(t)
+	defer db.Close()
+
+	cost, concurrency := 1.1, int64(100)
+	repo := NewTablespaceRepository(db)
+	require.NoError(t, repo.Create(ctx, TablespaceModel{
+		Name:                   "test_hot",
+		Location:               testTablespaceDirHot,
+		RandomPageCost:         &cost,
+		EffectiveIoConcurrency: &concurrency,
+		Comment:                "test comment",
+	}))
+
+	got, err := repo.Get(ctx, "test_hot")
+	assert.NoError(t, err)
+	assert.Equal(t, testTablespaceDirHot, got.Location)
+	assert.Equal(t, "test_tablespace_user", got.Owner)
+	assert.Nil(t, got.SeqPageCost)
+	assert.Equal(t, &cost, got.RandomPageCost)
+	assert.Equal(t, &concurrency, got.EffectiveIoConcurrency)
+	assert.Equal(t, "test comment", got.Comment)
+
+	desired := *got
+	desired.Name = "test_fast"
+	desired.Owner = "test_tablespace_owner"
+	desired.RandomPageCost = nil
+	desired.SeqPageCost = &cost
+	desired.Comment = ""
+	got, err = repo.Update(ctx, TablespaceUpdateParams{Current: *got, Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, desired, *got)
+
+	exists, err := repo.Exists(ctx, "test_hot")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestTablespaceSQL_DropNotEmpty(t *testing.T) {
+	ctx, db := testPrepareTablespaceTestCase(t)
+	defer db.Close()
+
+	repo := NewTablespaceRepository(db)
+	require.NoError(t, repo.Create(ctx, TablespaceModel{Name: "test_cold", Location: testTablespaceDirCold}))
+
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE public.test_archive (id int PRIMARY KEY) TABLESPACE test_cold;
+		CREATE INDEX test_archive_idx ON public.test_archive (id) TABLESPACE test_cold;`)
+	require.NoError(t, err)
+
+	err = repo.Drop(ctx, "test_cold")
+	assert.ErrorIs(t, err, errTablespaceNotEmpty)
+	assert.ErrorContains(t, err, "index public.test_archive_idx, table public.test_archive")
+
+	_, err = db.ExecContext(ctx, `DROP TABLE public.test_archive;`)
+	require.NoError(t, err)
+
+	assert.NoError(t, repo.Drop(ctx, "test_cold"))
+	exists, err := repo.Exists(ctx, "test_cold")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
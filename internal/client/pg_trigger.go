@@ -0,0 +1,516 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+const (
+	triggerObjectType = "TRIGGER"
+
+	TriggerTimingBefore    = "BEFORE"
+	TriggerTimingAfter     = "AFTER"
+	TriggerTimingInsteadOf = "INSTEAD OF"
+
+	TriggerEventInsert   = "INSERT"
+	TriggerEventUpdate   = "UPDATE"
+	TriggerEventDelete   = "DELETE"
+	TriggerEventTruncate = "TRUNCATE"
+
+	TriggerForEachRow       = "ROW"
+	TriggerForEachStatement = "STATEMENT"
+)
+
+// pg_trigger.tgtype bits, see include/catalog/pg_trigger.h
+const (
+	triggerTypeRow      = 1 << 0
+	triggerTypeBefore   = 1 << 1
+	triggerTypeInsert   = 1 << 2
+	triggerTypeDelete   = 1 << 3
+	triggerTypeUpdate   = 1 << 4
+	triggerTypeTruncate = 1 << 5
+	triggerTypeInstead  = 1 << 6
+)
+
+var (
+	TriggerTimings = []string{TriggerTimingBefore, TriggerTimingAfter, TriggerTimingInsteadOf}
+	TriggerEvents  = []string{TriggerEventInsert, TriggerEventUpdate, TriggerEventDelete, TriggerEventTruncate}
+
+	triggerEventTypes = map[string]int64{
+		TriggerEventInsert:   triggerTypeInsert,
+		TriggerEventUpdate:   triggerTypeUpdate,
+		TriggerEventDelete:   triggerTypeDelete,
+		TriggerEventTruncate: triggerTypeTruncate,
+	}
+
+	errTriggerDefinition = errors.New("unexpected trigger definition")
+)
+
+type triggerSQL struct {
+	db *sql.DB
+}
+
+// TriggerModel describes a trigger on a table, view or foreign table.
+type TriggerModel struct {
+	Schema   string `json:"schema" validate:"required"`
+	Table    string `json:"table" validate:"required"`
+	Name     string `json:"name" validate:"required"`
+	Database string `json:"database"`
+	Timing   string `json:"timing" validate:"required,oneof=BEFORE AFTER 'INSTEAD OF'"`
+	// Events are the INSERT, UPDATE, DELETE or TRUNCATE statements firing the trigger, the UPDATE can
+	// be limited to the UpdateColumns.
+	Events        []string `json:"events" validate:"required,min=1,unique,dive,oneof=INSERT UPDATE DELETE TRUNCATE"`
+	UpdateColumns []string `json:"update_columns" validate:"unique"`
+	ForEach       string   `json:"for_each" validate:"required,oneof=ROW STATEMENT"`
+	When          string   `json:"when"`
+	// OldTable and NewTable are the names of the transition tables, only for AFTER triggers.
+	OldTable          string   `json:"old_table"`
+	NewTable          string   `json:"new_table"`
+	Constraint        bool     `json:"constraint"`
+	Deferrable        bool     `json:"deferrable"`
+	InitiallyDeferred bool     `json:"initially_deferred"`
+	Function          string   `json:"function" validate:"required"`
+	Arguments         []string `json:"arguments"`
+	FiringMode        string   `json:"firing_mode" validate:"omitempty,oneof=origin replica always disabled"`
+	Comment           string   `json:"comment"`
+}
+
+type TriggerUpdateParams struct {
+	Current TriggerModel
+	Desired TriggerModel `validate:"required"`
+}
+
+type TriggerRepository interface {
+	Create(ctx context.Context, params TriggerModel) error
+	Drop(ctx context.Context, schema, table, name string) error
+	Get(ctx context.Context, schema, table, name string) (*TriggerModel, error)
+	Update(ctx context.Context, params TriggerUpdateParams) (*TriggerModel, error)
+	Exists(ctx context.Context, schema, table, name string) (bool, error)
+	Normalize(ctx context.Context, model TriggerModel) (*TriggerModel, error)
+}
+
+var _ TriggerRepository = &triggerSQL{}
+
+func NewTriggerRepository(db *sql.DB) TriggerRepository {
+	return &triggerSQL{
+		db: db,
+	}
+}
+
+func (t *triggerSQL) Create(ctx context.Context, params TriggerModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, t.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateTrigger, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	statements := []string{triggerCreateQuery(params)}
+	if params.FiringMode != "" && params.FiringMode != FiringModeOrigin {
+		statements = append(statements, triggerFiringModeQuery(params))
+	}
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateTrigger)
+		}
+	}
+
+	if params.Comment != "" {
+		if err = CreateComment(ctx, txn, triggerObjectType, triggerObjectName(params.Schema, params.Table, params.Name), params.Comment); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateTrigger)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateTrigger, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (t *triggerSQL) Drop(ctx context.Context, schema, table, name string) error {
+	txn, err := BeginTxWithRole(ctx, t.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropTrigger, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	err = DropObject(ctx, txn, triggerObjectType, triggerObjectName(schema, table, name))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropTrigger)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropTrigger, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (t *triggerSQL) Get(ctx context.Context, schema, table, name string) (*TriggerModel, error) {
+	model, err := readTrigger(ctx, t.db, pgQualifiedName(schema, table), name)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetTrigger)
+	}
+	return model, nil
+}
+
+// Update renames the trigger and changes its firing mode and comment, every other change requires
+// the trigger to be created again.
+func (t *triggerSQL) Update(ctx context.Context, params TriggerUpdateParams) (*TriggerModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	current, desired := params.Current, params.Desired
+	table := pgQualifiedName(desired.Schema, desired.Table)
+
+	var statements []string
+	if current.Name != desired.Name {
+		statements = append(statements, fmt.Sprintf("ALTER TRIGGER %s ON %s RENAME TO %s;", pq.QuoteIdentifier(current.Name), table, pq.QuoteIdentifier(desired.Name)))
+	}
+	if desired.FiringMode != "" && current.FiringMode != desired.FiringMode {
+		statements = append(statements, triggerFiringModeQuery(desired))
+	}
+	if current.Comment != desired.Comment {
+		statements = append(statements, fmt.Sprintf("COMMENT ON TRIGGER %s IS %s;", triggerObjectName(desired.Schema, desired.Table, desired.Name), pq.QuoteLiteral(desired.Comment)))
+	}
+
+	txn, err := BeginTxWithRole(ctx, t.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateTrigger, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateTrigger)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateTrigger, "pg_cmd", opCommitTransaction)
+	}
+
+	return t.Get(ctx, desired.Schema, desired.Table, desired.Name)
+}
+
+func (t *triggerSQL) Exists(ctx context.Context, schema, table, name string) (bool, error) {
+	existsQuery := `
+		SELECT EXISTS (SELECT 1
+					   FROM pg_catalog.pg_trigger t
+					   WHERE t.tgrelid = pg_catalog.to_regclass(%s)
+						 AND t.tgname = %s
+						 AND NOT t.tgisinternal);`
+
+	var exists bool
+	row := t.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(pgQualifiedName(schema, table)), pq.QuoteLiteral(name)))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsTrigger, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
+
+// Normalize returns the trigger as PostgreSQL would store it. The trigger is created on a temporary
+// copy of its table (or view) inside a transaction that is always rolled back.
+func (t *triggerSQL) Normalize(ctx context.Context, model TriggerModel) (*TriggerModel, error) {
+	txn, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeTrigger, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	table := pgQualifiedName(model.Schema, model.Table)
+	isView, err := relationExists(ctx, txn, table, relKindView)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeTrigger)
+	}
+
+	temporary := model
+	temporary.Schema = "pg_temp"
+	temporary.Table = "tf_normalize_" + model.Table
+	temporary.FiringMode = ""
+
+	copyQuery := "CREATE TEMPORARY TABLE %s (LIKE %s);"
+	if isView {
+		copyQuery = "CREATE TEMPORARY VIEW %s AS SELECT * FROM %s;"
+	}
+	statements := []string{
+		fmt.Sprintf(copyQuery, pgQualifiedName(temporary.Schema, temporary.Table), table),
+		triggerCreateQuery(temporary),
+	}
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opNormalizeTrigger)
+		}
+	}
+
+	normalized, err := readTrigger(ctx, txn, pgQualifiedName(temporary.Schema, temporary.Table), temporary.Name)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeTrigger)
+	}
+	normalized.Schema, normalized.Table, normalized.Database = model.Schema, model.Table, model.Database
+	normalized.FiringMode, normalized.Comment = model.FiringMode, model.Comment
+
+	return normalized, nil
+}
+
+// PreserveTriggerSpelling returns the actual trigger, but keeping the spelling of the prior
+// condition and function when their normalized form (see TriggerRepository.Normalize) matches the actual one.
+func PreserveTriggerSpelling(prior, normalizedPrior, actual TriggerModel) TriggerModel {
+	result := actual
+	if normalizedPrior.When == actual.When {
+		result.When = prior.When
+	}
+	if normalizedPrior.Function == actual.Function {
+		result.Function = prior.Function
+	}
+	return result
+}
+
+func triggerCreateQuery(model TriggerModel) string {
+	var query strings.Builder
+
+	query.WriteString("CREATE ")
+	if model.Constraint {
+		query.WriteString("CONSTRAINT ")
+	}
+	query.WriteString(fmt.Sprintf("TRIGGER %s %s ", pq.QuoteIdentifier(model.Name), model.Timing))
+
+	events := make([]string, len(model.Events))
+	for i, event := range model.Events {
+		events[i] = event
+		if event == TriggerEventUpdate && len(model.UpdateColumns) > 0 {
+			events[i] = fmt.Sprintf("UPDATE OF %s", pgQuoteListOfIdentifiers(model.UpdateColumns))
+		}
+	}
+	query.WriteString(fmt.Sprintf("%s ON %s", strings.Join(events, " OR "), pgQualifiedName(model.Schema, model.Table)))
+
+	if model.Constraint {
+		switch {
+		case model.Deferrable && model.InitiallyDeferred:
+			query.WriteString(" DEFERRABLE INITIALLY DEFERRED")
+		case model.Deferrable:
+			query.WriteString(" DEFERRABLE INITIALLY IMMEDIATE")
+		default:
+			query.WriteString(" NOT DEFERRABLE")
+		}
+	}
+
+	if model.OldTable != "" || model.NewTable != "" {
+		query.WriteString(" REFERENCING")
+		if model.OldTable != "" {
+			query.WriteString(fmt.Sprintf(" OLD TABLE AS %s", pq.QuoteIdentifier(model.OldTable)))
+		}
+		if model.NewTable != "" {
+			query.WriteString(fmt.Sprintf(" NEW TABLE AS %s", pq.QuoteIdentifier(model.NewTable)))
+		}
+	}
+
+	query.WriteString(fmt.Sprintf(" FOR EACH %s", model.ForEach))
+	if model.When != "" {
+		query.WriteString(fmt.Sprintf(" WHEN (%s)", model.When))
+	}
+	query.WriteString(fmt.Sprintf(" EXECUTE FUNCTION %s(%s);", model.Function, pgQuoteListOfLiterals(model.Arguments)))
+
+	return query.String()
+}
+
+func triggerFiringModeQuery(model TriggerModel) string {
+	return fmt.Sprintf("ALTER TABLE %s %s TRIGGER %s;", pgQualifiedName(model.Schema, model.Table), firingModeClause(model.FiringMode), pq.QuoteIdentifier(model.Name))
+}
+
+// triggerObjectName returns the name of a trigger in the COMMENT ON and DROP statements, i.e. 'name ON table'.
+func triggerObjectName(schema, table, name string) string {
+	return fmt.Sprintf("%s ON %s", pq.QuoteIdentifier(name), pgQualifiedName(schema, table))
+}
+
+// readTrigger reads the trigger with the given name on the relation with the given qualified name.
+// The flags come from pg_trigger, the condition and the arguments from pg_get_triggerdef.
+func readTrigger(ctx context.Context, q pgQueryer, qualifiedTable, name string) (*TriggerModel, error) {
+	var model TriggerModel
+	var tgType int64
+	var enabledRaw, definition string
+
+	triggerQuery := `
+		SELECT n.nspname                                                          as "schema",
+			   c.relname                                                          as "table",
+			   t.tgname                                                           as "name",
+			   pg_catalog.current_database()                                      as "database",
+			   t.tgtype                                                           as "type",
+			   ARRAY(SELECT a.attname
+					 FROM unnest(t.tgattr::int2[]) WITH ORDINALITY u(attnum, ord)
+							  JOIN pg_catalog.pg_attribute a ON a.attrelid = t.tgrelid AND a.attnum = u.attnum
+					 ORDER BY u.ord)                                              as "update_columns",
+			   COALESCE(t.tgoldtable, '')                                         as "old_table",
+			   COALESCE(t.tgnewtable, '')                                         as "new_table",
+			   t.tgconstraint <> 0                                                as "constraint",
+			   t.tgdeferrable                                                     as "deferrable",
+			   t.tginitdeferred                                                   as "initially_deferred",
+			   pn.nspname || '.' || p.proname                                     as "function",
+			   t.tgenabled                                                        as "enabled",
+			   pg_catalog.pg_get_triggerdef(t.oid)                                as "definition",
+			   COALESCE(pg_catalog.obj_description(t.oid, 'pg_trigger'), '')      as "comment"
+		FROM pg_catalog.pg_trigger t
+				 JOIN pg_catalog.pg_class c ON c.oid = t.tgrelid
+				 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+				 JOIN pg_catalog.pg_proc p ON p.oid = t.tgfoid
+				 JOIN pg_catalog.pg_namespace pn ON pn.oid = p.pronamespace
+		WHERE t.tgrelid = pg_catalog.to_regclass(%s)
+		  AND t.tgname = %s
+		  AND NOT t.tgisinternal;`
+
+	row := q.QueryRowContext(ctx, fmt.Sprintf(triggerQuery, pq.QuoteLiteral(qualifiedTable), pq.QuoteLiteral(name)))
+	err := row.Scan(
+		&model.Schema,
+		&model.Table,
+		&model.Name,
+		&model.Database,
+		&tgType,
+		(*pq.StringArray)(&model.UpdateColumns),
+		&model.OldTable,
+		&model.NewTable,
+		&model.Constraint,
+		&model.Deferrable,
+		&model.InitiallyDeferred,
+		&model.Function,
+		&enabledRaw,
+		&definition,
+		&model.Comment,
+	)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "trigger")
+	}
+
+	switch {
+	case tgType&triggerTypeInstead != 0:
+		model.Timing = TriggerTimingInsteadOf
+	case tgType&triggerTypeBefore != 0:
+		model.Timing = TriggerTimingBefore
+	default:
+		model.Timing = TriggerTimingAfter
+	}
+	model.ForEach = TriggerForEachStatement
+	if tgType&triggerTypeRow != 0 {
+		model.ForEach = TriggerForEachRow
+	}
+	for _, event := range TriggerEvents {
+		if tgType&triggerEventTypes[event] != 0 {
+			model.Events = append(model.Events, event)
+		}
+	}
+	model.FiringMode = firingModeFromCode(enabledRaw)
+	if len(model.UpdateColumns) == 0 {
+		model.UpdateColumns = nil
+	}
+
+	model.When, model.Arguments, err = parseTriggerDefinition(definition)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "trigger", "definition", definition)
+	}
+
+	return &model, nil
+}
+
+// parseTriggerDefinition extracts the WHEN condition and the arguments from the output of
+// pg_get_triggerdef, i.e. '... FOR EACH ROW WHEN (<condition>) EXECUTE FUNCTION <function>(<arguments>)'.
+func parseTriggerDefinition(definition string) (string, []string, error) {
+	forEach := strings.Index(definition, " FOR EACH ")
+	if forEach < 0 {
+		return "", nil, errTriggerDefinition
+	}
+	rest := definition[forEach:]
+
+	var when string
+	const whenPrefix = " WHEN ("
+	whenStart := strings.Index(rest, whenPrefix)
+	if execStart := strings.Index(rest, " EXECUTE FUNCTION "); whenStart >= 0 && whenStart < execStart {
+		start := whenStart + len(whenPrefix)
+		end := closingParenthesis(rest, start)
+		if end < 0 {
+			return "", nil, errTriggerDefinition
+		}
+		when, rest = rest[start:end], rest[end+1:]
+	}
+
+	const execPrefix = " EXECUTE FUNCTION "
+	execStart := strings.Index(rest, execPrefix)
+	if execStart < 0 {
+		return "", nil, errTriggerDefinition
+	}
+	rest = rest[execStart+len(execPrefix):]
+
+	// the function name may be quoted, the arguments start at the first parenthesis outside quotes
+	inQuotes := false
+	argsStart := -1
+	for i := 0; i < len(rest) && argsStart < 0; i++ {
+		switch {
+		case rest[i] == '"':
+			inQuotes = !inQuotes
+		case rest[i] == '(' && !inQuotes:
+			argsStart = i + 1
+		}
+	}
+	if argsStart < 0 {
+		return "", nil, errTriggerDefinition
+	}
+
+	var args []string
+	for i := argsStart; i < len(rest); i++ {
+		switch rest[i] {
+		case ' ', ',':
+			continue
+		case ')':
+			return when, args, nil
+		case '\'':
+			var arg strings.Builder
+			for i++; i < len(rest); i++ {
+				if rest[i] == '\'' {
+					if i+1 < len(rest) && rest[i+1] == '\'' {
+						i++
+					} else {
+						break
+					}
+				}
+				arg.WriteByte(rest[i])
+			}
+			args = append(args, arg.String())
+		default:
+			return "", nil, errTriggerDefinition
+		}
+	}
+	return "", nil, errTriggerDefinition
+}
+
+// closingParenthesis returns the index of the parenthesis closing the one opened right before start,
+// skipping the parentheses in string literals and quoted identifiers. It returns -1 when there is none.
+func closingParenthesis(s string, start int) int {
+	depth := 1
+	var quote byte
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
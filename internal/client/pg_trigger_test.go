@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+const (
+	testTriggerDb   = "test_trigger_db"
+	testTriggerUser = "test_trigger_user"
+)
+
+func testPrepareTriggerTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: testTriggerDb,
+		Username: testTriggerUser,
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE public.test_trigger_table (id int, status text);
+		CREATE FUNCTION public.test_trigger_func() RETURNS trigger LANGUAGE plpgsql AS $$ BEGIN RETURN NULL; END $$;`)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func mockTriggerModel(t *testing.T) TriggerModel {
+	t.Helper()
+	return TriggerModel{
+		Schema:        "public",
+		Table:         "test_trigger_table",
+		Name:          "test_trigger",
+		Timing:        TriggerTimingAfter,
+		Events:        []string{TriggerEventInsert, TriggerEventUpdate},
+		UpdateColumns: []string{"status"},
+		ForEach:       TriggerForEachRow,
+		When:          "NEW.status IS NOT NULL",
+		Function:      "test_trigger_func",
+		Arguments:     []string{"audit", "it's"},
+		FiringMode:    FiringModeReplica,
+		Comment:       "test comment",
+	}
+}
+
+func TestTriggerCreateQuery(t *testing.T) {
+	model := mockTriggerModel(t)
+	assert.Equal(t,
+		`CREATE TRIGGER "test_trigger" AFTER INSERT OR UPDATE OF "status" ON "public"."test_trigger_table" FOR EACH ROW WHEN (NEW.status IS NOT NULL) EXECUTE FUNCTION test_trigger_func('audit', 'it''s');`,
+		triggerCreateQuery(model),
+	)
+
+	model = TriggerModel{
+		Schema: "public", Table: "t", Name: "c", Timing: TriggerTimingAfter, Events: []string{TriggerEventDelete},
+		ForEach: TriggerForEachRow, Constraint: true, Deferrable: true, InitiallyDeferred: true, Function: "f",
+	}
+	assert.Equal(t,
+		`CREATE CONSTRAINT TRIGGER "c" AFTER DELETE ON "public"."t" DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION f();`,
+		triggerCreateQuery(model),
+	)
+
+	model = TriggerModel{
+		Schema: "public", Table: "t", Name: "s", Timing: TriggerTimingAfter, Events: []string{TriggerEventInsert},
+		ForEach: TriggerForEachStatement, NewTable: "inserted", Function: "f",
+	}
+	assert.Equal(t,
+		`CREATE TRIGGER "s" AFTER INSERT ON "public"."t" REFERENCING NEW TABLE AS "inserted" FOR EACH STATEMENT EXECUTE FUNCTION f();`,
+		triggerCreateQuery(model),
+	)
+}
+
+func TestParseTriggerDefinition(t *testing.T) {
+	testCases := []struct {
+		definition string
+		when       string
+		args       []string
+	}{
+		{
+			definition: `CREATE TRIGGER t AFTER INSERT ON public.x FOR EACH ROW EXECUTE FUNCTION f()`,
+		},
+		{
+			definition: `CREATE TRIGGER t BEFORE UPDATE OF status ON public.x FOR EACH ROW WHEN ((new.status <> 'a (b'::text)) EXECUTE FUNCTION public."odd(name"('audit', 'it''s', '')`,
+			when:       `(new.status <> 'a (b'::text)`,
+			args:       []string{"audit", "it's", ""},
+		},
+	}
+	for _, testCase := range testCases {
+		when, args, err := parseTriggerDefinition(testCase.definition)
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.when, when)
+		assert.Equal(t, testCase.args, args)
+	}
+
+	_, _, err := parseTriggerDefinition(`CREATE TRIGGER t AFTER INSERT ON public.x`)
+	assert.ErrorIs(t, err, errTriggerDefinition)
+}
+
+func TestTriggerSQL_CreateAndGet(t *testing.T) {
+	ctx, db := testPrepareTriggerTestCase(t)
+	defer db.Close()
+
+	triggerRepo := NewTriggerRepository(db)
+	model := mockTriggerModel(t)
+	assert.NoError(t, triggerRepo.Create(ctx, model))
+
+	got, err := triggerRepo.Get(ctx, model.Schema, model.Table, model.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, testTriggerDb, got.Database)
+	assert.Equal(t, model.Timing, got.Timing)
+	assert.Equal(t, model.Events, got.Events)
+	assert.Equal(t, model.UpdateColumns, got.UpdateColumns)
+	assert.Equal(t, model.ForEach, got.ForEach)
+	assert.Equal(t, "(new.status IS NOT NULL)", got.When)
+	assert.Equal(t, "public.test_trigger_func", got.Function)
+	assert.Equal(t, model.Arguments, got.Arguments)
+	assert.Equal(t, FiringModeReplica, got.FiringMode)
+	assert.Equal(t, model.Comment, got.Comment)
+	assert.False(t, got.Constraint)
+
+	normalized, err := triggerRepo.Normalize(ctx, model)
+	assert.NoError(t, err)
+	preserved := PreserveTriggerSpelling(model, *normalized, *got)
+	assert.Equal(t, model.When, preserved.When)
+	assert.Equal(t, model.Function, preserved.Function)
+
+	assert.NoError(t, triggerRepo.Drop(ctx, model.Schema, model.Table, model.Name))
+	exists, err := triggerRepo.Exists(ctx, model.Schema, model.Table, model.Name)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestTriggerSQL_Update(t *testing.T) {
+	ctx, db := testPrepareTriggerTestCase(t)
+	defer db.Close()
+
+	triggerRepo := NewTriggerRepository(db)
+	current := mockTriggerModel(t)
+	assert.NoError(t, triggerRepo.Create(ctx, current))
+
+	desired := current
+	desired.Name = "test_trigger_renamed"
+	desired.FiringMode = FiringModeDisabled
+	desired.Comment = ""
+
+	got, err := triggerRepo.Update(ctx, TriggerUpdateParams{Current: current, Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, desired.Name, got.Name)
+	assert.Equal(t, FiringModeDisabled, got.FiringMode)
+	assert.Empty(t, got.Comment)
+}
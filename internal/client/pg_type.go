@@ -0,0 +1,620 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/lib/pq"
+	"slices"
+	"strings"
+)
+
+const (
+	typeObjectType   = "TYPE"
+	domainObjectType = "DOMAIN"
+
+	TypeKindEnum      = "enum"
+	TypeKindComposite = "composite"
+	TypeKindRange     = "range"
+	TypeKindDomain    = "domain"
+)
+
+var (
+	// ErrTypeRequiresReplace is returned when the changes can't be applied in place, but only by
+	// creating the type again.
+	ErrTypeRequiresReplace = errors.New("the type can't be changed in place")
+
+	errTypeEnumValueRemoved   = errors.New("PostgreSQL can't remove values from an enum")
+	errTypeEnumValueReordered = errors.New("PostgreSQL can't reorder the values of an enum")
+
+	// pg_type.typtype
+	typeKindCodes = map[string]string{
+		"e": TypeKindEnum,
+		"c": TypeKindComposite,
+		"r": TypeKindRange,
+		"d": TypeKindDomain,
+	}
+)
+
+type typeSQL struct {
+	db *sql.DB
+}
+
+type TypeAttribute struct {
+	Name string `json:"name" validate:"required"`
+	Type string `json:"type" validate:"required"`
+}
+
+type TypeRange struct {
+	Subtype        string `json:"subtype" validate:"required"`
+	SubtypeOpclass string `json:"subtype_opclass"`
+	SubtypeDiff    string `json:"subtype_diff"`
+}
+
+type TypeCheckConstraint struct {
+	Name       string `json:"name" validate:"required"`
+	Expression string `json:"expression" validate:"required"`
+}
+
+type TypeDomain struct {
+	BaseType         string                `json:"base_type" validate:"required"`
+	Default          string                `json:"default"`
+	NotNull          bool                  `json:"not_null"`
+	CheckConstraints []TypeCheckConstraint `json:"check_constraints" validate:"dive"`
+}
+
+// TypeModel describes a user defined type, the definition matching its Kind is the only one set.
+type TypeModel struct {
+	Schema     string          `json:"schema" validate:"required"`
+	Name       string          `json:"name" validate:"required"`
+	Database   string          `json:"database"`
+	Kind       string          `json:"kind" validate:"required,oneof=enum composite range domain"`
+	EnumValues []string        `json:"enum_values" validate:"unique"`
+	Attributes []TypeAttribute `json:"attributes" validate:"required_if=Kind composite,dive"`
+	Range      *TypeRange      `json:"range" validate:"required_if=Kind range"`
+	Domain     *TypeDomain     `json:"domain" validate:"required_if=Kind domain"`
+	Owner      string          `json:"owner"`
+	Comment    string          `json:"comment"`
+}
+
+type TypeUpdateParams struct {
+	Current TypeModel
+	Desired TypeModel `validate:"required"`
+}
+
+type TypeRepository interface {
+	Create(ctx context.Context, params TypeModel) error
+	Drop(ctx context.Context, schema, name, kind string) error
+	Get(ctx context.Context, schema, name string) (*TypeModel, error)
+	Update(ctx context.Context, params TypeUpdateParams) (*TypeModel, error)
+	Exists(ctx context.Context, schema, name string) (bool, error)
+	Normalize(ctx context.Context, model TypeModel) (*TypeModel, error)
+}
+
+var _ TypeRepository = &typeSQL{}
+
+func NewTypeRepository(db *sql.DB) TypeRepository {
+	return &typeSQL{
+		db: db,
+	}
+}
+
+func (t *typeSQL) Create(ctx context.Context, params TypeModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, t.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateType, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	objectType, name := typeObjectTypeOf(params.Kind), pgQualifiedName(params.Schema, params.Name)
+
+	statements := []string{typeCreateQuery(params)}
+	if params.Owner != "" && params.Owner != GetAssumeRoleFromCtx(ctx) {
+		statements = append(statements, fmt.Sprintf("ALTER %s %s OWNER TO %s;", objectType, name, pq.QuoteIdentifier(params.Owner)))
+	}
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateType)
+		}
+	}
+
+	if params.Comment != "" {
+		if err = CreateComment(ctx, txn, objectType, name, params.Comment); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateType)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateType, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (t *typeSQL) Drop(ctx context.Context, schema, name, kind string) error {
+	txn, err := BeginTxWithRole(ctx, t.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropType, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	err = DropObject(ctx, txn, typeObjectTypeOf(kind), pgQualifiedName(schema, name))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropType)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropType, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (t *typeSQL) Get(ctx context.Context, schema, name string) (*TypeModel, error) {
+	model, err := readType(ctx, t.db, pgQualifiedName(schema, name))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetType)
+	}
+	return model, nil
+}
+
+// Update applies the changes in place, see PlanTypeAlterations.
+func (t *typeSQL) Update(ctx context.Context, params TypeUpdateParams) (*TypeModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	statements, err := PlanTypeAlterations(params.Current, params.Desired)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateType)
+	}
+
+	txn, err := BeginTxWithRole(ctx, t.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateType, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateType)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateType, "pg_cmd", opCommitTransaction)
+	}
+
+	return t.Get(ctx, params.Desired.Schema, params.Desired.Name)
+}
+
+func (t *typeSQL) Exists(ctx context.Context, schema, name string) (bool, error) {
+	existsQuery := `SELECT pg_catalog.to_regtype(%s) IS NOT NULL;`
+
+	var exists bool
+	row := t.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(pgQualifiedName(schema, name))))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsType, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
+
+// Normalize returns the type as PostgreSQL would store it: types through format_type and
+// expressions through pg_get_expr. The definition is created as a temporary type inside a
+// transaction that is always rolled back.
+func (t *typeSQL) Normalize(ctx context.Context, model TypeModel) (*TypeModel, error) {
+	txn, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeType, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	temporary := model
+	temporary.Schema = "pg_temp"
+	temporary.Name = "tf_normalize_type"
+
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, typeCreateQuery(temporary))); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeType)
+	}
+
+	normalized, err := readType(ctx, txn, pgQualifiedName(temporary.Schema, temporary.Name))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeType)
+	}
+	normalized.Schema, normalized.Name, normalized.Database = model.Schema, model.Name, model.Database
+	normalized.Owner, normalized.Comment = model.Owner, model.Comment
+
+	return normalized, nil
+}
+
+// PreserveTypeSpelling returns the actual type, but keeping the spelling of the prior definition
+// for the types and expressions whose normalized form (see TypeRepository.Normalize) matches the actual one.
+func PreserveTypeSpelling(prior, normalizedPrior, actual TypeModel) TypeModel {
+	result := actual
+	if prior.Kind != actual.Kind || normalizedPrior.Kind != actual.Kind {
+		return result
+	}
+
+	switch actual.Kind {
+	case TypeKindComposite:
+		result.Attributes = slices.Clone(actual.Attributes)
+		for i, attribute := range result.Attributes {
+			priorIdx := indexByName(prior.Attributes, attribute.Name, func(a TypeAttribute) string { return a.Name })
+			normIdx := indexByName(normalizedPrior.Attributes, attribute.Name, func(a TypeAttribute) string { return a.Name })
+			if priorIdx >= 0 && normIdx >= 0 && normalizedPrior.Attributes[normIdx].Type == attribute.Type {
+				result.Attributes[i].Type = prior.Attributes[priorIdx].Type
+			}
+		}
+	case TypeKindRange:
+		if prior.Range == nil || normalizedPrior.Range == nil || actual.Range == nil {
+			break
+		}
+		preserved := *actual.Range
+		if normalizedPrior.Range.Subtype == preserved.Subtype {
+			preserved.Subtype = prior.Range.Subtype
+		}
+		if normalizedPrior.Range.SubtypeOpclass == preserved.SubtypeOpclass {
+			preserved.SubtypeOpclass = prior.Range.SubtypeOpclass
+		}
+		if normalizedPrior.Range.SubtypeDiff == preserved.SubtypeDiff {
+			preserved.SubtypeDiff = prior.Range.SubtypeDiff
+		}
+		result.Range = &preserved
+	case TypeKindDomain:
+		if prior.Domain == nil || normalizedPrior.Domain == nil || actual.Domain == nil {
+			break
+		}
+		preserved := *actual.Domain
+		if normalizedPrior.Domain.BaseType == preserved.BaseType {
+			preserved.BaseType = prior.Domain.BaseType
+		}
+		if normalizedPrior.Domain.Default == preserved.Default {
+			preserved.Default = prior.Domain.Default
+		}
+		// constraints are read back sorted by name, keep the order of the prior definition instead
+		nameFn := func(c TypeCheckConstraint) string { return c.Name }
+		preserved.CheckConstraints = orderLike(actual.Domain.CheckConstraints, prior.Domain.CheckConstraints, nameFn)
+		for i, check := range preserved.CheckConstraints {
+			priorIdx := indexByName(prior.Domain.CheckConstraints, check.Name, nameFn)
+			normIdx := indexByName(normalizedPrior.Domain.CheckConstraints, check.Name, nameFn)
+			if priorIdx >= 0 && normIdx >= 0 && normalizedPrior.Domain.CheckConstraints[normIdx].Expression == check.Expression {
+				preserved.CheckConstraints[i].Expression = prior.Domain.CheckConstraints[priorIdx].Expression
+			}
+		}
+		result.Domain = &preserved
+	}
+	return result
+}
+
+// PlanTypeAlterations returns the statements that turn the current type into the desired one.
+// It fails with ErrTypeRequiresReplace when the changes can only be applied by creating the type
+// again, and with an error when they can't be applied at all, e.g. removing an enum value.
+func PlanTypeAlterations(current, desired TypeModel) ([]string, error) {
+	if current.Kind != desired.Kind {
+		return nil, ErrTypeRequiresReplace
+	}
+
+	objectType := typeObjectTypeOf(desired.Kind)
+	statements := relationRenameStatements(objectType, current.Schema, current.Name, desired.Schema, desired.Name)
+	name := pgQualifiedName(desired.Schema, desired.Name)
+
+	var kindStatements []string
+	var err error
+	switch desired.Kind {
+	case TypeKindEnum:
+		kindStatements, err = enumAlterations(name, current.EnumValues, desired.EnumValues)
+	case TypeKindComposite:
+		kindStatements, err = compositeAlterations(name, current.Attributes, desired.Attributes)
+	case TypeKindRange:
+		if current.Range == nil || desired.Range == nil || *current.Range != *desired.Range {
+			err = ErrTypeRequiresReplace
+		}
+	case TypeKindDomain:
+		kindStatements, err = domainAlterations(name, current.Domain, desired.Domain)
+	}
+	if err != nil {
+		return nil, err
+	}
+	statements = append(statements, kindStatements...)
+
+	if desired.Owner != "" && current.Owner != desired.Owner {
+		statements = append(statements, fmt.Sprintf("ALTER %s %s OWNER TO %s;", objectType, name, pq.QuoteIdentifier(desired.Owner)))
+	}
+	if current.Comment != desired.Comment {
+		statements = append(statements, fmt.Sprintf("COMMENT ON %s %s IS %s;", objectType, name, pq.QuoteLiteral(desired.Comment)))
+	}
+	return statements, nil
+}
+
+// enumAlterations adds the new values of the enum. Each one is placed before the next existing
+// value, or appended when there is none, so the order never depends on a value added in the same transaction.
+func enumAlterations(name string, current, desired []string) ([]string, error) {
+	last := -1
+	for _, value := range current {
+		idx := slices.Index(desired, value)
+		if idx < 0 {
+			return nil, fmt.Errorf("%w: '%s'", errTypeEnumValueRemoved, value)
+		}
+		if idx < last {
+			return nil, fmt.Errorf("%w: '%s'", errTypeEnumValueReordered, value)
+		}
+		last = idx
+	}
+
+	var statements []string
+	for i, value := range desired {
+		if slices.Contains(current, value) {
+			continue
+		}
+		statement := fmt.Sprintf("ALTER TYPE %s ADD VALUE %s", name, pq.QuoteLiteral(value))
+		for _, next := range desired[i+1:] {
+			if slices.Contains(current, next) {
+				statement += fmt.Sprintf(" BEFORE %s", pq.QuoteLiteral(next))
+				break
+			}
+		}
+		statements = append(statements, statement+";")
+	}
+	return statements, nil
+}
+
+// compositeAlterations drops, changes and adds attributes. New attributes can only be appended,
+// changing the order of the existing ones requires the type to be created again.
+func compositeAlterations(name string, current, desired []TypeAttribute) ([]string, error) {
+	nameFn := func(a TypeAttribute) string { return a.Name }
+
+	var statements []string
+	var kept []string
+	for _, attribute := range current {
+		idx := indexByName(desired, attribute.Name, nameFn)
+		if idx < 0 {
+			statements = append(statements, fmt.Sprintf("ALTER TYPE %s DROP ATTRIBUTE %s;", name, pq.QuoteIdentifier(attribute.Name)))
+			continue
+		}
+		kept = append(kept, attribute.Name)
+		if desired[idx].Type != attribute.Type {
+			statements = append(statements, fmt.Sprintf("ALTER TYPE %s ALTER ATTRIBUTE %s TYPE %s;", name, pq.QuoteIdentifier(attribute.Name), desired[idx].Type))
+		}
+	}
+
+	for i, attribute := range desired {
+		if i < len(kept) {
+			if attribute.Name != kept[i] {
+				return nil, ErrTypeRequiresReplace
+			}
+			continue
+		}
+		if slices.Contains(kept, attribute.Name) {
+			return nil, ErrTypeRequiresReplace
+		}
+		statements = append(statements, fmt.Sprintf("ALTER TYPE %s ADD ATTRIBUTE %s %s;", name, pq.QuoteIdentifier(attribute.Name), attribute.Type))
+	}
+	return statements, nil
+}
+
+// domainAlterations changes the default, the NOT NULL constraint and the check constraints of the
+// domain, a changed constraint is dropped and added again. A new base type requires the domain to
+// be created again.
+func domainAlterations(name string, current, desired *TypeDomain) ([]string, error) {
+	if current == nil || desired == nil || current.BaseType != desired.BaseType {
+		return nil, ErrTypeRequiresReplace
+	}
+
+	var statements []string
+	alter := func(format string, args ...any) {
+		statements = append(statements, fmt.Sprintf("ALTER DOMAIN %s %s;", name, fmt.Sprintf(format, args...)))
+	}
+
+	nameFn := func(c TypeCheckConstraint) string { return c.Name }
+	for _, check := range current.CheckConstraints {
+		idx := indexByName(desired.CheckConstraints, check.Name, nameFn)
+		if idx < 0 || desired.CheckConstraints[idx].Expression != check.Expression {
+			alter("DROP CONSTRAINT %s", pq.QuoteIdentifier(check.Name))
+		}
+	}
+	if current.Default != desired.Default {
+		if desired.Default == "" {
+			alter("DROP DEFAULT")
+		} else {
+			alter("SET DEFAULT %s", desired.Default)
+		}
+	}
+	if current.NotNull != desired.NotNull {
+		if desired.NotNull {
+			alter("SET NOT NULL")
+		} else {
+			alter("DROP NOT NULL")
+		}
+	}
+	for _, check := range desired.CheckConstraints {
+		idx := indexByName(current.CheckConstraints, check.Name, nameFn)
+		if idx < 0 || current.CheckConstraints[idx].Expression != check.Expression {
+			alter("ADD CONSTRAINT %s CHECK (%s)", pq.QuoteIdentifier(check.Name), check.Expression)
+		}
+	}
+	return statements, nil
+}
+
+func typeCreateQuery(model TypeModel) string {
+	name := pgQualifiedName(model.Schema, model.Name)
+
+	switch model.Kind {
+	case TypeKindEnum:
+		return fmt.Sprintf("CREATE TYPE %s AS ENUM (%s);", name, pgQuoteListOfLiterals(model.EnumValues))
+	case TypeKindComposite:
+		attributes := make([]string, len(model.Attributes))
+		for i, attribute := range model.Attributes {
+			attributes[i] = fmt.Sprintf("%s %s", pq.QuoteIdentifier(attribute.Name), attribute.Type)
+		}
+		return fmt.Sprintf("CREATE TYPE %s AS (%s);", name, strings.Join(attributes, ", "))
+	case TypeKindRange:
+		options := []string{fmt.Sprintf("SUBTYPE = %s", model.Range.Subtype)}
+		if model.Range.SubtypeOpclass != "" {
+			options = append(options, fmt.Sprintf("SUBTYPE_OPCLASS = %s", model.Range.SubtypeOpclass))
+		}
+		if model.Range.SubtypeDiff != "" {
+			options = append(options, fmt.Sprintf("SUBTYPE_DIFF = %s", model.Range.SubtypeDiff))
+		}
+		return fmt.Sprintf("CREATE TYPE %s AS RANGE (%s);", name, strings.Join(options, ", "))
+	case TypeKindDomain:
+		query := fmt.Sprintf("CREATE DOMAIN %s AS %s", name, model.Domain.BaseType)
+		if model.Domain.Default != "" {
+			query += fmt.Sprintf(" DEFAULT %s", model.Domain.Default)
+		}
+		if model.Domain.NotNull {
+			query += " NOT NULL"
+		}
+		for _, check := range model.Domain.CheckConstraints {
+			query += fmt.Sprintf(" CONSTRAINT %s CHECK (%s)", pq.QuoteIdentifier(check.Name), check.Expression)
+		}
+		return query + ";"
+	}
+	return ""
+}
+
+// typeObjectTypeOf returns the object type used in the statements on a type of the given kind.
+func typeObjectTypeOf(kind string) string {
+	if kind == TypeKindDomain {
+		return domainObjectType
+	}
+	return typeObjectType
+}
+
+// readType reads the type with the given qualified name, along with the definition of its kind.
+func readType(ctx context.Context, q pgQueryer, qualifiedName string) (*TypeModel, error) {
+	var model TypeModel
+	var oid, relid int64
+	var kindCode string
+
+	typeQuery := `
+		SELECT t.oid                                                          as "oid",
+			   t.typrelid                                                     as "relid",
+			   n.nspname                                                      as "schema",
+			   t.typname                                                      as "name",
+			   pg_catalog.current_database()                                  as "database",
+			   t.typtype                                                      as "kind",
+			   pg_catalog.pg_get_userbyid(t.typowner)                         as "owner",
+			   COALESCE(pg_catalog.obj_description(t.oid, 'pg_type'), '')     as "comment"
+		FROM pg_catalog.pg_type t
+				 JOIN pg_catalog.pg_namespace n ON n.oid = t.typnamespace
+		WHERE t.oid = pg_catalog.to_regtype(%s)
+		  AND (t.typtype <> 'c' OR (SELECT c.relkind FROM pg_catalog.pg_class c WHERE c.oid = t.typrelid) = 'c');`
+
+	row := q.QueryRowContext(ctx, fmt.Sprintf(typeQuery, pq.QuoteLiteral(qualifiedName)))
+	err := row.Scan(&oid, &relid, &model.Schema, &model.Name, &model.Database, &kindCode, &model.Owner, &model.Comment)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "type")
+	}
+
+	kind, ok := typeKindCodes[kindCode]
+	if !ok {
+		return nil, PgErrWithMetadata(fmt.Errorf("unsupported kind of type '%s'", kindCode), "operation", opScanRowResult, "model", "type")
+	}
+	model.Kind = kind
+
+	switch kind {
+	case TypeKindEnum:
+		err = readTypeEnumValues(ctx, q, oid, &model)
+	case TypeKindComposite:
+		err = readTypeAttributes(ctx, q, relid, &model)
+	case TypeKindRange:
+		model.Range = &TypeRange{}
+		err = q.QueryRowContext(ctx, fmt.Sprintf(`
+			SELECT pg_catalog.format_type(r.rngsubtype, NULL),
+				   CASE WHEN o.opcdefault THEN '' ELSE o.opcname END,
+				   CASE WHEN r.rngsubdiff = 0 THEN '' ELSE r.rngsubdiff::regproc::text END
+			FROM pg_catalog.pg_range r
+					 JOIN pg_catalog.pg_opclass o ON o.oid = r.rngsubopc
+			WHERE r.rngtypid = %d;`, oid)).Scan(&model.Range.Subtype, &model.Range.SubtypeOpclass, &model.Range.SubtypeDiff)
+	case TypeKindDomain:
+		err = readTypeDomain(ctx, q, oid, &model)
+	}
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "type_"+kind)
+	}
+
+	return &model, nil
+}
+
+func readTypeEnumValues(ctx context.Context, q pgQueryer, oid int64, model *TypeModel) error {
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(`
+		SELECT e.enumlabel
+		FROM pg_catalog.pg_enum e
+		WHERE e.enumtypid = %d
+		ORDER BY e.enumsortorder;`, oid))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	model.EnumValues = []string{}
+	for rows.Next() {
+		var value string
+		if err = rows.Scan(&value); err != nil {
+			return err
+		}
+		model.EnumValues = append(model.EnumValues, value)
+	}
+	return rows.Err()
+}
+
+func readTypeAttributes(ctx context.Context, q pgQueryer, relid int64, model *TypeModel) error {
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(`
+		SELECT a.attname, pg_catalog.format_type(a.atttypid, a.atttypmod)
+		FROM pg_catalog.pg_attribute a
+		WHERE a.attrelid = %d
+		  AND a.attnum > 0
+		  AND NOT a.attisdropped
+		ORDER BY a.attnum;`, relid))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attribute TypeAttribute
+		if err = rows.Scan(&attribute.Name, &attribute.Type); err != nil {
+			return err
+		}
+		model.Attributes = append(model.Attributes, attribute)
+	}
+	return rows.Err()
+}
+
+func readTypeDomain(ctx context.Context, q pgQueryer, oid int64, model *TypeModel) error {
+	model.Domain = &TypeDomain{}
+	err := q.QueryRowContext(ctx, fmt.Sprintf(`
+		SELECT pg_catalog.format_type(t.typbasetype, t.typtypmod),
+			   COALESCE(pg_catalog.pg_get_expr(t.typdefaultbin, 0), ''),
+			   t.typnotnull
+		FROM pg_catalog.pg_type t
+		WHERE t.oid = %d;`, oid)).Scan(&model.Domain.BaseType, &model.Domain.Default, &model.Domain.NotNull)
+	if err != nil {
+		return err
+	}
+
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(`
+		SELECT c.conname, pg_catalog.pg_get_expr(c.conbin, 0)
+		FROM pg_catalog.pg_constraint c
+		WHERE c.contypid = %d
+		  AND c.contype = 'c'
+		ORDER BY c.conname;`, oid))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var check TypeCheckConstraint
+		if err = rows.Scan(&check.Name, &check.Expression); err != nil {
+			return err
+		}
+		model.Domain.CheckConstraints = append(model.Domain.CheckConstraints, check)
+	}
+	return rows.Err()
+}
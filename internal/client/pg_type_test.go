@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+const (
+	testTypeDb   = "test_type_db"
+	testTypeUser = "test_type_user"
+)
+
+func testPrepareTypeTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: testTypeDb,
+		Username: testTypeUser,
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func TestPlanTypeAlterations(t *testing.T) {
+	enum := TypeModel{Schema: "public", Name: "mood", Kind: TypeKindEnum, EnumValues: []string{"sad", "happy"}}
+
+	t.Run("enum values are added in place", func(t *testing.T) {
+		desired := enum
+		desired.EnumValues = []string{"angry", "sad", "ok", "happy", "euphoric", "ecstatic"}
+		statements, err := PlanTypeAlterations(enum, desired)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			`ALTER TYPE "public"."mood" ADD VALUE 'angry' BEFORE 'sad';`,
+			`ALTER TYPE "public"."mood" ADD VALUE 'ok' BEFORE 'happy';`,
+			`ALTER TYPE "public"."mood" ADD VALUE 'euphoric';`,
+			`ALTER TYPE "public"."mood" ADD VALUE 'ecstatic';`,
+		}, statements)
+	})
+
+	t.Run("enum values can't be removed or reordered", func(t *testing.T) {
+		desired := enum
+		desired.EnumValues = []string{"happy"}
+		_, err := PlanTypeAlterations(enum, desired)
+		assert.ErrorIs(t, err, errTypeEnumValueRemoved)
+
+		desired.EnumValues = []string{"happy", "sad"}
+		_, err = PlanTypeAlterations(enum, desired)
+		assert.ErrorIs(t, err, errTypeEnumValueReordered)
+	})
+
+	t.Run("composite attributes", func(t *testing.T) {
+		current := TypeModel{Schema: "public", Name: "pair", Kind: TypeKindComposite, Attributes: []TypeAttribute{
+			{Name: "a", Type: "integer"}, {Name: "b", Type: "text"}, {Name: "c", Type: "text"},
+		}}
+		desired := current
+		desired.Attributes = []TypeAttribute{{Name: "a", Type: "bigint"}, {Name: "c", Type: "text"}, {Name: "d", Type: "date"}}
+		statements, err := PlanTypeAlterations(current, desired)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			`ALTER TYPE "public"."pair" ALTER ATTRIBUTE "a" TYPE bigint;`,
+			`ALTER TYPE "public"."pair" DROP ATTRIBUTE "b";`,
+			`ALTER TYPE "public"."pair" ADD ATTRIBUTE "d" date;`,
+		}, statements)
+
+		desired.Attributes = []TypeAttribute{{Name: "d", Type: "date"}, {Name: "a", Type: "integer"}}
+		_, err = PlanTypeAlterations(current, desired)
+		assert.ErrorIs(t, err, ErrTypeRequiresReplace)
+	})
+
+	t.Run("domain constraints", func(t *testing.T) {
+		current := TypeModel{Schema: "public", Name: "positive", Kind: TypeKindDomain, Domain: &TypeDomain{
+			BaseType:         "integer",
+			CheckConstraints: []TypeCheckConstraint{{Name: "positive_check", Expression: "VALUE > 0"}},
+		}}
+		desired := current
+		desired.Domain = &TypeDomain{
+			BaseType:         "integer",
+			Default:          "1",
+			NotNull:          true,
+			CheckConstraints: []TypeCheckConstraint{{Name: "positive_check", Expression: "VALUE >= 1"}},
+		}
+		desired.Owner = "other_owner"
+		statements, err := PlanTypeAlterations(current, desired)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			`ALTER DOMAIN "public"."positive" DROP CONSTRAINT "positive_check";`,
+			`ALTER DOMAIN "public"."positive" SET DEFAULT 1;`,
+			`ALTER DOMAIN "public"."positive" SET NOT NULL;`,
+			`ALTER DOMAIN "public"."positive" ADD CONSTRAINT "positive_check" CHECK (VALUE >= 1);`,
+			`ALTER DOMAIN "public"."positive" OWNER TO "other_owner";`,
+		}, statements)
+
+		desired.Domain = &TypeDomain{BaseType: "bigint"}
+		_, err = PlanTypeAlterations(current, desired)
+		assert.ErrorIs(t, err, ErrTypeRequiresReplace)
+	})
+
+	t.Run("range and kind changes require replace", func(t *testing.T) {
+		current := TypeModel{Schema: "public", Name: "span", Kind: TypeKindRange, Range: &TypeRange{Subtype: "float8"}}
+		desired := current
+		desired.Range = &TypeRange{Subtype: "float8", SubtypeDiff: "float8mi"}
+		_, err := PlanTypeAlterations(current, desired)
+		assert.ErrorIs(t, err, ErrTypeRequiresReplace)
+
+		_, err = PlanTypeAlterations(current, enum)
+		assert.ErrorIs(t, err, ErrTypeRequiresReplace)
+	})
+}
+
+func TestTypeSQL_CreateAndGet(t *testing.T) {
+	ctx, db := testPrepareTypeTestCase(t)
+	defer db.Close()
+
+	typeRepo := NewTypeRepository(db)
+	models := []TypeModel{
+		{Schema: "public", Name: "test_enum", Kind: TypeKindEnum, EnumValues: []string{"a", "b"}, Comment: "enum"},
+		{Schema: "public", Name: "test_composite", Kind: TypeKindComposite, Attributes: []TypeAttribute{
+			{Name: "x", Type: "int4"}, {Name: "y", Type: "varchar(10)"},
+		}},
+		{Schema: "public", Name: "test_range", Kind: TypeKindRange, Range: &TypeRange{Subtype: "float8", SubtypeDiff: "float8mi"}},
+		{Schema: "public", Name: "test_domain", Kind: TypeKindDomain, Domain: &TypeDomain{
+			BaseType: "int4", Default: "1", NotNull: true,
+			CheckConstraints: []TypeCheckConstraint{{Name: "test_domain_check", Expression: "VALUE > 0"}},
+		}},
+	}
+	for _, model := range models {
+		assert.NoError(t, typeRepo.Create(ctx, model))
+	}
+
+	got, err := typeRepo.Get(ctx, "public", "test_enum")
+	assert.NoError(t, err)
+	assert.Equal(t, testTypeDb, got.Database)
+	assert.Equal(t, testTypeUser, got.Owner)
+	assert.Equal(t, []string{"a", "b"}, got.EnumValues)
+	assert.Equal(t, "enum", got.Comment)
+
+	got, err = typeRepo.Get(ctx, "public", "test_composite")
+	assert.NoError(t, err)
+	assert.Equal(t, []TypeAttribute{{Name: "x", Type: "integer"}, {Name: "y", Type: "character varying(10)"}}, got.Attributes)
+
+	got, err = typeRepo.Get(ctx, "public", "test_range")
+	assert.NoError(t, err)
+	assert.Equal(t, &TypeRange{Subtype: "double precision", SubtypeDiff: "float8mi"}, got.Range)
+
+	got, err = typeRepo.Get(ctx, "public", "test_domain")
+	assert.NoError(t, err)
+	assert.Equal(t, "integer", got.Domain.BaseType)
+	assert.Equal(t, "1", got.Domain.Default)
+	assert.True(t, got.Domain.NotNull)
+	assert.Equal(t, []TypeCheckConstraint{{Name: "test_domain_check", Expression: "(VALUE > 0)"}}, got.Domain.CheckConstraints)
+
+	normalized, err := typeRepo.Normalize(ctx, models[3])
+	assert.NoError(t, err)
+	preserved := PreserveTypeSpelling(models[3], *normalized, *got)
+	assert.Equal(t, models[3].Domain, preserved.Domain)
+
+	// a table's row type is not a composite type managed by the resource
+	_, err = db.ExecContext(ctx, `CREATE TABLE public.test_type_table (id int);`)
+	assert.NoError(t, err)
+	_, err = typeRepo.Get(ctx, "public", "test_type_table")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	for _, model := range models {
+		assert.NoError(t, typeRepo.Drop(ctx, model.Schema, model.Name, model.Kind))
+		exists, err := typeRepo.Exists(ctx, model.Schema, model.Name)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	}
+}
+
+func TestTypeSQL_Update(t *testing.T) {
+	ctx, db := testPrepareTypeTestCase(t)
+	defer db.Close()
+
+	typeRepo := NewTypeRepository(db)
+	current := TypeModel{Schema: "public", Name: "test_enum", Kind: TypeKindEnum, EnumValues: []string{"b", "d"}}
+	assert.NoError(t, typeRepo.Create(ctx, current))
+
+	desired := current
+	desired.Name = "test_enum_renamed"
+	desired.EnumValues = []string{"a", "b", "c", "d", "e"}
+	desired.Comment = "renamed"
+	got, err := typeRepo.Update(ctx, TypeUpdateParams{Current: current, Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, desired.Name, got.Name)
+	assert.Equal(t, desired.EnumValues, got.EnumValues)
+	assert.Equal(t, desired.Comment, got.Comment)
+
+	_, err = typeRepo.Update(ctx, TypeUpdateParams{Current: *got, Desired: current})
+	assert.ErrorIs(t, err, errTypeEnumValueRemoved)
+}
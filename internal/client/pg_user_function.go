@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/lib/pq"
 	"log/slog"
+	"strings"
 )
 
 const functionObjectType = "function"
@@ -15,15 +17,36 @@ type userFunctionSQL struct {
 
 type UserFunctionRepository interface {
 	Create(ctx context.Context, params UserFunctionCreateParams) error
+	CreateInTx(ctx context.Context, txn *sql.Tx, params UserFunctionCreateParams) error
+	DropInTx(ctx context.Context, txn *sql.Tx, schema, name string, argTypes ...string) error
+	Get(ctx context.Context, name string, argTypes ...string) (*UserFunctionModel, error)
+	Exists(ctx context.Context, name string, argTypes ...string) (bool, error)
+}
+
+type UserFunctionModel struct {
+	Name    string `json:"name"`
+	Schema  string `json:"schema"`
+	Args    string `json:"args"`
+	Returns string `json:"returns"`
+	Lang    string `json:"lang"`
+	Body    string `json:"body"`
+	Owner   string `json:"owner"`
+	// SecurityDefiner reports whether the function runs with the privileges of its owner.
+	SecurityDefiner bool `json:"security_definer"`
+	// Executable reports whether the current role (or the assumed one) holds the EXECUTE privilege.
+	Executable bool `json:"executable"`
 }
 
 type UserFunctionCreateParams struct {
-	Name    string            `validate:"required"`
-	Args    map[string]string `validate:"unique,dive,required"`
-	Returns string            `validate:"required"`
-	Lang    string            `validate:"required,oneof=plpgsql sql"`
-	Body    string            `validate:"required"`
-	Replace bool              `validate:"boolean"`
+	Name string `validate:"required"`
+	// Schema is the schema of the function, the first schema of the search_path when empty.
+	Schema          string
+	Args            map[string]string `validate:"unique,dive,required"`
+	Returns         string            `validate:"required"`
+	Lang            string            `validate:"required"`
+	Body            string            `validate:"required"`
+	SecurityDefiner bool              `validate:"boolean"`
+	Replace         bool              `validate:"boolean"`
 }
 
 var _ UserFunctionRepository = &userFunctionSQL{}
@@ -35,18 +58,33 @@ func NewUserFunctionRepository(db *sql.DB) UserFunctionRepository {
 }
 
 func (f userFunctionSQL) Create(ctx context.Context, params UserFunctionCreateParams) error {
-	validate := GetValidatorFromCtx(ctx)
-	if err := validate.Struct(params); err != nil {
-		return err
-	}
-
-	txn, err := f.db.BeginTx(ctx, nil)
+	txn, err := BeginTxWithRole(ctx, f.db)
 	if err != nil {
 		return WrapPgError(err, msgErrorStartingTransaction)
 	}
 	defer DeferredRollback(txn)
 
-	var orReplace, funcArgs string
+	if err = f.CreateInTx(ctx, txn, params); err != nil {
+		return err
+	}
+
+	if err = txn.Commit(); err != nil {
+		return WrapPgError(err, msgErrorCommittingTransaction)
+	}
+
+	slog.Info(fmt.Sprintf(msgSuccessCreatingObject, functionObjectType), "name", params.Name)
+	return nil
+}
+
+// CreateInTx creates the function in the transaction of another object, e.g. the event trigger
+// executing it, so that both are created together.
+func (f userFunctionSQL) CreateInTx(ctx context.Context, txn *sql.Tx, params UserFunctionCreateParams) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return err
+	}
+
+	var orReplace, funcArgs, security string
 
 	if len(params.Args) > 0 {
 		funcArgs = pgMapToFuncArg(params.Args)
@@ -56,35 +94,243 @@ func (f userFunctionSQL) Create(ctx context.Context, params UserFunctionCreatePa
 		orReplace = "OR REPLACE"
 	}
 
+	if params.SecurityDefiner {
+		security = "SECURITY DEFINER"
+	}
+
 	createQuery := `
 		CREATE %s FUNCTION %s(%s)
 		RETURNS %s
 		LANGUAGE %s
-		AS $$
 		%s
-		$$;`
+		AS %s;`
 
-	result, err := txn.ExecContext(ctx, fmt.Sprintf(createQuery,
+	_, err := txn.ExecContext(ctx, fmt.Sprintf(createQuery,
 		orReplace,
-		params.Name,
+		userFunctionName(params.Schema, params.Name),
 		funcArgs,
 		params.Returns,
 		params.Lang,
-		params.Body,
+		security,
+		pgDollarQuote(params.Body),
 	))
 	if err != nil {
 		return WrapPgError(err, fmt.Sprintf(msgErrorCreatingObject, functionObjectType))
 	}
+	return nil
+}
 
-	rowsAffected, err := result.RowsAffected()
+// DropInTx drops the function in the transaction of another object, e.g. the event trigger executing it.
+func (f userFunctionSQL) DropInTx(ctx context.Context, txn *sql.Tx, schema, name string, argTypes ...string) error {
+	err := DropObject(ctx, txn, "FUNCTION", routineName(schema, name, strings.Join(argTypes, ", ")))
 	if err != nil {
-		return WrapPgError(err, msgErrorExecContextGetRowsAffected)
+		return PgErrWithMetadata(err, "operation", opDropUserFunction)
 	}
+	return nil
+}
 
-	if err = txn.Commit(); err != nil {
-		return WrapPgError(err, msgErrorCommittingTransaction)
+// Get looks up a function by its (optionally schema qualified) name and argument types,
+// resolving it the same way PostgreSQL does, including the search_path.
+func (f userFunctionSQL) Get(ctx context.Context, name string, argTypes ...string) (*UserFunctionModel, error) {
+	readQuery := `
+		SELECT p.proname                                         as "name",
+			   n.nspname                                         as "schema",
+			   pg_catalog.pg_get_function_identity_arguments(p.oid) as "args",
+			   pg_catalog.pg_get_function_result(p.oid)           as "returns",
+			   l.lanname                                         as "lang",
+			   p.prosrc                                          as "body",
+			   pg_catalog.pg_get_userbyid(p.proowner)            as "owner",
+			   p.prosecdef                                       as "security_definer",
+			   pg_catalog.has_function_privilege(%s, p.oid, 'EXECUTE') as "executable"
+		FROM pg_catalog.pg_proc p
+				 JOIN pg_catalog.pg_namespace n ON n.oid = p.pronamespace
+				 JOIN pg_catalog.pg_language l ON l.oid = p.prolang
+		WHERE p.oid = pg_catalog.to_regprocedure(%s);`
+
+	privilegeRole := "current_user"
+	if role := GetAssumeRoleFromCtx(ctx); role != "" {
+		privilegeRole = pq.QuoteLiteral(role)
 	}
+	signature := fmt.Sprintf("%s(%s)", name, strings.Join(argTypes, ", "))
 
-	slog.Info(fmt.Sprintf(msgSuccessCreatingObject, functionObjectType), "rows_affected", rowsAffected)
-	return nil
+	var model UserFunctionModel
+	row := f.db.QueryRowContext(ctx, fmt.Sprintf(readQuery, privilegeRole, pq.QuoteLiteral(signature)))
+	err := row.Scan(
+		&model.Name,
+		&model.Schema,
+		&model.Args,
+		&model.Returns,
+		&model.Lang,
+		&model.Body,
+		&model.Owner,
+		&model.SecurityDefiner,
+		&model.Executable,
+	)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetUserFunction, "pg_cmd", opScanRowResult)
+	}
+
+	return &model, nil
+}
+
+func (f userFunctionSQL) Exists(ctx context.Context, name string, argTypes ...string) (bool, error) {
+	existsQuery := `SELECT pg_catalog.to_regprocedure(%s) IS NOT NULL;`
+
+	signature := fmt.Sprintf("%s(%s)", name, strings.Join(argTypes, ", "))
+
+	var exists bool
+	row := f.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(signature)))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsUserFunction, "pg_cmd", opQueryRow)
+	}
+
+	return exists, nil
+}
+
+// userFunctionName returns the name of a function in a CREATE statement: quoted when it is schema
+// qualified, as written otherwise, e.g. a name resolved through the search_path.
+func userFunctionName(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+	return pgQualifiedName(schema, name)
+}
+
+// RoutineArg is an argument of a procedure or an aggregate, in declaration order.
+type RoutineArg struct {
+	Name string `json:"name"`
+	Type string `json:"type" validate:"required"`
+	// Mode is one of IN, OUT, INOUT or VARIADIC, empty meaning IN.
+	Mode string `json:"mode" validate:"omitempty,oneof=IN OUT INOUT VARIADIC"`
+}
+
+// routineArgModeCodes maps the pg_proc.proargmodes codes to the argument modes.
+var routineArgModeCodes = map[string]string{
+	"i": "IN",
+	"o": "OUT",
+	"b": "INOUT",
+	"v": "VARIADIC",
+}
+
+// routineArgsDefinition returns the argument list of a CREATE statement, e.g. 'INOUT "total" integer'.
+func routineArgsDefinition(args []RoutineArg) string {
+	definitions := make([]string, len(args))
+	for i, arg := range args {
+		var parts []string
+		if arg.Mode != "" && arg.Mode != "IN" {
+			parts = append(parts, arg.Mode)
+		}
+		if arg.Name != "" {
+			parts = append(parts, pq.QuoteIdentifier(arg.Name))
+		}
+		definitions[i] = strings.Join(append(parts, arg.Type), " ")
+	}
+	return strings.Join(definitions, ", ")
+}
+
+// RoutineArgTypes returns the types identifying a procedure or an aggregate. Since PostgreSQL 14
+// the OUT arguments are part of the signature of a procedure, and they can't be used before.
+func RoutineArgTypes(args []RoutineArg) []string {
+	types := make([]string, len(args))
+	for i, arg := range args {
+		types[i] = arg.Type
+	}
+	return types
+}
+
+// routineOid returns the SQL expression resolving the oid of the routine with the given argument types.
+func routineOid(schema, name string, argTypes []string) string {
+	signature := fmt.Sprintf("%s(%s)", pgQualifiedName(schema, name), strings.Join(argTypes, ", "))
+	return fmt.Sprintf("pg_catalog.to_regprocedure(%s)", pq.QuoteLiteral(signature))
+}
+
+// routineIdentityArgs returns the identity arguments of the routine whose oid is returned by the
+// SQL expression, as ALTER and DROP statements expect them, e.g. 'total integer, OUT result text'.
+func routineIdentityArgs(ctx context.Context, q pgQueryer, oid string) (string, error) {
+	identityQuery := `
+		SELECT pg_catalog.pg_get_function_identity_arguments(p.oid)
+		FROM pg_catalog.pg_proc p
+		WHERE p.oid = %s;`
+
+	var identityArgs string
+	if err := q.QueryRowContext(ctx, fmt.Sprintf(identityQuery, oid)).Scan(&identityArgs); err != nil {
+		return "", PgErrWithMetadata(err, "pg_cmd", opQueryRow)
+	}
+	return identityArgs, nil
+}
+
+// routineName returns the quoted name and identity arguments of a routine.
+func routineName(schema, name, identityArgs string) string {
+	return fmt.Sprintf("%s(%s)", pgQualifiedName(schema, name), identityArgs)
+}
+
+// routineRenameStatements returns the statements renaming a routine and moving it to another schema.
+func routineRenameStatements(objectType, identityArgs, currentSchema, currentName, desiredSchema, desiredName string) []string {
+	var statements []string
+	if currentName != desiredName {
+		statements = append(statements, fmt.Sprintf("ALTER %s %s RENAME TO %s;",
+			objectType, routineName(currentSchema, currentName, identityArgs), pq.QuoteIdentifier(desiredName)))
+	}
+	if currentSchema != desiredSchema {
+		statements = append(statements, fmt.Sprintf("ALTER %s %s SET SCHEMA %s;",
+			objectType, routineName(currentSchema, desiredName, identityArgs), pq.QuoteIdentifier(desiredSchema)))
+	}
+	return statements
+}
+
+// readRoutineArgs reads the arguments of the routine whose oid is returned by the SQL expression.
+func readRoutineArgs(ctx context.Context, q pgQueryer, oid string) ([]RoutineArg, error) {
+	argsQuery := `
+		SELECT COALESCE(p.proargnames[a.ord], '')     as "name",
+			   pg_catalog.format_type(a.type, NULL)   as "type",
+			   COALESCE(p.proargmodes[a.ord]::text, 'i') as "mode"
+		FROM pg_catalog.pg_proc p,
+			 pg_catalog.unnest(COALESCE(p.proallargtypes, p.proargtypes::oid[])) WITH ORDINALITY a(type, ord)
+		WHERE p.oid = %s
+		ORDER BY a.ord;`
+
+	rows, err := q.QueryContext(ctx, fmt.Sprintf(argsQuery, oid))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery, "model", "routine_arg")
+	}
+	defer rows.Close()
+
+	var args []RoutineArg
+	for rows.Next() {
+		var arg RoutineArg
+		if err = rows.Scan(&arg.Name, &arg.Type, &arg.Mode); err != nil {
+			return nil, PgErrWithMetadata(err, "pg_cmd", opScanRowResult, "model", "routine_arg")
+		}
+		arg.Mode = routineArgModeCodes[arg.Mode]
+		args = append(args, arg)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opQuery, "model", "routine_arg")
+	}
+	return args, nil
+}
+
+// PreserveRoutineArgsSpelling returns the actual arguments, but keeping the spelling of the prior
+// types whose normalized form matches the actual one, e.g. 'int' instead of 'integer'.
+func PreserveRoutineArgsSpelling(prior, normalizedPrior, actual []RoutineArg) []RoutineArg {
+	if len(actual) == 0 {
+		return nil
+	}
+	result := make([]RoutineArg, len(actual))
+	for i, arg := range actual {
+		result[i] = arg
+		if i < len(prior) && i < len(normalizedPrior) && normalizedPrior[i].Type == arg.Type {
+			result[i].Type = prior[i].Type
+		}
+	}
+	return result
+}
+
+// pgDollarQuote quotes a routine body with a dollar quote tag that doesn't appear in the body.
+func pgDollarQuote(body string) string {
+	tag := "$body$"
+	for i := 1; strings.Contains(body, tag); i++ {
+		tag = fmt.Sprintf("$body%d$", i)
+	}
+	return tag + body + tag
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"gocloud.dev/postgres"
@@ -81,3 +82,99 @@ func TestUserFunctionSQL_Create(t *testing.T) {
 		})
 	}
 }
+
+func TestUserFunctionSQL_Get(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_user_function",
+		Username: "test_user_function_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	repo := NewUserFunctionRepository(db)
+	createParams := mockUserFunctionCreateParams(t)
+	assert.NoError(t, repo.Create(ctx, createParams))
+
+	tests := []struct {
+		name     string
+		funcName string
+		argTypes []string
+		result   *UserFunctionModel
+		wantErr  error
+	}{
+		{
+			name:     "Success",
+			funcName: createParams.Name,
+			argTypes: []string{"TEXT"},
+			result: &UserFunctionModel{
+				Name:       createParams.Name,
+				Schema:     "public",
+				Args:       "arg1 text",
+				Returns:    "text",
+				Lang:       createParams.Lang,
+				Owner:      runOpts.Username,
+				Executable: true,
+			},
+		},
+		{
+			name:     "SuccessSchemaQualified",
+			funcName: "public." + createParams.Name,
+			argTypes: []string{"TEXT"},
+		},
+		{
+			name:     "FailWrongArgTypes",
+			funcName: createParams.Name,
+			wantErr:  sql.ErrNoRows,
+		},
+		{
+			name:     "FailNotFound",
+			funcName: "test_missing_function",
+			wantErr:  sql.ErrNoRows,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := repo.Get(ctx, tt.funcName, tt.argTypes...)
+			exists, existsErr := repo.Exists(ctx, tt.funcName, tt.argTypes...)
+			assert.NoError(t, existsErr)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.False(t, exists)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, exists)
+			if tt.result != nil {
+				// prosrc keeps the whitespace surrounding the body
+				assert.Contains(t, m.Body, createParams.Body)
+				tt.result.Body = m.Body
+				assert.Equal(t, tt.result, m)
+			}
+		})
+	}
+}
+
+func TestRoutineArgsDefinition(t *testing.T) {
+	args := []RoutineArg{
+		{Name: "target", Type: "text"},
+		{Name: "total", Type: "int", Mode: "INOUT"},
+		{Type: "numeric", Mode: "IN"},
+		{Name: "extra", Type: "text[]", Mode: "VARIADIC"},
+	}
+	assert.Equal(t, `"target" text, INOUT "total" int, numeric, VARIADIC "extra" text[]`, routineArgsDefinition(args))
+	assert.Equal(t, []string{"text", "int", "numeric", "text[]"}, RoutineArgTypes(args))
+	assert.Empty(t, routineArgsDefinition(nil))
+}
+
+func TestPgDollarQuote(t *testing.T) {
+	assert.Equal(t, `$body$BEGIN NULL; END;$body$`, pgDollarQuote("BEGIN NULL; END;"))
+	assert.Equal(t, `$body1$SELECT '$body$';$body1$`, pgDollarQuote("SELECT '$body$';"))
+}
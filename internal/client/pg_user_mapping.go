@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// UserMappingPublic is the user of the mappings applying to every role without a mapping of its own.
+const UserMappingPublic = "public"
+
+type userMappingSQL struct {
+	db *sql.DB
+}
+
+// UserMappingModel describes a user mapping, the credentials a role uses on a foreign server.
+type UserMappingModel struct {
+	Server   string `json:"server" validate:"required"`
+	User     string `json:"user" validate:"required"`
+	Database string `json:"database"`
+	// Options are the wrapper specific options of the mapping, e.g. user and password for postgres_fdw.
+	// They're only visible to the owner of the server, the mapped role and the superusers, a nil map
+	// is read back otherwise.
+	Options map[string]string `json:"options"`
+}
+
+type UserMappingUpdateParams struct {
+	Current UserMappingModel
+	Desired UserMappingModel `validate:"required"`
+}
+
+type UserMappingRepository interface {
+	Create(ctx context.Context, params UserMappingModel) error
+	Drop(ctx context.Context, server, user string) error
+	Get(ctx context.Context, server, user string) (*UserMappingModel, error)
+	Update(ctx context.Context, params UserMappingUpdateParams) (*UserMappingModel, error)
+	Exists(ctx context.Context, server, user string) (bool, error)
+}
+
+var _ UserMappingRepository = &userMappingSQL{}
+
+func NewUserMappingRepository(db *sql.DB) UserMappingRepository {
+	return &userMappingSQL{
+		db: db,
+	}
+}
+
+func (u *userMappingSQL) Create(ctx context.Context, params UserMappingModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, u.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateUserMapping, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	createQuery := fmt.Sprintf("CREATE USER MAPPING FOR %s SERVER %s", userMappingUser(params.User), pq.QuoteIdentifier(params.Server))
+	if options := pgOptionsClause(params.Options); options != "" {
+		createQuery += " " + options
+	}
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, createQuery+";")); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateUserMapping)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateUserMapping, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (u *userMappingSQL) Drop(ctx context.Context, server, user string) error {
+	txn, err := BeginTxWithRole(ctx, u.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropUserMapping, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	dropQuery := fmt.Sprintf("DROP USER MAPPING IF EXISTS FOR %s SERVER %s;", userMappingUser(user), pq.QuoteIdentifier(server))
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, dropQuery)); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropUserMapping)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropUserMapping, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (u *userMappingSQL) Get(ctx context.Context, server, user string) (*UserMappingModel, error) {
+	// pg_user_mappings hides the options from the roles that are not allowed to see them
+	readQuery := `
+		SELECT m.srvname                     as "server",
+			   m.usename                     as "user",
+			   pg_catalog.current_database() as "database",
+			   m.umoptions                   as "options"
+		FROM pg_catalog.pg_user_mappings m
+		WHERE m.srvname = %s
+		  AND m.usename = %s;`
+
+	var model UserMappingModel
+	var options []string
+	row := u.db.QueryRowContext(ctx, fmt.Sprintf(readQuery, pq.QuoteLiteral(server), pq.QuoteLiteral(userMappingUserName(user))))
+	err := row.Scan(&model.Server, &model.User, &model.Database, (*pq.StringArray)(&options))
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetUserMapping, "pg_cmd", opScanRowResult)
+	}
+
+	model.Options = parseRelOptions(options)
+	return &model, nil
+}
+
+// Update changes the options of the mapping, added, set and dropped one by one.
+func (u *userMappingSQL) Update(ctx context.Context, params UserMappingUpdateParams) (*UserMappingModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, u.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateUserMapping, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	desired := params.Desired
+	if options := pgOptionsAlterClause(params.Current.Options, desired.Options); options != "" {
+		alterQuery := fmt.Sprintf("ALTER USER MAPPING FOR %s SERVER %s %s;", userMappingUser(desired.User), pq.QuoteIdentifier(desired.Server), options)
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, alterQuery)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateUserMapping)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateUserMapping, "pg_cmd", opCommitTransaction)
+	}
+
+	return u.Get(ctx, desired.Server, desired.User)
+}
+
+func (u *userMappingSQL) Exists(ctx context.Context, server, user string) (bool, error) {
+	existsQuery := `
+		SELECT EXISTS (SELECT 1
+					   FROM pg_catalog.pg_user_mappings m
+					   WHERE m.srvname = %s
+						 AND m.usename = %s);`
+
+	var exists bool
+	row := u.db.QueryRowContext(ctx, fmt.Sprintf(existsQuery, pq.QuoteLiteral(server), pq.QuoteLiteral(userMappingUserName(user))))
+	if err := row.Scan(&exists); err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsUserMapping, "pg_cmd", opQueryRow)
+	}
+	return exists, nil
+}
+
+// userMappingUser returns the user of a mapping as written in the statements, the PUBLIC keyword
+// or a quoted role name.
+func userMappingUser(user string) string {
+	if strings.EqualFold(user, UserMappingPublic) {
+		return "PUBLIC"
+	}
+	return pq.QuoteIdentifier(user)
+}
+
+// userMappingUserName returns the user of a mapping as named by pg_user_mappings.
+func userMappingUserName(user string) string {
+	if strings.EqualFold(user, UserMappingPublic) {
+		return UserMappingPublic
+	}
+	return user
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestUserMappingUser(t *testing.T) {
+	assert.Equal(t, "PUBLIC", userMappingUser("public"))
+	assert.Equal(t, "PUBLIC", userMappingUser("PUBLIC"))
+	assert.Equal(t, `"app_user"`, userMappingUser("app_user"))
+	assert.Equal(t, "public", userMappingUserName("PUBLIC"))
+}
+
+func TestUserMappingSQL_CreateGetAndUpdate(t *testing.T) {
+	ctx, db := testPrepareForeignServerTestCase(t)
+	defer db.Close()
+
+	require.NoError(t, NewForeignServerRepository(db).Create(ctx, mockForeignServerModel(t)))
+
+	repo := NewUserMappingRepository(db)
+	model := UserMappingModel{Server: "remote", User: "test_foreign_reader", Options: map[string]string{"user": testRemoteUser}}
+	require.NoError(t, repo.Create(ctx, model))
+	require.NoError(t, repo.Create(ctx, UserMappingModel{Server: "remote", User: "PUBLIC"}))
+
+	got, err := repo.Get(ctx, "remote", "test_foreign_reader")
+	assert.NoError(t, err)
+	assert.Equal(t, testForeignServerDb, got.Database)
+	assert.Equal(t, model.Options, got.Options)
+
+	got, err = repo.Get(ctx, "remote", "public")
+	assert.NoError(t, err)
+	assert.Equal(t, "public", got.User)
+	assert.Nil(t, got.Options)
+
+	desired := model
+	desired.Options = map[string]string{"user": testRemoteUser, "password": testRemotePassword}
+	got, err = repo.Update(ctx, UserMappingUpdateParams{Current: model, Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, desired.Options, got.Options)
+
+	assert.NoError(t, repo.Drop(ctx, "remote", "test_foreign_reader"))
+	assert.NoError(t, repo.Drop(ctx, "remote", "public"))
+	exists, err := repo.Exists(ctx, "remote", "public")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
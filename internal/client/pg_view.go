@@ -0,0 +1,381 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/lib/pq"
+	"slices"
+	"strings"
+)
+
+const viewObjectType = "VIEW"
+
+type viewSQL struct {
+	db *sql.DB
+}
+
+type ViewModel struct {
+	Schema          string   `json:"schema" validate:"required"`
+	Name            string   `json:"name" validate:"required"`
+	Database        string   `json:"database"`
+	Query           string   `json:"query" validate:"required"`
+	Columns         []string `json:"columns" validate:"unique"`
+	Owner           string   `json:"owner"`
+	SecurityBarrier bool     `json:"security_barrier"`
+	SecurityInvoker bool     `json:"security_invoker"`
+	CheckOption     string   `json:"check_option" validate:"omitempty,oneof=LOCAL CASCADED"`
+	Comment         string   `json:"comment"`
+}
+
+type ViewUpdateParams struct {
+	Current ViewModel
+	Desired ViewModel `validate:"required"`
+}
+
+type ViewRepository interface {
+	Create(ctx context.Context, params ViewModel) error
+	Drop(ctx context.Context, schema, name string) error
+	Get(ctx context.Context, schema, name string) (*ViewModel, error)
+	Update(ctx context.Context, params ViewUpdateParams) (*ViewModel, error)
+	Exists(ctx context.Context, schema, name string) (bool, error)
+	Normalize(ctx context.Context, model ViewModel) (*ViewModel, error)
+	// RequiresReplace reports whether the query and columns of the desired view can't be applied
+	// to the current one with CREATE OR REPLACE VIEW, see viewColumnsExtend.
+	RequiresReplace(ctx context.Context, current, desired ViewModel) (bool, error)
+}
+
+var _ ViewRepository = &viewSQL{}
+
+// pgView holds the attributes shared by views and materialized views.
+type pgView struct {
+	Schema   string
+	Name     string
+	Database string
+	Query    string
+	Columns  []string
+	// ColumnTypes holds the type and collation of each column, only meant to be compared.
+	ColumnTypes []string
+	Owner       string
+	Options     map[string]string
+	Comment     string
+	Populated   bool
+}
+
+func NewViewRepository(db *sql.DB) ViewRepository {
+	return &viewSQL{
+		db: db,
+	}
+}
+
+func (v *viewSQL) Create(ctx context.Context, params ViewModel) error {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	txn, err := BeginTxWithRole(ctx, v.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateView, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	name := pgQualifiedName(params.Schema, params.Name)
+	statements := []string{viewCreateQuery(params, false)}
+	if params.Owner != "" && params.Owner != GetAssumeRoleFromCtx(ctx) {
+		statements = append(statements, fmt.Sprintf("ALTER VIEW %s OWNER TO %s;", name, pq.QuoteIdentifier(params.Owner)))
+	}
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateView)
+		}
+	}
+
+	if params.Comment != "" {
+		if err = CreateComment(ctx, txn, viewObjectType, name, params.Comment); err != nil {
+			return PgErrWithMetadata(err, "operation", opCreateView)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opCreateView, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (v *viewSQL) Drop(ctx context.Context, schema, name string) error {
+	txn, err := BeginTxWithRole(ctx, v.db)
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropView, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	err = DropObject(ctx, txn, viewObjectType, pgQualifiedName(schema, name))
+	if err != nil {
+		return PgErrWithMetadata(err, "operation", opDropView)
+	}
+
+	if err = txn.Commit(); err != nil {
+		return PgErrWithMetadata(err, "operation", opDropView, "pg_cmd", opCommitTransaction)
+	}
+	return nil
+}
+
+func (v *viewSQL) Get(ctx context.Context, schema, name string) (*ViewModel, error) {
+	view, err := readView(ctx, v.db, pgQualifiedName(schema, name), relKindView)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opGetView)
+	}
+
+	return &ViewModel{
+		Schema:          view.Schema,
+		Name:            view.Name,
+		Database:        view.Database,
+		Query:           view.Query,
+		Columns:         view.Columns,
+		Owner:           view.Owner,
+		SecurityBarrier: view.Options["security_barrier"] == "true",
+		SecurityInvoker: view.Options["security_invoker"] == "true",
+		CheckOption:     strings.ToUpper(view.Options["check_option"]),
+		Comment:         view.Comment,
+	}, nil
+}
+
+// Update applies the changes in place. The query, columns and options are replaced with
+// CREATE OR REPLACE VIEW, which only accepts queries that keep the existing columns. Otherwise
+// the view is dropped and created again in the same transaction, losing its privileges.
+func (v *viewSQL) Update(ctx context.Context, params ViewUpdateParams) (*ViewModel, error) {
+	validate := GetValidatorFromCtx(ctx)
+	if err := validate.Struct(params); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opStructValidation)
+	}
+
+	current, desired := params.Current, params.Desired
+
+	statements := relationRenameStatements(viewObjectType, current.Schema, current.Name, desired.Schema, desired.Name)
+	name := pgQualifiedName(desired.Schema, desired.Name)
+
+	recreate := false
+	if current.Query != desired.Query || !slices.Equal(current.Columns, desired.Columns) {
+		var err error
+		if recreate, err = v.RequiresReplace(ctx, current, desired); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateView)
+		}
+	}
+
+	switch {
+	case recreate:
+		statements = append(statements, fmt.Sprintf("DROP VIEW %s;", name), viewCreateQuery(desired, false))
+	case current.Query != desired.Query || !slices.Equal(current.Columns, desired.Columns) ||
+		current.SecurityBarrier != desired.SecurityBarrier || current.SecurityInvoker != desired.SecurityInvoker ||
+		current.CheckOption != desired.CheckOption:
+		statements = append(statements, viewCreateQuery(desired, true))
+	}
+	if desired.Owner != "" && (recreate || current.Owner != desired.Owner) {
+		statements = append(statements, fmt.Sprintf("ALTER VIEW %s OWNER TO %s;", name, pq.QuoteIdentifier(desired.Owner)))
+	}
+	if (recreate && desired.Comment != "") || (!recreate && current.Comment != desired.Comment) {
+		statements = append(statements, fmt.Sprintf("COMMENT ON VIEW %s IS %s;", name, pq.QuoteLiteral(desired.Comment)))
+	}
+
+	txn, err := BeginTxWithRole(ctx, v.db)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateView, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	for _, statement := range statements {
+		if err = WithQueryExecHandler(txn.ExecContext(ctx, statement)); err != nil {
+			return nil, PgErrWithMetadata(err, "operation", opUpdateView)
+		}
+	}
+
+	if err = txn.Commit(); err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opUpdateView, "pg_cmd", opCommitTransaction)
+	}
+
+	return v.Get(ctx, desired.Schema, desired.Name)
+}
+
+func (v *viewSQL) Exists(ctx context.Context, schema, name string) (bool, error) {
+	exists, err := relationExists(ctx, v.db, pgQualifiedName(schema, name), relKindView)
+	if err != nil {
+		return false, PgErrWithMetadata(err, "operation", opExistsView)
+	}
+	return exists, nil
+}
+
+// Normalize returns the view with the query and columns as PostgreSQL would store them.
+func (v *viewSQL) Normalize(ctx context.Context, model ViewModel) (*ViewModel, error) {
+	query, columns, err := normalizeViewQuery(ctx, v.db, model.Query, model.Columns)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opNormalizeView)
+	}
+
+	normalized := model
+	normalized.Query = query
+	normalized.Columns = columns
+	return &normalized, nil
+}
+
+func (v *viewSQL) RequiresReplace(ctx context.Context, current, desired ViewModel) (bool, error) {
+	actual, err := readView(ctx, v.db, pgQualifiedName(current.Schema, current.Name), relKindView)
+	if err != nil {
+		return false, PgErrWithMetadata(err, "operation", opGetView)
+	}
+
+	normalized, err := normalizeView(ctx, v.db, desired.Query, desired.Columns)
+	if err != nil {
+		return false, PgErrWithMetadata(err, "operation", opNormalizeView)
+	}
+	return !viewColumnsExtend(actual, normalized), nil
+}
+
+// PreserveViewSpelling returns the actual view, but keeping the query and columns of the prior
+// definition when their normalized form (see ViewRepository.Normalize) matches the actual one.
+func PreserveViewSpelling(prior, normalizedPrior, actual ViewModel) ViewModel {
+	result := actual
+	result.Query, result.Columns = preserveViewQuery(
+		prior.Query, prior.Columns,
+		normalizedPrior.Query, normalizedPrior.Columns,
+		actual.Query, actual.Columns,
+	)
+	return result
+}
+
+func viewCreateQuery(model ViewModel, replace bool) string {
+	var options []string
+	if model.SecurityBarrier {
+		options = append(options, "security_barrier = true")
+	}
+	if model.SecurityInvoker {
+		options = append(options, "security_invoker = true")
+	}
+	if model.CheckOption != "" {
+		options = append(options, fmt.Sprintf("check_option = %s", strings.ToLower(model.CheckOption)))
+	}
+
+	var query strings.Builder
+	query.WriteString("CREATE ")
+	if replace {
+		query.WriteString("OR REPLACE ")
+	}
+	fmt.Fprintf(&query, "VIEW %s", pgQualifiedName(model.Schema, model.Name))
+	if len(model.Columns) > 0 {
+		fmt.Fprintf(&query, " (%s)", pgQuoteListOfIdentifiers(model.Columns))
+	}
+	if len(options) > 0 {
+		fmt.Fprintf(&query, " WITH (%s)", strings.Join(options, ", "))
+	}
+	fmt.Fprintf(&query, " AS %s;", strings.TrimRight(strings.TrimSpace(model.Query), ";"))
+
+	return query.String()
+}
+
+// normalizeViewQuery returns the query and columns as stored by PostgreSQL, by creating a temporary
+// view in a transaction that is always rolled back. An empty list of columns stays empty.
+func normalizeViewQuery(ctx context.Context, db *sql.DB, query string, columns []string) (string, []string, error) {
+	view, err := normalizeView(ctx, db, query, columns)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(columns) == 0 {
+		return view.Query, nil, nil
+	}
+	return view.Query, view.Columns, nil
+}
+
+// normalizeView returns the view as stored by PostgreSQL, by creating a temporary view in a
+// transaction that is always rolled back.
+func normalizeView(ctx context.Context, db *sql.DB, query string, columns []string) (*pgView, error) {
+	txn, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "pg_cmd", opStartTransaction)
+	}
+	defer DeferredRollback(txn)
+
+	temporary := ViewModel{Schema: "pg_temp", Name: "tf_normalize_view", Query: query, Columns: columns}
+	if err = WithQueryExecHandler(txn.ExecContext(ctx, viewCreateQuery(temporary, false))); err != nil {
+		return nil, err
+	}
+	return readView(ctx, txn, pgQualifiedName(temporary.Schema, temporary.Name), relKindView)
+}
+
+// viewColumnsExtend reports whether the columns of the desired view start with the current ones,
+// with the same names, types and collations, which is what CREATE OR REPLACE VIEW requires.
+func viewColumnsExtend(current, desired *pgView) bool {
+	if len(desired.Columns) < len(current.Columns) || len(desired.ColumnTypes) < len(current.ColumnTypes) {
+		return false
+	}
+	return slices.Equal(current.Columns, desired.Columns[:len(current.Columns)]) &&
+		slices.Equal(current.ColumnTypes, desired.ColumnTypes[:len(current.ColumnTypes)])
+}
+
+// preserveViewQuery keeps the prior query and columns when their normalized form matches the actual one.
+// Prior columns that were left empty, i.e. derived from the query, stay empty.
+func preserveViewQuery(priorQuery string, priorColumns []string, normQuery string, normColumns []string, actualQuery string, actualColumns []string) (string, []string) {
+	query, columns := actualQuery, actualColumns
+	if normQuery == actualQuery {
+		query = priorQuery
+		if len(priorColumns) == 0 {
+			columns = nil
+		}
+	}
+	if len(normColumns) > 0 && slices.Equal(normColumns, actualColumns) {
+		columns = priorColumns
+	}
+	return query, columns
+}
+
+// readView reads the view or materialized view with the given qualified name.
+func readView(ctx context.Context, q pgQueryer, qualifiedName, relKind string) (*pgView, error) {
+	var view pgView
+	var options []string
+
+	viewQuery := `
+		SELECT n.nspname                                                      as "schema",
+			   c.relname                                                      as "name",
+			   pg_catalog.current_database()                                  as "database",
+			   pg_catalog.pg_get_viewdef(c.oid, true)                         as "query",
+			   ARRAY(SELECT a.attname
+					 FROM pg_catalog.pg_attribute a
+					 WHERE a.attrelid = c.oid
+					   AND a.attnum > 0
+					   AND NOT a.attisdropped
+					 ORDER BY a.attnum)                                       as "columns",
+			   ARRAY(SELECT pg_catalog.format_type(a.atttypid, a.atttypmod) || ' ' || a.attcollation
+					 FROM pg_catalog.pg_attribute a
+					 WHERE a.attrelid = c.oid
+					   AND a.attnum > 0
+					   AND NOT a.attisdropped
+					 ORDER BY a.attnum)                                       as "column_types",
+			   pg_catalog.pg_get_userbyid(c.relowner)                         as "owner",
+			   COALESCE(c.reloptions, '{}')                                   as "options",
+			   COALESCE(pg_catalog.obj_description(c.oid, 'pg_class'), '')    as "comment",
+			   c.relispopulated                                               as "populated"
+		FROM pg_catalog.pg_class c
+				 JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.oid = pg_catalog.to_regclass(%s)
+		  AND c.relkind = %s;`
+
+	row := q.QueryRowContext(ctx, fmt.Sprintf(viewQuery, pq.QuoteLiteral(qualifiedName), pq.QuoteLiteral(relKind)))
+	err := row.Scan(
+		&view.Schema,
+		&view.Name,
+		&view.Database,
+		&view.Query,
+		(*pq.StringArray)(&view.Columns),
+		(*pq.StringArray)(&view.ColumnTypes),
+		&view.Owner,
+		(*pq.StringArray)(&options),
+		&view.Comment,
+		&view.Populated,
+	)
+	if err != nil {
+		return nil, PgErrWithMetadata(err, "operation", opScanRowResult, "model", "view")
+	}
+	view.Query = strings.TrimSpace(view.Query)
+	view.Options = parseRelOptions(options)
+
+	return &view, nil
+}
@@ -0,0 +1,214 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+const (
+	testViewDb   = "test_view_db"
+	testViewUser = "test_view_user"
+)
+
+func testPrepareViewTestCase(t *testing.T) (context.Context, *sql.DB) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: testViewDb,
+		Username: testViewUser,
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, false)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE public.test_view_table (id int, email text, active bool);
+		INSERT INTO public.test_view_table VALUES (1, 'a@example.com', true), (2, 'b@example.com', false);`)
+	assert.NoError(t, err)
+	return ctx, db
+}
+
+func mockViewModel(t *testing.T) ViewModel {
+	t.Helper()
+	return ViewModel{
+		Schema:          "public",
+		Name:            "test_view",
+		Query:           "select id, email from test_view_table where active;",
+		Columns:         []string{"user_id", "user_email"},
+		SecurityBarrier: true,
+		CheckOption:     "LOCAL",
+		Comment:         "test comment",
+	}
+}
+
+func TestViewCreateQuery(t *testing.T) {
+	assert.Equal(t,
+		`CREATE VIEW "public"."test_view" ("user_id", "user_email") WITH (security_barrier = true, check_option = local) AS select id, email from test_view_table where active;`,
+		viewCreateQuery(mockViewModel(t), false),
+	)
+	assert.Equal(t,
+		`CREATE OR REPLACE VIEW "public"."test_view" WITH (security_invoker = true) AS SELECT 1;`,
+		viewCreateQuery(ViewModel{Schema: "public", Name: "test_view", Query: " SELECT 1 ", SecurityInvoker: true}, true),
+	)
+}
+
+func TestPreserveViewQuery(t *testing.T) {
+	tests := []struct {
+		name         string
+		priorQuery   string
+		priorColumns []string
+		normQuery    string
+		normColumns  []string
+		actualQuery  string
+		actualCols   []string
+		wantQuery    string
+		wantColumns  []string
+	}{
+		{
+			name:        "SameDefinitionKeepsPriorSpelling",
+			priorQuery:  "select 1 as a",
+			normQuery:   "SELECT 1 AS a",
+			actualQuery: "SELECT 1 AS a",
+			actualCols:  []string{"a"},
+			wantQuery:   "select 1 as a",
+		},
+		{
+			name:         "ChangedQueryUsesServerSpelling",
+			priorQuery:   "select 1 as a",
+			priorColumns: []string{"x"},
+			normQuery:    "SELECT 1 AS a",
+			normColumns:  []string{"x"},
+			actualQuery:  "SELECT 2 AS a",
+			actualCols:   []string{"x"},
+			wantQuery:    "SELECT 2 AS a",
+			wantColumns:  []string{"x"},
+		},
+		{
+			name:         "ChangedColumnsAreReported",
+			priorQuery:   "select 1 as a",
+			priorColumns: []string{"x"},
+			normQuery:    "SELECT 1 AS a",
+			normColumns:  []string{"x"},
+			actualQuery:  "SELECT 1 AS a",
+			actualCols:   []string{"y"},
+			wantQuery:    "select 1 as a",
+			wantColumns:  []string{"y"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, columns := preserveViewQuery(tt.priorQuery, tt.priorColumns, tt.normQuery, tt.normColumns, tt.actualQuery, tt.actualCols)
+			assert.Equal(t, tt.wantQuery, query)
+			assert.Equal(t, tt.wantColumns, columns)
+		})
+	}
+}
+
+func TestViewSQL_CreateAndGet(t *testing.T) {
+	ctx, db := testPrepareViewTestCase(t)
+	defer db.Close()
+
+	viewRepo := NewViewRepository(db)
+	model := mockViewModel(t)
+	assert.NoError(t, viewRepo.Create(ctx, model))
+
+	got, err := viewRepo.Get(ctx, model.Schema, model.Name)
+	assert.NoError(t, err)
+	assert.Equal(t, testViewDb, got.Database)
+	assert.Equal(t, testViewUser, got.Owner)
+	assert.Equal(t, model.Columns, got.Columns)
+	assert.True(t, got.SecurityBarrier)
+	assert.False(t, got.SecurityInvoker)
+	assert.Equal(t, "LOCAL", got.CheckOption)
+	assert.Equal(t, model.Comment, got.Comment)
+	assert.NotEqual(t, model.Query, got.Query)
+
+	normalized, err := viewRepo.Normalize(ctx, model)
+	assert.NoError(t, err)
+	assert.Equal(t, got.Query, normalized.Query)
+	result := PreserveViewSpelling(model, *normalized, *got)
+	assert.Equal(t, model.Query, result.Query)
+	assert.Equal(t, model.Columns, result.Columns)
+
+	assert.NoError(t, viewRepo.Drop(ctx, model.Schema, model.Name))
+	exists, err := viewRepo.Exists(ctx, model.Schema, model.Name)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestViewSQL_Update(t *testing.T) {
+	ctx, db := testPrepareViewTestCase(t)
+	defer db.Close()
+
+	_, err := db.ExecContext(ctx, `CREATE SCHEMA test_view_schema;`)
+	assert.NoError(t, err)
+
+	viewRepo := NewViewRepository(db)
+	current := mockViewModel(t)
+	assert.NoError(t, viewRepo.Create(ctx, current))
+
+	desired := mockViewModel(t)
+	desired.Schema = "test_view_schema"
+	desired.Name = "test_view_renamed"
+	desired.Query = "SELECT id, email FROM public.test_view_table"
+	desired.SecurityBarrier = false
+	desired.CheckOption = ""
+	desired.Comment = ""
+
+	got, err := viewRepo.Update(ctx, ViewUpdateParams{Current: current, Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, desired.Schema, got.Schema)
+	assert.Equal(t, desired.Name, got.Name)
+	assert.False(t, got.SecurityBarrier)
+	assert.Empty(t, got.CheckOption)
+	assert.Empty(t, got.Comment)
+	assert.NotContains(t, got.Query, "active")
+
+	// a column added at the end is replaced in place
+	desired.Query = "SELECT id, email, active FROM public.test_view_table"
+	replace, err := viewRepo.RequiresReplace(ctx, *got, desired)
+	assert.NoError(t, err)
+	assert.False(t, replace)
+
+	// CREATE OR REPLACE VIEW can't drop columns, the view is created again
+	current = *got
+	desired.Query = "SELECT id FROM public.test_view_table"
+	desired.Columns = []string{"user_id"}
+	desired.Comment = "test comment"
+	replace, err = viewRepo.RequiresReplace(ctx, current, desired)
+	assert.NoError(t, err)
+	assert.True(t, replace)
+
+	got, err = viewRepo.Update(ctx, ViewUpdateParams{Current: current, Desired: desired})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user_id"}, got.Columns)
+	assert.Equal(t, "test comment", got.Comment)
+}
+
+func TestViewColumnsExtend(t *testing.T) {
+	current := &pgView{Columns: []string{"id", "email"}, ColumnTypes: []string{"integer 0", "text 100"}}
+
+	tests := []struct {
+		name    string
+		desired *pgView
+		want    bool
+	}{
+		{name: "Same", desired: &pgView{Columns: []string{"id", "email"}, ColumnTypes: []string{"integer 0", "text 100"}}, want: true},
+		{name: "Appended", desired: &pgView{Columns: []string{"id", "email", "active"}, ColumnTypes: []string{"integer 0", "text 100", "boolean 0"}}, want: true},
+		{name: "Removed", desired: &pgView{Columns: []string{"id"}, ColumnTypes: []string{"integer 0"}}, want: false},
+		{name: "Renamed", desired: &pgView{Columns: []string{"id", "mail"}, ColumnTypes: []string{"integer 0", "text 100"}}, want: false},
+		{name: "Retyped", desired: &pgView{Columns: []string{"id", "email"}, ColumnTypes: []string{"bigint 0", "text 100"}}, want: false},
+		{name: "Inserted", desired: &pgView{Columns: []string{"id", "active", "email"}, ColumnTypes: []string{"integer 0", "boolean 0", "text 100"}}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, viewColumnsExtend(current, tt.desired))
+		})
+	}
+}
@@ -0,0 +1,454 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strings"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type aggregateResource struct {
+	client client.PgClient
+}
+
+type aggregateResourceModel struct {
+	Id          types.String      `tfsdk:"id"`
+	LastUpdated types.String      `tfsdk:"last_updated"`
+	Database    types.String      `tfsdk:"database"`
+	Schema      types.String      `tfsdk:"schema"`
+	Name        types.String      `tfsdk:"name"`
+	Args        []routineArgModel `tfsdk:"args"`
+	StateFunc   types.String      `tfsdk:"state_func"`
+	StateType   types.String      `tfsdk:"state_type"`
+	FinalFunc   types.String      `tfsdk:"final_func"`
+	CombineFunc types.String      `tfsdk:"combine_func"`
+	InitCond    types.String      `tfsdk:"init_cond"`
+	Parallel    types.String      `tfsdk:"parallel"`
+	Owner       types.String      `tfsdk:"owner"`
+	Comment     types.String      `tfsdk:"comment"`
+	AssumeRole  types.String      `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                = &aggregateResource{}
+	_ resource.ResourceWithConfigure   = &aggregateResource{}
+	_ resource.ResourceWithImportState = &aggregateResource{}
+	_ resource.ResourceWithModifyPlan  = &aggregateResource{}
+)
+
+func NewAggregateResource() resource.Resource {
+	return &aggregateResource{}
+}
+
+func (r *aggregateResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'aggregate' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *aggregateResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_aggregate"
+}
+
+func (r *aggregateResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the aggregate, in the format `database_name.schema_name.aggregate_name(arg_type, ...)`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the aggregate",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the aggregate is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("public"),
+				MarkdownDescription: "Schema of the aggregate. Changing it moves the aggregate with `ALTER AGGREGATE ... SET SCHEMA`.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the aggregate. Changes rename the aggregate in place.",
+				Validators:          nonEmptyString,
+			},
+			"args": routineArgsAttribute(
+				"Arguments of the aggregate, in order. An aggregate without arguments (e.g. `count(*)`) aggregates the rows themselves. Changing the arguments creates the aggregate again.",
+				"IN", "VARIADIC",
+			),
+			"state_func": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the state transition function, called for each row with the current state and the arguments, and returning the next state",
+				Validators:          nonEmptyString,
+			},
+			"state_type": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Data type of the state, e.g. `numeric[]`. Changes create the aggregate again.",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"final_func": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the final function, computing the result from the final state. If not provided, the final state is the result.",
+				Validators:          nonEmptyString,
+			},
+			"combine_func": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the combine function, merging two states. It allows the aggregate to be computed by parallel workers, when it's also parallel safe.",
+				Validators:          nonEmptyString,
+			},
+			"init_cond": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Initial value of the state, as a string literal of the state type, e.g. `{0,0}`. If not provided, the state starts as null.",
+			},
+			"parallel": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("UNSAFE"),
+				MarkdownDescription: "Parallel safety of the aggregate, one of `SAFE`, `RESTRICTED` or `UNSAFE`. Defaults to `UNSAFE`.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("SAFE", "RESTRICTED", "UNSAFE"),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The owner of the aggregate. If not provided, the aggregate is owned by the role that creates it (see `assume_role`).",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the aggregate",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the aggregate. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceAggregate,
+	}
+}
+
+func (r *aggregateResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on create or destroy
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var model, stateModel aggregateResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// the identifier is derived from the schema and the name, moving the aggregate produces a new one
+	if !model.Schema.Equal(stateModel.Schema) || !model.Name.Equal(stateModel.Name) {
+		res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+}
+
+func (r *aggregateResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'aggregate' resource")
+
+	var model aggregateResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	role := resolveAssumeRole(r.client, model.AssumeRole)
+	ctx = client.ContextWithAssumeRole(ctx, role)
+
+	// the aggregate is owned by the assumed role, unless another owner is explicitly requested
+	expectedOwner := role
+	if !model.Owner.IsNull() && !model.Owner.IsUnknown() {
+		expectedOwner = model.Owner.ValueString()
+	}
+
+	pgModel := model.toPgModel()
+	pgModel.Owner = expectedOwner
+
+	repository := conn.AggregateRepository()
+	if err = repository.Create(ctx, pgModel); err != nil {
+		res.Diagnostics.AddError("Error creating aggregate", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readAggregateModel(ctx, repository, pgModel.Schema, pgModel.Name, client.RoutineArgTypes(pgModel.Args), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("aggregate", expectedOwner, model.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'aggregate' resource")
+}
+
+func (r *aggregateResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'aggregate' resource")
+
+	var model aggregateResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the aggregate", "Id is required for reading aggregate")
+		return
+	}
+
+	database, schemaName, name, argTypes, ok := splitRoutineId(model.Id.ValueString())
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the aggregate", "Id should be in the format 'database_name.schema_name.aggregate_name(arg_type, ...)'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// an aggregate dropped outside of Terraform is removed from the state and planned again
+	repository := conn.AggregateRepository()
+	exists, err := repository.Exists(ctx, schemaName, name, argTypes)
+	if err != nil {
+		res.Diagnostics.AddError("Error reading aggregate", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Aggregate not found, removing it from the state", map[string]any{"aggregate": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readAggregateModel(ctx, repository, schemaName, name, argTypes, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'aggregate' resource")
+}
+
+func (r *aggregateResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'aggregate' resource")
+
+	var stateModel aggregateResourceModel
+	var planModel aggregateResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	current := stateModel.toPgModel()
+	desired := planModel.toPgModel()
+	if planModel.Owner.IsUnknown() {
+		desired.Owner = current.Owner
+	}
+
+	repository := conn.AggregateRepository()
+	_, err = repository.Update(ctx, client.AggregateUpdateParams{Current: current, Desired: desired})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating aggregate", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readAggregateModel(ctx, repository, desired.Schema, desired.Name, client.RoutineArgTypes(desired.Args), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("aggregate", desired.Owner, planModel.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'aggregate' resource")
+}
+
+func (r *aggregateResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'aggregate' resource")
+
+	var model aggregateResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	pgModel := model.toPgModel()
+	err = conn.AggregateRepository().Drop(ctx, pgModel.Schema, pgModel.Name, client.RoutineArgTypes(pgModel.Args))
+	if err != nil {
+		res.Diagnostics.AddError("Error deleting aggregate", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'aggregate' resource")
+}
+
+func (r *aggregateResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readAggregateModel reads the aggregate into the target model, keeping the spelling of the types
+// and functions of the target when PostgreSQL normalizes them to the same definition.
+func readAggregateModel(ctx context.Context, repository client.AggregateRepository, schema, name string, argTypes []string, target *aggregateResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, schema, name, argTypes)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading aggregate: '%s.%s(%s)'", schema, name, strings.Join(argTypes, ", ")), err.Error())
+		return diags
+	}
+
+	prior := target.toPgModel()
+	if prior.StateFunc != "" {
+		normalized, err := repository.Normalize(ctx, prior)
+		if err != nil {
+			// the prior definition may no longer be valid, e.g. a referenced function was dropped
+			tflog.Warn(ctx, "Unable to normalize the aggregate definition, using the server spelling", map[string]any{"error": err.Error()})
+		} else {
+			*actual = client.PreserveAggregateSpelling(prior, *normalized, *actual)
+		}
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *aggregateResourceModel) toPgModel() client.AggregateModel {
+	pgModel := client.AggregateModel{
+		Schema:      rm.Schema.ValueString(),
+		Name:        rm.Name.ValueString(),
+		Database:    rm.Database.ValueString(),
+		Args:        mapRoutineArgsToPg(rm.Args),
+		StateFunc:   rm.StateFunc.ValueString(),
+		StateType:   rm.StateType.ValueString(),
+		FinalFunc:   rm.FinalFunc.ValueString(),
+		CombineFunc: rm.CombineFunc.ValueString(),
+		Parallel:    rm.Parallel.ValueString(),
+		Owner:       rm.Owner.ValueString(),
+		Comment:     rm.Comment.ValueString(),
+	}
+	if !rm.InitCond.IsNull() && !rm.InitCond.IsUnknown() {
+		initCond := rm.InitCond.ValueString()
+		pgModel.InitCond = &initCond
+	}
+	return pgModel
+}
+
+func (rm *aggregateResourceModel) fromPgModel(pgModel client.AggregateModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Schema = types.StringValue(pgModel.Schema)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Args = mapRoutineArgsFromPg(pgModel.Args)
+	rm.StateFunc = types.StringValue(pgModel.StateFunc)
+	rm.StateType = types.StringValue(pgModel.StateType)
+	rm.FinalFunc = stringValueOrNull(pgModel.FinalFunc)
+	rm.CombineFunc = stringValueOrNull(pgModel.CombineFunc)
+	rm.InitCond = types.StringPointerValue(pgModel.InitCond)
+	rm.Parallel = types.StringValue(pgModel.Parallel)
+	rm.Owner = types.StringValue(pgModel.Owner)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+}
+
+func (rm *aggregateResourceModel) SetId() {
+	rm.Id = types.StringValue(routineId(rm.Database.ValueString(), rm.Schema.ValueString(), rm.Name.ValueString(), rm.Args))
+}
+
+func (rm *aggregateResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccAggregateResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_aggregate_resource_db",
+		Username: "test_aggregate_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_aggregate"
+	mockResourceName := fmt.Sprintf("postgresql_aggregate.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `
+				CREATE SCHEMA app;
+				CREATE ROLE test_aggregate_owner;
+				CREATE FUNCTION public.test_sum_state(numeric[], numeric) RETURNS numeric[]
+					LANGUAGE sql IMMUTABLE PARALLEL SAFE AS 'SELECT ARRAY[$1[1] + $2, $1[2] + 1]';
+				CREATE FUNCTION public.test_sum_combine(numeric[], numeric[]) RETURNS numeric[]
+					LANGUAGE sql IMMUTABLE PARALLEL SAFE AS 'SELECT ARRAY[$1[1] + $2[1], $1[2] + $2[2]]';
+				CREATE FUNCTION public.test_avg_final(numeric[]) RETURNS numeric
+					LANGUAGE sql IMMUTABLE PARALLEL SAFE AS 'SELECT CASE WHEN $1[2] = 0 THEN NULL ELSE $1[1] / $1[2] END';`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccAggregateToTFResource(t, mockResourceId, "test_avg", `
+					schema     = "public"
+					final_func = "public.test_avg_final"
+					comment    = "test comment"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_avg(numeric)", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "state_func", "test_sum_state"),
+					resource.TestCheckResourceAttr(mockResourceName, "state_type", "numeric[]"),
+					resource.TestCheckResourceAttr(mockResourceName, "final_func", "public.test_avg_final"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "combine_func"),
+					resource.TestCheckResourceAttr(mockResourceName, "init_cond", "{0,0}"),
+					resource.TestCheckResourceAttr(mockResourceName, "parallel", "UNSAFE"),
+					resource.TestCheckResourceAttr(mockResourceName, "owner", runOpts.Username),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment"),
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "final_func"},
+			},
+			{
+				// Update testing - Properties without re-creating the resource
+				Config: testAccAggregateToTFResource(t, mockResourceId, "test_mean", `
+					schema       = "app"
+					final_func   = "test_avg_final"
+					combine_func = "test_sum_combine"
+					parallel     = "SAFE"
+					owner        = "test_aggregate_owner"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.app.test_mean(numeric)", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "combine_func", "test_sum_combine"),
+					resource.TestCheckResourceAttr(mockResourceName, "parallel", "SAFE"),
+					resource.TestCheckResourceAttr(mockResourceName, "owner", "test_aggregate_owner"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "comment"),
+				),
+			},
+			{
+				// Drift testing - a aggregate dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP AGGREGATE app.test_mean(numeric);`)
+					assert.NoError(t, err)
+				},
+				Config: testAccAggregateToTFResource(t, mockResourceId, "test_mean", `
+					schema       = "app"
+					final_func   = "test_avg_final"
+					combine_func = "test_sum_combine"
+					parallel     = "SAFE"
+					owner        = "test_aggregate_owner"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(mockResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAggregateToTFResource(t *testing.T, resId, name, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_aggregate" "%s" {
+			name       = "%s"
+			args       = [{ name = "value", type = "numeric" }]
+			state_func = "test_sum_state"
+			state_type = "numeric[]"
+			init_cond  = "{0,0}"
+			%s
+		}`, resId, name, body)
+}
@@ -0,0 +1,329 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type databaseSettingResource struct {
+	client client.PgClient
+}
+
+type databaseSettingResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+	Database    types.String `tfsdk:"database"`
+	Name        types.String `tfsdk:"name"`
+	Value       types.String `tfsdk:"value"`
+	AssumeRole  types.String `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                = &databaseSettingResource{}
+	_ resource.ResourceWithConfigure   = &databaseSettingResource{}
+	_ resource.ResourceWithImportState = &databaseSettingResource{}
+	_ resource.ResourceWithModifyPlan  = &databaseSettingResource{}
+)
+
+func NewDatabaseSettingResource() resource.Resource {
+	return &databaseSettingResource{}
+}
+
+func (r *databaseSettingResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'database_setting' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *databaseSettingResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_database_setting"
+}
+
+func (r *databaseSettingResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the database setting, in the format `database_name.parameter_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the database setting",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database the setting applies to. If not provided, the database from the provider configuration will be used.",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the configuration parameter, e.g. `statement_timeout`, or of a custom parameter qualified by its prefix, e.g. `app.tenant`",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Value of the configuration parameter, set when a session connects to the database. Settings of the role (see `postgresql_role_setting`) take precedence. It is validated against `pg_settings`; values with units, e.g. `1GB` and `1024MB`, are compared in the unit of the parameter.",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET ROLE`) while managing the database setting. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceDatabaseSetting,
+	}
+}
+
+func (r *databaseSettingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var model databaseSettingResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(validateSessionSetting(ctx, r.client, model.Name, model.Value)...)
+}
+
+func (r *databaseSettingResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'database_setting' resource")
+
+	var model databaseSettingResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	// the settings are stored in a catalog shared by every database, any database connection manages them
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	repository := conn.DbRoleSettingRepository()
+	if err = repository.Create(ctx, model.toPgModel()); err != nil {
+		res.Diagnostics.AddError("Error creating database setting", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readDatabaseSettingModel(ctx, repository, model.toPgModel(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'database_setting' resource")
+}
+
+func (r *databaseSettingResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'database_setting' resource")
+
+	var model databaseSettingResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the database setting", "Id is required for reading database setting")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 2)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the database setting", "Id should be in the format 'database_name.parameter_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	setting := client.DbRoleSettingModel{Database: idParts[0], Name: idParts[1], Value: model.Value.ValueString()}
+	// a database setting reset outside of Terraform is removed from the state and planned again
+	repository := conn.DbRoleSettingRepository()
+	exists, err := repository.Exists(ctx, setting.Role, setting.Database, setting.Name)
+	if err != nil {
+		res.Diagnostics.AddError("Error reading database setting", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Database setting not found, removing it from the state", map[string]any{"setting": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readDatabaseSettingModel(ctx, repository, setting, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'database_setting' resource")
+}
+
+func (r *databaseSettingResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'database_setting' resource")
+
+	var stateModel databaseSettingResourceModel
+	var planModel databaseSettingResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	repository := conn.DbRoleSettingRepository()
+	_, err = repository.Update(ctx, client.DbRoleSettingUpdateParams{Current: stateModel.toPgModel(), Desired: planModel.toPgModel()})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating database setting", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readDatabaseSettingModel(ctx, repository, planModel.toPgModel(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'database_setting' resource")
+}
+
+func (r *databaseSettingResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'database_setting' resource")
+
+	var model databaseSettingResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	if err = conn.DbRoleSettingRepository().Drop(ctx, "", model.Database.ValueString(), model.Name.ValueString()); err != nil {
+		res.Diagnostics.AddError("Error deleting database setting", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'database_setting' resource")
+}
+
+func (r *databaseSettingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readDatabaseSettingModel reads the setting into the target, keeping the spelling of the prior value
+// when the server holds an equivalent one (see readDbRoleSetting).
+func readDatabaseSettingModel(ctx context.Context, repository client.DbRoleSettingRepository, prior client.DbRoleSettingModel, target *databaseSettingResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := readDbRoleSetting(ctx, repository, prior)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading database setting: '%s' of '%s'", prior.Name, prior.Database), err.Error())
+		return diags
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *databaseSettingResourceModel) toPgModel() client.DbRoleSettingModel {
+	return client.DbRoleSettingModel{
+		Database: rm.Database.ValueString(),
+		Name:     rm.Name.ValueString(),
+		Value:    rm.Value.ValueString(),
+	}
+}
+
+func (rm *databaseSettingResourceModel) fromPgModel(pgModel client.DbRoleSettingModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Value = types.StringValue(pgModel.Value)
+}
+
+func (rm *databaseSettingResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s", rm.Database.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *databaseSettingResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
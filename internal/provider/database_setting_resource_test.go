@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"regexp"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccDatabaseSettingResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_database_setting_resource_db",
+		Username: "test_database_setting_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_database_setting"
+	mockResourceName := fmt.Sprintf("postgresql_database_setting.%s", mockResourceId)
+	// custom parameters are accepted when qualified by a prefix
+	custom := `
+		resource "postgresql_database_setting" "test_database_setting_custom" {
+			name  = "app.tenant"
+			value = "acme"
+		}`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Plan testing - Unknown parameters are refused
+				Config:      testAccDatabaseSettingToTFResource(t, mockResourceId, "statement_timeot", "30s"),
+				ExpectError: regexp.MustCompile(`The server has no parameter 'statement_timeot'`),
+			},
+			{
+				// Create and Read testing
+				Config: testAccDatabaseSettingToTFResource(t, mockResourceId, "statement_timeout", "30s") + custom,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", "test_database_setting_resource_db.statement_timeout"),
+					resource.TestCheckResourceAttr(mockResourceName, "database", runOpts.Database),
+					resource.TestCheckResourceAttr(mockResourceName, "value", "30s"),
+					resource.TestCheckResourceAttr("postgresql_database_setting.test_database_setting_custom", "id", "test_database_setting_resource_db.app.tenant"),
+					resource.TestCheckResourceAttr("postgresql_database_setting.test_database_setting_custom", "value", "acme"),
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				// ImportState testing - Custom parameter, with dots in its name
+				ResourceName:            "postgresql_database_setting.test_database_setting_custom",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				// Update testing - The value is changed in place
+				Config: testAccDatabaseSettingToTFResource(t, mockResourceId, "statement_timeout", "1min") + custom,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", "test_database_setting_resource_db.statement_timeout"),
+					resource.TestCheckResourceAttr(mockResourceName, "value", "1min"),
+				),
+			},
+			{
+				// Plan testing - An equivalent value set outside of Terraform produces no diff
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `ALTER DATABASE test_database_setting_resource_db SET statement_timeout = '60000';`)
+					assert.NoError(t, err)
+				},
+				Config:   testAccDatabaseSettingToTFResource(t, mockResourceId, "statement_timeout", "1min") + custom,
+				PlanOnly: true,
+			},
+			{
+				// Drift testing - a database setting reset outside of Terraform is set again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `ALTER DATABASE test_database_setting_resource_db RESET statement_timeout;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccDatabaseSettingToTFResource(t, mockResourceId, "statement_timeout", "1min") + custom,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "value", "1min"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatabaseSettingToTFResource(t *testing.T, resId, name, value string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_database_setting" "%s" {
+			name  = "%s"
+			value = "%s"
+		}`, resId, name, value)
+}
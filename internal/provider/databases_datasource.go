@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"maps"
+	"terraform-provider-postgresql/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &databasesDataSource{}
+	_ datasource.DataSourceWithConfigure = &databasesDataSource{}
+)
+
+type databasesDataSource struct {
+	client client.PgClient
+}
+
+type databasesDataSourceModel struct {
+	NameRegex     types.String            `tfsdk:"name_regex"`
+	IncludeSystem types.Bool              `tfsdk:"include_system"`
+	Databases     []databaseItemDataModel `tfsdk:"databases"`
+}
+
+type databaseItemDataModel struct {
+	Name             types.String `tfsdk:"name"`
+	Owner            types.String `tfsdk:"owner"`
+	Encoding         types.String `tfsdk:"encoding"`
+	Collate          types.String `tfsdk:"collate"`
+	Ctype            types.String `tfsdk:"ctype"`
+	IsTemplate       types.Bool   `tfsdk:"is_template"`
+	AllowConnections types.Bool   `tfsdk:"allow_connections"`
+	ConnectionLimit  types.Int64  `tfsdk:"connection_limit"`
+	Size             types.Int64  `tfsdk:"size"`
+	Comment          types.String `tfsdk:"comment"`
+}
+
+func NewDatabasesDataSource() datasource.DataSource {
+	return &databasesDataSource{}
+}
+
+func (d *databasesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, res *datasource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'databases' datasource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Configured 'databases' datasource")
+	d.client = pgClient
+}
+
+func (d *databasesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, res *datasource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_databases"
+}
+
+func (d *databasesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, res *datasource.SchemaResponse) {
+	attributes := listFilterAttributes("databases", "`template0` and `template1`", false)
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"databases": schema.ListNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: "The databases, ordered by name",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Name of the database",
+					},
+					"owner": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The owner of the database",
+					},
+					"encoding": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Character set encoding of the database, e.g. `UTF8`",
+					},
+					"collate": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Collation order (`LC_COLLATE`) of the database",
+					},
+					"ctype": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Character classification (`LC_CTYPE`) of the database",
+					},
+					"is_template": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the database is a template, cloned by `CREATE DATABASE`",
+					},
+					"allow_connections": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the database accepts connections",
+					},
+					"connection_limit": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Maximum number of concurrent connections to the database, `-1` for no limit",
+					},
+					"size": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Disk space used by the database, in bytes. Null when the provider role can't connect to the database.",
+					},
+					"comment": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Comment associated with the database",
+					},
+				},
+			},
+		},
+	})
+
+	res.Schema = schema.Schema{
+		Attributes:          attributes,
+		MarkdownDescription: mdDocDataSourceDatabases,
+	}
+}
+
+func (d *databasesDataSource) Read(ctx context.Context, req datasource.ReadRequest, res *datasource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'databases' datasource")
+
+	var model databasesDataSourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// databases are global to the server, any database connection lists them
+	conn, err := d.client.GetConnection(ctx, d.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	databases, err := conn.DiscoveryRepository().ListDatabases(ctx, toListFilter(model.NameRegex, types.StringNull(), model.IncludeSystem))
+	if err != nil {
+		res.Diagnostics.AddError("Error listing databases", err.Error())
+		return
+	}
+
+	model.Databases = make([]databaseItemDataModel, len(databases))
+	for i, database := range databases {
+		model.Databases[i] = databaseItemDataModel{
+			Name:             types.StringValue(database.Name),
+			Owner:            types.StringValue(database.Owner),
+			Encoding:         types.StringValue(database.Encoding),
+			Collate:          types.StringValue(database.Collate),
+			Ctype:            types.StringValue(database.Ctype),
+			IsTemplate:       types.BoolValue(database.IsTemplate),
+			AllowConnections: types.BoolValue(database.AllowConnections),
+			ConnectionLimit:  types.Int64Value(database.ConnectionLimit),
+			Size:             int64ValueOrNull(database.Size),
+			Comment:          stringValueOrNull(database.Comment),
+		}
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'databases' datasource")
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccDatabasesDataSource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_databases_datasource_db",
+		Username: "test_databases_datasource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_databases"
+	mockResourceName := fmt.Sprintf("data.postgresql_databases.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `
+				CREATE DATABASE test_databases_app_a CONNECTION LIMIT 5;
+				COMMENT ON DATABASE test_databases_app_a IS 'application A';
+				CREATE DATABASE test_databases_app_b ALLOW_CONNECTIONS false;`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Selected by name
+				Config: testAccDatabasesToTFDataSource(t, mockResourceId, `name_regex = "^test_databases_app_"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "databases.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "databases.0.name", "test_databases_app_a"),
+					resource.TestCheckResourceAttr(mockResourceName, "databases.0.owner", runOpts.Username),
+					resource.TestCheckResourceAttr(mockResourceName, "databases.0.encoding", "UTF8"),
+					resource.TestCheckResourceAttr(mockResourceName, "databases.0.is_template", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "databases.0.allow_connections", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "databases.0.connection_limit", "5"),
+					resource.TestCheckResourceAttrSet(mockResourceName, "databases.0.size"),
+					resource.TestCheckResourceAttr(mockResourceName, "databases.0.comment", "application A"),
+					resource.TestCheckResourceAttr(mockResourceName, "databases.1.name", "test_databases_app_b"),
+					resource.TestCheckResourceAttr(mockResourceName, "databases.1.allow_connections", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "databases.1.connection_limit", "-1"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "databases.1.comment"),
+				),
+			},
+			{
+				// Without the template databases
+				Config: testAccDatabasesToTFDataSource(t, mockResourceId, `name_regex = "^template"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "databases.#", "0"),
+				),
+			},
+			{
+				// With the template databases
+				Config: testAccDatabasesToTFDataSource(t, mockResourceId, `
+					name_regex     = "^template"
+					include_system = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "databases.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "databases.0.name", "template0"),
+					resource.TestCheckResourceAttr(mockResourceName, "databases.0.is_template", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "databases.1.name", "template1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDatabasesToTFDataSource(t *testing.T, resName, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`data "postgresql_databases" "%s" {
+			%s
+		}`, resName, body)
+}
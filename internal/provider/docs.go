@@ -12,17 +12,196 @@ Terraform providers. That means it is built using the (Terraform Plugin Framewor
 
 ## 🏁 Roadmap
 
-| Name          | Resource | Data Source |
-|---------------|:--------:|:-----------:|
-| Event Trigger |    ✅    |     ✅      |
-| Functions     |    🔜    |     🔜      |
-| Database      |    🔜    |     🔜      |
-| Schema        |    🔜    |     🔜      |
-| Role          |    🔜    |     🔜      |
+| Name              | Resource | Data Source |
+|-------------------|:--------:|:-----------:|
+| Event Trigger     |    ✅    |     ✅      |
+| Table             |    ✅    |     🔜      |
+| Index             |    ✅    |     🔜      |
+| View              |    ✅    |     🔜      |
+| Materialized View |    ✅    |     🔜      |
+| Sequence          |    ✅    |     🔜      |
+| Type              |    ✅    |     🔜      |
+| Trigger           |    ✅    |     🔜      |
+| Policy            |    ✅    |     🔜      |
+| Row Security      |    ✅    |     🔜      |
+| Publication       |    ✅    |     🔜      |
+| Subscription      |    ✅    |     🔜      |
+| Replication Slot  |    ✅    |     🔜      |
+| Foreign Server    |    ✅    |     🔜      |
+| User Mapping      |    ✅    |     🔜      |
+| Foreign Table     |    ✅    |     🔜      |
+| Foreign Import    |    ✅    |     🔜      |
+| Tablespace        |    ✅    |     🔜      |
+| Procedure         |    ✅    |     🔜      |
+| Aggregate         |    ✅    |     🔜      |
+| Role Setting      |    ✅    |     🔜      |
+| Database Setting  |    ✅    |     🔜      |
+| System Setting    |    ✅    |     🔜      |
+| SQL               |    ✅    |     ➖      |
+| Settings          |    ➖    |     ✅      |
+| Query             |    ➖    |     ✅      |
+| Databases         |    ➖    |     ✅      |
+| Schemas           |    ➖    |     ✅      |
+| Roles             |    ➖    |     ✅      |
+| Tables            |    ➖    |     ✅      |
+| Server            |    ➖    |     ✅      |
+| Functions         |    🔜    |     🔜      |
+| Database          |    🔜    |     🔜      |
+| Schema            |    🔜    |     🔜      |
+| Role              |    🔜    |     🔜      |
 
 `
 	mdDocResourceEventTrigger = `
 Event Trigger is a PostgreSQL object that allows you to define a set of actions that should be executed when a certain event occurs.
 They are are global objects for a particular database and are capable of capturing events from multiple tables.
 (PostgreSQL Event Triggers)[https://www.postgresql.org/docs/current/event-triggers.html]`
+
+	mdDocResourceTable = `
+Table is the PostgreSQL object that stores the data as rows of typed columns.
+Changes are applied in place with ` + "`ALTER TABLE`" + `; the ones that may lose data, like dropping a column or changing its type,
+are only applied when ` + "`allow_destructive_changes`" + ` is set.
+(PostgreSQL Tables)[https://www.postgresql.org/docs/current/ddl.html]`
+
+	mdDocResourceIndex = `
+Index is a PostgreSQL object that speeds up the retrieval of rows from a table, at the cost of slower writes.
+With ` + "`concurrently`" + ` the index is built and dropped without blocking writes on the table.
+(PostgreSQL Indexes)[https://www.postgresql.org/docs/current/indexes.html]`
+
+	mdDocResourceView = `
+View is a named query that can be used like a table, the query is run every time the view is referenced.
+Changes to the query are applied with ` + "`CREATE OR REPLACE VIEW`" + `, which only accepts queries that keep the existing columns and add new ones at the end. Other changes of the columns recreate the view, which loses its privileges.
+(PostgreSQL Views)[https://www.postgresql.org/docs/current/sql-createview.html]`
+
+	mdDocResourceMaterializedView = `
+Materialized View is a view whose query result is stored like a table, and updated with ` + "`REFRESH MATERIALIZED VIEW`" + `.
+The materialized view can be refreshed along with in-place updates, see ` + "`refresh_on_change`" + ` and ` + "`refresh_triggers`" + `.
+(PostgreSQL Materialized Views)[https://www.postgresql.org/docs/current/rules-materializedviews.html]`
+
+	mdDocResourceSequence = `
+Sequence is a PostgreSQL object that generates a series of unique numbers, usually for the default value of a column.
+Every attribute is changed in place with ` + "`ALTER SEQUENCE`" + `; a new ` + "`start`" + ` value does not restart the sequence.
+(PostgreSQL Sequences)[https://www.postgresql.org/docs/current/sql-createsequence.html]`
+	mdDocResourceType = `
+Type is a user defined PostgreSQL data type: an enum, a composite type, a range type or a domain.
+New enum values are added in place with ` + "`ALTER TYPE ... ADD VALUE`" + `, but PostgreSQL can't remove or reorder them, so such plans are refused.
+(PostgreSQL Types)[https://www.postgresql.org/docs/current/sql-createtype.html], (PostgreSQL Domains)[https://www.postgresql.org/docs/current/sql-createdomain.html]`
+	mdDocResourceTrigger = `
+Trigger is a PostgreSQL object that calls a function when rows of a table, view or foreign table are inserted, updated or deleted, or when it's truncated.
+The name, ` + "`firing_mode`" + ` and comment are changed in place, any other change creates the trigger again.
+(PostgreSQL Triggers)[https://www.postgresql.org/docs/current/sql-createtrigger.html]`
+
+	mdDocResourcePolicy = `
+Policy is a PostgreSQL object that limits the rows of a table a role can see or modify, when the row level security of the table is enabled.
+The name, roles, expressions and comment are changed in place, removing an expression or any other change creates the policy again.
+(PostgreSQL Row Security Policies)[https://www.postgresql.org/docs/current/sql-createpolicy.html]`
+
+	mdDocResourceRowLevelSecurity = `
+Row Level Security enables, and optionally forces, the row level security of an existing table, so its policies are applied.
+Destroying the resource disables the row level security of the table.
+(PostgreSQL Row Security Policies)[https://www.postgresql.org/docs/current/ddl-rowsecurity.html]`
+
+	mdDocResourcePublication = `
+Publication is a PostgreSQL object that defines the changes of a set of tables replicated to subscribers, with logical replication.
+Column lists, row filters and schemas require PostgreSQL 15 or later.
+(PostgreSQL Publication)[https://www.postgresql.org/docs/current/sql-createpublication.html]`
+
+	mdDocResourceSubscription = `
+Subscription is a PostgreSQL object that replicates the publications of another server, with logical replication.
+The connection info is never read back from the server, and destroying the subscription drops its replication slot on the publisher.
+(PostgreSQL Subscription)[https://www.postgresql.org/docs/current/sql-createsubscription.html]`
+
+	mdDocResourceReplicationSlot = `
+Replication Slot is a PostgreSQL object that retains the WAL needed by a consumer, a logical decoding tool like Debezium or a streaming replica, until it confirms it.
+Slots can't be changed, any change creates the slot again. The lag and the activity of the slot are exposed as read-only attributes.
+(PostgreSQL Replication Slots)[https://www.postgresql.org/docs/current/warm-standby.html#STREAMING-REPLICATION-SLOTS]`
+
+	mdDocResourceForeignServer = `
+Foreign Server is a PostgreSQL object that holds the connection details of a remote data source, accessed through a foreign data wrapper like postgres_fdw.
+The options are changed in place, one by one. The foreign data wrapper extension must be installed in the database.
+(PostgreSQL Foreign Server)[https://www.postgresql.org/docs/current/sql-createserver.html]`
+
+	mdDocResourceUserMapping = `
+User Mapping is a PostgreSQL object that holds the credentials a role uses to connect to a foreign server.
+The options are only readable by the owner of the server, the mapped role and the superusers, they are kept as configured otherwise.
+(PostgreSQL User Mapping)[https://www.postgresql.org/docs/current/sql-createusermapping.html]`
+
+	mdDocResourceForeignTable = `
+Foreign Table is a PostgreSQL object that exposes a table of a foreign server, as if it was a local one.
+Columns and options are changed in place, changing the server creates the table again.
+(PostgreSQL Foreign Table)[https://www.postgresql.org/docs/current/sql-createforeigntable.html]`
+
+	mdDocResourceImportForeignSchema = `
+Import Foreign Schema creates the foreign tables matching the tables of a remote schema, in a local schema.
+The import is a one-off operation: later changes of the remote schema are not followed, and destroying the resource drops the imported tables.
+(PostgreSQL Import Foreign Schema)[https://www.postgresql.org/docs/current/sql-importforeignschema.html]`
+
+	mdDocResourceTablespace = `
+Tablespace is a PostgreSQL object that maps a directory of the server, to place the files of tables and indexes on a given storage, e.g. hot and cold data.
+Tablespaces are global to the server. Destroying a tablespace fails while objects are still stored in it.
+(PostgreSQL Tablespaces)[https://www.postgresql.org/docs/current/manage-ag-tablespaces.html]`
+
+	mdDocResourceProcedure = `
+Procedure is a PostgreSQL routine without result, invoked with ` + "`CALL`" + `. Unlike functions, procedures can commit or roll back transactions, unless they are ` + "`SECURITY DEFINER`" + `.
+The name, schema, language, body, security and comment are changed in place, changing the arguments creates another procedure.
+(PostgreSQL Procedures)[https://www.postgresql.org/docs/current/sql-createprocedure.html]`
+
+	mdDocResourceAggregate = `
+Aggregate is a custom PostgreSQL aggregate function, computing a single result from a set of rows with a state function and an optional final function.
+The name, schema, functions, initial condition, parallel safety and comment are changed in place, changing the arguments or the state type creates the aggregate again.
+(PostgreSQL Aggregates)[https://www.postgresql.org/docs/current/sql-createaggregate.html]`
+
+	mdDocResourceRoleSetting = `
+Role Setting is the default value of a configuration parameter for a role, set when a session of the role starts, in every database or in a given one.
+Role settings take precedence over database settings, the role setting of a database over the one of every database.
+(PostgreSQL Alter Role)[https://www.postgresql.org/docs/current/sql-alterrole.html]`
+
+	mdDocResourceDatabaseSetting = `
+Database Setting is the default value of a configuration parameter for a database, set when a session connects to it.
+The value is overridden by the settings of the role (see ` + "`postgresql_role_setting`" + `) and by the sessions themselves.
+(PostgreSQL Alter Database)[https://www.postgresql.org/docs/current/sql-alterdatabase.html]`
+
+	mdDocResourceSystemSetting = `
+System Setting is a configuration parameter of the server, written to ` + "`postgresql.auto.conf`" + ` with ` + "`ALTER SYSTEM`" + ` and applied by reloading the configuration.
+Parameters of the ` + "`postmaster`" + ` context are only applied once the server restarts, see ` + "`pending_restart`" + `. Destroying the resource resets the parameter to the value of ` + "`postgresql.conf`" + `.
+(PostgreSQL Alter System)[https://www.postgresql.org/docs/current/sql-altersystem.html]`
+
+	mdDocResourceSql = `
+SQL runs raw SQL scripts, for the objects the provider doesn't model yet: a create script on creation and a destroy script on destruction.
+A new create script runs after the previous destroy script, in a single transaction. An optional read query detects the objects dropped outside of Terraform.
+(PostgreSQL SQL Commands)[https://www.postgresql.org/docs/current/sql-commands.html]`
+
+	mdDocDataSourceSettings = `
+Settings lists the configuration parameters of the server from ` + "`pg_settings`" + `, as seen by a session of the database, e.g. to check the ` + "`wal_level`" + ` before creating publications.
+The parameters can be selected by name or by category, every parameter is listed otherwise.
+(PostgreSQL pg_settings)[https://www.postgresql.org/docs/current/view-pg-settings.html]`
+
+	mdDocDataSourceQuery = `
+Query runs a read-only SQL statement on a database and returns its rows, for the values the provider doesn't model, e.g. a row count or a custom catalog query.
+The statement runs in a ` + "`READ ONLY`" + ` transaction with a statement timeout, and fails when it returns more rows than the limit.
+(PostgreSQL SELECT)[https://www.postgresql.org/docs/current/sql-select.html]`
+
+	mdDocDataSourceDatabases = `
+Databases lists the databases of the server with their owner, encoding and size, e.g. to grant privileges on every database matching a naming convention.
+The template databases are excluded unless ` + "`include_system`" + ` is set.
+(PostgreSQL pg_database)[https://www.postgresql.org/docs/current/catalog-pg-database.html]`
+
+	mdDocDataSourceSchemas = `
+Schemas lists the schemas of a database with their owner, e.g. to grant usage on every schema matching a naming convention.
+The ` + "`pg_*`" + ` and ` + "`information_schema`" + ` schemas are excluded unless ` + "`include_system`" + ` is set.
+(PostgreSQL pg_namespace)[https://www.postgresql.org/docs/current/catalog-pg-namespace.html]`
+
+	mdDocDataSourceRoles = `
+Roles lists the roles of the server with their attributes and direct memberships, e.g. to find the login roles of a team.
+The predefined ` + "`pg_*`" + ` roles are excluded unless ` + "`include_system`" + ` is set.
+(PostgreSQL pg_roles)[https://www.postgresql.org/docs/current/view-pg-roles.html]`
+
+	mdDocDataSourceTables = `
+Tables lists the tables of a database with their owner and estimated row count, e.g. to grant privileges table by table. Partitions are listed through their partitioned table only.
+The tables of the ` + "`pg_*`" + ` and ` + "`information_schema`" + ` schemas are excluded unless ` + "`include_system`" + ` is set.
+(PostgreSQL pg_class)[https://www.postgresql.org/docs/current/catalog-pg-class.html]`
+
+	mdDocDataSourceServer = `
+Server describes the PostgreSQL server the provider is connected to: version, connected role, recovery state, main configuration and installed extensions, e.g. to make a pipeline depend on the server version.
+The information is read once per database connection and shared with the capability checks of the resources.
+(PostgreSQL System Information Functions)[https://www.postgresql.org/docs/current/functions-info.html]`
 )
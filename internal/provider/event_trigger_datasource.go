@@ -2,8 +2,12 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"terraform-provider-postgresql/internal/client"
@@ -19,6 +23,7 @@ var (
 		"ddl_command_end",
 		"sql_drop",
 		"table_rewrite",
+		client.EventTriggerEventLogin,
 	}
 )
 
@@ -75,9 +80,13 @@ func (d *eventTriggerDataSource) Schema(_ context.Context, _ datasource.SchemaRe
 				MarkdownDescription: "The event that triggers the event trigger",
 			},
 			"tags": schema.SetAttribute{
+				Optional:            true,
 				Computed:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "List of command tags that the event trigger will respond to",
+				MarkdownDescription: "List of command tags that the event trigger will respond to. If provided, the event trigger must respond to exactly these tags, compared case-insensitively, and they are kept as written.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(commandTagValidator{}),
+				},
 			},
 			"exec_func": schema.StringAttribute{
 				Computed:            true,
@@ -113,12 +122,22 @@ func (d *eventTriggerDataSource) Read(ctx context.Context, req datasource.ReadRe
 	if model.Database.IsNull() {
 		model.Database = types.StringValue(d.client.GetInitConfig().Database)
 	}
+	configTags := model.Tags
 
 	res.Diagnostics.Append(readEventTrigger(ctx, d.client, model.Database.ValueString(), model.Name.ValueString(), &model)...)
 	if res.Diagnostics.HasError() {
 		return
 	}
 
+	if !configTags.IsNull() {
+		model.Tags = preserveTagsCase(configTags, model.Tags)
+		if !model.Tags.Equal(configTags) {
+			res.Diagnostics.AddAttributeError(path.Root("tags"), "Event trigger tags mismatch",
+				fmt.Sprintf("The event trigger '%s' responds to the tags %s.", model.Name.ValueString(), model.Tags))
+			return
+		}
+	}
+
 	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
 	if res.Diagnostics.HasError() {
 		return
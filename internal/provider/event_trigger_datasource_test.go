@@ -6,6 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/stretchr/testify/assert"
 	"gocloud.dev/postgres"
+	"regexp"
 	"strconv"
 	"terraform-provider-postgresql/internal/client"
 	"terraform-provider-postgresql/internal/test"
@@ -67,6 +68,21 @@ func TestAccEventTriggerDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr(mockResourceName, "owner", runOpts.Username),
 					resource.TestCheckResourceAttr(mockResourceName, "comment", mockEventTriggerCreateParams.Comment),
 				)},
+			{
+				// the configured tags are kept as written
+				Config: testAccEventTriggerToTFDataSourceWithTags(t, mockResourceId, mockEventTriggerCreateParams.Name, runOpts.Database, `["create table"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "tags.#", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "tags.0", "create table"),
+				)},
+			{
+				Config:      testAccEventTriggerToTFDataSourceWithTags(t, mockResourceId, mockEventTriggerCreateParams.Name, runOpts.Database, `["DROP TABLE"]`),
+				ExpectError: regexp.MustCompile("Event trigger tags mismatch"),
+			},
+			{
+				Config:      testAccEventTriggerToTFDataSourceWithTags(t, mockResourceId, mockEventTriggerCreateParams.Name, runOpts.Database, `["CREATE NOTHING"]`),
+				ExpectError: regexp.MustCompile("Invalid command tag"),
+			},
 		},
 	})
 }
@@ -78,3 +94,12 @@ func testAccEventTriggerToTFDataSource(t *testing.T, resName, etName, etDatabase
 			database = "%s"
 		}`, resName, etName, etDatabase)
 }
+
+func testAccEventTriggerToTFDataSourceWithTags(t *testing.T, resName, etName, etDatabase, tags string) string {
+	t.Helper()
+	return fmt.Sprintf(`data "postgresql_event_trigger" "%s" {
+  			name     = "%s"
+			database = "%s"
+			tags     = %s
+		}`, resName, etName, etDatabase, tags)
+}
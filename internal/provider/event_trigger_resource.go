@@ -2,7 +2,10 @@ package provider
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -11,11 +14,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"slices"
 	"strings"
 	"terraform-provider-postgresql/internal/client"
 	"time"
@@ -26,22 +30,33 @@ type eventTriggerResource struct {
 }
 
 type eventTriggerResourceModel struct {
-	Id          types.String `tfsdk:"id"`
-	LastUpdated types.String `tfsdk:"last_updated"`
-	Name        types.String `tfsdk:"name"`
-	Event       types.String `tfsdk:"event"`
-	Tags        types.Set    `tfsdk:"tags"`
-	ExecFunc    types.String `tfsdk:"exec_func"`
-	Enabled     types.Bool   `tfsdk:"enabled"`
-	Database    types.String `tfsdk:"database"`
-	Owner       types.String `tfsdk:"owner"`
-	Comment     types.String `tfsdk:"comment"`
+	Id          types.String               `tfsdk:"id"`
+	LastUpdated types.String               `tfsdk:"last_updated"`
+	Name        types.String               `tfsdk:"name"`
+	Event       types.String               `tfsdk:"event"`
+	Tags        types.Set                  `tfsdk:"tags"`
+	ExecFunc    types.String               `tfsdk:"exec_func"`
+	Enabled     types.Bool                 `tfsdk:"enabled"`
+	Database    types.String               `tfsdk:"database"`
+	Owner       types.String               `tfsdk:"owner"`
+	Comment     types.String               `tfsdk:"comment"`
+	Function    *eventTriggerFunctionModel `tfsdk:"function"`
+	AssumeRole  types.String               `tfsdk:"assume_role"`
+}
+
+type eventTriggerFunctionModel struct {
+	Language        types.String `tfsdk:"language"`
+	Body            types.String `tfsdk:"body"`
+	SecurityDefiner types.Bool   `tfsdk:"security_definer"`
+	Schema          types.String `tfsdk:"schema"`
 }
 
 var (
-	_ resource.Resource                = &eventTriggerResource{}
-	_ resource.ResourceWithConfigure   = &eventTriggerResource{}
-	_ resource.ResourceWithImportState = &eventTriggerResource{}
+	_ resource.Resource                   = &eventTriggerResource{}
+	_ resource.ResourceWithConfigure      = &eventTriggerResource{}
+	_ resource.ResourceWithImportState    = &eventTriggerResource{}
+	_ resource.ResourceWithValidateConfig = &eventTriggerResource{}
+	_ resource.ResourceWithModifyPlan     = &eventTriggerResource{}
 )
 
 func NewEventTriggerResource() resource.Resource {
@@ -97,7 +112,7 @@ func (r *eventTriggerResource) Schema(_ context.Context, _ resource.SchemaReques
 			},
 			"event": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "The event that will trigger the event trigger",
+				MarkdownDescription: "The event that will trigger the event trigger. The `login` event requires PostgreSQL 17 or later and does not support tags.",
 				Validators: []validator.String{
 					stringvalidator.OneOf(eventTriggerEventOptions...),
 				},
@@ -108,14 +123,14 @@ func (r *eventTriggerResource) Schema(_ context.Context, _ resource.SchemaReques
 			"tags": schema.SetAttribute{
 				Optional:            true,
 				ElementType:         types.StringType,
-				MarkdownDescription: "List of command tags that the event trigger will respond to",
-				PlanModifiers: []planmodifier.Set{
-					setplanmodifier.RequiresReplace(),
+				MarkdownDescription: "List of command tags that the event trigger will respond to. Tags are case-insensitive and must fire the selected `event`, as listed by the [Event Trigger Firing Matrix](https://www.postgresql.org/docs/current/event-trigger-matrix.html) of the server version. The provider checks them against its own copy of the matrix, generated from the documentation of PostgreSQL 12 up to 17.",
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(commandTagValidator{}),
 				},
 			},
 			"exec_func": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "The function that will be executed when the event trigger fires",
+				MarkdownDescription: "The function that will be executed when the event trigger fires. With `function`, the unqualified name of the function the provider creates.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
 				},
@@ -132,16 +147,166 @@ func (r *eventTriggerResource) Schema(_ context.Context, _ resource.SchemaReques
 			"owner": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "The owner of the event trigger",
+				MarkdownDescription: "The owner of the event trigger. If not provided, the trigger is owned by the role that creates it (see `assume_role`).",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"function": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Definition of the function executed by the event trigger, created or replaced with the trigger and dropped with it. Adding it replaces the event trigger; removing it leaves the function in place.",
+				Attributes: map[string]schema.Attribute{
+					"language": schema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString("plpgsql"),
+						MarkdownDescription: "Language of the function body, e.g. `plpython3u`. SQL functions can't return `event_trigger`. Defaults to `plpgsql`.",
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"body": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Body of the function, e.g. `BEGIN RAISE NOTICE 'command %', tg_tag; END;`",
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"security_definer": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+						MarkdownDescription: "Whether the function runs with the privileges of its owner rather than the ones of the role running the command",
+					},
+					"schema": schema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						MarkdownDescription: "Schema of the function. If not provided, the first schema of the `search_path`. Changing it replaces the event trigger.",
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the event trigger. Overrides the provider `assume_role` attribute.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 		},
 		MarkdownDescription: mdDocResourceEventTrigger,
 	}
 }
 
+func (r *eventTriggerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	var model eventTriggerResourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Event.ValueString() == client.EventTriggerEventLogin && len(model.Tags.Elements()) > 0 {
+		res.Diagnostics.AddAttributeError(
+			path.Root("tags"),
+			"Invalid attribute combination",
+			"Event triggers on the 'login' event do not support tags.",
+		)
+	}
+
+	if model.Function != nil && strings.Contains(model.ExecFunc.ValueString(), ".") {
+		res.Diagnostics.AddAttributeError(
+			path.Root("exec_func"),
+			"Invalid attribute combination",
+			"The function created with the event trigger is named by an unqualified 'exec_func', its schema is set by 'function.schema'.",
+		)
+	}
+}
+
+func (r *eventTriggerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var model eventTriggerResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	db := plannedDatabase(r.client, model.Database)
+
+	if model.Event.ValueString() == client.EventTriggerEventLogin {
+		res.Diagnostics.Append(checkServerCapability(
+			ctx,
+			r.client,
+			db,
+			client.CapabilityLoginEventTrigger,
+			path.Root("event"),
+			"The 'login' event",
+		)...)
+	}
+
+	// the bundled function is only created with the trigger, and a function created by another
+	// resource of the same apply is referenced through its unknown name until then
+	if !model.ExecFunc.IsUnknown() && model.Function == nil {
+		roleCtx := client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+		res.Diagnostics.Append(validateEventTriggerFunction(roleCtx, r.client, db, model.ExecFunc.ValueString())...)
+	}
+
+	if !model.Event.IsUnknown() && !model.Tags.IsUnknown() && len(model.Tags.Elements()) > 0 {
+		info, diags := getServerInfo(ctx, r.client, db)
+		res.Diagnostics.Append(diags...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+
+		for _, tag := range mapSetValueToSlice[string](model.Tags) {
+			commandTag, ok := client.GetEventTriggerCommandTag(tag)
+			if ok && !commandTag.FiresEvent(model.Event.ValueString(), info.VersionNum) {
+				res.Diagnostics.AddAttributeError(
+					path.Root("tags"),
+					"Invalid command tag",
+					fmt.Sprintf("The command tag '%s' does not fire the '%s' event on %s.", commandTag.Tag, model.Event.ValueString(), info),
+				)
+			}
+		}
+	}
+
+	// the identifier is derived from the name, a rename produces a new one
+	if !req.State.Raw.IsNull() {
+		var stateModel eventTriggerResourceModel
+
+		res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+		if !model.Name.Equal(stateModel.Name) {
+			res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		}
+
+		// the tags are case-insensitive, only other commands need a new trigger
+		if !sameCommandTags(model.Tags, stateModel.Tags) {
+			res.RequiresReplace = append(res.RequiresReplace, path.Root("tags"))
+		}
+
+		// the trigger executes the function it was created with, a new function needs a new trigger
+		switch {
+		case model.Function != nil && stateModel.Function == nil:
+			res.RequiresReplace = append(res.RequiresReplace, path.Root("function"))
+		case model.Function != nil && !model.Function.Schema.IsUnknown() && !model.Function.Schema.Equal(stateModel.Function.Schema):
+			res.RequiresReplace = append(res.RequiresReplace, path.Root("function").AtName("schema"))
+		}
+	}
+}
+
 func (r *eventTriggerResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
 	tflog.Trace(ctx, "Creating 'event_trigger' resource")
 
@@ -162,6 +327,15 @@ func (r *eventTriggerResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	role := resolveAssumeRole(r.client, model.AssumeRole)
+	ctx = client.ContextWithAssumeRole(ctx, role)
+
+	// the trigger is owned by the assumed role, unless another owner is explicitly requested
+	expectedOwner := role
+	if !model.Owner.IsNull() && !model.Owner.IsUnknown() {
+		expectedOwner = model.Owner.ValueString()
+	}
+
 	createParams := client.EventTriggerCreateParams{
 		Name:     model.Name.ValueString(),
 		Event:    model.Event.ValueString(),
@@ -169,6 +343,8 @@ func (r *eventTriggerResource) Create(ctx context.Context, req resource.CreateRe
 		Enabled:  model.Enabled.ValueBool(),
 		Tags:     mapSetValueToSlice[string](model.Tags),
 		Comment:  model.Comment.ValueString(),
+		Owner:    expectedOwner,
+		Function: model.Function.toUserFunctionParams(model.ExecFunc),
 	}
 	err = conn.EventTriggerRepository().Create(ctx, createParams)
 	if err != nil {
@@ -180,15 +356,29 @@ func (r *eventTriggerResource) Create(ctx context.Context, req resource.CreateRe
 	model.SetLastUpdated()
 
 	// execute a Read operation to populate computed values
+	configTags := model.Tags
 	res.Diagnostics.Append(readEventTrigger(ctx, r.client, model.Database.ValueString(), model.Name.ValueString(), &model)...)
 	if res.Diagnostics.HasError() {
 		return
 	}
+	model.Tags = preserveTagsCase(configTags, model.Tags)
+
+	if model.Function != nil {
+		res.Diagnostics.Append(readEventTriggerFunction(ctx, r.client, model.Database.ValueString(), model.Name.ValueString(), model.Function)...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	res.Diagnostics.Append(res.State.Set(ctx, model)...)
 	if res.Diagnostics.HasError() {
 		return
 	}
+
+	res.Diagnostics.Append(verifyOwner("event trigger", expectedOwner, model.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
 	tflog.Trace(ctx, "Created 'event_trigger' resource")
 }
 
@@ -217,10 +407,20 @@ func (r *eventTriggerResource) Read(ctx context.Context, req resource.ReadReques
 	targetDb := idParts[0]
 	targetName := idParts[1]
 
+	stateTags := model.Tags
 	res.Diagnostics.Append(readEventTrigger(ctx, r.client, targetDb, targetName, &model)...)
 	if res.Diagnostics.HasError() {
 		return
 	}
+	model.Tags = preserveTagsCase(stateTags, model.Tags)
+
+	// the function is only tracked when it is managed with the trigger
+	if model.Function != nil {
+		res.Diagnostics.Append(readEventTriggerFunction(ctx, r.client, targetDb, targetName, model.Function)...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	if model.Id.IsNull() {
 		model.SetId()
@@ -241,6 +441,9 @@ func (r *eventTriggerResource) Update(ctx context.Context, req resource.UpdateRe
 
 	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
 	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
 
 	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
 	if err != nil {
@@ -248,12 +451,30 @@ func (r *eventTriggerResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	// only the attributes that changed are sent to the repository
 	updateParams := client.EventTriggerUpdateParams{
-		Name:    stateModel.Name.ValueString(),
-		NewName: planModel.Name.ValueStringPointer(),
-		Enabled: planModel.Enabled.ValueBoolPointer(),
-		Owner:   planModel.Owner.ValueStringPointer(),
-		Comment: planModel.Comment.ValueStringPointer(),
+		Name: stateModel.Name.ValueString(),
+	}
+	if !planModel.Name.Equal(stateModel.Name) {
+		updateParams.NewName = planModel.Name.ValueStringPointer()
+	}
+	if !planModel.Enabled.Equal(stateModel.Enabled) {
+		updateParams.Enabled = planModel.Enabled.ValueBoolPointer()
+	}
+	if !planModel.Owner.IsUnknown() && !planModel.Owner.Equal(stateModel.Owner) {
+		updateParams.Owner = planModel.Owner.ValueStringPointer()
+	}
+	if !planModel.Comment.Equal(stateModel.Comment) {
+		updateParams.Comment = planModel.Comment.ValueStringPointer()
+	}
+	if planModel.Function != nil && (stateModel.Function == nil || !planModel.Function.equal(*stateModel.Function)) {
+		updateParams.Function = planModel.Function.toUserFunctionParams(planModel.ExecFunc)
+	}
+	expectedOwner := ""
+	if updateParams.Owner != nil {
+		expectedOwner = *updateParams.Owner
 	}
 
 	pgModel, err := conn.EventTriggerRepository().Update(ctx, updateParams)
@@ -262,18 +483,33 @@ func (r *eventTriggerResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	planTags := planModel.Tags
 	err = mapPgModelToTerraformModel(pgModel, &planModel, make(map[string]any))
 	if err != nil {
 		res.Diagnostics.AddError(msgErrMapPgModel, err.Error())
 		return
 	}
+	planModel.Tags = preserveTagsCase(planTags, planModel.Tags)
+
+	if planModel.Function != nil {
+		res.Diagnostics.Append(readEventTriggerFunction(ctx, r.client, stateModel.Database.ValueString(), planModel.Name.ValueString(), planModel.Function)...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+	}
 
+	planModel.SetId()
 	planModel.SetLastUpdated()
 
 	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
 	if res.Diagnostics.HasError() {
 		return
 	}
+
+	res.Diagnostics.Append(verifyOwner("event trigger", expectedOwner, planModel.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
 	tflog.Trace(ctx, "Updated 'event_trigger' resource")
 }
 
@@ -292,7 +528,13 @@ func (r *eventTriggerResource) Delete(ctx context.Context, req resource.DeleteRe
 		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
 		return
 	}
-	err = conn.EventTriggerRepository().Drop(ctx, model.Name.ValueString())
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	if model.Function != nil {
+		err = conn.EventTriggerRepository().DropWithFunction(ctx, model.Name.ValueString(), model.Function.Schema.ValueString(), model.ExecFunc.ValueString())
+	} else {
+		err = conn.EventTriggerRepository().Drop(ctx, model.Name.ValueString())
+	}
 	if err != nil {
 		res.Diagnostics.AddError("Error deleting event_trigger", err.Error())
 		return
@@ -319,6 +561,8 @@ func readEventTrigger(ctx context.Context, pgClient client.PgClient, db, name st
 		return diags
 	}
 
+	pgModel.Tags = normalizeCommandTags(pgModel.Tags)
+
 	_, diagErr := types.SetValueFrom(ctx, types.StringType, pgModel.Tags)
 	if diagErr.HasError() {
 		diags.Append(diagErr...)
@@ -334,6 +578,117 @@ func readEventTrigger(ctx context.Context, pgClient client.PgClient, db, name st
 	return diags
 }
 
+// readEventTriggerFunction reads the definition of the function executed by the trigger into target.
+func readEventTriggerFunction(ctx context.Context, pgClient client.PgClient, db, name string, target *eventTriggerFunctionModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	conn, err := pgClient.GetConnection(ctx, db)
+	if err != nil {
+		diags.AddError(msgErrGetPgConnection, err.Error())
+		return diags
+	}
+
+	function, err := conn.EventTriggerRepository().GetFunction(ctx, name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading the function of the event_trigger: '%s'", name), err.Error())
+		return diags
+	}
+
+	target.Language = types.StringValue(function.Lang)
+	target.Body = types.StringValue(function.Body)
+	target.SecurityDefiner = types.BoolValue(function.SecurityDefiner)
+	target.Schema = types.StringValue(function.Schema)
+	return diags
+}
+
+// validateEventTriggerFunction checks that the function exists, returns event_trigger
+// and can be executed by the role that manages the event trigger.
+func validateEventTriggerFunction(ctx context.Context, pgClient client.PgClient, db, execFunc string) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	attrPath := path.Root("exec_func")
+
+	conn, err := pgClient.GetConnection(ctx, db)
+	if err != nil {
+		diags.AddError(msgErrGetPgConnection, err.Error())
+		return diags
+	}
+
+	function, err := conn.UserFunctionRepository().Get(ctx, execFunc)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		diags.AddAttributeError(attrPath, "Event trigger function not found",
+			fmt.Sprintf("The function '%s()' does not exist in the database '%s'.", execFunc, db))
+	case err != nil:
+		diags.AddAttributeError(attrPath, fmt.Sprintf("Error reading the function '%s()'", execFunc), err.Error())
+	case function.Returns != "event_trigger":
+		diags.AddAttributeError(attrPath, "Invalid event trigger function",
+			fmt.Sprintf("The function '%s.%s()' returns '%s', event trigger functions must return 'event_trigger'.", function.Schema, function.Name, function.Returns))
+	case !function.Executable:
+		diags.AddAttributeError(attrPath, "Event trigger function not accessible",
+			fmt.Sprintf("The role managing the event trigger lacks the EXECUTE privilege on '%s.%s()'.", function.Schema, function.Name))
+	}
+
+	return diags
+}
+
+// preserveTagsCase keeps the tags as written in the configuration when they only differ
+// from the tags stored by PostgreSQL in their case or spacing.
+func preserveTagsCase(prior, actual types.Set) types.Set {
+	if sameCommandTags(prior, actual) {
+		return prior
+	}
+	return actual
+}
+
+// sameCommandTags reports whether both sets hold the same command tags once normalized.
+func sameCommandTags(a, b types.Set) bool {
+	if a.IsNull() || a.IsUnknown() || b.IsNull() || b.IsUnknown() || len(a.Elements()) != len(b.Elements()) {
+		return a.Equal(b)
+	}
+
+	aTags := normalizeCommandTags(mapSetValueToSlice[string](a))
+	bTags := normalizeCommandTags(mapSetValueToSlice[string](b))
+	for _, tag := range aTags {
+		if !slices.Contains(bTags, tag) {
+			return false
+		}
+	}
+	for _, tag := range bTags {
+		if !slices.Contains(aTags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeCommandTags(tags []string) []string {
+	for i, tag := range tags {
+		tags[i] = client.NormalizeCommandTag(tag)
+	}
+	return tags
+}
+
+// toUserFunctionParams returns the parameters creating or replacing the function named execFunc, nil without function.
+func (fm *eventTriggerFunctionModel) toUserFunctionParams(execFunc types.String) *client.UserFunctionCreateParams {
+	if fm == nil {
+		return nil
+	}
+	return &client.UserFunctionCreateParams{
+		Name:            execFunc.ValueString(),
+		Schema:          fm.Schema.ValueString(),
+		Returns:         "event_trigger",
+		Lang:            fm.Language.ValueString(),
+		Body:            fm.Body.ValueString(),
+		SecurityDefiner: fm.SecurityDefiner.ValueBool(),
+		Replace:         true,
+	}
+}
+
+// equal reports whether both functions have the same definition, regardless of their schema.
+func (fm eventTriggerFunctionModel) equal(other eventTriggerFunctionModel) bool {
+	return fm.Language.Equal(other.Language) && fm.Body.Equal(other.Body) && fm.SecurityDefiner.Equal(other.SecurityDefiner)
+}
+
 func (rm *eventTriggerResourceModel) SetId() {
 	rm.Id = types.StringValue(fmt.Sprintf("%s.%s", rm.Database.ValueString(), rm.Name.ValueString()))
 }
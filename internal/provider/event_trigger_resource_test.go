@@ -3,9 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/stretchr/testify/assert"
 	"gocloud.dev/postgres"
+	"regexp"
 	"strconv"
 	"terraform-provider-postgresql/internal/client"
 	"terraform-provider-postgresql/internal/test"
@@ -53,6 +57,30 @@ func TestAccEventTriggerResource(t *testing.T) {
 			assert.NoError(t, userFunctionRepo.Create(ctx, mockUserFunctionCreateParams))
 		},
 		Steps: []resource.TestStep{
+			{
+				// Plan time validation of the function
+				Config: testAccEventTriggerToTFResource(t, mockResourceId, client.EventTriggerModel{
+					Name:     mockEventTriggerModel.Name,
+					Event:    mockEventTriggerModel.Event,
+					Tags:     mockEventTriggerModel.Tags,
+					ExecFunc: "test_event_trigger_missing_func",
+					Enabled:  true,
+					Database: runOpts.Database,
+				}),
+				ExpectError: regexp.MustCompile("Event trigger function not found"),
+			},
+			{
+				// Plan time validation of the tags for the event
+				Config: testAccEventTriggerToTFResource(t, mockResourceId, client.EventTriggerModel{
+					Name:     mockEventTriggerModel.Name,
+					Event:    "table_rewrite",
+					Tags:     []string{"CREATE TABLE"},
+					ExecFunc: mockEventTriggerModel.ExecFunc,
+					Enabled:  true,
+					Database: runOpts.Database,
+				}),
+				ExpectError: regexp.MustCompile("Invalid command tag"),
+			},
 			{
 				// Create and Read testing
 				Config: testAccEventTriggerToTFResource(t, mockResourceId, mockEventTriggerModel),
@@ -89,6 +117,22 @@ func TestAccEventTriggerResource(t *testing.T) {
 					resource.TestCheckResourceAttr(mockResourceName, "comment", mockEventTriggerModel.Comment),
 				),
 			},
+			{
+				// Update testing - the tags are case-insensitive, a new spelling keeps the trigger
+				PreConfig: func() {
+					mockEventTriggerModel.Tags = []string{"create table"}
+				},
+				Config: testAccEventTriggerToTFResource(t, mockResourceId, mockEventTriggerModel),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction(mockResourceName, plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "tags.#", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "tags.0", "create table"),
+				),
+			},
 			{
 				// Update testing - Properties WITH re-creating the resource
 				PreConfig: func() {
@@ -109,6 +153,216 @@ func TestAccEventTriggerResource(t *testing.T) {
 	})
 }
 
+func TestAccEventTriggerResource_LoginEvent(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Image:    "postgres:17-alpine",
+		Database: "test_event_trigger_login_db",
+		Username: "test_event_trigger_login_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockUserFunctionCreateParams := client.UserFunctionCreateParams{
+		Name:    "test_event_trigger_login_func",
+		Returns: "event_trigger",
+		Lang:    "plpgsql",
+		Body:    "BEGIN RAISE NOTICE 'login'; END;",
+		Replace: true,
+	}
+	mockResourceId := "test_event_trigger"
+	mockResourceName := fmt.Sprintf("postgresql_event_trigger.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			userFunctionRepo := client.NewUserFunctionRepository(db)
+			assert.NoError(t, userFunctionRepo.Create(ctx, mockUserFunctionCreateParams))
+		},
+		Steps: []resource.TestStep{
+			{
+				// login triggers do not accept tags
+				Config: fmt.Sprintf(`resource "postgresql_event_trigger" "%s" {
+					name      = "test_event_trigger_login"
+					event     = "login"
+					tags      = ["CREATE TABLE"]
+					exec_func = "%s"
+				}`, mockResourceId, mockUserFunctionCreateParams.Name),
+				ExpectError: regexp.MustCompile("do not support tags"),
+			},
+			{
+				Config: testAccEventTriggerLoginToTFResource(t, mockResourceId, mockUserFunctionCreateParams.Name),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "event", client.EventTriggerEventLogin),
+					resource.TestCheckResourceAttr(mockResourceName, "tags.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccEventTriggerResource_LoginEventUnsupported(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Image:    "postgres:16-alpine",
+		Database: "test_event_trigger_login_db",
+	}
+	test.LoadPostgresTestContainer(t, runOpts, true)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccEventTriggerLoginToTFResource(t, "test_event_trigger", "test_event_trigger_login_func"),
+				ExpectError: regexp.MustCompile("requires PostgreSQL 17 or later"),
+			},
+		},
+	})
+}
+
+func TestAccEventTriggerResource_Function(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_event_trigger_function_db",
+		Username: "test_event_trigger_function_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_event_trigger"
+	mockResourceName := fmt.Sprintf("postgresql_event_trigger.%s", mockResourceId)
+	body := "BEGIN RAISE NOTICE 'audit %', tg_tag; END;"
+
+	functionExists := func(name string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			exists, err := client.NewUserFunctionRepository(db).Exists(ctx, name)
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return fmt.Errorf("function %s does not exist", name)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `CREATE SCHEMA audit;`)
+			assert.NoError(t, err)
+		},
+		CheckDestroy: func(*terraform.State) error {
+			exists, err := client.NewUserFunctionRepository(db).Exists(ctx, "audit.test_event_trigger_audit_func")
+			if err != nil {
+				return err
+			}
+			if exists {
+				return fmt.Errorf("the function of the event trigger was not dropped")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				// The function is named by exec_func and function.schema
+				Config:      testAccEventTriggerFunctionToTFResource(t, mockResourceId, "audit.test_event_trigger_audit_func", body, false),
+				ExpectError: regexp.MustCompile("Invalid attribute combination"),
+			},
+			{
+				// Create and Read testing
+				Config: testAccEventTriggerFunctionToTFResource(t, mockResourceId, "test_event_trigger_audit_func", body, true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "exec_func", "test_event_trigger_audit_func"),
+					resource.TestCheckResourceAttr(mockResourceName, "function.language", "plpgsql"),
+					resource.TestCheckResourceAttr(mockResourceName, "function.body", body),
+					resource.TestCheckResourceAttr(mockResourceName, "function.security_definer", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "function.schema", "audit"),
+					functionExists("audit.test_event_trigger_audit_func"),
+				),
+			},
+			{
+				// Drift of the function body
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `
+						CREATE OR REPLACE FUNCTION audit.test_event_trigger_audit_func() RETURNS event_trigger
+						LANGUAGE plpgsql SECURITY DEFINER AS $$BEGIN NULL; END;$$;`)
+					assert.NoError(t, err)
+				},
+				Config:             testAccEventTriggerFunctionToTFResource(t, mockResourceId, "test_event_trigger_audit_func", body, true),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// Update testing - the function is replaced in place
+				Config: testAccEventTriggerFunctionToTFResource(t, mockResourceId, "test_event_trigger_audit_func", body, false),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "function.body", body),
+					resource.TestCheckResourceAttr(mockResourceName, "function.security_definer", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "function.schema", "audit"),
+				),
+			},
+		},
+	})
+}
+
+func TestSameCommandTags(t *testing.T) {
+	tagSet := func(tags ...string) types.Set {
+		set, diags := types.SetValueFrom(context.TODO(), types.StringType, tags)
+		assert.False(t, diags.HasError())
+		return set
+	}
+	tests := []struct {
+		name string
+		a    types.Set
+		b    types.Set
+		same bool
+	}{
+		{name: "spelling", a: tagSet("create table", "DROP  TABLE"), b: tagSet("CREATE TABLE", "DROP TABLE"), same: true},
+		{name: "other tag", a: tagSet("CREATE TABLE"), b: tagSet("DROP TABLE"), same: false},
+		{name: "duplicate spelling", a: tagSet("create table", "CREATE TABLE"), b: tagSet("CREATE TABLE", "DROP TABLE"), same: false},
+		{name: "other size", a: tagSet("CREATE TABLE"), b: tagSet("CREATE TABLE", "DROP TABLE"), same: false},
+		{name: "null", a: types.SetNull(types.StringType), b: types.SetNull(types.StringType), same: true},
+		{name: "null and empty", a: types.SetNull(types.StringType), b: types.SetValueMust(types.StringType, nil), same: false},
+		{name: "unknown", a: types.SetUnknown(types.StringType), b: tagSet("CREATE TABLE"), same: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.same, sameCommandTags(tt.a, tt.b))
+		})
+	}
+}
+
+func testAccEventTriggerFunctionToTFResource(t *testing.T, resId, execFunc, body string, securityDefiner bool) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_event_trigger" "%s" {
+			name      = "test_event_trigger_audit"
+			event     = "ddl_command_end"
+			exec_func = "%s"
+			function = {
+				body             = "%s"
+				security_definer = %v
+				schema           = "audit"
+			}
+		}`, resId, execFunc, body, securityDefiner)
+}
+
+func testAccEventTriggerLoginToTFResource(t *testing.T, resId, execFunc string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_event_trigger" "%s" {
+			name      = "test_event_trigger_login"
+			event     = "login"
+			exec_func = "%s"
+		}`, resId, execFunc)
+}
+
 func testAccEventTriggerToTFResource(t *testing.T, resId string, pgModel client.EventTriggerModel) string {
 	t.Helper()
 	return fmt.Sprintf(`resource "postgresql_event_trigger" "%s" {
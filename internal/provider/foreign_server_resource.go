@@ -0,0 +1,401 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type foreignServerResource struct {
+	client client.PgClient
+}
+
+type foreignServerResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+	Database    types.String `tfsdk:"database"`
+	Name        types.String `tfsdk:"name"`
+	Wrapper     types.String `tfsdk:"foreign_data_wrapper"`
+	Type        types.String `tfsdk:"type"`
+	Version     types.String `tfsdk:"version"`
+	Options     types.Map    `tfsdk:"options"`
+	Owner       types.String `tfsdk:"owner"`
+	Comment     types.String `tfsdk:"comment"`
+	AssumeRole  types.String `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                = &foreignServerResource{}
+	_ resource.ResourceWithConfigure   = &foreignServerResource{}
+	_ resource.ResourceWithImportState = &foreignServerResource{}
+	_ resource.ResourceWithModifyPlan  = &foreignServerResource{}
+)
+
+func NewForeignServerResource() resource.Resource {
+	return &foreignServerResource{}
+}
+
+func (r *foreignServerResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'foreign_server' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *foreignServerResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_foreign_server"
+}
+
+func (r *foreignServerResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the foreign server, in the format `database_name.server_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the foreign server",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the foreign server is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the foreign server. Changes rename the server in place.",
+				Validators:          nonEmptyString,
+			},
+			"foreign_data_wrapper": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Foreign data wrapper of the server, e.g. `postgres_fdw`. Its extension must be installed in the database.",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Type of the server, only meaningful to some foreign data wrappers",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"version": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Version of the server, only meaningful to some foreign data wrappers",
+				Validators:          nonEmptyString,
+			},
+			"options": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Options of the server, specific to the foreign data wrapper, e.g. `host`, `port` and `dbname` for `postgres_fdw`. Changes are applied in place, option by option.",
+			},
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The owner of the foreign server. If not provided, the server is owned by the role that creates it (see `assume_role`).",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the foreign server",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the foreign server. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceForeignServer,
+	}
+}
+
+func (r *foreignServerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on create or destroy
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var model, stateModel foreignServerResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// the identifier is derived from the name, a rename produces a new one
+	if !model.Name.Equal(stateModel.Name) {
+		res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+}
+
+func (r *foreignServerResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'foreign_server' resource")
+
+	var model foreignServerResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	role := resolveAssumeRole(r.client, model.AssumeRole)
+	ctx = client.ContextWithAssumeRole(ctx, role)
+
+	// the server is owned by the assumed role, unless another owner is explicitly requested
+	expectedOwner := role
+	if !model.Owner.IsNull() && !model.Owner.IsUnknown() {
+		expectedOwner = model.Owner.ValueString()
+	}
+
+	pgModel := model.toPgModel()
+	pgModel.Owner = expectedOwner
+
+	repository := conn.ForeignServerRepository()
+	if err = repository.Create(ctx, pgModel); err != nil {
+		res.Diagnostics.AddError("Error creating foreign server", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readForeignServerModel(ctx, repository, model.Name.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("foreign server", expectedOwner, model.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'foreign_server' resource")
+}
+
+func (r *foreignServerResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'foreign_server' resource")
+
+	var model foreignServerResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the foreign server", "Id is required for reading foreign server")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 2)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the foreign server", "Id should be in the format 'database_name.server_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a foreign server dropped outside of Terraform is removed from the state and planned again
+	repository := conn.ForeignServerRepository()
+	exists, err := repository.Exists(ctx, idParts[1])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading foreign server", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Foreign server not found, removing it from the state", map[string]any{"foreign_server": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readForeignServerModel(ctx, repository, idParts[1], &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'foreign_server' resource")
+}
+
+func (r *foreignServerResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'foreign_server' resource")
+
+	var stateModel foreignServerResourceModel
+	var planModel foreignServerResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	current := stateModel.toPgModel()
+	desired := planModel.toPgModel()
+	if planModel.Owner.IsUnknown() {
+		desired.Owner = current.Owner
+	}
+
+	repository := conn.ForeignServerRepository()
+	_, err = repository.Update(ctx, client.ForeignServerUpdateParams{Current: current, Desired: desired})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating foreign server", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readForeignServerModel(ctx, repository, planModel.Name.ValueString(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("foreign server", desired.Owner, planModel.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'foreign_server' resource")
+}
+
+func (r *foreignServerResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'foreign_server' resource")
+
+	var model foreignServerResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	if err = conn.ForeignServerRepository().Drop(ctx, model.Name.ValueString()); err != nil {
+		res.Diagnostics.AddError("Error deleting foreign server", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'foreign_server' resource")
+}
+
+func (r *foreignServerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+func readForeignServerModel(ctx context.Context, repository client.ForeignServerRepository, name string, target *foreignServerResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading foreign server: '%s'", name), err.Error())
+		return diags
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *foreignServerResourceModel) toPgModel() client.ForeignServerModel {
+	return client.ForeignServerModel{
+		Name:     rm.Name.ValueString(),
+		Database: rm.Database.ValueString(),
+		Wrapper:  rm.Wrapper.ValueString(),
+		Type:     rm.Type.ValueString(),
+		Version:  rm.Version.ValueString(),
+		Options:  mapStringMapValue(rm.Options),
+		Owner:    rm.Owner.ValueString(),
+		Comment:  rm.Comment.ValueString(),
+	}
+}
+
+func (rm *foreignServerResourceModel) fromPgModel(pgModel client.ForeignServerModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Wrapper = types.StringValue(pgModel.Wrapper)
+	rm.Type = stringValueOrNull(pgModel.Type)
+	rm.Version = stringValueOrNull(pgModel.Version)
+	rm.Options = mapToStringMapValue(pgModel.Options)
+	rm.Owner = types.StringValue(pgModel.Owner)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+}
+
+func (rm *foreignServerResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s", rm.Database.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *foreignServerResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+// testAccPrepareForeignDataTestCase starts a remote server holding the remote_app schema, and the local
+// one used by the provider, sharing a network where the remote is reachable as 'remote'. The local
+// database has the postgres_fdw extension and the local_app schema.
+func testAccPrepareForeignDataTestCase(t *testing.T, runOpts test.PostgresContainerRunOptions) (context.Context, *sql.DB, test.PostgresContainerRunOptions) {
+	t.Helper()
+	nw := test.NewTestNetwork(t)
+	remoteOpts := test.PostgresContainerRunOptions{
+		Database:     "test_remote_db",
+		Username:     "test_remote_user",
+		Password:     "test_remote_password",
+		Network:      nw,
+		NetworkAlias: "remote",
+	}
+	runOpts.Network = nw
+	ctx := context.TODO()
+
+	remote, err := postgres.Open(ctx, test.GetPostgresConnectionString(t, test.LoadPostgresTestContainer(t, remoteOpts, false)))
+	assert.NoError(t, err)
+	defer remote.Close()
+
+	_, err = remote.ExecContext(ctx, `
+		CREATE SCHEMA remote_app;
+		CREATE TABLE remote_app.orders (id int PRIMARY KEY, status text NOT NULL);
+		CREATE TABLE remote_app.items (id int PRIMARY KEY, order_id int, name text);`)
+	assert.NoError(t, err)
+
+	db, err := postgres.Open(ctx, test.GetPostgresConnectionString(t, test.LoadPostgresTestContainer(t, runOpts, true)))
+	assert.NoError(t, err)
+
+	_, err = db.ExecContext(ctx, `
+		CREATE EXTENSION postgres_fdw;
+		CREATE SCHEMA local_app;`)
+	assert.NoError(t, err)
+	return ctx, db, remoteOpts
+}
+
+func TestAccForeignServerResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_foreign_server_resource_db",
+		Username: "test_foreign_server_resource_user",
+	}
+	ctx, db, remoteOpts := testAccPrepareForeignDataTestCase(t, runOpts)
+	defer db.Close()
+
+	mockResourceId := "test_foreign_server"
+	mockResourceName := fmt.Sprintf("postgresql_foreign_server.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `CREATE ROLE test_foreign_server_owner;`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccForeignServerToTFResource(t, mockResourceId, "test_foreign_server_resource", fmt.Sprintf(`
+					version = "16"
+					options = {
+						host   = "remote"
+						port   = "5432"
+						dbname = "%s"
+					}
+					comment = "test comment"`, remoteOpts.Database)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.test_foreign_server_resource", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "foreign_data_wrapper", "postgres_fdw"),
+					resource.TestCheckResourceAttr(mockResourceName, "version", "16"),
+					resource.TestCheckResourceAttr(mockResourceName, "options.%", "3"),
+					resource.TestCheckResourceAttr(mockResourceName, "options.host", "remote"),
+					resource.TestCheckResourceAttr(mockResourceName, "owner", runOpts.Username),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment"),
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				// Update testing - Rename, options added, set and dropped, version removed, owner changed
+				Config: testAccForeignServerToTFResource(t, mockResourceId, "test_foreign_server_resource_renamed", fmt.Sprintf(`
+					options = {
+						host             = "remote"
+						dbname           = "%s"
+						fetch_size       = "500"
+					}
+					owner = "test_foreign_server_owner"`, remoteOpts.Database)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.test_foreign_server_resource_renamed", runOpts.Database)),
+					resource.TestCheckNoResourceAttr(mockResourceName, "version"),
+					resource.TestCheckResourceAttr(mockResourceName, "options.%", "3"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "options.port"),
+					resource.TestCheckResourceAttr(mockResourceName, "options.fetch_size", "500"),
+					resource.TestCheckResourceAttr(mockResourceName, "owner", "test_foreign_server_owner"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "comment"),
+				),
+			},
+			{
+				// Drift testing - a foreign server dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP SERVER test_foreign_server_resource_renamed;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccForeignServerToTFResource(t, mockResourceId, "test_foreign_server_resource_renamed", fmt.Sprintf(`
+					options = {
+						host             = "remote"
+						dbname           = "%s"
+						fetch_size       = "500"
+					}
+					owner = "test_foreign_server_owner"`, remoteOpts.Database)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(mockResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccForeignServerToTFResource(t *testing.T, resId, name, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_foreign_server" "%s" {
+			name                 = "%s"
+			foreign_data_wrapper = "postgres_fdw"
+			%s
+		}`, resId, name, body)
+}
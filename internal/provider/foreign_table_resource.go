@@ -0,0 +1,431 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type foreignTableResource struct {
+	client client.PgClient
+}
+
+type foreignTableResourceModel struct {
+	Id          types.String              `tfsdk:"id"`
+	LastUpdated types.String              `tfsdk:"last_updated"`
+	Database    types.String              `tfsdk:"database"`
+	Schema      types.String              `tfsdk:"schema"`
+	Name        types.String              `tfsdk:"name"`
+	Server      types.String              `tfsdk:"server"`
+	Columns     []foreignTableColumnModel `tfsdk:"columns"`
+	Options     types.Map                 `tfsdk:"options"`
+	Comment     types.String              `tfsdk:"comment"`
+	AssumeRole  types.String              `tfsdk:"assume_role"`
+}
+
+type foreignTableColumnModel struct {
+	Name     types.String `tfsdk:"name"`
+	Type     types.String `tfsdk:"type"`
+	Nullable types.Bool   `tfsdk:"nullable"`
+	Options  types.Map    `tfsdk:"options"`
+}
+
+var (
+	_ resource.Resource                = &foreignTableResource{}
+	_ resource.ResourceWithConfigure   = &foreignTableResource{}
+	_ resource.ResourceWithImportState = &foreignTableResource{}
+	_ resource.ResourceWithModifyPlan  = &foreignTableResource{}
+)
+
+func NewForeignTableResource() resource.Resource {
+	return &foreignTableResource{}
+}
+
+func (r *foreignTableResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'foreign_table' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *foreignTableResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_foreign_table"
+}
+
+func (r *foreignTableResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the foreign table, in the format `database_name.schema_name.table_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the foreign table",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the foreign table is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("public"),
+				MarkdownDescription: "Schema of the foreign table. Changing it moves the table with `ALTER FOREIGN TABLE ... SET SCHEMA`.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the foreign table. Changes rename the table in place.",
+				Validators:          nonEmptyString,
+			},
+			"server": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the foreign server storing the rows of the table",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"columns": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Columns of the foreign table, in order. Columns are matched by name: renaming a column drops it and adds a new one.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the column",
+							Validators:          nonEmptyString,
+						},
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Data type of the column, e.g. `text` or `numeric(10, 2)`",
+							Validators:          nonEmptyString,
+						},
+						"nullable": schema.BoolAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+							MarkdownDescription: "Whether the column accepts null values. The constraint isn't enforced on the remote data. Defaults to `true`.",
+						},
+						"options": schema.MapAttribute{
+							Optional:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Options of the column, specific to the foreign data wrapper, e.g. `column_name` for `postgres_fdw`",
+						},
+					},
+				},
+			},
+			"options": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Options of the foreign table, specific to the foreign data wrapper, e.g. `schema_name` and `table_name` for `postgres_fdw`. Changes are applied in place, option by option.",
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the foreign table",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the foreign table. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceForeignTable,
+	}
+}
+
+func (r *foreignTableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on create or destroy
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var model, stateModel foreignTableResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// the identifier is derived from the schema and the name, moving the table produces a new one
+	if !model.Schema.Equal(stateModel.Schema) || !model.Name.Equal(stateModel.Name) {
+		res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+}
+
+func (r *foreignTableResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'foreign_table' resource")
+
+	var model foreignTableResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	repository := conn.ForeignTableRepository()
+	if err = repository.Create(ctx, model.toPgModel()); err != nil {
+		res.Diagnostics.AddError("Error creating foreign table", err.Error())
+		return
+	}
+
+	pgModel, err := repository.Get(ctx, model.Schema.ValueString(), model.Name.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(fmt.Sprintf("Error reading foreign table: '%s'", model.Name.ValueString()), err.Error())
+		return
+	}
+	res.Diagnostics.Append(readForeignTableModel(ctx, repository, pgModel, &model)...)
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'foreign_table' resource")
+}
+
+func (r *foreignTableResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'foreign_table' resource")
+
+	var model foreignTableResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the foreign table", "Id is required for reading foreign table")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 3)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the foreign table", "Id should be in the format 'database_name.schema_name.table_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a foreign table dropped outside of Terraform is removed from the state and planned again
+	repository := conn.ForeignTableRepository()
+	exists, err := repository.Exists(ctx, idParts[1], idParts[2])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading foreign table", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Foreign table not found, removing it from the state", map[string]any{"foreign_table": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	pgModel, err := repository.Get(ctx, idParts[1], idParts[2])
+	if err != nil {
+		res.Diagnostics.AddError(fmt.Sprintf("Error reading foreign table: '%s'", model.Id.ValueString()), err.Error())
+		return
+	}
+	res.Diagnostics.Append(readForeignTableModel(ctx, repository, pgModel, &model)...)
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'foreign_table' resource")
+}
+
+func (r *foreignTableResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'foreign_table' resource")
+
+	var stateModel foreignTableResourceModel
+	var planModel foreignTableResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	repository := conn.ForeignTableRepository()
+	pgModel, err := repository.Update(ctx, client.ForeignTableUpdateParams{
+		Current: stateModel.toPgModel(),
+		Desired: planModel.toPgModel(),
+	})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating foreign table", err.Error())
+		return
+	}
+	res.Diagnostics.Append(readForeignTableModel(ctx, repository, pgModel, &planModel)...)
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'foreign_table' resource")
+}
+
+func (r *foreignTableResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'foreign_table' resource")
+
+	var model foreignTableResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	err = conn.ForeignTableRepository().Drop(ctx, model.Schema.ValueString(), model.Name.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("Error deleting foreign table", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'foreign_table' resource")
+}
+
+func (r *foreignTableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readForeignTableModel maps the foreign table read from the server into the target model, keeping
+// the spelling of the column types of the target model, as readTableModel does.
+func readForeignTableModel(ctx context.Context, repository client.ForeignTableRepository, pgModel *client.ForeignTableModel, target *foreignTableResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	actual := *pgModel
+
+	if len(target.Columns) > 0 {
+		prior := target.toPgModel()
+		normalized, err := repository.Normalize(ctx, prior)
+		if err != nil {
+			// the prior definition may no longer be valid, e.g. it references a dropped server
+			diags.AddWarning(
+				"Unable to normalize the foreign table definition",
+				fmt.Sprintf("The foreign table '%s.%s' is read with the spelling of the server, the types written differently in the configuration show up as changes: %s", target.Schema.ValueString(), target.Name.ValueString(), err),
+			)
+		} else {
+			actual = client.PreserveForeignTableSpelling(prior, *normalized, actual)
+		}
+	}
+
+	target.fromPgModel(actual)
+	return diags
+}
+
+func (rm *foreignTableResourceModel) toPgModel() client.ForeignTableModel {
+	pgModel := client.ForeignTableModel{
+		Schema:   rm.Schema.ValueString(),
+		Name:     rm.Name.ValueString(),
+		Database: rm.Database.ValueString(),
+		Server:   rm.Server.ValueString(),
+		Options:  mapStringMapValue(rm.Options),
+		Comment:  rm.Comment.ValueString(),
+	}
+
+	for _, column := range rm.Columns {
+		pgModel.Columns = append(pgModel.Columns, client.ForeignTableColumn{
+			Name:     column.Name.ValueString(),
+			Type:     column.Type.ValueString(),
+			Nullable: column.Nullable.ValueBool(),
+			Options:  mapStringMapValue(column.Options),
+		})
+	}
+	return pgModel
+}
+
+func (rm *foreignTableResourceModel) fromPgModel(pgModel client.ForeignTableModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Schema = types.StringValue(pgModel.Schema)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Server = types.StringValue(pgModel.Server)
+	rm.Options = mapToStringMapValue(pgModel.Options)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+
+	rm.Columns = nil
+	for _, column := range pgModel.Columns {
+		rm.Columns = append(rm.Columns, foreignTableColumnModel{
+			Name:     types.StringValue(column.Name),
+			Type:     types.StringValue(column.Type),
+			Nullable: types.BoolValue(column.Nullable),
+			Options:  mapToStringMapValue(column.Options),
+		})
+	}
+}
+
+func (rm *foreignTableResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s.%s", rm.Database.ValueString(), rm.Schema.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *foreignTableResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccForeignTableResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_foreign_table_resource_db",
+		Username: "test_foreign_table_resource_user",
+	}
+	ctx, db, remoteOpts := testAccPrepareForeignDataTestCase(t, runOpts)
+	defer db.Close()
+
+	mockResourceId := "test_foreign_table"
+	mockResourceName := fmt.Sprintf("postgresql_foreign_table.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, fmt.Sprintf(`
+				CREATE SERVER test_foreign_table_server FOREIGN DATA WRAPPER postgres_fdw OPTIONS (host 'remote', dbname '%s');
+				CREATE USER MAPPING FOR CURRENT_USER SERVER test_foreign_table_server OPTIONS (user '%s', password '%s');`,
+				remoteOpts.Database, remoteOpts.Username, remoteOpts.Password))
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccForeignTableToTFResource(t, mockResourceId, "test_foreign_table_resource", `
+					columns = [
+						{ name = "id", type = "int", nullable = false },
+						{ name = "state", type = "varchar(20)", options = { column_name = "status" } },
+					]
+					options = {
+						schema_name = "remote_app"
+						table_name  = "orders"
+					}
+					comment = "test comment"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_foreign_table_resource", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "server", "test_foreign_table_server"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.0.type", "int"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.0.nullable", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.1.type", "varchar(20)"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.1.options.column_name", "status"),
+					resource.TestCheckResourceAttr(mockResourceName, "options.table_name", "orders"),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment"),
+					// the rows are read from the remote table
+					func(_ *terraform.State) error {
+						_, err := db.ExecContext(ctx, `SELECT id, state FROM public.test_foreign_table_resource;`)
+						return err
+					},
+				),
+			},
+			{
+				// ImportState testing, the server spells the types in their canonical form
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "columns.0.type", "columns.1.type"},
+			},
+			{
+				// Update testing - Moved, columns and options changed without re-creating the resource
+				Config: testAccForeignTableToTFResource(t, mockResourceId, "test_foreign_table_resource_moved", `
+					schema  = "local_app"
+					columns = [
+						{ name = "id", type = "bigint", nullable = false },
+						{ name = "status", type = "text" },
+					]
+					options = {
+						schema_name = "remote_app"
+						table_name  = "orders"
+						fetch_size  = "100"
+					}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.local_app.test_foreign_table_resource_moved", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.0.type", "bigint"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.1.name", "status"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "columns.1.options"),
+					resource.TestCheckResourceAttr(mockResourceName, "options.fetch_size", "100"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "comment"),
+				),
+			},
+			{
+				// Drift testing - a foreign table dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP FOREIGN TABLE local_app.test_foreign_table_resource_moved;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccForeignTableToTFResource(t, mockResourceId, "test_foreign_table_resource_moved", `
+					schema  = "local_app"
+					columns = [
+						{ name = "id", type = "bigint", nullable = false },
+						{ name = "status", type = "text" },
+					]
+					options = {
+						schema_name = "remote_app"
+						table_name  = "orders"
+						fetch_size  = "100"
+					}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(mockResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccForeignTableToTFResource(t *testing.T, resId, name, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_foreign_table" "%s" {
+			name   = "%s"
+			server = "test_foreign_table_server"
+			%s
+		}`, resId, name, body)
+}
@@ -3,13 +3,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"reflect"
+	"slices"
 	"strings"
 	"terraform-provider-postgresql/internal/client"
 )
@@ -18,8 +21,34 @@ import (
 const (
 	msgErrGetPgConnection = "Error establishing a PostgreSQL connection"
 	msgErrMapPgModel      = "Error mapping Postgres model to Terraform model"
+	msgErrOwnerMismatch   = "Unexpected owner after applying changes"
+	msgErrGetServerInfo   = "Error retrieving the PostgreSQL server information"
+	msgErrUnsupported     = "Unsupported by the PostgreSQL server"
 )
 
+// resolveAssumeRole returns the role a resource operation should run as. The resource level
+// override takes precedence over the provider level `assume_role` configuration.
+func resolveAssumeRole(pgClient client.PgClient, override types.String) string {
+	if !override.IsNull() && !override.IsUnknown() && override.ValueString() != "" {
+		return override.ValueString()
+	}
+	return pgClient.GetInitConfig().AssumeRole
+}
+
+// verifyOwner compares the owner read back from the server with the expected one.
+// An empty expected owner means there is nothing to verify.
+func verifyOwner(objectType, expected, actual string) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if expected != "" && expected != actual {
+		diags.AddAttributeError(
+			path.Root("owner"),
+			msgErrOwnerMismatch,
+			fmt.Sprintf("The %s is owned by '%s' but '%s' was expected. Check the `owner` and `assume_role` attributes.", objectType, actual, expected),
+		)
+	}
+	return diags
+}
+
 func parsePgClientFromRequest[R datasource.ConfigureRequest | resource.ConfigureRequest](ctx context.Context, req R) (client.PgClient, diag.Diagnostics) {
 	diags := diag.Diagnostics{}
 	var providerData any
@@ -65,6 +94,181 @@ func parsePgClientFromRequest[R datasource.ConfigureRequest | resource.Configure
 
 }
 
+// getServerInfo returns the information of the server hosting the given database.
+func getServerInfo(ctx context.Context, pgClient client.PgClient, db string) (*client.ServerInfo, diag.Diagnostics) {
+	diags := diag.Diagnostics{}
+
+	conn, err := pgClient.GetConnection(ctx, db)
+	if err != nil {
+		diags.AddError(msgErrGetPgConnection, err.Error())
+		return nil, diags
+	}
+
+	info, err := conn.ServerInfo(ctx)
+	if err != nil {
+		diags.AddError(msgErrGetServerInfo, err.Error())
+		return nil, diags
+	}
+	return info, diags
+}
+
+// checkServerCapability adds an attribute error when the server hosting the given database
+// does not provide the capability that the attribute needs.
+func checkServerCapability(ctx context.Context, pgClient client.PgClient, db string, capability client.PgCapability, attrPath path.Path, feature string) diag.Diagnostics {
+	info, diags := getServerInfo(ctx, pgClient, db)
+	if diags.HasError() {
+		return diags
+	}
+
+	if !info.Supports(capability) {
+		diags.AddAttributeError(
+			attrPath,
+			msgErrUnsupported,
+			fmt.Sprintf("%s requires PostgreSQL %d or later, but the server is running %s.", feature, client.CapabilityMinVersion(capability), info),
+		)
+	}
+	return diags
+}
+
+// plannedDatabase returns the database targeted by a plan, falling back to the
+// database from the provider configuration when it is not known yet.
+func plannedDatabase(pgClient client.PgClient, database types.String) string {
+	if database.IsNull() || database.IsUnknown() {
+		return pgClient.GetInitConfig().Database
+	}
+	return database.ValueString()
+}
+
+// splitResourceId splits a resource identifier made of the given number of non-empty parts
+// separated by dots, e.g. 'database_name.schema_name.table_name'.
+func splitResourceId(id string, parts int) ([]string, bool) {
+	idParts := strings.SplitN(id, ".", parts)
+	if len(idParts) != parts || slices.Contains(idParts, "") {
+		return nil, false
+	}
+	return idParts, true
+}
+
+// stringValueOrNull maps the empty strings that PostgreSQL returns for unset values to null.
+func stringValueOrNull(value string) types.String {
+	if value == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// int64ValueOrNull maps the values that PostgreSQL returns as NULL to null.
+func int64ValueOrNull(value *int64) types.Int64 {
+	if value == nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(*value)
+}
+
+// knownInt64Pointer returns nil for the null and unknown values, the server picks the value then.
+func knownInt64Pointer(value types.Int64) *int64 {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return value.ValueInt64Pointer()
+}
+
+// float64ValueOrNull maps the values that PostgreSQL returns as NULL to null.
+func float64ValueOrNull(value *float64) types.Float64 {
+	if value == nil {
+		return types.Float64Null()
+	}
+	return types.Float64Value(*value)
+}
+
+// knownFloat64Pointer returns nil for the null and unknown values, the server picks the value then.
+func knownFloat64Pointer(value types.Float64) *float64 {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return value.ValueFloat64Pointer()
+}
+
+func mapStringValuesToSlice(values []types.String) []string {
+	if values == nil {
+		return nil
+	}
+	slice := make([]string, len(values))
+	for i, value := range values {
+		slice[i] = value.ValueString()
+	}
+	return slice
+}
+
+func mapSliceToStringValues(slice []string) []types.String {
+	if len(slice) == 0 {
+		return nil
+	}
+	values := make([]types.String, len(slice))
+	for i, value := range slice {
+		values[i] = types.StringValue(value)
+	}
+	return values
+}
+
+// mapSliceToStringSet maps a slice to a set of strings, an empty set for an empty slice.
+func mapSliceToStringSet(slice []string) types.Set {
+	values := make([]attr.Value, len(slice))
+	for i, value := range slice {
+		values[i] = types.StringValue(value)
+	}
+	return types.SetValueMust(types.StringType, values)
+}
+
+// mapSliceToStringList maps a slice to a list of strings, an empty list for an empty slice.
+func mapSliceToStringList(slice []string) types.List {
+	values := make([]attr.Value, len(slice))
+	for i, value := range slice {
+		values[i] = types.StringValue(value)
+	}
+	return types.ListValueMust(types.StringType, values)
+}
+
+// mapListValueToSlice maps a list of strings to a slice, nil for the null and unknown lists.
+func mapListValueToSlice(value types.List) []string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	slice := make([]string, 0, len(value.Elements()))
+	for _, element := range value.Elements() {
+		if str, ok := element.(types.String); ok {
+			slice = append(slice, str.ValueString())
+		}
+	}
+	return slice
+}
+
+// mapStringMapValue maps a map of strings to a Go map, nil for the null and unknown maps.
+func mapStringMapValue(value types.Map) map[string]string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	result := make(map[string]string, len(value.Elements()))
+	for key, element := range value.Elements() {
+		if str, ok := element.(types.String); ok {
+			result[key] = str.ValueString()
+		}
+	}
+	return result
+}
+
+// mapToStringMapValue maps a Go map to a map of strings, null for an empty map.
+func mapToStringMapValue(value map[string]string) types.Map {
+	if len(value) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	elements := make(map[string]attr.Value, len(value))
+	for key, element := range value {
+		elements[key] = types.StringValue(element)
+	}
+	return types.MapValueMust(types.StringType, elements)
+}
+
 func sliceToTerraformSetString[T interface{} | string](arr []T) string {
 	var strSet []string
 	for _, v := range arr {
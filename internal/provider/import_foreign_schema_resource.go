@@ -0,0 +1,331 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type importForeignSchemaResource struct {
+	client client.PgClient
+}
+
+type importForeignSchemaResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	LastUpdated  types.String `tfsdk:"last_updated"`
+	Database     types.String `tfsdk:"database"`
+	Server       types.String `tfsdk:"server"`
+	RemoteSchema types.String `tfsdk:"remote_schema"`
+	LocalSchema  types.String `tfsdk:"local_schema"`
+	LimitTo      types.Set    `tfsdk:"limit_to"`
+	Except       types.Set    `tfsdk:"except"`
+	Options      types.Map    `tfsdk:"options"`
+	Tables       types.List   `tfsdk:"tables"`
+	AssumeRole   types.String `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource              = &importForeignSchemaResource{}
+	_ resource.ResourceWithConfigure = &importForeignSchemaResource{}
+)
+
+func NewImportForeignSchemaResource() resource.Resource {
+	return &importForeignSchemaResource{}
+}
+
+func (r *importForeignSchemaResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'import_foreign_schema' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *importForeignSchemaResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_import_foreign_schema"
+}
+
+func (r *importForeignSchemaResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the import, in the format `database_name.local_schema.server_name.remote_schema`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the import",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the foreign tables are created. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"server": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the foreign server to import the schema from",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"remote_schema": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Schema of the foreign server to import",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"local_schema": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Existing local schema where the foreign tables are created",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"limit_to": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Remote tables to import, the others are skipped. Conflicts with `except`.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+					setvalidator.ConflictsWith(path.MatchRoot("except")),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"except": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Remote tables to skip, the others are imported. Conflicts with `limit_to`.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"options": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Options of the import, specific to the foreign data wrapper, e.g. `import_default` for `postgres_fdw`",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"tables": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Foreign tables created by the import and still existing, sorted by name. The import is created again when all of them are dropped.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while importing and dropping the foreign tables. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceImportForeignSchema,
+	}
+}
+
+func (r *importForeignSchemaResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'import_foreign_schema' resource")
+
+	var model importForeignSchemaResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	pgModel, err := conn.ForeignSchemaImportRepository().Import(ctx, model.toPgModel())
+	if err != nil {
+		res.Diagnostics.AddError("Error importing foreign schema", err.Error())
+		return
+	}
+	if len(pgModel.Tables) == 0 {
+		res.Diagnostics.AddError(
+			"Error importing foreign schema",
+			fmt.Sprintf("No foreign table was created in '%s', check the remote schema and the limit_to/except attributes", model.LocalSchema.ValueString()),
+		)
+		return
+	}
+	model.fromPgModel(*pgModel)
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'import_foreign_schema' resource")
+}
+
+func (r *importForeignSchemaResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'import_foreign_schema' resource")
+
+	var model importForeignSchemaResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	pgModel, err := conn.ForeignSchemaImportRepository().Get(ctx, model.toPgModel())
+	if err != nil {
+		res.Diagnostics.AddError(fmt.Sprintf("Error reading imported foreign tables: '%s'", model.Id.ValueString()), err.Error())
+		return
+	}
+
+	// every imported table was dropped outside of Terraform, the import has to be done again
+	if len(pgModel.Tables) == 0 {
+		res.State.RemoveResource(ctx)
+		return
+	}
+	model.fromPgModel(*pgModel)
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'import_foreign_schema' resource")
+}
+
+// Update only stores the attributes that exist in Terraform, every other change imports the schema again.
+func (r *importForeignSchemaResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'import_foreign_schema' resource")
+
+	var stateModel importForeignSchemaResourceModel
+	var planModel importForeignSchemaResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.Tables = stateModel.Tables
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'import_foreign_schema' resource")
+}
+
+func (r *importForeignSchemaResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'import_foreign_schema' resource")
+
+	var model importForeignSchemaResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	repository := conn.ForeignSchemaImportRepository()
+	pgModel, err := repository.Get(ctx, model.toPgModel())
+	if err != nil {
+		res.Diagnostics.AddError(fmt.Sprintf("Error reading imported foreign tables: '%s'", model.Id.ValueString()), err.Error())
+		return
+	}
+
+	if err = repository.Drop(ctx, pgModel.LocalSchema, pgModel.Tables); err != nil {
+		res.Diagnostics.AddError("Error deleting imported foreign tables", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'import_foreign_schema' resource")
+}
+
+func (rm *importForeignSchemaResourceModel) toPgModel() client.ForeignSchemaImportModel {
+	return client.ForeignSchemaImportModel{
+		Database:     rm.Database.ValueString(),
+		Server:       rm.Server.ValueString(),
+		RemoteSchema: rm.RemoteSchema.ValueString(),
+		LocalSchema:  rm.LocalSchema.ValueString(),
+		LimitTo:      mapSetValueToSlice[string](rm.LimitTo),
+		Except:       mapSetValueToSlice[string](rm.Except),
+		Options:      mapStringMapValue(rm.Options),
+		Tables:       mapListValueToSlice(rm.Tables),
+	}
+}
+
+// fromPgModel only maps the imported tables, the other attributes are the configured ones.
+func (rm *importForeignSchemaResourceModel) fromPgModel(pgModel client.ForeignSchemaImportModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Tables = mapSliceToStringList(pgModel.Tables)
+}
+
+func (rm *importForeignSchemaResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s.%s.%s", rm.Database.ValueString(), rm.LocalSchema.ValueString(), rm.Server.ValueString(), rm.RemoteSchema.ValueString()))
+}
+
+func (rm *importForeignSchemaResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
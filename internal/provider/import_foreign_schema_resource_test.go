@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccImportForeignSchemaResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_import_foreign_schema_db",
+		Username: "test_import_foreign_schema_user",
+	}
+	ctx, db, remoteOpts := testAccPrepareForeignDataTestCase(t, runOpts)
+	defer db.Close()
+
+	mockResourceId := "test_import_foreign_schema"
+	mockResourceName := fmt.Sprintf("postgresql_import_foreign_schema.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, fmt.Sprintf(`
+				CREATE SERVER test_import_server FOREIGN DATA WRAPPER postgres_fdw OPTIONS (host 'remote', dbname '%s');
+				CREATE USER MAPPING FOR CURRENT_USER SERVER test_import_server OPTIONS (user '%s', password '%s');`,
+				remoteOpts.Database, remoteOpts.Username, remoteOpts.Password))
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccImportForeignSchemaToTFResource(t, mockResourceId, `
+					options = { import_default = "true" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.local_app.test_import_server.remote_app", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.0", "items"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.1", "orders"),
+				),
+			},
+			{
+				// Update testing - Any change imports the schema again
+				Config: testAccImportForeignSchemaToTFResource(t, mockResourceId, `
+					limit_to = ["orders"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "tables.#", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.0", "orders"),
+				),
+			},
+		},
+	})
+}
+
+func testAccImportForeignSchemaToTFResource(t *testing.T, resId, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_import_foreign_schema" "%s" {
+			server        = "test_import_server"
+			remote_schema = "remote_app"
+			local_schema  = "local_app"
+			%s
+		}`, resId, body)
+}
@@ -0,0 +1,576 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type indexResource struct {
+	client client.PgClient
+}
+
+type indexResourceModel struct {
+	Id                types.String    `tfsdk:"id"`
+	LastUpdated       types.String    `tfsdk:"last_updated"`
+	Database          types.String    `tfsdk:"database"`
+	Schema            types.String    `tfsdk:"schema"`
+	Name              types.String    `tfsdk:"name"`
+	Table             types.String    `tfsdk:"table"`
+	Keys              []indexKeyModel `tfsdk:"keys"`
+	Method            types.String    `tfsdk:"method"`
+	Unique            types.Bool      `tfsdk:"unique"`
+	Where             types.String    `tfsdk:"where"`
+	Include           []types.String  `tfsdk:"include"`
+	StorageParameters types.Map       `tfsdk:"storage_parameters"`
+	Tablespace        types.String    `tfsdk:"tablespace"`
+	Concurrently      types.Bool      `tfsdk:"concurrently"`
+	Valid             types.Bool      `tfsdk:"valid"`
+	Definition        types.String    `tfsdk:"definition"`
+	AssumeRole        types.String    `tfsdk:"assume_role"`
+}
+
+type indexKeyModel struct {
+	Column     types.String `tfsdk:"column"`
+	Expression types.String `tfsdk:"expression"`
+	Order      types.String `tfsdk:"order"`
+	Nulls      types.String `tfsdk:"nulls"`
+}
+
+var (
+	_ resource.Resource                = &indexResource{}
+	_ resource.ResourceWithConfigure   = &indexResource{}
+	_ resource.ResourceWithImportState = &indexResource{}
+	_ resource.ResourceWithModifyPlan  = &indexResource{}
+)
+
+func NewIndexResource() resource.Resource {
+	return &indexResource{}
+}
+
+func (r *indexResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'index' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *indexResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_index"
+}
+
+func (r *indexResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the index, in the format `database_name.schema_name.index_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the index",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the index is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("public"),
+				MarkdownDescription: "Schema of the indexed table, indexes always live in the schema of their table",
+				PlanModifiers:       requiresReplace,
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the index",
+				Validators:          nonEmptyString,
+			},
+			"table": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the indexed table",
+				Validators:          nonEmptyString,
+				PlanModifiers:       requiresReplace,
+			},
+			"keys": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Keys of the index, in order. Each key is either a `column` or an `expression`.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: indexKeyNestedObject(),
+			},
+			"method": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("btree"),
+				MarkdownDescription: "Index access method, e.g. `btree`, `hash`, `gin`, `gist`, `spgist` or `brin`",
+				Validators:          nonEmptyString,
+				PlanModifiers:       requiresReplace,
+			},
+			"unique": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the index enforces unique values",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"where": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Predicate of a partial index",
+				Validators:          nonEmptyString,
+				PlanModifiers:       requiresReplace,
+			},
+			"include": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Non-key columns included in the index",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"storage_parameters": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Storage parameters of the index, e.g. `fillfactor`. Changes are applied in place.",
+			},
+			"tablespace": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Tablespace of the index, e.g. the name of a `postgresql_tablespace`. Changes move the index in place.",
+				Validators:          nonEmptyString,
+			},
+			"concurrently": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Build and drop the index with `CONCURRENTLY`, without blocking writes on the table",
+			},
+			"valid": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the index is valid. An invalid index, left behind by a failed concurrent build, is rebuilt on the next apply.",
+			},
+			"definition": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Definition of the index, as returned by `pg_get_indexdef`",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET ROLE`) while managing the index. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceIndex,
+	}
+}
+
+// indexKeyNestedObject is the schema of an index key, shared with the indexes of materialized views.
+func indexKeyNestedObject() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"column": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the indexed column",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("expression")),
+				},
+			},
+			"expression": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "SQL expression of the key, e.g. `lower(email)`",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"order": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Sort order of the key, `ASC` or `DESC`",
+				Validators: []validator.String{
+					stringvalidator.OneOf("ASC", "DESC"),
+				},
+			},
+			"nulls": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether nulls sort `FIRST` or `LAST`",
+				Validators: []validator.String{
+					stringvalidator.OneOf("FIRST", "LAST"),
+				},
+			},
+		},
+	}
+}
+
+func (r *indexResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on create or destroy
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var model, stateModel indexResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// an invalid index is never used by the planner, rebuild it
+	if !stateModel.Valid.ValueBool() {
+		res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("valid"), types.BoolValue(true))...)
+		res.RequiresReplace = append(res.RequiresReplace, path.Root("valid"))
+	}
+
+	// the identifier is derived from the name, a rename produces a new one
+	if !model.Name.Equal(stateModel.Name) {
+		res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+}
+
+func (r *indexResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'index' resource")
+
+	var model indexResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	pgModel, diags := model.toPgModel(ctx)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	repository := conn.IndexRepository()
+	if err = repository.Create(ctx, pgModel, model.Concurrently.ValueBool()); err != nil {
+		res.Diagnostics.AddError("Error creating index", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readIndexModel(ctx, repository, model.Schema.ValueString(), model.Name.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'index' resource")
+}
+
+func (r *indexResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'index' resource")
+
+	var model indexResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the index", "Id is required for reading index")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 3)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the index", "Id should be in the format 'database_name.schema_name.index_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// an index dropped outside of Terraform is removed from the state and planned again
+	repository := conn.IndexRepository()
+	exists, err := repository.Exists(ctx, idParts[1], idParts[2])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading index", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Index not found, removing it from the state", map[string]any{"index": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readIndexModel(ctx, repository, idParts[1], idParts[2], &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// imported indexes get the defaults of the attributes that only exist in Terraform
+	if model.Concurrently.IsNull() {
+		model.Concurrently = types.BoolValue(false)
+	}
+
+	if !model.Valid.ValueBool() {
+		res.Diagnostics.AddWarning(
+			"Invalid index",
+			fmt.Sprintf("The index '%s' is invalid, most likely after a failed concurrent build. It will be rebuilt on the next apply.", model.Id.ValueString()),
+		)
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'index' resource")
+}
+
+func (r *indexResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'index' resource")
+
+	var stateModel indexResourceModel
+	var planModel indexResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	current, diags := stateModel.toPgModel(ctx)
+	res.Diagnostics.Append(diags...)
+	desired, diags := planModel.toPgModel(ctx)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	repository := conn.IndexRepository()
+	_, err = repository.Update(ctx, client.IndexUpdateParams{Current: current, Desired: desired})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating index", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readIndexModel(ctx, repository, planModel.Schema.ValueString(), planModel.Name.ValueString(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'index' resource")
+}
+
+func (r *indexResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'index' resource")
+
+	var model indexResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	err = conn.IndexRepository().Drop(ctx, model.Schema.ValueString(), model.Name.ValueString(), model.Concurrently.ValueBool())
+	if err != nil {
+		res.Diagnostics.AddError("Error deleting index", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'index' resource")
+}
+
+func (r *indexResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readIndexModel reads the index into the target model. Keys and predicate keep the spelling of
+// the target model when PostgreSQL stores an equivalent form.
+func readIndexModel(ctx context.Context, repository client.IndexRepository, schema, name string, target *indexResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, schema, name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading index: '%s.%s'", schema, name), err.Error())
+		return diags
+	}
+
+	if len(target.Keys) > 0 {
+		prior, priorDiags := target.toPgModel(ctx)
+		diags.Append(priorDiags...)
+		if diags.HasError() {
+			return diags
+		}
+
+		normalized, err := repository.Normalize(ctx, prior)
+		if err != nil {
+			// the prior definition may no longer be valid, e.g. the indexed column was dropped
+			tflog.Warn(ctx, "Unable to normalize the index definition, using the server spelling", map[string]any{"error": err.Error()})
+		} else {
+			*actual = client.PreserveIndexSpelling(prior, *normalized, *actual)
+		}
+	}
+
+	diags.Append(target.fromPgModel(ctx, *actual)...)
+	return diags
+}
+
+func (rm *indexResourceModel) toPgModel(ctx context.Context) (client.IndexModel, diag.Diagnostics) {
+	pgModel := client.IndexModel{
+		Schema:     rm.Schema.ValueString(),
+		Name:       rm.Name.ValueString(),
+		Table:      rm.Table.ValueString(),
+		Database:   rm.Database.ValueString(),
+		Method:     rm.Method.ValueString(),
+		Unique:     rm.Unique.ValueBool(),
+		Where:      rm.Where.ValueString(),
+		Include:    mapStringValuesToSlice(rm.Include),
+		Tablespace: rm.Tablespace.ValueString(),
+	}
+
+	pgModel.Keys = mapIndexKeysToPg(rm.Keys)
+
+	var diags diag.Diagnostics
+	if !rm.StorageParameters.IsNull() && !rm.StorageParameters.IsUnknown() {
+		diags = rm.StorageParameters.ElementsAs(ctx, &pgModel.StorageParameters, false)
+	}
+	return pgModel, diags
+}
+
+func (rm *indexResourceModel) fromPgModel(ctx context.Context, pgModel client.IndexModel) diag.Diagnostics {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Schema = types.StringValue(pgModel.Schema)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Table = types.StringValue(pgModel.Table)
+	rm.Method = types.StringValue(pgModel.Method)
+	rm.Unique = types.BoolValue(pgModel.Unique)
+	rm.Where = stringValueOrNull(pgModel.Where)
+	rm.Include = mapSliceToStringValues(pgModel.Include)
+	rm.Tablespace = stringValueOrNull(pgModel.Tablespace)
+	rm.Valid = types.BoolValue(pgModel.Valid)
+	rm.Definition = types.StringValue(pgModel.Definition)
+
+	rm.Keys = mapPgToIndexKeys(pgModel.Keys)
+
+	rm.StorageParameters = types.MapNull(types.StringType)
+	if len(pgModel.StorageParameters) == 0 {
+		return nil
+	}
+	var diags diag.Diagnostics
+	rm.StorageParameters, diags = types.MapValueFrom(ctx, types.StringType, pgModel.StorageParameters)
+	return diags
+}
+
+func mapIndexKeysToPg(keys []indexKeyModel) []client.IndexKey {
+	var pgKeys []client.IndexKey
+	for _, key := range keys {
+		pgKeys = append(pgKeys, client.IndexKey{
+			Column:     key.Column.ValueString(),
+			Expression: key.Expression.ValueString(),
+			Order:      key.Order.ValueString(),
+			Nulls:      key.Nulls.ValueString(),
+		})
+	}
+	return pgKeys
+}
+
+func mapPgToIndexKeys(pgKeys []client.IndexKey) []indexKeyModel {
+	var keys []indexKeyModel
+	for _, key := range pgKeys {
+		keys = append(keys, indexKeyModel{
+			Column:     stringValueOrNull(key.Column),
+			Expression: stringValueOrNull(key.Expression),
+			Order:      stringValueOrNull(key.Order),
+			Nulls:      stringValueOrNull(key.Nulls),
+		})
+	}
+	return keys
+}
+
+func (rm *indexResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s.%s", rm.Database.ValueString(), rm.Schema.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *indexResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
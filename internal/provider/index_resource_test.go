@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccIndexResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_index_resource_db",
+		Username: "test_index_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_index"
+	mockResourceName := fmt.Sprintf("postgresql_index.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `CREATE TABLE public.test_index_resource_table (id int, email text, active bool);`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccIndexToTFResource(t, mockResourceId, "test_index_resource", `
+					keys         = [{ expression = "LOWER(email)" }, { column = "id", order = "DESC" }]
+					unique       = true
+					where        = "active = true"
+					include      = ["active"]
+					concurrently = true
+					storage_parameters = { fillfactor = "80" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_index_resource", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "method", "btree"),
+					resource.TestCheckResourceAttr(mockResourceName, "keys.0.expression", "LOWER(email)"),
+					resource.TestCheckResourceAttr(mockResourceName, "keys.1.column", "id"),
+					resource.TestCheckResourceAttr(mockResourceName, "where", "active = true"),
+					resource.TestCheckResourceAttr(mockResourceName, "storage_parameters.fillfactor", "80"),
+					resource.TestCheckResourceAttr(mockResourceName, "valid", "true"),
+					resource.TestCheckResourceAttrSet(mockResourceName, "definition"),
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "keys", "where", "concurrently"},
+			},
+			{
+				// Update testing - Properties without re-creating the resource
+				Config: testAccIndexToTFResource(t, mockResourceId, "test_index_resource_modified", `
+					keys         = [{ expression = "LOWER(email)" }, { column = "id", order = "DESC" }]
+					unique       = true
+					where        = "active = true"
+					include      = ["active"]
+					concurrently = true
+					storage_parameters = { fillfactor = "90" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_index_resource_modified", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "storage_parameters.fillfactor", "90"),
+				),
+			},
+			{
+				// Update testing - Properties WITH re-creating the resource
+				Config: testAccIndexToTFResource(t, mockResourceId, "test_index_resource_modified", `
+					keys   = [{ column = "email" }]
+					method = "hash"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "method", "hash"),
+					resource.TestCheckResourceAttr(mockResourceName, "unique", "false"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "storage_parameters"),
+				),
+			},
+			{
+				// Drift testing - an index dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP INDEX public.test_index_resource_modified;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccIndexToTFResource(t, mockResourceId, "test_index_resource_modified", `
+					keys   = [{ column = "email" }]
+					method = "hash"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(mockResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccIndexToTFResource(t *testing.T, resId, name, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_index" "%s" {
+			name  = "%s"
+			table = "test_index_resource_table"
+			%s
+		}`, resId, name, body)
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"terraform-provider-postgresql/internal/client"
+)
+
+// listFilterAttributes returns the attributes selecting the objects of a list data source (see
+// client.ListFilter), with the schema filter for the objects located in a schema.
+func listFilterAttributes(objects, systemObjects string, withSchema bool) map[string]schema.Attribute {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	attributes := map[string]schema.Attribute{
+		"name_regex": schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("POSIX regular expression the name of the %s must match, e.g. `^app_`", objects),
+			Validators:          nonEmptyString,
+		},
+		"include_system": schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("Whether the system %s are listed, e.g. %s. Defaults to `false`.", objects, systemObjects),
+		},
+	}
+	if withSchema {
+		attributes["schema_regex"] = schema.StringAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("POSIX regular expression the schema of the %s must match, e.g. `^public$`", objects),
+			Validators:          nonEmptyString,
+		}
+	}
+	return attributes
+}
+
+// toListFilter returns the filter of a list data source, from the attributes of listFilterAttributes.
+func toListFilter(nameRegex, schemaRegex types.String, includeSystem types.Bool) client.ListFilter {
+	return client.ListFilter{
+		NameRegex:     nameRegex.ValueString(),
+		SchemaRegex:   schemaRegex.ValueString(),
+		IncludeSystem: includeSystem.ValueBool(),
+	}
+}
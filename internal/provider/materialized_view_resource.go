@@ -0,0 +1,564 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type materializedViewResource struct {
+	client client.PgClient
+}
+
+type materializedViewResourceModel struct {
+	Id              types.String                 `tfsdk:"id"`
+	LastUpdated     types.String                 `tfsdk:"last_updated"`
+	Database        types.String                 `tfsdk:"database"`
+	Schema          types.String                 `tfsdk:"schema"`
+	Name            types.String                 `tfsdk:"name"`
+	Query           types.String                 `tfsdk:"query"`
+	Columns         []types.String               `tfsdk:"columns"`
+	Owner           types.String                 `tfsdk:"owner"`
+	Comment         types.String                 `tfsdk:"comment"`
+	WithNoData      types.Bool                   `tfsdk:"with_no_data"`
+	Populated       types.Bool                   `tfsdk:"populated"`
+	RefreshOnChange types.Bool                   `tfsdk:"refresh_on_change"`
+	RefreshTriggers types.Map                    `tfsdk:"refresh_triggers"`
+	Indexes         []materializedViewIndexModel `tfsdk:"indexes"`
+	AssumeRole      types.String                 `tfsdk:"assume_role"`
+}
+
+type materializedViewIndexModel struct {
+	Name   types.String    `tfsdk:"name"`
+	Keys   []indexKeyModel `tfsdk:"keys"`
+	Method types.String    `tfsdk:"method"`
+	Unique types.Bool      `tfsdk:"unique"`
+	Where  types.String    `tfsdk:"where"`
+}
+
+var (
+	_ resource.Resource                   = &materializedViewResource{}
+	_ resource.ResourceWithConfigure      = &materializedViewResource{}
+	_ resource.ResourceWithImportState    = &materializedViewResource{}
+	_ resource.ResourceWithValidateConfig = &materializedViewResource{}
+	_ resource.ResourceWithModifyPlan     = &materializedViewResource{}
+)
+
+func NewMaterializedViewResource() resource.Resource {
+	return &materializedViewResource{}
+}
+
+func (r *materializedViewResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'materialized_view' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *materializedViewResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_materialized_view"
+}
+
+func (r *materializedViewResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the materialized view, in the format `database_name.schema_name.materialized_view_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the materialized view",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the materialized view is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("public"),
+				MarkdownDescription: "Schema where the materialized view is located. Changes move the materialized view in place.",
+				Validators:          nonEmptyString,
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the materialized view",
+				Validators:          nonEmptyString,
+			},
+			"query": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The `SELECT` query of the materialized view. It's compared with the definition returned by `pg_get_viewdef`, so formatting differences are not reported as changes. Changes recreate the materialized view.",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"columns": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the materialized view columns. If not provided, the names are derived from the query. Changes recreate the materialized view.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The owner of the materialized view. If not provided, the materialized view is owned by the role that creates it (see `assume_role`).",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the materialized view",
+			},
+			"with_no_data": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Create the materialized view `WITH NO DATA`, leaving it unpopulated until the first refresh. Setting it back to `false` refreshes an unpopulated materialized view.",
+			},
+			"populated": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the materialized view is populated and can be queried",
+			},
+			"refresh_on_change": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Run `REFRESH MATERIALIZED VIEW` after every in-place update of the materialized view",
+			},
+			"refresh_triggers": schema.MapAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arbitrary values that refresh the materialized view when they change, e.g. the version of the data it's built from",
+			},
+			"indexes": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Indexes of the materialized view. Indexes are matched by name, a changed index is dropped and created again.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the index",
+							Validators:          nonEmptyString,
+						},
+						"keys": schema.ListNestedAttribute{
+							Required:            true,
+							MarkdownDescription: "Keys of the index, in order. Each key is either a `column` or an `expression`.",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+							},
+							NestedObject: indexKeyNestedObject(),
+						},
+						"method": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("btree"),
+							MarkdownDescription: "Index access method, e.g. `btree`, `hash`, `gin`, `gist`, `spgist` or `brin`",
+							Validators:          nonEmptyString,
+						},
+						"unique": schema.BoolAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(false),
+							MarkdownDescription: "Whether the index enforces unique values. A unique index allows `REFRESH MATERIALIZED VIEW CONCURRENTLY`.",
+						},
+						"where": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Predicate of a partial index",
+							Validators:          nonEmptyString,
+						},
+					},
+				},
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the materialized view. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceMaterializedView,
+	}
+}
+
+func (r *materializedViewResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	var model materializedViewResourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	seen := make(map[string]bool)
+	for i, index := range model.Indexes {
+		if index.Name.IsNull() || index.Name.IsUnknown() {
+			continue
+		}
+		if seen[index.Name.ValueString()] {
+			res.Diagnostics.AddAttributeError(
+				path.Root("indexes").AtListIndex(i).AtName("name"),
+				"Duplicate index",
+				fmt.Sprintf("The index '%s' is declared more than once.", index.Name.ValueString()),
+			)
+		}
+		seen[index.Name.ValueString()] = true
+	}
+}
+
+func (r *materializedViewResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on create or destroy
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var model, stateModel materializedViewResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// the identifier is derived from the schema and name, moving the materialized view produces a new one
+	if !model.Name.Equal(stateModel.Name) || !model.Schema.Equal(stateModel.Schema) {
+		res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+}
+
+func (r *materializedViewResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'materialized_view' resource")
+
+	var model materializedViewResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	role := resolveAssumeRole(r.client, model.AssumeRole)
+	ctx = client.ContextWithAssumeRole(ctx, role)
+
+	// the materialized view is owned by the assumed role, unless another owner is explicitly requested
+	expectedOwner := role
+	if !model.Owner.IsNull() && !model.Owner.IsUnknown() {
+		expectedOwner = model.Owner.ValueString()
+	}
+
+	pgModel := model.toPgModel()
+	pgModel.Owner = expectedOwner
+
+	repository := conn.MaterializedViewRepository()
+	if err = repository.Create(ctx, pgModel); err != nil {
+		res.Diagnostics.AddError("Error creating materialized view", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readMaterializedViewModel(ctx, repository, model.Schema.ValueString(), model.Name.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("materialized view", expectedOwner, model.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'materialized_view' resource")
+}
+
+func (r *materializedViewResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'materialized_view' resource")
+
+	var model materializedViewResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the materialized view", "Id is required for reading materialized view")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 3)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the materialized view", "Id should be in the format 'database_name.schema_name.materialized_view_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a materialized view dropped outside of Terraform is removed from the state and planned again
+	repository := conn.MaterializedViewRepository()
+	exists, err := repository.Exists(ctx, idParts[1], idParts[2])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading materialized view", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Materialized view not found, removing it from the state", map[string]any{"materialized_view": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readMaterializedViewModel(ctx, repository, idParts[1], idParts[2], &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// imported materialized views get the defaults of the attributes that only exist in Terraform
+	if model.WithNoData.IsNull() {
+		model.WithNoData = types.BoolValue(false)
+	}
+	if model.RefreshOnChange.IsNull() {
+		model.RefreshOnChange = types.BoolValue(false)
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'materialized_view' resource")
+}
+
+func (r *materializedViewResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'materialized_view' resource")
+
+	var stateModel materializedViewResourceModel
+	var planModel materializedViewResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	updateParams := client.MaterializedViewUpdateParams{
+		Current: stateModel.toPgModel(),
+		Desired: planModel.toPgModel(),
+		Refresh: materializedViewNeedsRefresh(stateModel, planModel),
+	}
+	if planModel.Owner.IsUnknown() {
+		updateParams.Desired.Owner = updateParams.Current.Owner
+	}
+
+	repository := conn.MaterializedViewRepository()
+	if _, err = repository.Update(ctx, updateParams); err != nil {
+		res.Diagnostics.AddError("Error updating materialized view", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readMaterializedViewModel(ctx, repository, planModel.Schema.ValueString(), planModel.Name.ValueString(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("materialized view", updateParams.Desired.Owner, planModel.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'materialized_view' resource")
+}
+
+func (r *materializedViewResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'materialized_view' resource")
+
+	var model materializedViewResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	err = conn.MaterializedViewRepository().Drop(ctx, model.Schema.ValueString(), model.Name.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("Error deleting materialized view", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'materialized_view' resource")
+}
+
+func (r *materializedViewResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// materializedViewNeedsRefresh reports whether an in-place update must refresh the materialized view:
+// always with refresh_on_change, when the refresh_triggers change, or to populate a materialized view
+// created with no data once with_no_data is turned off.
+func materializedViewNeedsRefresh(state, plan materializedViewResourceModel) bool {
+	if plan.RefreshOnChange.ValueBool() {
+		return true
+	}
+	if !plan.RefreshTriggers.Equal(state.RefreshTriggers) {
+		return true
+	}
+	return !plan.WithNoData.ValueBool() && !state.Populated.ValueBool()
+}
+
+// readMaterializedViewModel reads the materialized view into the target model. The query, columns
+// and indexes keep the spelling of the target model when PostgreSQL stores an equivalent form.
+func readMaterializedViewModel(ctx context.Context, repository client.MaterializedViewRepository, schema, name string, target *materializedViewResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, schema, name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading materialized view: '%s.%s'", schema, name), err.Error())
+		return diags
+	}
+
+	if !target.Query.IsNull() && !target.Query.IsUnknown() {
+		prior := target.toPgModel()
+		prior.Schema, prior.Name = schema, name
+
+		normalized, err := repository.Normalize(ctx, prior)
+		if err != nil {
+			// the prior definition may no longer be valid, e.g. a referenced table was dropped
+			tflog.Warn(ctx, "Unable to normalize the materialized view definition, using the server spelling", map[string]any{"error": err.Error()})
+		} else {
+			*actual = client.PreserveMaterializedViewSpelling(prior, *normalized, *actual)
+		}
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *materializedViewResourceModel) toPgModel() client.MaterializedViewModel {
+	pgModel := client.MaterializedViewModel{
+		Schema:     rm.Schema.ValueString(),
+		Name:       rm.Name.ValueString(),
+		Database:   rm.Database.ValueString(),
+		Query:      rm.Query.ValueString(),
+		Columns:    mapStringValuesToSlice(rm.Columns),
+		Owner:      rm.Owner.ValueString(),
+		Comment:    rm.Comment.ValueString(),
+		WithNoData: rm.WithNoData.ValueBool(),
+	}
+
+	for _, index := range rm.Indexes {
+		pgModel.Indexes = append(pgModel.Indexes, client.IndexModel{
+			Name:   index.Name.ValueString(),
+			Keys:   mapIndexKeysToPg(index.Keys),
+			Method: index.Method.ValueString(),
+			Unique: index.Unique.ValueBool(),
+			Where:  index.Where.ValueString(),
+		})
+	}
+	return pgModel
+}
+
+func (rm *materializedViewResourceModel) fromPgModel(pgModel client.MaterializedViewModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Schema = types.StringValue(pgModel.Schema)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Query = types.StringValue(pgModel.Query)
+	rm.Columns = mapSliceToStringValues(pgModel.Columns)
+	rm.Owner = types.StringValue(pgModel.Owner)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+	rm.Populated = types.BoolValue(pgModel.Populated)
+
+	rm.Indexes = nil
+	for _, index := range pgModel.Indexes {
+		rm.Indexes = append(rm.Indexes, materializedViewIndexModel{
+			Name:   types.StringValue(index.Name),
+			Keys:   mapPgToIndexKeys(index.Keys),
+			Method: types.StringValue(index.Method),
+			Unique: types.BoolValue(index.Unique),
+			Where:  stringValueOrNull(index.Where),
+		})
+	}
+}
+
+func (rm *materializedViewResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s.%s", rm.Database.ValueString(), rm.Schema.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *materializedViewResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccMaterializedViewResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_matview_resource_db",
+		Username: "test_matview_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_matview"
+	mockResourceName := fmt.Sprintf("postgresql_materialized_view.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `CREATE TABLE public.test_matview_resource_table (id int, active bool);`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccMaterializedViewToTFResource(t, mockResourceId, "test_matview_resource", `
+					with_no_data = true
+					indexes = [
+						{ name = "test_matview_resource_active_key", keys = [{ column = "active" }], unique = true },
+					]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_matview_resource", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "query", "select active, count(*) as total from test_matview_resource_table group by active"),
+					resource.TestCheckResourceAttr(mockResourceName, "populated", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "indexes.#", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "indexes.0.method", "btree"),
+					resource.TestCheckResourceAttr(mockResourceName, "indexes.0.unique", "true"),
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "query", "columns", "with_no_data"},
+			},
+			{
+				// Update testing - turning with_no_data off populates the materialized view
+				Config: testAccMaterializedViewToTFResource(t, mockResourceId, "test_matview_resource_modified", `
+					comment = "test comment"
+					indexes = [
+						{ name = "test_matview_resource_total_idx", keys = [{ column = "total", order = "DESC" }] },
+					]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_matview_resource_modified", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "populated", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment"),
+					resource.TestCheckResourceAttr(mockResourceName, "indexes.0.name", "test_matview_resource_total_idx"),
+					resource.TestCheckResourceAttr(mockResourceName, "indexes.0.keys.0.order", "DESC"),
+				),
+			},
+			{
+				// Update testing - changing a refresh trigger refreshes the materialized view
+				Config: testAccMaterializedViewToTFResource(t, mockResourceId, "test_matview_resource_modified", `
+					comment          = "test comment"
+					refresh_triggers = { version = "2" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "refresh_triggers.version", "2"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "indexes"),
+				),
+			},
+			{
+				// Drift testing - a materialized view dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP MATERIALIZED VIEW public.test_matview_resource_modified;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccMaterializedViewToTFResource(t, mockResourceId, "test_matview_resource_modified", `
+					comment          = "test comment"
+					refresh_triggers = { version = "2" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(mockResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMaterializedViewToTFResource(t *testing.T, resId, name, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_materialized_view" "%s" {
+			name  = "%s"
+			query = "select active, count(*) as total from test_matview_resource_table group by active"
+			%s
+		}`, resId, name, body)
+}
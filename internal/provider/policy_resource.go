@@ -0,0 +1,455 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type policyResource struct {
+	client client.PgClient
+}
+
+type policyResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+	Database    types.String `tfsdk:"database"`
+	Schema      types.String `tfsdk:"schema"`
+	Table       types.String `tfsdk:"table"`
+	Name        types.String `tfsdk:"name"`
+	Permissive  types.Bool   `tfsdk:"permissive"`
+	Command     types.String `tfsdk:"command"`
+	Roles       types.Set    `tfsdk:"roles"`
+	Using       types.String `tfsdk:"using"`
+	WithCheck   types.String `tfsdk:"with_check"`
+	Comment     types.String `tfsdk:"comment"`
+	AssumeRole  types.String `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                   = &policyResource{}
+	_ resource.ResourceWithConfigure      = &policyResource{}
+	_ resource.ResourceWithImportState    = &policyResource{}
+	_ resource.ResourceWithValidateConfig = &policyResource{}
+	_ resource.ResourceWithModifyPlan     = &policyResource{}
+)
+
+func NewPolicyResource() resource.Resource {
+	return &policyResource{}
+}
+
+func (r *policyResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'policy' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *policyResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_policy"
+}
+
+func (r *policyResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the policy, in the format `database_name.schema_name.table_name.policy_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the policy",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the policy is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("public"),
+				MarkdownDescription: "Schema of the table the policy is defined for",
+				PlanModifiers:       requiresReplace,
+			},
+			"table": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the table the policy is defined for. The policy only applies when the row level security of the table is enabled, see `postgresql_row_level_security`.",
+				Validators:          nonEmptyString,
+				PlanModifiers:       requiresReplace,
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the policy, unique among the policies of the table. Changes rename the policy in place.",
+				Validators:          nonEmptyString,
+			},
+			"permissive": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the policy is permissive, combined with the other permissive policies with `OR`, or restrictive, combined with every policy with `AND`",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"command": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(client.PolicyCommandAll),
+				MarkdownDescription: "Command the policy applies to: `ALL`, `SELECT`, `INSERT`, `UPDATE` or `DELETE`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.PolicyCommands...),
+				},
+				PlanModifiers: requiresReplace,
+			},
+			"roles": schema.SetAttribute{
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+				Default:             setdefault.StaticValue(types.SetValueMust(types.StringType, []attr.Value{types.StringValue(client.PolicyRolePublic)})),
+				MarkdownDescription: "Roles the policy applies to, `public` for every role. Defaults to `public`.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"using": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Boolean expression deciding which existing rows are visible or can be updated and deleted (`USING`). Not allowed for `INSERT` policies.",
+				Validators:          nonEmptyString,
+			},
+			"with_check": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Boolean expression the new rows of inserts and updates must satisfy (`WITH CHECK`). Not allowed for `SELECT` and `DELETE` policies.",
+				Validators:          nonEmptyString,
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the policy",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the policy. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourcePolicy,
+	}
+}
+
+func (r *policyResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	var model policyResourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Command.IsUnknown() || model.Command.IsNull() {
+		return
+	}
+	switch command := model.Command.ValueString(); command {
+	case client.PolicyCommandInsert:
+		if !model.Using.IsNull() {
+			res.Diagnostics.AddAttributeError(path.Root("using"), "Invalid policy definition", "INSERT policies only accept a with_check expression.")
+		}
+	case client.PolicyCommandSelect, client.PolicyCommandDelete:
+		if !model.WithCheck.IsNull() {
+			res.Diagnostics.AddAttributeError(path.Root("with_check"), "Invalid policy definition",
+				fmt.Sprintf("%s policies only accept a using expression.", command))
+		}
+	}
+}
+
+func (r *policyResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on create or destroy
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var model, stateModel policyResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// the identifier is derived from the name, a rename produces a new one
+	if !model.Name.Equal(stateModel.Name) {
+		res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+
+	// ALTER POLICY can replace the expressions, but not remove them
+	if model.Using.IsNull() && !stateModel.Using.IsNull() {
+		res.RequiresReplace = append(res.RequiresReplace, path.Root("using"))
+	}
+	if model.WithCheck.IsNull() && !stateModel.WithCheck.IsNull() {
+		res.RequiresReplace = append(res.RequiresReplace, path.Root("with_check"))
+	}
+}
+
+func (r *policyResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'policy' resource")
+
+	var model policyResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	repository := conn.PolicyRepository()
+	if err = repository.Create(ctx, model.toPgModel()); err != nil {
+		res.Diagnostics.AddError("Error creating policy", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readPolicyModel(ctx, repository, model.Schema.ValueString(), model.Table.ValueString(), model.Name.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'policy' resource")
+}
+
+func (r *policyResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'policy' resource")
+
+	var model policyResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the policy", "Id is required for reading policy")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 4)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the policy", "Id should be in the format 'database_name.schema_name.table_name.policy_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a policy dropped outside of Terraform is removed from the state and planned again
+	repository := conn.PolicyRepository()
+	exists, err := repository.Exists(ctx, idParts[1], idParts[2], idParts[3])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading policy", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Policy not found, removing it from the state", map[string]any{"policy": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readPolicyModel(ctx, repository, idParts[1], idParts[2], idParts[3], &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'policy' resource")
+}
+
+func (r *policyResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'policy' resource")
+
+	var stateModel policyResourceModel
+	var planModel policyResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	repository := conn.PolicyRepository()
+	_, err = repository.Update(ctx, client.PolicyUpdateParams{Current: stateModel.toPgModel(), Desired: planModel.toPgModel()})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating policy", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readPolicyModel(ctx, repository, planModel.Schema.ValueString(), planModel.Table.ValueString(), planModel.Name.ValueString(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'policy' resource")
+}
+
+func (r *policyResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'policy' resource")
+
+	var model policyResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	err = conn.PolicyRepository().Drop(ctx, model.Schema.ValueString(), model.Table.ValueString(), model.Name.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("Error deleting policy", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'policy' resource")
+}
+
+func (r *policyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readPolicyModel reads the policy into the target model, keeping the spelling of the expressions
+// of the target when PostgreSQL normalizes them to the same definition.
+func readPolicyModel(ctx context.Context, repository client.PolicyRepository, schema, table, name string, target *policyResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, schema, table, name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading policy: '%s' on '%s.%s'", name, schema, table), err.Error())
+		return diags
+	}
+
+	if !target.Using.IsNull() || !target.WithCheck.IsNull() {
+		prior := target.toPgModel()
+		normalized, err := repository.Normalize(ctx, prior)
+		if err != nil {
+			// the prior expressions may no longer be valid, e.g. a referenced column was dropped
+			tflog.Warn(ctx, "Unable to normalize the policy expressions, using the server spelling", map[string]any{"error": err.Error()})
+		} else {
+			*actual = client.PreservePolicySpelling(prior, *normalized, *actual)
+		}
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *policyResourceModel) toPgModel() client.PolicyModel {
+	return client.PolicyModel{
+		Schema:     rm.Schema.ValueString(),
+		Table:      rm.Table.ValueString(),
+		Name:       rm.Name.ValueString(),
+		Database:   rm.Database.ValueString(),
+		Permissive: rm.Permissive.ValueBool(),
+		Command:    rm.Command.ValueString(),
+		Roles:      mapSetValueToSlice[string](rm.Roles),
+		Using:      rm.Using.ValueString(),
+		WithCheck:  rm.WithCheck.ValueString(),
+		Comment:    rm.Comment.ValueString(),
+	}
+}
+
+func (rm *policyResourceModel) fromPgModel(pgModel client.PolicyModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Schema = types.StringValue(pgModel.Schema)
+	rm.Table = types.StringValue(pgModel.Table)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Permissive = types.BoolValue(pgModel.Permissive)
+	rm.Command = types.StringValue(pgModel.Command)
+	rm.Roles = mapSliceToStringSet(pgModel.Roles)
+	rm.Using = stringValueOrNull(pgModel.Using)
+	rm.WithCheck = stringValueOrNull(pgModel.WithCheck)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+}
+
+func (rm *policyResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s.%s.%s", rm.Database.ValueString(), rm.Schema.ValueString(), rm.Table.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *policyResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccPolicyResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_policy_resource_db",
+		Username: "test_policy_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_policy"
+	mockResourceName := fmt.Sprintf("postgresql_policy.%s", mockResourceId)
+	mockRlsName := "postgresql_row_level_security.test_rls"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `
+				CREATE TABLE public.test_policy_resource_table (id int, tenant text, owner_name text);
+				CREATE ROLE test_policy_resource_reader;`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccPolicyToTFResource(t, mockResourceId, "test_policy_resource", `
+					roles      = ["test_policy_resource_reader"]
+					using      = "owner_name = current_user"
+					with_check = "tenant IS NOT NULL"
+					comment    = "test comment"`, "forced = true"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_policy_resource_table.test_policy_resource", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "permissive", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "command", "UPDATE"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.#", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "using", "owner_name = current_user"),
+					resource.TestCheckResourceAttr(mockResourceName, "with_check", "tenant IS NOT NULL"),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment"),
+					resource.TestCheckResourceAttr(mockRlsName, "id", fmt.Sprintf("%s.public.test_policy_resource_table", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockRlsName, "enabled", "true"),
+					resource.TestCheckResourceAttr(mockRlsName, "forced", "true"),
+				),
+			},
+			{
+				// ImportState testing, the server spelling is used without a prior state
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "using", "with_check"},
+			},
+			{
+				ResourceName:            mockRlsName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				// Update testing - Properties without re-creating the resource, the roles default to public
+				Config: testAccPolicyToTFResource(t, mockResourceId, "test_policy_resource_renamed", `
+					using      = "tenant = 'a'"
+					with_check = "tenant IS NOT NULL"`, "forced = false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_policy_resource_table.test_policy_resource_renamed", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.#", "1"),
+					resource.TestCheckTypeSetElemAttr(mockResourceName, "roles.*", "public"),
+					resource.TestCheckResourceAttr(mockResourceName, "using", "tenant = 'a'"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "comment"),
+					resource.TestCheckResourceAttr(mockRlsName, "forced", "false"),
+				),
+			},
+			{
+				// Drift testing - a policy dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP POLICY test_policy_resource_renamed ON public.test_policy_resource_table;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccPolicyToTFResource(t, mockResourceId, "test_policy_resource_renamed", `
+					using      = "tenant = 'a'"
+					with_check = "tenant IS NOT NULL"`, "forced = false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(mockResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPolicyToTFResource(t *testing.T, resId, name, body, rlsBody string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_row_level_security" "test_rls" {
+			table = "test_policy_resource_table"
+			%s
+		}
+
+		resource "postgresql_policy" "%s" {
+			name    = "%s"
+			table   = "test_policy_resource_table"
+			command = "UPDATE"
+			%s
+		}`, rlsBody, resId, name, body)
+}
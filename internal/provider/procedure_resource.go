@@ -0,0 +1,557 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"regexp"
+	"strings"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type procedureResource struct {
+	client client.PgClient
+}
+
+type procedureResourceModel struct {
+	Id              types.String      `tfsdk:"id"`
+	LastUpdated     types.String      `tfsdk:"last_updated"`
+	Database        types.String      `tfsdk:"database"`
+	Schema          types.String      `tfsdk:"schema"`
+	Name            types.String      `tfsdk:"name"`
+	Args            []routineArgModel `tfsdk:"args"`
+	Language        types.String      `tfsdk:"language"`
+	Body            types.String      `tfsdk:"body"`
+	SecurityDefiner types.Bool        `tfsdk:"security_definer"`
+	Owner           types.String      `tfsdk:"owner"`
+	Comment         types.String      `tfsdk:"comment"`
+	AssumeRole      types.String      `tfsdk:"assume_role"`
+}
+
+type routineArgModel struct {
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+	Mode types.String `tfsdk:"mode"`
+}
+
+var (
+	_ resource.Resource                = &procedureResource{}
+	_ resource.ResourceWithConfigure   = &procedureResource{}
+	_ resource.ResourceWithImportState = &procedureResource{}
+	_ resource.ResourceWithModifyPlan  = &procedureResource{}
+)
+
+func NewProcedureResource() resource.Resource {
+	return &procedureResource{}
+}
+
+func (r *procedureResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'procedure' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *procedureResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_procedure"
+}
+
+func (r *procedureResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the procedure, in the format `database_name.schema_name.procedure_name(arg_type, ...)`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the procedure",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the procedure is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("public"),
+				MarkdownDescription: "Schema of the procedure. Changing it moves the procedure with `ALTER PROCEDURE ... SET SCHEMA`.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the procedure. Changes rename the procedure in place.",
+				Validators:          nonEmptyString,
+			},
+			"args": routineArgsAttribute(
+				"Arguments of the procedure, in order. `OUT` arguments require PostgreSQL 14 or later, and are passed as `NULL` to `CALL`. Changing the arguments creates another procedure.",
+				"IN", "OUT", "INOUT", "VARIADIC",
+			),
+			"language": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("plpgsql"),
+				MarkdownDescription: "Language of the body, e.g. `plpgsql` or `sql`. Defaults to `plpgsql`.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z_][a-z0-9_]*$`), "must be the lowercase name of a language"),
+				},
+			},
+			"body": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Body of the procedure, without the dollar quotes. Only procedures that are not `SECURITY DEFINER` can `COMMIT` or `ROLLBACK`, when called outside of a transaction block.",
+				Validators:          nonEmptyString,
+			},
+			"security_definer": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the procedure runs with the privileges of its owner instead of the caller's. Such a procedure can't execute transaction control statements. Defaults to `false`.",
+			},
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The owner of the procedure. If not provided, the procedure is owned by the role that creates it (see `assume_role`).",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the procedure",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the procedure. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceProcedure,
+	}
+}
+
+func (r *procedureResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var model procedureResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	for _, arg := range model.Args {
+		if arg.Mode.ValueString() == "OUT" {
+			res.Diagnostics.Append(checkServerCapability(
+				ctx,
+				r.client,
+				plannedDatabase(r.client, model.Database),
+				client.CapabilityProcedureOutArgs,
+				path.Root("args"),
+				"The OUT arguments of procedures",
+			)...)
+			break
+		}
+	}
+
+	// the identifier is derived from the schema and the name, moving the procedure produces a new one
+	if !req.State.Raw.IsNull() {
+		var stateModel procedureResourceModel
+
+		res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+		if !model.Schema.Equal(stateModel.Schema) || !model.Name.Equal(stateModel.Name) {
+			res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		}
+	}
+}
+
+func (r *procedureResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'procedure' resource")
+
+	var model procedureResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	role := resolveAssumeRole(r.client, model.AssumeRole)
+	ctx = client.ContextWithAssumeRole(ctx, role)
+
+	// the procedure is owned by the assumed role, unless another owner is explicitly requested
+	expectedOwner := role
+	if !model.Owner.IsNull() && !model.Owner.IsUnknown() {
+		expectedOwner = model.Owner.ValueString()
+	}
+
+	pgModel := model.toPgModel()
+	pgModel.Owner = expectedOwner
+
+	repository := conn.ProcedureRepository()
+	if err = repository.Create(ctx, pgModel); err != nil {
+		res.Diagnostics.AddError("Error creating procedure", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readProcedureModel(ctx, repository, pgModel.Schema, pgModel.Name, client.RoutineArgTypes(pgModel.Args), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("procedure", expectedOwner, model.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'procedure' resource")
+}
+
+func (r *procedureResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'procedure' resource")
+
+	var model procedureResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the procedure", "Id is required for reading procedure")
+		return
+	}
+
+	database, schemaName, name, argTypes, ok := splitRoutineId(model.Id.ValueString())
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the procedure", "Id should be in the format 'database_name.schema_name.procedure_name(arg_type, ...)'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a procedure dropped outside of Terraform is removed from the state and planned again
+	repository := conn.ProcedureRepository()
+	exists, err := repository.Exists(ctx, schemaName, name, argTypes)
+	if err != nil {
+		res.Diagnostics.AddError("Error reading procedure", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Procedure not found, removing it from the state", map[string]any{"procedure": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readProcedureModel(ctx, repository, schemaName, name, argTypes, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'procedure' resource")
+}
+
+func (r *procedureResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'procedure' resource")
+
+	var stateModel procedureResourceModel
+	var planModel procedureResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	current := stateModel.toPgModel()
+	desired := planModel.toPgModel()
+	if planModel.Owner.IsUnknown() {
+		desired.Owner = current.Owner
+	}
+
+	repository := conn.ProcedureRepository()
+	_, err = repository.Update(ctx, client.ProcedureUpdateParams{Current: current, Desired: desired})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating procedure", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readProcedureModel(ctx, repository, desired.Schema, desired.Name, client.RoutineArgTypes(desired.Args), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("procedure", desired.Owner, planModel.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'procedure' resource")
+}
+
+func (r *procedureResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'procedure' resource")
+
+	var model procedureResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	pgModel := model.toPgModel()
+	err = conn.ProcedureRepository().Drop(ctx, pgModel.Schema, pgModel.Name, client.RoutineArgTypes(pgModel.Args))
+	if err != nil {
+		res.Diagnostics.AddError("Error deleting procedure", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'procedure' resource")
+}
+
+func (r *procedureResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readProcedureModel reads the procedure into the target model, keeping the spelling of the
+// argument types of the target when PostgreSQL normalizes them to the same definition.
+func readProcedureModel(ctx context.Context, repository client.ProcedureRepository, schema, name string, argTypes []string, target *procedureResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, schema, name, argTypes)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading procedure: '%s.%s(%s)'", schema, name, strings.Join(argTypes, ", ")), err.Error())
+		return diags
+	}
+
+	prior := target.toPgModel()
+	if len(prior.Args) > 0 {
+		normalized, err := repository.Normalize(ctx, prior)
+		if err != nil {
+			// the prior definition may no longer be valid, e.g. a referenced type was dropped
+			tflog.Warn(ctx, "Unable to normalize the procedure definition, using the server spelling", map[string]any{"error": err.Error()})
+		} else {
+			*actual = client.PreserveProcedureSpelling(prior, *normalized, *actual)
+		}
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *procedureResourceModel) toPgModel() client.ProcedureModel {
+	return client.ProcedureModel{
+		Schema:          rm.Schema.ValueString(),
+		Name:            rm.Name.ValueString(),
+		Database:        rm.Database.ValueString(),
+		Args:            mapRoutineArgsToPg(rm.Args),
+		Language:        rm.Language.ValueString(),
+		Body:            rm.Body.ValueString(),
+		SecurityDefiner: rm.SecurityDefiner.ValueBool(),
+		Owner:           rm.Owner.ValueString(),
+		Comment:         rm.Comment.ValueString(),
+	}
+}
+
+func (rm *procedureResourceModel) fromPgModel(pgModel client.ProcedureModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Schema = types.StringValue(pgModel.Schema)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Args = mapRoutineArgsFromPg(pgModel.Args)
+	rm.Language = types.StringValue(pgModel.Language)
+	rm.Body = types.StringValue(pgModel.Body)
+	rm.SecurityDefiner = types.BoolValue(pgModel.SecurityDefiner)
+	rm.Owner = types.StringValue(pgModel.Owner)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+}
+
+func (rm *procedureResourceModel) SetId() {
+	rm.Id = types.StringValue(routineId(rm.Database.ValueString(), rm.Schema.ValueString(), rm.Name.ValueString(), rm.Args))
+}
+
+func (rm *procedureResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
+
+// routineArgsAttribute returns the schema of the arguments of a procedure or an aggregate,
+// accepting the given argument modes.
+func routineArgsAttribute(description string, modes ...string) schema.ListNestedAttribute {
+	return schema.ListNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: description,
+		PlanModifiers: []planmodifier.List{
+			listplanmodifier.RequiresReplace(),
+		},
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					Optional:            true,
+					MarkdownDescription: "Name of the argument",
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					},
+				},
+				"type": schema.StringAttribute{
+					Required:            true,
+					MarkdownDescription: "Data type of the argument, e.g. `text` or `integer`",
+					Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					},
+				},
+				"mode": schema.StringAttribute{
+					Optional:            true,
+					Computed:            true,
+					Default:             stringdefault.StaticString("IN"),
+					MarkdownDescription: fmt.Sprintf("Mode of the argument, one of `%s`. Defaults to `IN`.", strings.Join(modes, "`, `")),
+					Validators: []validator.String{
+						stringvalidator.OneOf(modes...),
+					},
+				},
+			},
+		},
+	}
+}
+
+func mapRoutineArgsToPg(args []routineArgModel) []client.RoutineArg {
+	var pgArgs []client.RoutineArg
+	for _, arg := range args {
+		pgArgs = append(pgArgs, client.RoutineArg{
+			Name: arg.Name.ValueString(),
+			Type: arg.Type.ValueString(),
+			Mode: arg.Mode.ValueString(),
+		})
+	}
+	return pgArgs
+}
+
+func mapRoutineArgsFromPg(pgArgs []client.RoutineArg) []routineArgModel {
+	var args []routineArgModel
+	for _, arg := range pgArgs {
+		args = append(args, routineArgModel{
+			Name: stringValueOrNull(arg.Name),
+			Type: types.StringValue(arg.Type),
+			Mode: types.StringValue(arg.Mode),
+		})
+	}
+	return args
+}
+
+// routineId returns the identifier of a procedure or an aggregate, e.g. 'db.public.archive(integer, text)'.
+func routineId(database, schema, name string, args []routineArgModel) string {
+	return fmt.Sprintf("%s.%s.%s(%s)", database, schema, name, strings.Join(client.RoutineArgTypes(mapRoutineArgsToPg(args)), ", "))
+}
+
+// splitRoutineId splits the identifier of a procedure or an aggregate (see routineId).
+// The argument types are split on the commas outside of parentheses, e.g. 'numeric(10, 2), text'.
+func splitRoutineId(id string) (database, schema, name string, argTypes []string, ok bool) {
+	idParts, ok := splitResourceId(id, 3)
+	if !ok {
+		return "", "", "", nil, false
+	}
+
+	open := strings.Index(idParts[2], "(")
+	if open < 1 || !strings.HasSuffix(idParts[2], ")") {
+		return "", "", "", nil, false
+	}
+	name, args := idParts[2][:open], idParts[2][open+1:len(idParts[2])-1]
+
+	depth, start := 0, 0
+	for i, c := range args {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				argTypes = append(argTypes, strings.TrimSpace(args[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if last := strings.TrimSpace(args[start:]); last != "" || len(argTypes) > 0 {
+		argTypes = append(argTypes, last)
+	}
+	return idParts[0], idParts[1], name, argTypes, true
+}
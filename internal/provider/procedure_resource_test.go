@@ -0,0 +1,150 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccProcedureResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_procedure_resource_db",
+		Username: "test_procedure_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_procedure"
+	mockResourceName := fmt.Sprintf("postgresql_procedure.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `
+				CREATE TABLE public.test_procedure_audit (id serial PRIMARY KEY, note text);
+				CREATE SCHEMA app;
+				CREATE ROLE test_procedure_owner;`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccProcedureToTFResource(t, mockResourceId, "test_insert_audit", `
+					schema  = "public"
+					comment = "test comment"
+					body    = <<-EOT
+						BEGIN
+							FOR i IN 1..times LOOP
+								INSERT INTO public.test_procedure_audit (note) VALUES (note);
+								COMMIT;
+							END LOOP;
+						END;
+					EOT`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_insert_audit(text, int)", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "args.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "args.1.type", "int"),
+					resource.TestCheckResourceAttr(mockResourceName, "args.1.mode", "IN"),
+					resource.TestCheckResourceAttr(mockResourceName, "language", "plpgsql"),
+					resource.TestCheckResourceAttr(mockResourceName, "security_definer", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "owner", runOpts.Username),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment"),
+					func(_ *terraform.State) error {
+						// the procedure commits every row, which is only allowed outside a transaction block
+						_, err := db.ExecContext(ctx, `CALL public.test_insert_audit('called', 2);`)
+						return err
+					},
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "args.1.type"},
+			},
+			{
+				// Update testing - Properties without re-creating the resource
+				Config: testAccProcedureToTFResource(t, mockResourceId, "test_audit_notes", `
+					schema           = "app"
+					language         = "sql"
+					security_definer = true
+					owner            = "test_procedure_owner"
+					body             = "INSERT INTO public.test_procedure_audit (note) SELECT note FROM generate_series(1, times)"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.app.test_audit_notes(text, int)", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "language", "sql"),
+					resource.TestCheckResourceAttr(mockResourceName, "security_definer", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "owner", "test_procedure_owner"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "comment"),
+				),
+			},
+			{
+				// Drift testing - a procedure dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP PROCEDURE app.test_audit_notes(text, int);`)
+					assert.NoError(t, err)
+				},
+				Config: testAccProcedureToTFResource(t, mockResourceId, "test_audit_notes", `
+					schema           = "app"
+					language         = "sql"
+					security_definer = true
+					owner            = "test_procedure_owner"
+					body             = "INSERT INTO public.test_procedure_audit (note) SELECT note FROM generate_series(1, times)"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(mockResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func TestSplitRoutineId(t *testing.T) {
+	tests := []struct {
+		id       string
+		name     string
+		argTypes []string
+		ok       bool
+	}{
+		{id: "db.public.archive()", name: "archive", ok: true},
+		{id: "db.public.archive(integer, text)", name: "archive", argTypes: []string{"integer", "text"}, ok: true},
+		{id: "db.public.round_to(numeric(10, 2), public.my_type)", name: "round_to", argTypes: []string{"numeric(10, 2)", "public.my_type"}, ok: true},
+		{id: "db.public.archive", ok: false},
+		{id: "db.public.(integer)", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			database, schema, name, argTypes, ok := splitRoutineId(tt.id)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, "db", database)
+				assert.Equal(t, "public", schema)
+				assert.Equal(t, tt.name, name)
+				assert.Equal(t, tt.argTypes, argTypes)
+			}
+		})
+	}
+}
+
+func testAccProcedureToTFResource(t *testing.T, resId, name, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_procedure" "%s" {
+			name = "%s"
+			args = [
+				{ name = "note", type = "text" },
+				{ name = "times", type = "int" },
+			]
+			%s
+		}`, resId, name, body)
+}
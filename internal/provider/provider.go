@@ -29,6 +29,7 @@ const (
 	providerAttrSSLMode     = "sslmode"
 	providerAttrMaxOpenConn = "max_open_conn"
 	providerAttrMaxIdleConn = "max_idle_conn"
+	providerAttrAssumeRole  = "assume_role"
 )
 
 // Ensure PostgresqlProvider satisfies various provider interfaces.
@@ -54,6 +55,7 @@ type PostgresqlProviderConfig struct {
 	SSLMode     types.String `tfsdk:"sslmode" validate:"required"`
 	MaxOpenConn types.Int64  `tfsdk:"max_open_conn" validate:"required"`
 	MaxIdleConn types.Int64  `tfsdk:"max_idle_conn" validate:"required"`
+	AssumeRole  types.String `tfsdk:"assume_role"`
 }
 
 func (p *PostgresqlProvider) Metadata(_ context.Context, _ provider.MetadataRequest, res *provider.MetadataResponse) {
@@ -127,6 +129,15 @@ The SSL mode to use when connecting to the PostgreSQL server. The value must be
 					int64validator.AtLeast(0),
 				},
 			},
+			providerAttrAssumeRole: schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) inside every transaction executed by the provider, " +
+					"so objects are created as, and owned by, this role instead of the connecting user. " +
+					"Resources can override it with their own `assume_role` attribute.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
 		},
 		MarkdownDescription: mdDocProviderOverview,
 	}
@@ -174,12 +185,42 @@ func (p *PostgresqlProvider) Configure(ctx context.Context, req provider.Configu
 func (p *PostgresqlProvider) Resources(context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewEventTriggerResource,
+		NewTableResource,
+		NewIndexResource,
+		NewViewResource,
+		NewMaterializedViewResource,
+		NewSequenceResource,
+		NewTypeResource,
+		NewTriggerResource,
+		NewPolicyResource,
+		NewRowLevelSecurityResource,
+		NewPublicationResource,
+		NewSubscriptionResource,
+		NewReplicationSlotResource,
+		NewForeignServerResource,
+		NewUserMappingResource,
+		NewForeignTableResource,
+		NewImportForeignSchemaResource,
+		NewTablespaceResource,
+		NewProcedureResource,
+		NewAggregateResource,
+		NewRoleSettingResource,
+		NewDatabaseSettingResource,
+		NewSystemSettingResource,
+		NewSqlResource,
 	}
 }
 
 func (p *PostgresqlProvider) DataSources(context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewEventTriggerDataSource,
+		NewSettingsDataSource,
+		NewQueryDataSource,
+		NewDatabasesDataSource,
+		NewSchemasDataSource,
+		NewRolesDataSource,
+		NewTablesDataSource,
+		NewServerDataSource,
 	}
 }
 
@@ -265,6 +306,10 @@ func (c *PostgresqlProviderConfig) loadConfig() diag.Diagnostics {
 		c.MaxIdleConn = defaultMaxIdleConn
 	}
 
+	if c.AssumeRole.IsNull() {
+		c.AssumeRole = types.StringValue(os.Getenv("POSTGRES_ASSUME_ROLE"))
+	}
+
 	return diags
 }
 
@@ -281,6 +326,7 @@ func (c *PostgresqlProviderConfig) toPgConnectionOpts(ctx context.Context) (*cli
 		client.WithSSLMode(c.SSLMode.ValueString()),
 		client.WithMaxOpenConn(int(c.MaxOpenConn.ValueInt64())),
 		client.WithMaxIdleConn(int(c.MaxIdleConn.ValueInt64())),
+		client.WithAssumeRole(c.AssumeRole.ValueString()),
 	)
 	if err != nil {
 		tflog.Debug(ctx, fmt.Sprintf("\n\n\nFailed to create Postgres client connection options. Error: %v\n\n\n", err))
@@ -0,0 +1,548 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type publicationResource struct {
+	client client.PgClient
+}
+
+type publicationResourceModel struct {
+	Id                      types.String            `tfsdk:"id"`
+	LastUpdated             types.String            `tfsdk:"last_updated"`
+	Database                types.String            `tfsdk:"database"`
+	Name                    types.String            `tfsdk:"name"`
+	AllTables               types.Bool              `tfsdk:"all_tables"`
+	Tables                  []publicationTableModel `tfsdk:"tables"`
+	Schemas                 []types.String          `tfsdk:"schemas"`
+	Publish                 types.Set               `tfsdk:"publish"`
+	PublishViaPartitionRoot types.Bool              `tfsdk:"publish_via_partition_root"`
+	Owner                   types.String            `tfsdk:"owner"`
+	Comment                 types.String            `tfsdk:"comment"`
+	AssumeRole              types.String            `tfsdk:"assume_role"`
+}
+
+type publicationTableModel struct {
+	Schema    types.String   `tfsdk:"schema"`
+	Name      types.String   `tfsdk:"name"`
+	Columns   []types.String `tfsdk:"columns"`
+	RowFilter types.String   `tfsdk:"row_filter"`
+}
+
+var (
+	_ resource.Resource                   = &publicationResource{}
+	_ resource.ResourceWithConfigure      = &publicationResource{}
+	_ resource.ResourceWithImportState    = &publicationResource{}
+	_ resource.ResourceWithValidateConfig = &publicationResource{}
+	_ resource.ResourceWithModifyPlan     = &publicationResource{}
+)
+
+func NewPublicationResource() resource.Resource {
+	return &publicationResource{}
+}
+
+func (r *publicationResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'publication' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *publicationResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_publication"
+}
+
+func (r *publicationResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the publication, in the format `database_name.publication_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the publication",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the publication is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the publication. Changes rename the publication in place.",
+				Validators:          nonEmptyString,
+			},
+			"all_tables": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether every table of the database is published, including the tables created later. It excludes `tables` and `schemas`.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"tables": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Tables published, optionally limited to some columns and rows. Changes replace the whole list of published tables and schemas.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"schema": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString("public"),
+							MarkdownDescription: "Schema of the table",
+						},
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the table",
+							Validators:          nonEmptyString,
+						},
+						"columns": schema.ListAttribute{
+							Optional:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Columns published, every column when not provided. Requires PostgreSQL 15 or later.",
+							Validators: []validator.List{
+								listvalidator.SizeAtLeast(1),
+								listvalidator.UniqueValues(),
+							},
+						},
+						"row_filter": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Boolean expression selecting the rows published (`WHERE`). Requires PostgreSQL 15 or later.",
+							Validators:          nonEmptyString,
+						},
+					},
+				},
+			},
+			"schemas": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Schemas whose tables are all published (`TABLES IN SCHEMA`), including the tables created later. Requires PostgreSQL 15 or later.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"publish": schema.SetAttribute{
+				Optional:            true,
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Operations published: `insert`, `update`, `delete` and `truncate`. Defaults to every operation.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(client.PublicationOperations...)),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"publish_via_partition_root": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the changes of partitions are published as changes of their partitioned table, instead of the partition itself",
+			},
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The owner of the publication. If not provided, the publication is owned by the role that creates it (see `assume_role`).",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the publication",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the publication. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourcePublication,
+	}
+}
+
+func (r *publicationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	var model publicationResourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.AllTables.ValueBool() && (len(model.Tables) > 0 || len(model.Schemas) > 0) {
+		res.Diagnostics.AddAttributeError(
+			path.Root("all_tables"),
+			"Invalid attribute combination",
+			"A publication of all tables can't list tables or schemas.",
+		)
+	}
+}
+
+func (r *publicationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var model publicationResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.usesFilters() {
+		res.Diagnostics.Append(checkServerCapability(
+			ctx,
+			r.client,
+			plannedDatabase(r.client, model.Database),
+			client.CapabilityPublicationFilters,
+			path.Root("tables"),
+			"Column lists, row filters and schemas of publications",
+		)...)
+	}
+
+	// the identifier is derived from the name, a rename produces a new one
+	if !req.State.Raw.IsNull() {
+		var stateModel publicationResourceModel
+		res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+		if !model.Name.Equal(stateModel.Name) {
+			res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		}
+	}
+}
+
+func (r *publicationResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'publication' resource")
+
+	var model publicationResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	role := resolveAssumeRole(r.client, model.AssumeRole)
+	ctx = client.ContextWithAssumeRole(ctx, role)
+
+	// the publication is owned by the assumed role, unless another owner is explicitly requested
+	expectedOwner := role
+	if !model.Owner.IsNull() && !model.Owner.IsUnknown() {
+		expectedOwner = model.Owner.ValueString()
+	}
+
+	pgModel := model.toPgModel()
+	pgModel.Owner = expectedOwner
+
+	repository := conn.PublicationRepository()
+	if err = repository.Create(ctx, pgModel); err != nil {
+		res.Diagnostics.AddError("Error creating publication", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readPublicationModel(ctx, repository, model.Name.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("publication", expectedOwner, model.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'publication' resource")
+}
+
+func (r *publicationResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'publication' resource")
+
+	var model publicationResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the publication", "Id is required for reading publication")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 2)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the publication", "Id should be in the format 'database_name.publication_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a publication dropped outside of Terraform is removed from the state and planned again
+	repository := conn.PublicationRepository()
+	exists, err := repository.Exists(ctx, idParts[1])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading publication", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Publication not found, removing it from the state", map[string]any{"publication": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readPublicationModel(ctx, repository, idParts[1], &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'publication' resource")
+}
+
+func (r *publicationResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'publication' resource")
+
+	var stateModel publicationResourceModel
+	var planModel publicationResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	current := stateModel.toPgModel()
+	desired := planModel.toPgModel()
+	if planModel.Owner.IsUnknown() {
+		desired.Owner = current.Owner
+	}
+	if planModel.Publish.IsUnknown() {
+		desired.Publish = current.Publish
+	}
+
+	repository := conn.PublicationRepository()
+	_, err = repository.Update(ctx, client.PublicationUpdateParams{Current: current, Desired: desired})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating publication", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readPublicationModel(ctx, repository, planModel.Name.ValueString(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("publication", desired.Owner, planModel.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'publication' resource")
+}
+
+func (r *publicationResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'publication' resource")
+
+	var model publicationResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	if err = conn.PublicationRepository().Drop(ctx, model.Name.ValueString()); err != nil {
+		res.Diagnostics.AddError("Error deleting publication", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'publication' resource")
+}
+
+func (r *publicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readPublicationModel reads the publication into the target model, keeping the order of the lists
+// of the target and the spelling of its row filters when PostgreSQL normalizes them to the same definition.
+func readPublicationModel(ctx context.Context, repository client.PublicationRepository, name string, target *publicationResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading publication: '%s'", name), err.Error())
+		return diags
+	}
+
+	if len(target.Tables) > 0 || len(target.Schemas) > 0 {
+		prior := target.toPgModel()
+		normalized := &prior
+		if target.usesFilters() {
+			normalized, err = repository.Normalize(ctx, prior)
+		}
+		if err != nil {
+			// the prior definition may no longer be valid, e.g. a published table was dropped
+			tflog.Warn(ctx, "Unable to normalize the publication definition, using the server spelling", map[string]any{"error": err.Error()})
+		} else {
+			*actual = client.PreservePublicationSpelling(prior, *normalized, *actual)
+		}
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+// usesFilters reports whether the publication uses column lists, row filters or schemas.
+func (rm *publicationResourceModel) usesFilters() bool {
+	if len(rm.Schemas) > 0 {
+		return true
+	}
+	for _, table := range rm.Tables {
+		if len(table.Columns) > 0 || !table.RowFilter.IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+func (rm *publicationResourceModel) toPgModel() client.PublicationModel {
+	var tables []client.PublicationTable
+	for _, table := range rm.Tables {
+		tables = append(tables, client.PublicationTable{
+			Schema:    table.Schema.ValueString(),
+			Name:      table.Name.ValueString(),
+			Columns:   mapStringValuesToSlice(table.Columns),
+			RowFilter: table.RowFilter.ValueString(),
+		})
+	}
+
+	return client.PublicationModel{
+		Name:                    rm.Name.ValueString(),
+		Database:                rm.Database.ValueString(),
+		AllTables:               rm.AllTables.ValueBool(),
+		Tables:                  tables,
+		Schemas:                 mapStringValuesToSlice(rm.Schemas),
+		Publish:                 mapSetValueToSlice[string](rm.Publish),
+		PublishViaPartitionRoot: rm.PublishViaPartitionRoot.ValueBool(),
+		Owner:                   rm.Owner.ValueString(),
+		Comment:                 rm.Comment.ValueString(),
+	}
+}
+
+func (rm *publicationResourceModel) fromPgModel(pgModel client.PublicationModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.AllTables = types.BoolValue(pgModel.AllTables)
+
+	rm.Tables = nil
+	for _, table := range pgModel.Tables {
+		rm.Tables = append(rm.Tables, publicationTableModel{
+			Schema:    types.StringValue(table.Schema),
+			Name:      types.StringValue(table.Name),
+			Columns:   mapSliceToStringValues(table.Columns),
+			RowFilter: stringValueOrNull(table.RowFilter),
+		})
+	}
+
+	rm.Schemas = mapSliceToStringValues(pgModel.Schemas)
+	rm.Publish = mapSliceToStringSet(pgModel.Publish)
+	rm.PublishViaPartitionRoot = types.BoolValue(pgModel.PublishViaPartitionRoot)
+	rm.Owner = types.StringValue(pgModel.Owner)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+}
+
+func (rm *publicationResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s", rm.Database.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *publicationResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccPublicationResource(t *testing.T) {
+	// the column lists, row filters and schemas of publications come with PostgreSQL 15
+	test.SkipBelowPostgres(t, 15)
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_publication_resource_db",
+		Username: "test_publication_resource_user",
+		Settings: map[string]string{"wal_level": "logical"},
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_publication"
+	mockResourceName := fmt.Sprintf("postgresql_publication.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `
+				CREATE TABLE public.test_publication_resource_orders (id int PRIMARY KEY, status text, amount int);
+				CREATE TABLE public.test_publication_resource_items (id int PRIMARY KEY, name text);
+				CREATE SCHEMA test_publication_resource_schema;`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccPublicationToTFResource(t, mockResourceId, "test_publication_resource", `
+					tables = [
+						{
+							name       = "test_publication_resource_orders"
+							columns    = ["id", "status"]
+							row_filter = "status <> 'draft'"
+						},
+					]
+					publish = ["insert", "update"]
+					comment = "test comment"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.test_publication_resource", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "all_tables", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.#", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.0.schema", "public"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.0.columns.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.0.row_filter", "status <> 'draft'"),
+					resource.TestCheckResourceAttr(mockResourceName, "publish.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "publish_via_partition_root", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "owner", runOpts.Username),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment"),
+				),
+			},
+			{
+				// ImportState testing, the server spelling is used without a prior state
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "tables.0.row_filter"},
+			},
+			{
+				// Update testing - Properties without re-creating the resource
+				Config: testAccPublicationToTFResource(t, mockResourceId, "test_publication_resource_renamed", `
+					tables = [
+						{ name = "test_publication_resource_orders" },
+						{ name = "test_publication_resource_items" },
+					]
+					schemas = ["test_publication_resource_schema"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.test_publication_resource_renamed", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.#", "2"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "tables.0.row_filter"),
+					resource.TestCheckResourceAttr(mockResourceName, "schemas.#", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "publish.#", "2"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "comment"),
+				),
+			},
+			{
+				// Drift testing - a publication dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP PUBLICATION test_publication_resource_renamed;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccPublicationToTFResource(t, mockResourceId, "test_publication_resource_renamed", `
+					tables = [
+						{ name = "test_publication_resource_orders" },
+						{ name = "test_publication_resource_items" },
+					]
+					schemas = ["test_publication_resource_schema"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(mockResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPublicationToTFResource(t *testing.T, resId, name, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_publication" "%s" {
+			name = "%s"
+			%s
+		}`, resId, name, body)
+}
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+)
+
+const (
+	queryDefaultRowLimit         = 1000
+	queryDefaultStatementTimeout = "30s"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &queryDataSource{}
+	_ datasource.DataSourceWithConfigure = &queryDataSource{}
+)
+
+type queryDataSource struct {
+	client client.PgClient
+}
+
+type queryDataSourceModel struct {
+	Database         types.String `tfsdk:"database"`
+	Query            types.String `tfsdk:"query"`
+	Args             types.List   `tfsdk:"args"`
+	RowLimit         types.Int64  `tfsdk:"row_limit"`
+	StatementTimeout types.String `tfsdk:"statement_timeout"`
+	Columns          types.List   `tfsdk:"columns"`
+	Rows             types.List   `tfsdk:"rows"`
+	AssumeRole       types.String `tfsdk:"assume_role"`
+}
+
+func NewQueryDataSource() datasource.DataSource {
+	return &queryDataSource{}
+}
+
+func (d *queryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, res *datasource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'query' datasource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Configured 'query' datasource")
+	d.client = pgClient
+}
+
+func (d *queryDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, res *datasource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_query"
+}
+
+func (d *queryDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, res *datasource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database the query runs on. If not provided, the database from the provider configuration will be used.",
+				Validators:          nonEmptyString,
+			},
+			"query": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "A single read-only statement, starting with `SELECT`, `WITH`, `VALUES`, `TABLE`, `SHOW` or `EXPLAIN`. It runs in a `READ ONLY` transaction, rolled back once the rows are read.",
+				Validators:          nonEmptyString,
+			},
+			"args": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arguments of the query, bound to the placeholders `$1`, `$2`... as text. Cast the placeholders to use other types, e.g. `$1::int`.",
+			},
+			"row_limit": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Maximum number of rows, the query fails when it returns more. Defaults to `1000`.",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"statement_timeout": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The query is aborted when it runs longer, e.g. `5min`, `0` to disable. Defaults to `30s`.",
+				Validators:          nonEmptyString,
+			},
+			"columns": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the columns returned by the query, in order",
+			},
+			"rows": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.MapType{ElemType: types.StringType},
+				MarkdownDescription: "The rows returned by the query, as maps of the column names to their values as text. NULL values are null.",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while running the query, e.g. a role with limited privileges. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocDataSourceQuery,
+	}
+}
+
+func (d *queryDataSource) Read(ctx context.Context, req datasource.ReadRequest, res *datasource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'query' datasource")
+
+	var model queryDataSourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() {
+		model.Database = types.StringValue(d.client.GetInitConfig().Database)
+	}
+	if model.RowLimit.IsNull() {
+		model.RowLimit = types.Int64Value(queryDefaultRowLimit)
+	}
+	if model.StatementTimeout.IsNull() {
+		model.StatementTimeout = types.StringValue(queryDefaultStatementTimeout)
+	}
+
+	conn, err := d.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(d.client, model.AssumeRole))
+
+	params := client.QueryParams{
+		Query:            model.Query.ValueString(),
+		Args:             mapListValueToSlice(model.Args),
+		RowLimit:         int(model.RowLimit.ValueInt64()),
+		StatementTimeout: model.StatementTimeout.ValueString(),
+	}
+	result, err := conn.QueryRepository().Query(ctx, params)
+	if err != nil {
+		res.Diagnostics.AddError("Error running query", err.Error())
+		return
+	}
+
+	rows := make([]map[string]types.String, len(result.Rows))
+	for i, row := range result.Rows {
+		rows[i] = make(map[string]types.String, len(row))
+		for column, value := range row {
+			rows[i][column] = types.StringPointerValue(value)
+		}
+	}
+
+	model.Columns = mapSliceToStringList(result.Columns)
+	rowsValue, diags := types.ListValueFrom(ctx, types.MapType{ElemType: types.StringType}, rows)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	model.Rows = rowsValue
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'query' datasource")
+}
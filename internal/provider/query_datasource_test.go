@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"regexp"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccQueryDataSource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_query_datasource_db",
+		Username: "test_query_datasource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_query"
+	mockResourceName := fmt.Sprintf("data.postgresql_query.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `
+				CREATE TABLE public.test_query_datasource_table (id int, label text);
+				INSERT INTO public.test_query_datasource_table VALUES (1, 'one'), (2, NULL), (3, 'three');`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Query with arguments
+				Config: testAccQueryToTFDataSource(t, mockResourceId, `
+					query = "SELECT id, label FROM public.test_query_datasource_table WHERE id <= $1::int ORDER BY id"
+					args  = ["2"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "database", runOpts.Database),
+					resource.TestCheckResourceAttr(mockResourceName, "row_limit", "1000"),
+					resource.TestCheckResourceAttr(mockResourceName, "statement_timeout", "30s"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.0", "id"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.1", "label"),
+					resource.TestCheckResourceAttr(mockResourceName, "rows.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "rows.0.id", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "rows.0.label", "one"),
+					resource.TestCheckResourceAttr(mockResourceName, "rows.1.id", "2"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "rows.1.label"),
+				),
+			},
+			{
+				// Row limit testing
+				Config: testAccQueryToTFDataSource(t, mockResourceId, `
+					query     = "SELECT * FROM public.test_query_datasource_table"
+					row_limit = 2`),
+				ExpectError: regexp.MustCompile(`the query returned too many rows: the limit is 2 rows`),
+			},
+			{
+				// Read-only testing - Statements that write are refused
+				Config: testAccQueryToTFDataSource(t, mockResourceId, `
+					query = "DELETE FROM public.test_query_datasource_table RETURNING id"`),
+				ExpectError: regexp.MustCompile(`the query is not read-only`),
+			},
+			{
+				// Read-only testing - Data-modifying CTEs are refused by the transaction
+				Config: testAccQueryToTFDataSource(t, mockResourceId, `
+					query = "WITH d AS (DELETE FROM public.test_query_datasource_table RETURNING id) SELECT * FROM d"`),
+				ExpectError: regexp.MustCompile(`cannot execute DELETE in a read-only transaction`),
+			},
+			{
+				// Statement timeout testing
+				Config: testAccQueryToTFDataSource(t, mockResourceId, `
+					query             = "SELECT pg_sleep(1)"
+					statement_timeout = "100ms"`),
+				ExpectError: regexp.MustCompile(`canceling statement due to statement timeout`),
+			},
+		},
+	})
+}
+
+func testAccQueryToTFDataSource(t *testing.T, resName, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`data "postgresql_query" "%s" {
+			%s
+		}`, resName, body)
+}
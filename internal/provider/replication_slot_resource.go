@@ -0,0 +1,452 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type replicationSlotResource struct {
+	client client.PgClient
+}
+
+type replicationSlotResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	LastUpdated       types.String `tfsdk:"last_updated"`
+	Database          types.String `tfsdk:"database"`
+	Name              types.String `tfsdk:"name"`
+	Type              types.String `tfsdk:"type"`
+	Plugin            types.String `tfsdk:"plugin"`
+	Temporary         types.Bool   `tfsdk:"temporary"`
+	TwoPhase          types.Bool   `tfsdk:"two_phase"`
+	TerminateActive   types.Bool   `tfsdk:"terminate_active"`
+	Active            types.Bool   `tfsdk:"active"`
+	ActivePid         types.Int64  `tfsdk:"active_pid"`
+	RestartLsn        types.String `tfsdk:"restart_lsn"`
+	ConfirmedFlushLsn types.String `tfsdk:"confirmed_flush_lsn"`
+	WalStatus         types.String `tfsdk:"wal_status"`
+	LagBytes          types.Int64  `tfsdk:"lag_bytes"`
+	AssumeRole        types.String `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                   = &replicationSlotResource{}
+	_ resource.ResourceWithConfigure      = &replicationSlotResource{}
+	_ resource.ResourceWithImportState    = &replicationSlotResource{}
+	_ resource.ResourceWithValidateConfig = &replicationSlotResource{}
+	_ resource.ResourceWithModifyPlan     = &replicationSlotResource{}
+)
+
+func NewReplicationSlotResource() resource.Resource {
+	return &replicationSlotResource{}
+}
+
+func (r *replicationSlotResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'replication_slot' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *replicationSlotResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_replication_slot"
+}
+
+func (r *replicationSlotResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the replication slot, in the format `database_name.slot_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the replication slot",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database whose changes a logical slot decodes. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the replication slot, unique in the server",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(client.ReplicationSlotLogical),
+				MarkdownDescription: "Type of the replication slot: `logical`, for logical decoding and CDC tools, or `physical`, for streaming replicas",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.ReplicationSlotTypes...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"plugin": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Output plugin of a logical slot, e.g. `pgoutput`, `test_decoding` or `wal2json`. Defaults to `pgoutput`.",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"temporary": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the slot is temporary. Only `false` is accepted: a temporary slot is released when the session of the provider that created it ends, so every plan would create it again.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"two_phase": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether a logical slot decodes the prepared transactions when they're prepared. Requires PostgreSQL 14 or later.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"terminate_active": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the connection using the slot is terminated when the slot is destroyed. Otherwise destroying an active slot fails.",
+			},
+			"active": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether a connection is currently using the slot",
+			},
+			"active_pid": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The process id of the connection using the slot, `0` when the slot is inactive",
+			},
+			"restart_lsn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The oldest WAL position still retained for the consumer of the slot",
+			},
+			"confirmed_flush_lsn": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The last WAL position confirmed by the consumer of a logical slot",
+			},
+			"wal_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Availability of the WAL retained for the slot: `reserved`, `extended`, `unreserved` or `lost`. Empty before PostgreSQL 13.",
+			},
+			"lag_bytes": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "The amount of WAL, in bytes, between the current position of the server and the last position confirmed by the consumer of the slot",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET ROLE`) while managing the replication slot. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceReplicationSlot,
+	}
+}
+
+func (r *replicationSlotResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	var model replicationSlotResourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// the session of the provider ends with every run, a temporary slot would never stay created
+	if model.Temporary.ValueBool() {
+		res.Diagnostics.AddAttributeError(path.Root("temporary"), "Invalid replication slot definition",
+			"Temporary replication slots are released when the session that created them ends, they can't be managed by Terraform.")
+	}
+
+	if model.Type.ValueString() != client.ReplicationSlotPhysical {
+		return
+	}
+	if !model.Plugin.IsNull() {
+		res.Diagnostics.AddAttributeError(path.Root("plugin"), "Invalid replication slot definition", "Physical replication slots have no output plugin.")
+	}
+	if model.TwoPhase.ValueBool() {
+		res.Diagnostics.AddAttributeError(path.Root("two_phase"), "Invalid replication slot definition", "Only logical replication slots decode prepared transactions.")
+	}
+}
+
+func (r *replicationSlotResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var model replicationSlotResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.TwoPhase.ValueBool() {
+		res.Diagnostics.Append(checkServerCapability(ctx, r.client, plannedDatabase(r.client, model.Database),
+			client.CapabilityTwoPhaseSlot, path.Root("two_phase"), "The two-phase decoding of replication slots")...)
+	}
+
+	// a slot changing type doesn't keep the plugin of the previous one
+	if !req.State.Raw.IsNull() {
+		var stateModel, configModel replicationSlotResourceModel
+		res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+		res.Diagnostics.Append(req.Config.Get(ctx, &configModel)...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+		if !model.Type.Equal(stateModel.Type) && configModel.Plugin.IsNull() {
+			res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("plugin"), types.StringUnknown())...)
+		}
+	}
+}
+
+func (r *replicationSlotResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'replication_slot' resource")
+
+	var model replicationSlotResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	repository := conn.ReplicationSlotRepository()
+	if err = repository.Create(ctx, model.toPgModel()); err != nil {
+		res.Diagnostics.AddError("Error creating replication slot", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readReplicationSlotModel(ctx, repository, model.Name.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'replication_slot' resource")
+}
+
+func (r *replicationSlotResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'replication_slot' resource")
+
+	var model replicationSlotResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the replication slot", "Id is required for reading replication slot")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 2)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the replication slot", "Id should be in the format 'database_name.slot_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a replication slot dropped outside of Terraform is removed from the state and planned again
+	repository := conn.ReplicationSlotRepository()
+	exists, err := repository.Exists(ctx, idParts[1])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading replication slot", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Replication slot not found, removing it from the state", map[string]any{"slot": idParts[1]})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	// the termination flag only lives in the configuration, imported slots get the default
+	if model.TerminateActive.IsNull() {
+		model.TerminateActive = types.BoolValue(false)
+	}
+
+	res.Diagnostics.Append(readReplicationSlotModel(ctx, repository, idParts[1], &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'replication_slot' resource")
+}
+
+// Update only stores the new termination flag, every other change replaces the slot.
+func (r *replicationSlotResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'replication_slot' resource")
+
+	var planModel replicationSlotResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, planModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readReplicationSlotModel(ctx, conn.ReplicationSlotRepository(), planModel.Name.ValueString(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'replication_slot' resource")
+}
+
+func (r *replicationSlotResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'replication_slot' resource")
+
+	var model replicationSlotResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	err = conn.ReplicationSlotRepository().Drop(ctx, model.Name.ValueString(), model.TerminateActive.ValueBool())
+	if err != nil {
+		res.Diagnostics.AddError("Error deleting replication slot", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'replication_slot' resource")
+}
+
+func (r *replicationSlotResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+func readReplicationSlotModel(ctx context.Context, repository client.ReplicationSlotRepository, name string, target *replicationSlotResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading replication slot: '%s'", name), err.Error())
+		return diags
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *replicationSlotResourceModel) toPgModel() client.ReplicationSlotModel {
+	return client.ReplicationSlotModel{
+		Name:      rm.Name.ValueString(),
+		Database:  rm.Database.ValueString(),
+		Type:      rm.Type.ValueString(),
+		Plugin:    rm.Plugin.ValueString(),
+		Temporary: rm.Temporary.ValueBool(),
+		TwoPhase:  rm.TwoPhase.ValueBool(),
+	}
+}
+
+func (rm *replicationSlotResourceModel) fromPgModel(pgModel client.ReplicationSlotModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Type = types.StringValue(pgModel.Type)
+	rm.Plugin = stringValueOrNull(pgModel.Plugin)
+	rm.Temporary = types.BoolValue(pgModel.Temporary)
+	rm.TwoPhase = types.BoolValue(pgModel.TwoPhase)
+	rm.Active = types.BoolValue(pgModel.Active)
+	rm.ActivePid = types.Int64Value(pgModel.ActivePid)
+	rm.RestartLsn = types.StringValue(pgModel.RestartLsn)
+	rm.ConfirmedFlushLsn = types.StringValue(pgModel.ConfirmedFlushLsn)
+	rm.WalStatus = types.StringValue(pgModel.WalStatus)
+	rm.LagBytes = types.Int64Value(pgModel.LagBytes)
+}
+
+func (rm *replicationSlotResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s", rm.Database.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *replicationSlotResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
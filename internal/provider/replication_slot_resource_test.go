@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"regexp"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccReplicationSlotResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_replication_slot_resource_db",
+		Username: "test_replication_slot_resource_user",
+		Settings: map[string]string{"wal_level": "logical"},
+	}
+	_ = test.LoadPostgresTestContainer(t, runOpts, true)
+
+	mockLogicalName := "postgresql_replication_slot.test_logical"
+	mockPhysicalName := "postgresql_replication_slot.test_physical"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Plan testing - Temporary slots are refused
+				Config: `resource "postgresql_replication_slot" "test_temporary" {
+					name      = "test_temporary_slot"
+					temporary = true
+				}`,
+				ExpectError: regexp.MustCompile(`Temporary replication slots are released`),
+			},
+			{
+				// Create and Read testing
+				Config: testAccReplicationSlotToTFResource(t, "test_decoding", "false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockLogicalName, "id", fmt.Sprintf("%s.test_logical_slot", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockLogicalName, "type", "logical"),
+					resource.TestCheckResourceAttr(mockLogicalName, "plugin", "test_decoding"),
+					resource.TestCheckResourceAttr(mockLogicalName, "temporary", "false"),
+					resource.TestCheckResourceAttr(mockLogicalName, "active", "false"),
+					resource.TestCheckResourceAttr(mockLogicalName, "active_pid", "0"),
+					resource.TestCheckResourceAttrSet(mockLogicalName, "confirmed_flush_lsn"),
+					resource.TestCheckResourceAttrSet(mockLogicalName, "lag_bytes"),
+					resource.TestCheckResourceAttr(mockPhysicalName, "type", "physical"),
+					resource.TestCheckNoResourceAttr(mockPhysicalName, "plugin"),
+					resource.TestCheckResourceAttrSet(mockPhysicalName, "restart_lsn"),
+				),
+			},
+			{
+				// ImportState testing, the state of the slot moves on between reads
+				ResourceName:            mockLogicalName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "lag_bytes", "restart_lsn", "confirmed_flush_lsn"},
+			},
+			{
+				// Update testing - the termination flag is changed in place
+				Config: testAccReplicationSlotToTFResource(t, "test_decoding", "true"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockLogicalName, "terminate_active", "true"),
+					resource.TestCheckResourceAttr(mockLogicalName, "plugin", "test_decoding"),
+				),
+			},
+			{
+				// Update testing - a new plugin replaces the slot
+				Config: testAccReplicationSlotToTFResource(t, "pgoutput", "true"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockLogicalName, "plugin", "pgoutput"),
+				),
+			},
+		},
+	})
+}
+
+func testAccReplicationSlotToTFResource(t *testing.T, plugin, terminateActive string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_replication_slot" "test_logical" {
+			name             = "test_logical_slot"
+			plugin           = "%s"
+			terminate_active = %s
+		}
+
+		resource "postgresql_replication_slot" "test_physical" {
+			name = "test_physical_slot"
+			type = "physical"
+		}`, plugin, terminateActive)
+}
@@ -0,0 +1,413 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"slices"
+	"strings"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+// roleSettingAllDatabases stands for the database of a role setting applied in every database, in the identifier.
+const roleSettingAllDatabases = "*"
+
+// sessionSettingContexts are the contexts of the parameters that can have a default value per role
+// or per database, the others can only be changed in the server configuration.
+var sessionSettingContexts = []string{"user", "superuser", "backend", "superuser-backend"}
+
+type roleSettingResource struct {
+	client client.PgClient
+}
+
+type roleSettingResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+	Role        types.String `tfsdk:"role"`
+	Database    types.String `tfsdk:"database"`
+	Name        types.String `tfsdk:"name"`
+	Value       types.String `tfsdk:"value"`
+	AssumeRole  types.String `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                = &roleSettingResource{}
+	_ resource.ResourceWithConfigure   = &roleSettingResource{}
+	_ resource.ResourceWithImportState = &roleSettingResource{}
+	_ resource.ResourceWithModifyPlan  = &roleSettingResource{}
+)
+
+func NewRoleSettingResource() resource.Resource {
+	return &roleSettingResource{}
+}
+
+func (r *roleSettingResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'role_setting' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *roleSettingResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_role_setting"
+}
+
+func (r *roleSettingResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the role setting, in the format `role_name.database_name.parameter_name`, with `*` as database name for a setting applied in every database",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the role setting",
+			},
+			"role": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the role the setting applies to",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the database the setting applies to, when the role connects to it. If not provided, the setting applies in every database.",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the configuration parameter, e.g. `search_path`, or of a custom parameter qualified by its prefix, e.g. `app.tenant`",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Value of the configuration parameter, set when a session of the role starts. It is validated against `pg_settings`; values with units, e.g. `1GB` and `1024MB`, are compared in the unit of the parameter.",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET ROLE`) while managing the role setting. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceRoleSetting,
+	}
+}
+
+func (r *roleSettingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on destroy
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var model roleSettingResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(validateSessionSetting(ctx, r.client, model.Name, model.Value)...)
+}
+
+func (r *roleSettingResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'role_setting' resource")
+
+	var model roleSettingResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// the settings are stored in a catalog shared by every database, any database connection manages them
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	repository := conn.DbRoleSettingRepository()
+	if err = repository.Create(ctx, model.toPgModel()); err != nil {
+		res.Diagnostics.AddError("Error creating role setting", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readRoleSettingModel(ctx, repository, model.toPgModel(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'role_setting' resource")
+}
+
+func (r *roleSettingResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'role_setting' resource")
+
+	var model roleSettingResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the role setting", "Id is required for reading role setting")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 3)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the role setting", "Id should be in the format 'role_name.database_name.parameter_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	setting := client.DbRoleSettingModel{Role: idParts[0], Database: idParts[1], Name: idParts[2], Value: model.Value.ValueString()}
+	if setting.Database == roleSettingAllDatabases {
+		setting.Database = ""
+	}
+
+	// a role setting reset outside of Terraform is removed from the state and planned again
+	repository := conn.DbRoleSettingRepository()
+	exists, err := repository.Exists(ctx, setting.Role, setting.Database, setting.Name)
+	if err != nil {
+		res.Diagnostics.AddError("Error reading role setting", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Role setting not found, removing it from the state", map[string]any{"setting": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readRoleSettingModel(ctx, repository, setting, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'role_setting' resource")
+}
+
+func (r *roleSettingResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'role_setting' resource")
+
+	var stateModel roleSettingResourceModel
+	var planModel roleSettingResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	repository := conn.DbRoleSettingRepository()
+	_, err = repository.Update(ctx, client.DbRoleSettingUpdateParams{Current: stateModel.toPgModel(), Desired: planModel.toPgModel()})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating role setting", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readRoleSettingModel(ctx, repository, planModel.toPgModel(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'role_setting' resource")
+}
+
+func (r *roleSettingResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'role_setting' resource")
+
+	var model roleSettingResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	if err = conn.DbRoleSettingRepository().Drop(ctx, model.Role.ValueString(), model.Database.ValueString(), model.Name.ValueString()); err != nil {
+		res.Diagnostics.AddError("Error deleting role setting", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'role_setting' resource")
+}
+
+func (r *roleSettingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// validateSessionSetting checks the parameter and its value against pg_settings, once both are known.
+// Custom parameters, qualified by a prefix, are accepted as they are when the server doesn't know them.
+func validateSessionSetting(ctx context.Context, pgClient client.PgClient, name, value types.String) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if name.IsUnknown() || value.IsUnknown() {
+		return diags
+	}
+
+	conn, err := pgClient.GetConnection(ctx, pgClient.GetInitConfig().Database)
+	if err != nil {
+		diags.AddError(msgErrGetPgConnection, err.Error())
+		return diags
+	}
+
+	definition, err := conn.DbRoleSettingRepository().Definition(ctx, name.ValueString())
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading parameter: '%s'", name.ValueString()), err.Error())
+		return diags
+	}
+	diags.Append(checkSettingValue(definition, name.ValueString(), value.ValueString(), sessionSettingContexts)...)
+	return diags
+}
+
+// checkSettingValue adds an attribute error when the parameter is unknown, can't be changed in the
+// given contexts or when the value is not valid for it.
+func checkSettingValue(definition *client.SettingDefinition, name, value string, contexts []string) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+	if definition == nil {
+		if !strings.Contains(name, ".") {
+			diags.AddAttributeError(path.Root("name"), "Unknown configuration parameter",
+				fmt.Sprintf("The server has no parameter '%s'. The names of custom parameters must be qualified by a prefix, e.g. 'app.%s'.", name, name))
+		}
+		return diags
+	}
+
+	if !slices.Contains(contexts, definition.Context) {
+		diags.AddAttributeError(path.Root("name"), "Unsupported configuration parameter",
+			fmt.Sprintf("The parameter '%s' has the context '%s', it can only be changed in the contexts %s.", definition.Name, definition.Context, strings.Join(contexts, ", ")))
+		return diags
+	}
+
+	if err := definition.Validate(value); err != nil {
+		diags.AddAttributeError(path.Root("value"), "Invalid configuration parameter value", err.Error())
+	}
+	return diags
+}
+
+// readRoleSettingModel reads the setting into the target, keeping the spelling of the prior value
+// when the server holds an equivalent one, e.g. '1GB' for '1024MB'.
+func readRoleSettingModel(ctx context.Context, repository client.DbRoleSettingRepository, prior client.DbRoleSettingModel, target *roleSettingResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := readDbRoleSetting(ctx, repository, prior)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading role setting: '%s' of '%s'", prior.Name, prior.Role), err.Error())
+		return diags
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+// readDbRoleSetting reads the setting, keeping the spelling of the prior value when equivalent.
+func readDbRoleSetting(ctx context.Context, repository client.DbRoleSettingRepository, prior client.DbRoleSettingModel) (*client.DbRoleSettingModel, error) {
+	actual, err := repository.Get(ctx, prior.Role, prior.Database, prior.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	definition, err := repository.Definition(ctx, prior.Name)
+	if err != nil {
+		return nil, err
+	}
+	actual.Value = client.PreserveSettingSpelling(definition, prior.Value, actual.Value)
+	return actual, nil
+}
+
+func (rm *roleSettingResourceModel) toPgModel() client.DbRoleSettingModel {
+	return client.DbRoleSettingModel{
+		Role:     rm.Role.ValueString(),
+		Database: rm.Database.ValueString(),
+		Name:     rm.Name.ValueString(),
+		Value:    rm.Value.ValueString(),
+	}
+}
+
+func (rm *roleSettingResourceModel) fromPgModel(pgModel client.DbRoleSettingModel) {
+	rm.Role = types.StringValue(pgModel.Role)
+	rm.Database = stringValueOrNull(pgModel.Database)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Value = types.StringValue(pgModel.Value)
+}
+
+func (rm *roleSettingResourceModel) SetId() {
+	database := rm.Database.ValueString()
+	if database == "" {
+		database = roleSettingAllDatabases
+	}
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s.%s", rm.Role.ValueString(), database, rm.Name.ValueString()))
+}
+
+func (rm *roleSettingResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
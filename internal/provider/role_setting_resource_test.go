@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"regexp"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccRoleSettingResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_role_setting_resource_db",
+		Username: "test_role_setting_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_role_setting"
+	mockResourceName := fmt.Sprintf("postgresql_role_setting.%s", mockResourceId)
+	// the role setting of the database applies next to the one of every database
+	inDatabase := `
+		resource "postgresql_role_setting" "test_role_setting_in_database" {
+			role     = "test_role_setting_app"
+			database = "test_role_setting_resource_db"
+			name     = "search_path"
+			value    = "\"$user\", app"
+		}`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `CREATE ROLE test_role_setting_app;`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Plan testing - The parameters of the server configuration are refused
+				Config:      testAccRoleSettingToTFResource(t, mockResourceId, "shared_buffers", "128MB"),
+				ExpectError: regexp.MustCompile(`The parameter 'shared_buffers' has the context 'postmaster'`),
+			},
+			{
+				// Plan testing - The values are validated against pg_settings
+				Config:      testAccRoleSettingToTFResource(t, mockResourceId, "work_mem", "64s"),
+				ExpectError: regexp.MustCompile(`unknown unit 's', valid units are B, GB, MB, TB, kB`),
+			},
+			{
+				// Create and Read testing
+				Config: testAccRoleSettingToTFResource(t, mockResourceId, "work_mem", "64MB") + inDatabase,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", "test_role_setting_app.*.work_mem"),
+					resource.TestCheckResourceAttr(mockResourceName, "role", "test_role_setting_app"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "database"),
+					resource.TestCheckResourceAttr(mockResourceName, "value", "64MB"),
+					resource.TestCheckResourceAttr("postgresql_role_setting.test_role_setting_in_database", "id", "test_role_setting_app.test_role_setting_resource_db.search_path"),
+					resource.TestCheckResourceAttr("postgresql_role_setting.test_role_setting_in_database", "value", `"$user", app`),
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				// ImportState testing - Role setting of a database
+				ResourceName:            "postgresql_role_setting.test_role_setting_in_database",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				// Update testing - The value is changed in place
+				Config: testAccRoleSettingToTFResource(t, mockResourceId, "work_mem", "1GB") + inDatabase,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", "test_role_setting_app.*.work_mem"),
+					resource.TestCheckResourceAttr(mockResourceName, "value", "1GB"),
+				),
+			},
+			{
+				// Plan testing - An equivalent value set outside of Terraform produces no diff
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `ALTER ROLE test_role_setting_app SET work_mem = '1024MB';`)
+					assert.NoError(t, err)
+				},
+				Config:   testAccRoleSettingToTFResource(t, mockResourceId, "work_mem", "1GB") + inDatabase,
+				PlanOnly: true,
+			},
+			{
+				// Drift testing - a role setting reset outside of Terraform is set again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `ALTER ROLE test_role_setting_app RESET work_mem;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccRoleSettingToTFResource(t, mockResourceId, "work_mem", "1GB") + inDatabase,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "value", "1GB"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRoleSettingToTFResource(t *testing.T, resId, name, value string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_role_setting" "%s" {
+			role  = "test_role_setting_app"
+			name  = "%s"
+			value = "%s"
+		}`, resId, name, value)
+}
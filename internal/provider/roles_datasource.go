@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"maps"
+	"terraform-provider-postgresql/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &rolesDataSource{}
+	_ datasource.DataSourceWithConfigure = &rolesDataSource{}
+)
+
+type rolesDataSource struct {
+	client client.PgClient
+}
+
+type rolesDataSourceModel struct {
+	NameRegex     types.String        `tfsdk:"name_regex"`
+	IncludeSystem types.Bool          `tfsdk:"include_system"`
+	Roles         []roleItemDataModel `tfsdk:"roles"`
+}
+
+type roleItemDataModel struct {
+	Name            types.String `tfsdk:"name"`
+	Superuser       types.Bool   `tfsdk:"superuser"`
+	Inherit         types.Bool   `tfsdk:"inherit"`
+	CreateRole      types.Bool   `tfsdk:"create_role"`
+	CreateDatabase  types.Bool   `tfsdk:"create_database"`
+	Login           types.Bool   `tfsdk:"login"`
+	Replication     types.Bool   `tfsdk:"replication"`
+	BypassRls       types.Bool   `tfsdk:"bypass_rls"`
+	ConnectionLimit types.Int64  `tfsdk:"connection_limit"`
+	ValidUntil      types.String `tfsdk:"valid_until"`
+	MemberOf        types.List   `tfsdk:"member_of"`
+	Comment         types.String `tfsdk:"comment"`
+}
+
+func NewRolesDataSource() datasource.DataSource {
+	return &rolesDataSource{}
+}
+
+func (d *rolesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, res *datasource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'roles' datasource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Configured 'roles' datasource")
+	d.client = pgClient
+}
+
+func (d *rolesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, res *datasource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_roles"
+}
+
+func (d *rolesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, res *datasource.SchemaResponse) {
+	attributes := listFilterAttributes("roles", "`pg_read_all_data` and `pg_monitor`", false)
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"roles": schema.ListNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: "The roles, ordered by name",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Name of the role",
+					},
+					"superuser": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the role is a superuser",
+					},
+					"inherit": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the role inherits the privileges of the roles it is a member of",
+					},
+					"create_role": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the role can create more roles",
+					},
+					"create_database": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the role can create databases",
+					},
+					"login": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the role can log in",
+					},
+					"replication": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the role can initiate streaming replication",
+					},
+					"bypass_rls": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the role bypasses every row-level security policy",
+					},
+					"connection_limit": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Maximum number of concurrent connections of the role, `-1` for no limit",
+					},
+					"valid_until": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Expiration time of the password of the role, null when it never expires",
+					},
+					"member_of": schema.ListAttribute{
+						Computed:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "The roles the role is a direct member of, ordered by name",
+					},
+					"comment": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Comment associated with the role",
+					},
+				},
+			},
+		},
+	})
+
+	res.Schema = schema.Schema{
+		Attributes:          attributes,
+		MarkdownDescription: mdDocDataSourceRoles,
+	}
+}
+
+func (d *rolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, res *datasource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'roles' datasource")
+
+	var model rolesDataSourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// roles are global to the server, any database connection lists them
+	conn, err := d.client.GetConnection(ctx, d.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	roles, err := conn.DiscoveryRepository().ListRoles(ctx, toListFilter(model.NameRegex, types.StringNull(), model.IncludeSystem))
+	if err != nil {
+		res.Diagnostics.AddError("Error listing roles", err.Error())
+		return
+	}
+
+	model.Roles = make([]roleItemDataModel, len(roles))
+	for i, role := range roles {
+		model.Roles[i] = roleItemDataModel{
+			Name:            types.StringValue(role.Name),
+			Superuser:       types.BoolValue(role.Superuser),
+			Inherit:         types.BoolValue(role.Inherit),
+			CreateRole:      types.BoolValue(role.CreateRole),
+			CreateDatabase:  types.BoolValue(role.CreateDatabase),
+			Login:           types.BoolValue(role.Login),
+			Replication:     types.BoolValue(role.Replication),
+			BypassRls:       types.BoolValue(role.BypassRls),
+			ConnectionLimit: types.Int64Value(role.ConnectionLimit),
+			ValidUntil:      stringValueOrNull(role.ValidUntil),
+			MemberOf:        mapSliceToStringList(role.MemberOf),
+			Comment:         stringValueOrNull(role.Comment),
+		}
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'roles' datasource")
+}
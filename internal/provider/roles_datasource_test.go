@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccRolesDataSource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_roles_datasource_db",
+		Username: "test_roles_datasource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_roles"
+	mockResourceName := fmt.Sprintf("data.postgresql_roles.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `
+				CREATE ROLE team_readers NOLOGIN;
+				CREATE ROLE team_writers NOLOGIN;
+				CREATE ROLE team_alice LOGIN CREATEDB CONNECTION LIMIT 3 VALID UNTIL 'infinity' IN ROLE team_writers, team_readers;
+				COMMENT ON ROLE team_alice IS 'Alice';`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Selected by name
+				Config: testAccRolesToTFDataSource(t, mockResourceId, `name_regex = "^team_"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "roles.#", "3"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.name", "team_alice"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.superuser", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.inherit", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.create_role", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.create_database", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.login", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.replication", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.bypass_rls", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.connection_limit", "3"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.valid_until", "infinity"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.member_of.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.member_of.0", "team_readers"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.member_of.1", "team_writers"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.comment", "Alice"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.1.name", "team_readers"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.1.login", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.1.connection_limit", "-1"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "roles.1.valid_until"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.1.member_of.#", "0"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "roles.1.comment"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.2.name", "team_writers"),
+				),
+			},
+			{
+				// Without the predefined roles
+				Config: testAccRolesToTFDataSource(t, mockResourceId, `name_regex = "^pg_"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "roles.#", "0"),
+				),
+			},
+			{
+				// With the predefined roles
+				Config: testAccRolesToTFDataSource(t, mockResourceId, `
+					name_regex     = "^pg_monitor$"
+					include_system = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "roles.#", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.name", "pg_monitor"),
+					resource.TestCheckResourceAttr(mockResourceName, "roles.0.login", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRolesToTFDataSource(t *testing.T, resName, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`data "postgresql_roles" "%s" {
+			%s
+		}`, resName, body)
+}
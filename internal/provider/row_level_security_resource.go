@@ -0,0 +1,311 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type rowLevelSecurityResource struct {
+	client client.PgClient
+}
+
+type rowLevelSecurityResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+	Database    types.String `tfsdk:"database"`
+	Schema      types.String `tfsdk:"schema"`
+	Table       types.String `tfsdk:"table"`
+	Enabled     types.Bool   `tfsdk:"enabled"`
+	Forced      types.Bool   `tfsdk:"forced"`
+	AssumeRole  types.String `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                = &rowLevelSecurityResource{}
+	_ resource.ResourceWithConfigure   = &rowLevelSecurityResource{}
+	_ resource.ResourceWithImportState = &rowLevelSecurityResource{}
+)
+
+func NewRowLevelSecurityResource() resource.Resource {
+	return &rowLevelSecurityResource{}
+}
+
+func (r *rowLevelSecurityResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'row_level_security' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *rowLevelSecurityResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_row_level_security"
+}
+
+func (r *rowLevelSecurityResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the row level security settings, in the format `database_name.schema_name.table_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the row level security settings",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the table is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("public"),
+				MarkdownDescription: "Schema of the table",
+				PlanModifiers:       requiresReplace,
+			},
+			"table": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the table",
+				Validators:          nonEmptyString,
+				PlanModifiers:       requiresReplace,
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the row level security is enabled, the rows are then only visible or modifiable when a policy allows it. With no policy, nothing is allowed.",
+			},
+			"forced": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the policies also apply to the owner of the table. Superusers and roles with `BYPASSRLS` always bypass them.",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the settings, it must own the table. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceRowLevelSecurity,
+	}
+}
+
+func (r *rowLevelSecurityResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'row_level_security' resource")
+
+	var model rowLevelSecurityResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	res.Diagnostics.Append(r.apply(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'row_level_security' resource")
+}
+
+func (r *rowLevelSecurityResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'row_level_security' resource")
+
+	var model rowLevelSecurityResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the row level security", "Id is required for reading row level security")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 3)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the row level security", "Id should be in the format 'database_name.schema_name.table_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// the row level security goes with its table, a table dropped outside of Terraform removes it from the state
+	exists, err := conn.TableRepository().Exists(ctx, idParts[1], idParts[2])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading row level security", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Table of the row level security not found, removing it from the state", map[string]any{"row_level_security": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	actual, err := conn.RowLevelSecurityRepository().Get(ctx, idParts[1], idParts[2])
+	if err != nil {
+		res.Diagnostics.AddError(fmt.Sprintf("Error reading row level security of table: '%s.%s'", idParts[1], idParts[2]), err.Error())
+		return
+	}
+	model.fromPgModel(*actual)
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'row_level_security' resource")
+}
+
+func (r *rowLevelSecurityResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'row_level_security' resource")
+
+	var planModel rowLevelSecurityResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(r.apply(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'row_level_security' resource")
+}
+
+// Delete disables the row level security of the table, the default of PostgreSQL.
+func (r *rowLevelSecurityResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'row_level_security' resource")
+
+	var model rowLevelSecurityResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	_, err = conn.RowLevelSecurityRepository().Set(ctx, client.RowLevelSecurityModel{
+		Schema: model.Schema.ValueString(),
+		Table:  model.Table.ValueString(),
+	})
+	if err != nil {
+		res.Diagnostics.AddError("Error disabling row level security", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'row_level_security' resource")
+}
+
+func (r *rowLevelSecurityResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// apply sets the planned settings on create and update, and reads them back into the model.
+func (r *rowLevelSecurityResource) apply(ctx context.Context, model *rowLevelSecurityResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		diags.AddError(msgErrGetPgConnection, err.Error())
+		return diags
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	actual, err := conn.RowLevelSecurityRepository().Set(ctx, model.toPgModel())
+	if err != nil {
+		diags.AddError("Error setting row level security", err.Error())
+		return diags
+	}
+
+	model.fromPgModel(*actual)
+	model.SetId()
+	model.SetLastUpdated()
+	return diags
+}
+
+func (rm *rowLevelSecurityResourceModel) toPgModel() client.RowLevelSecurityModel {
+	return client.RowLevelSecurityModel{
+		Schema:   rm.Schema.ValueString(),
+		Table:    rm.Table.ValueString(),
+		Database: rm.Database.ValueString(),
+		Enabled:  rm.Enabled.ValueBool(),
+		Forced:   rm.Forced.ValueBool(),
+	}
+}
+
+func (rm *rowLevelSecurityResourceModel) fromPgModel(pgModel client.RowLevelSecurityModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Schema = types.StringValue(pgModel.Schema)
+	rm.Table = types.StringValue(pgModel.Table)
+	rm.Enabled = types.BoolValue(pgModel.Enabled)
+	rm.Forced = types.BoolValue(pgModel.Forced)
+}
+
+func (rm *rowLevelSecurityResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s.%s", rm.Database.ValueString(), rm.Schema.ValueString(), rm.Table.ValueString()))
+}
+
+func (rm *rowLevelSecurityResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/client"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccRowLevelSecurityResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_rls_resource_db",
+		Username: "test_rls_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_rls"
+	mockResourceName := fmt.Sprintf("postgresql_row_level_security.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `CREATE TABLE public.test_rls_resource_table (id int, tenant text);`)
+			assert.NoError(t, err)
+		},
+		// the row level security is disabled again when the resource is destroyed
+		CheckDestroy: func(*terraform.State) error {
+			actual, err := client.NewRowLevelSecurityRepository(db).Get(ctx, "public", "test_rls_resource_table")
+			if err != nil {
+				return err
+			}
+			if actual.Enabled || actual.Forced {
+				return fmt.Errorf("the row level security of the table was not disabled")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccRowLevelSecurityToTFResource(t, mockResourceId, "test_rls_resource_table", ``),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_rls_resource_table", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "database", runOpts.Database),
+					resource.TestCheckResourceAttr(mockResourceName, "schema", "public"),
+					resource.TestCheckResourceAttr(mockResourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "forced", "false"),
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				// Update testing - the policies apply to the owner of the table
+				Config: testAccRowLevelSecurityToTFResource(t, mockResourceId, "test_rls_resource_table", `forced = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "forced", "true"),
+				),
+			},
+			{
+				// Update testing - disabled, but still forced for when it's enabled again
+				Config: testAccRowLevelSecurityToTFResource(t, mockResourceId, "test_rls_resource_table", `
+					enabled = false
+					forced  = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "forced", "true"),
+				),
+			},
+			{
+				// Drift testing - the row level security of a table dropped outside of Terraform is planned again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP TABLE public.test_rls_resource_table;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccRowLevelSecurityToTFResource(t, mockResourceId, "test_rls_resource_table", `
+					enabled = false
+					forced  = true`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `CREATE TABLE public.test_rls_resource_table (id int, tenant text);`)
+					assert.NoError(t, err)
+				},
+				Config: testAccRowLevelSecurityToTFResource(t, mockResourceId, "test_rls_resource_table", `
+					enabled = false
+					forced  = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "forced", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccRowLevelSecurityToTFResource(t *testing.T, resId, table, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_row_level_security" "%s" {
+			table = "%s"
+			%s
+		}`, resId, table, body)
+}
@@ -0,0 +1,139 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"maps"
+	"terraform-provider-postgresql/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &schemasDataSource{}
+	_ datasource.DataSourceWithConfigure = &schemasDataSource{}
+)
+
+type schemasDataSource struct {
+	client client.PgClient
+}
+
+type schemasDataSourceModel struct {
+	Database      types.String          `tfsdk:"database"`
+	NameRegex     types.String          `tfsdk:"name_regex"`
+	IncludeSystem types.Bool            `tfsdk:"include_system"`
+	Schemas       []schemaItemDataModel `tfsdk:"schemas"`
+}
+
+type schemaItemDataModel struct {
+	Name    types.String `tfsdk:"name"`
+	Owner   types.String `tfsdk:"owner"`
+	Comment types.String `tfsdk:"comment"`
+}
+
+func NewSchemasDataSource() datasource.DataSource {
+	return &schemasDataSource{}
+}
+
+func (d *schemasDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, res *datasource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'schemas' datasource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Configured 'schemas' datasource")
+	d.client = pgClient
+}
+
+func (d *schemasDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, res *datasource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_schemas"
+}
+
+func (d *schemasDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, res *datasource.SchemaResponse) {
+	attributes := listFilterAttributes("schemas", "`pg_catalog` and `information_schema`", false)
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"database": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			MarkdownDescription: "Name of the database the schemas are listed from. If not provided, the database from the provider configuration will be used.",
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"schemas": schema.ListNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: "The schemas, ordered by name",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Name of the schema",
+					},
+					"owner": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The owner of the schema",
+					},
+					"comment": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Comment associated with the schema",
+					},
+				},
+			},
+		},
+	})
+
+	res.Schema = schema.Schema{
+		Attributes:          attributes,
+		MarkdownDescription: mdDocDataSourceSchemas,
+	}
+}
+
+func (d *schemasDataSource) Read(ctx context.Context, req datasource.ReadRequest, res *datasource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'schemas' datasource")
+
+	var model schemasDataSourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() {
+		model.Database = types.StringValue(d.client.GetInitConfig().Database)
+	}
+
+	conn, err := d.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	schemas, err := conn.DiscoveryRepository().ListSchemas(ctx, toListFilter(model.NameRegex, types.StringNull(), model.IncludeSystem))
+	if err != nil {
+		res.Diagnostics.AddError("Error listing schemas", err.Error())
+		return
+	}
+
+	model.Schemas = make([]schemaItemDataModel, len(schemas))
+	for i, pgSchema := range schemas {
+		model.Schemas[i] = schemaItemDataModel{
+			Name:    types.StringValue(pgSchema.Name),
+			Owner:   types.StringValue(pgSchema.Owner),
+			Comment: stringValueOrNull(pgSchema.Comment),
+		}
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'schemas' datasource")
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccSchemasDataSource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_schemas_datasource_db",
+		Username: "test_schemas_datasource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_schemas"
+	mockResourceName := fmt.Sprintf("data.postgresql_schemas.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `
+				CREATE ROLE test_schemas_owner;
+				CREATE SCHEMA tenant_a AUTHORIZATION test_schemas_owner;
+				COMMENT ON SCHEMA tenant_a IS 'tenant A';
+				CREATE SCHEMA tenant_b;`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Selected by name
+				Config: testAccSchemasToTFDataSource(t, mockResourceId, `name_regex = "^tenant_"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "database", runOpts.Database),
+					resource.TestCheckResourceAttr(mockResourceName, "schemas.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "schemas.0.name", "tenant_a"),
+					resource.TestCheckResourceAttr(mockResourceName, "schemas.0.owner", "test_schemas_owner"),
+					resource.TestCheckResourceAttr(mockResourceName, "schemas.0.comment", "tenant A"),
+					resource.TestCheckResourceAttr(mockResourceName, "schemas.1.name", "tenant_b"),
+					resource.TestCheckResourceAttr(mockResourceName, "schemas.1.owner", runOpts.Username),
+					resource.TestCheckNoResourceAttr(mockResourceName, "schemas.1.comment"),
+				),
+			},
+			{
+				// Without the system schemas
+				Config: testAccSchemasToTFDataSource(t, mockResourceId, ``),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "schemas.#", "3"),
+					resource.TestCheckResourceAttr(mockResourceName, "schemas.0.name", "public"),
+				),
+			},
+			{
+				// With the system schemas
+				Config: testAccSchemasToTFDataSource(t, mockResourceId, `
+					database       = "postgres"
+					name_regex     = "^(pg_catalog|information_schema)$"
+					include_system = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "database", "postgres"),
+					resource.TestCheckResourceAttr(mockResourceName, "schemas.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "schemas.0.name", "information_schema"),
+					resource.TestCheckResourceAttr(mockResourceName, "schemas.1.name", "pg_catalog"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSchemasToTFDataSource(t *testing.T, resName, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`data "postgresql_schemas" "%s" {
+			%s
+		}`, resName, body)
+}
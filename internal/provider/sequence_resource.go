@@ -0,0 +1,508 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"regexp"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type sequenceResource struct {
+	client client.PgClient
+}
+
+type sequenceResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	LastUpdated  types.String `tfsdk:"last_updated"`
+	Database     types.String `tfsdk:"database"`
+	Schema       types.String `tfsdk:"schema"`
+	Name         types.String `tfsdk:"name"`
+	DataType     types.String `tfsdk:"data_type"`
+	Increment    types.Int64  `tfsdk:"increment"`
+	MinValue     types.Int64  `tfsdk:"min_value"`
+	MaxValue     types.Int64  `tfsdk:"max_value"`
+	Start        types.Int64  `tfsdk:"start"`
+	Cache        types.Int64  `tfsdk:"cache"`
+	Cycle        types.Bool   `tfsdk:"cycle"`
+	OwnedBy      types.String `tfsdk:"owned_by"`
+	Owner        types.String `tfsdk:"owner"`
+	Comment      types.String `tfsdk:"comment"`
+	CurrentValue types.Int64  `tfsdk:"current_value"`
+	AssumeRole   types.String `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                   = &sequenceResource{}
+	_ resource.ResourceWithConfigure      = &sequenceResource{}
+	_ resource.ResourceWithImportState    = &sequenceResource{}
+	_ resource.ResourceWithValidateConfig = &sequenceResource{}
+	_ resource.ResourceWithModifyPlan     = &sequenceResource{}
+)
+
+func NewSequenceResource() resource.Resource {
+	return &sequenceResource{}
+}
+
+func (r *sequenceResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'sequence' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *sequenceResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_sequence"
+}
+
+func (r *sequenceResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+	useStateForUnknown := []planmodifier.Int64{
+		int64planmodifier.UseStateForUnknown(),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the sequence, in the format `database_name.schema_name.sequence_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the sequence",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the sequence is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("public"),
+				MarkdownDescription: "Schema where the sequence is located. Changes move the sequence in place.",
+				Validators:          nonEmptyString,
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the sequence",
+				Validators:          nonEmptyString,
+			},
+			"data_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("bigint"),
+				MarkdownDescription: "Data type of the sequence, `smallint`, `integer` or `bigint`. The bounds that are not configured follow the limits of the new type.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.SequenceDataTypes...),
+				},
+			},
+			"increment": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				MarkdownDescription: "Value added to the current value to create a new one, a negative value makes a descending sequence",
+				Validators: []validator.Int64{
+					int64validator.NoneOf(0),
+				},
+			},
+			"min_value": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Minimum value of the sequence. If not provided, `1` for ascending sequences and the minimum of the data type for descending ones.",
+				PlanModifiers:       useStateForUnknown,
+			},
+			"max_value": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Maximum value of the sequence. If not provided, the maximum of the data type for ascending sequences and `-1` for descending ones.",
+				PlanModifiers:       useStateForUnknown,
+			},
+			"start": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Start value of the sequence. If not provided, `min_value` for ascending sequences and `max_value` for descending ones. Changes are recorded without restarting the sequence.",
+				PlanModifiers:       useStateForUnknown,
+			},
+			"cache": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             int64default.StaticInt64(1),
+				MarkdownDescription: "How many values are preallocated and stored in memory for faster access",
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"cycle": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the sequence wraps around when it reaches `max_value` (or `min_value` when descending)",
+			},
+			"owned_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Column owning the sequence, in the format `table_name.column_name`. The sequence is dropped along with the column, and the table must be in the schema of the sequence.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^[^.]+\.[^.]+$`), "must be in the format 'table_name.column_name'"),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The owner of the sequence. If not provided, the sequence is owned by the role that creates it (see `assume_role`). A sequence owned by a column must have the owner of its table.",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the sequence",
+			},
+			"current_value": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Last value returned by `nextval`, null when the sequence was never used. It's refreshed on every read and never reported as a change.",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the sequence. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceSequence,
+	}
+}
+
+func (r *sequenceResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	var model sequenceResourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	minValue, maxValue, start := knownInt64Pointer(model.MinValue), knownInt64Pointer(model.MaxValue), knownInt64Pointer(model.Start)
+	if minValue != nil && maxValue != nil && *minValue >= *maxValue {
+		res.Diagnostics.AddAttributeError(
+			path.Root("min_value"),
+			"Invalid sequence bounds",
+			fmt.Sprintf("The min_value (%d) must be less than the max_value (%d).", *minValue, *maxValue),
+		)
+	}
+	if start != nil && ((minValue != nil && *start < *minValue) || (maxValue != nil && *start > *maxValue)) {
+		res.Diagnostics.AddAttributeError(
+			path.Root("start"),
+			"Invalid sequence start",
+			fmt.Sprintf("The start value (%d) must be between min_value and max_value.", *start),
+		)
+	}
+}
+
+func (r *sequenceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on create or destroy
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var model, stateModel, configModel sequenceResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Config.Get(ctx, &configModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// PostgreSQL moves the bounds that follow the limits of the data type along with it
+	if !model.DataType.Equal(stateModel.DataType) {
+		for attr, value := range map[string]types.Int64{"min_value": configModel.MinValue, "max_value": configModel.MaxValue} {
+			if value.IsNull() {
+				res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root(attr), types.Int64Unknown())...)
+			}
+		}
+	}
+
+	// the identifier is derived from the schema and name, moving the sequence produces a new one
+	if !model.Name.Equal(stateModel.Name) || !model.Schema.Equal(stateModel.Schema) {
+		res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+}
+
+func (r *sequenceResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'sequence' resource")
+
+	var model sequenceResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	role := resolveAssumeRole(r.client, model.AssumeRole)
+	ctx = client.ContextWithAssumeRole(ctx, role)
+
+	// the sequence is owned by the assumed role, unless another owner is explicitly requested
+	expectedOwner := role
+	if !model.Owner.IsNull() && !model.Owner.IsUnknown() {
+		expectedOwner = model.Owner.ValueString()
+	}
+
+	pgModel := model.toPgModel()
+	pgModel.Owner = expectedOwner
+
+	repository := conn.SequenceRepository()
+	if err = repository.Create(ctx, pgModel); err != nil {
+		res.Diagnostics.AddError("Error creating sequence", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readSequenceModel(ctx, repository, model.Schema.ValueString(), model.Name.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("sequence", expectedOwner, model.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'sequence' resource")
+}
+
+func (r *sequenceResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'sequence' resource")
+
+	var model sequenceResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the sequence", "Id is required for reading sequence")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 3)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the sequence", "Id should be in the format 'database_name.schema_name.sequence_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a sequence dropped outside of Terraform is removed from the state and planned again
+	repository := conn.SequenceRepository()
+	exists, err := repository.Exists(ctx, idParts[1], idParts[2])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading sequence", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Sequence not found, removing it from the state", map[string]any{"sequence": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readSequenceModel(ctx, repository, idParts[1], idParts[2], &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'sequence' resource")
+}
+
+func (r *sequenceResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'sequence' resource")
+
+	var stateModel sequenceResourceModel
+	var planModel sequenceResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	current := stateModel.toPgModel()
+	desired := planModel.toPgModel()
+	if planModel.Owner.IsUnknown() {
+		desired.Owner = current.Owner
+	}
+
+	repository := conn.SequenceRepository()
+	_, err = repository.Update(ctx, client.SequenceUpdateParams{Current: current, Desired: desired})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating sequence", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readSequenceModel(ctx, repository, planModel.Schema.ValueString(), planModel.Name.ValueString(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("sequence", desired.Owner, planModel.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'sequence' resource")
+}
+
+func (r *sequenceResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'sequence' resource")
+
+	var model sequenceResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	err = conn.SequenceRepository().Drop(ctx, model.Schema.ValueString(), model.Name.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("Error deleting sequence", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'sequence' resource")
+}
+
+func (r *sequenceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readSequenceModel reads the sequence into the target model.
+func readSequenceModel(ctx context.Context, repository client.SequenceRepository, schema, name string, target *sequenceResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, schema, name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading sequence: '%s.%s'", schema, name), err.Error())
+		return diags
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *sequenceResourceModel) toPgModel() client.SequenceModel {
+	return client.SequenceModel{
+		Schema:    rm.Schema.ValueString(),
+		Name:      rm.Name.ValueString(),
+		Database:  rm.Database.ValueString(),
+		DataType:  rm.DataType.ValueString(),
+		Increment: rm.Increment.ValueInt64(),
+		MinValue:  knownInt64Pointer(rm.MinValue),
+		MaxValue:  knownInt64Pointer(rm.MaxValue),
+		Start:     knownInt64Pointer(rm.Start),
+		Cache:     rm.Cache.ValueInt64(),
+		Cycle:     rm.Cycle.ValueBool(),
+		OwnedBy:   rm.OwnedBy.ValueString(),
+		Owner:     rm.Owner.ValueString(),
+		Comment:   rm.Comment.ValueString(),
+	}
+}
+
+func (rm *sequenceResourceModel) fromPgModel(pgModel client.SequenceModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Schema = types.StringValue(pgModel.Schema)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.DataType = types.StringValue(pgModel.DataType)
+	rm.Increment = types.Int64Value(pgModel.Increment)
+	rm.MinValue = int64ValueOrNull(pgModel.MinValue)
+	rm.MaxValue = int64ValueOrNull(pgModel.MaxValue)
+	rm.Start = int64ValueOrNull(pgModel.Start)
+	rm.Cache = types.Int64Value(pgModel.Cache)
+	rm.Cycle = types.BoolValue(pgModel.Cycle)
+	rm.OwnedBy = stringValueOrNull(pgModel.OwnedBy)
+	rm.Owner = types.StringValue(pgModel.Owner)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+	rm.CurrentValue = int64ValueOrNull(pgModel.LastValue)
+}
+
+func (rm *sequenceResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s.%s", rm.Database.ValueString(), rm.Schema.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *sequenceResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccSequenceResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_sequence_resource_db",
+		Username: "test_sequence_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_sequence"
+	mockResourceName := fmt.Sprintf("postgresql_sequence.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `CREATE TABLE public.test_sequence_resource_table (id int);`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccSequenceToTFResource(t, mockResourceId, "test_sequence_resource", `
+					data_type = "integer"
+					start     = 100
+					cache     = 5
+					owned_by  = "test_sequence_resource_table.id"
+					comment   = "test comment"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_sequence_resource", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "data_type", "integer"),
+					resource.TestCheckResourceAttr(mockResourceName, "increment", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "min_value", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "max_value", "2147483647"),
+					resource.TestCheckResourceAttr(mockResourceName, "start", "100"),
+					resource.TestCheckResourceAttr(mockResourceName, "cache", "5"),
+					resource.TestCheckResourceAttr(mockResourceName, "cycle", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "owned_by", "test_sequence_resource_table.id"),
+					resource.TestCheckResourceAttr(mockResourceName, "owner", runOpts.Username),
+					resource.TestCheckNoResourceAttr(mockResourceName, "current_value"),
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				// Using the sequence is not a change
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `SELECT nextval('public.test_sequence_resource');`)
+					assert.NoError(t, err)
+				},
+				Config: testAccSequenceToTFResource(t, mockResourceId, "test_sequence_resource", `
+					data_type = "integer"
+					start     = 100
+					cache     = 5
+					owned_by  = "test_sequence_resource_table.id"
+					comment   = "test comment"`),
+				PlanOnly: true,
+			},
+			{
+				// Update testing - Properties without re-creating the resource
+				Config: testAccSequenceToTFResource(t, mockResourceId, "test_sequence_resource_modified", `
+					data_type = "bigint"
+					start     = 10
+					increment = 5
+					cycle     = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_sequence_resource_modified", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "data_type", "bigint"),
+					resource.TestCheckResourceAttr(mockResourceName, "max_value", "9223372036854775807"),
+					resource.TestCheckResourceAttr(mockResourceName, "start", "10"),
+					resource.TestCheckResourceAttr(mockResourceName, "increment", "5"),
+					resource.TestCheckResourceAttr(mockResourceName, "cycle", "true"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "owned_by"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "comment"),
+					// a new start value does not restart the sequence
+					resource.TestCheckResourceAttr(mockResourceName, "current_value", "100"),
+				),
+			},
+			{
+				// Drift testing - a sequence dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP SEQUENCE public.test_sequence_resource_modified;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccSequenceToTFResource(t, mockResourceId, "test_sequence_resource_modified", `
+					data_type = "bigint"
+					start     = 10
+					increment = 5
+					cycle     = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(mockResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSequenceToTFResource(t *testing.T, resId, name, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_sequence" "%s" {
+			name = "%s"
+			%s
+		}`, resId, name, body)
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &serverDataSource{}
+	_ datasource.DataSourceWithConfigure = &serverDataSource{}
+)
+
+type serverDataSource struct {
+	client client.PgClient
+}
+
+type serverDataSourceModel struct {
+	Database       types.String `tfsdk:"database"`
+	Version        types.String `tfsdk:"version"`
+	VersionNum     types.Int64  `tfsdk:"version_num"`
+	CurrentUser    types.String `tfsdk:"current_user"`
+	Superuser      types.Bool   `tfsdk:"superuser"`
+	InRecovery     types.Bool   `tfsdk:"in_recovery"`
+	DataChecksums  types.Bool   `tfsdk:"data_checksums"`
+	MaxConnections types.Int64  `tfsdk:"max_connections"`
+	ServerEncoding types.String `tfsdk:"server_encoding"`
+	Extensions     types.Map    `tfsdk:"extensions"`
+	Capabilities   types.List   `tfsdk:"capabilities"`
+}
+
+func NewServerDataSource() datasource.DataSource {
+	return &serverDataSource{}
+}
+
+func (d *serverDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, res *datasource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'server' datasource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Configured 'server' datasource")
+	d.client = pgClient
+}
+
+func (d *serverDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, res *datasource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_server"
+}
+
+func (d *serverDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, res *datasource.SchemaResponse) {
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database the server is inspected from, which the installed extensions belong to. If not provided, the database from the provider configuration will be used.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"version": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Version of the server (`server_version`), e.g. `16.4`",
+			},
+			"version_num": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Version of the server as a number (`server_version_num`), e.g. `160004`",
+			},
+			"current_user": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The role the provider is connected as",
+			},
+			"superuser": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the role the provider is connected as is a superuser",
+			},
+			"in_recovery": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the server is a standby in recovery, rejecting the writes",
+			},
+			"data_checksums": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the data checksums are enabled on the cluster",
+			},
+			"max_connections": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Maximum number of concurrent connections to the server",
+			},
+			"server_encoding": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Character set encoding of the server, e.g. `UTF8`",
+			},
+			"extensions": schema.MapAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Versions of the extensions installed in the database, by name",
+			},
+			"capabilities": schema.ListAttribute{
+				Computed:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Features of the provider that depend on the server version and are supported, e.g. `icu_locale`",
+			},
+		},
+		MarkdownDescription: mdDocDataSourceServer,
+	}
+}
+
+func (d *serverDataSource) Read(ctx context.Context, req datasource.ReadRequest, res *datasource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'server' datasource")
+
+	var model serverDataSourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() {
+		model.Database = types.StringValue(d.client.GetInitConfig().Database)
+	}
+
+	info, diags := getServerInfo(ctx, d.client, model.Database.ValueString())
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	extensions, diags := types.MapValueFrom(ctx, types.StringType, info.Extensions)
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	capabilities := make([]string, len(info.Capabilities))
+	for i, capability := range info.Capabilities {
+		capabilities[i] = string(capability)
+	}
+
+	model.Version = types.StringValue(info.Version)
+	model.VersionNum = types.Int64Value(int64(info.VersionNum))
+	model.CurrentUser = types.StringValue(info.CurrentUser)
+	model.Superuser = types.BoolValue(info.IsSuperuser)
+	model.InRecovery = types.BoolValue(info.InRecovery)
+	model.DataChecksums = types.BoolValue(info.DataChecksums)
+	model.MaxConnections = types.Int64Value(int64(info.MaxConnections))
+	model.ServerEncoding = types.StringValue(info.ServerEncoding)
+	model.Extensions = extensions
+	model.Capabilities = mapSliceToStringList(capabilities)
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'server' datasource")
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"regexp"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccServerDataSource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_server_datasource_db",
+		Username: "test_server_datasource_user",
+	}
+	test.LoadPostgresTestContainer(t, runOpts, true)
+
+	mockResourceId := "test_server"
+	mockResourceName := fmt.Sprintf("data.postgresql_server.%s", mockResourceId)
+
+	// DROP DATABASE ... WITH (FORCE) comes with PostgreSQL 13, no capability is listed on older servers
+	capabilitiesCheck := resource.TestCheckResourceAttrSet(mockResourceName, "capabilities.#")
+	if test.PostgresImageMajorVersion(test.DefaultPostgresImage()) >= 13 {
+		capabilitiesCheck = resource.TestCheckTypeSetElemAttr(mockResourceName, "capabilities.*", "drop_database_force")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Read testing
+				Config: testAccServerToTFDataSource(t, mockResourceId, ``),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "database", runOpts.Database),
+					resource.TestMatchResourceAttr(mockResourceName, "version", regexp.MustCompile(`^\d+\.\d+`)),
+					resource.TestMatchResourceAttr(mockResourceName, "version_num", regexp.MustCompile(`^\d{6}$`)),
+					resource.TestCheckResourceAttr(mockResourceName, "current_user", runOpts.Username),
+					resource.TestCheckResourceAttr(mockResourceName, "superuser", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "in_recovery", "false"),
+					resource.TestCheckResourceAttrSet(mockResourceName, "data_checksums"),
+					resource.TestCheckResourceAttr(mockResourceName, "max_connections", "100"),
+					resource.TestCheckResourceAttr(mockResourceName, "server_encoding", "UTF8"),
+					resource.TestCheckResourceAttrSet(mockResourceName, "extensions.plpgsql"),
+					capabilitiesCheck,
+				),
+			},
+			{
+				// Extensions of another database
+				Config: testAccServerToTFDataSource(t, mockResourceId, `database = "postgres"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "database", "postgres"),
+					resource.TestCheckResourceAttrSet(mockResourceName, "extensions.plpgsql"),
+				),
+			},
+		},
+	})
+}
+
+func testAccServerToTFDataSource(t *testing.T, resName, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`data "postgresql_server" "%s" {
+			%s
+		}`, resName, body)
+}
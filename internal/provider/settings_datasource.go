@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &settingsDataSource{}
+	_ datasource.DataSourceWithConfigure = &settingsDataSource{}
+)
+
+type settingsDataSource struct {
+	client client.PgClient
+}
+
+type settingsDataSourceModel struct {
+	Database types.String          `tfsdk:"database"`
+	Names    types.Set             `tfsdk:"names"`
+	Category types.String          `tfsdk:"category"`
+	Settings []settingRowDataModel `tfsdk:"settings"`
+}
+
+type settingRowDataModel struct {
+	Name           types.String `tfsdk:"name"`
+	Setting        types.String `tfsdk:"setting"`
+	Unit           types.String `tfsdk:"unit"`
+	Category       types.String `tfsdk:"category"`
+	Context        types.String `tfsdk:"context"`
+	Source         types.String `tfsdk:"source"`
+	PendingRestart types.Bool   `tfsdk:"pending_restart"`
+}
+
+func NewSettingsDataSource() datasource.DataSource {
+	return &settingsDataSource{}
+}
+
+func (d *settingsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, res *datasource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'settings' datasource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Configured 'settings' datasource")
+	d.client = pgClient
+}
+
+func (d *settingsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, res *datasource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_settings"
+}
+
+func (d *settingsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, res *datasource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database the settings are read from, including the ones set for the database. If not provided, the database from the provider configuration will be used.",
+				Validators:          nonEmptyString,
+			},
+			"names": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the configuration parameters to read, case-insensitive. If not provided, every parameter is read.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(nonEmptyString...),
+				},
+			},
+			"category": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Category of the configuration parameters to read, including its subcategories, e.g. `Write-Ahead Log` for `Write-Ahead Log / Settings`.",
+				Validators:          nonEmptyString,
+			},
+			"settings": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The configuration parameters, ordered by name",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Name of the configuration parameter",
+						},
+						"setting": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Current value of the configuration parameter, in its unit",
+						},
+						"unit": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Implicit unit of the value, e.g. `kB` or `ms`, null when unit-less",
+						},
+						"category": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Category of the configuration parameter",
+						},
+						"context": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Context required to change the configuration parameter, e.g. `user`, `sighup` or `postmaster`",
+						},
+						"source": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "Source of the current value, e.g. `default`, `configuration file` or `database`",
+						},
+						"pending_restart": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Whether a new value of the configuration file is waiting for a restart of the server to be applied",
+						},
+					},
+				},
+			},
+		},
+		MarkdownDescription: mdDocDataSourceSettings,
+	}
+}
+
+func (d *settingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, res *datasource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'settings' datasource")
+
+	var model settingsDataSourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() {
+		model.Database = types.StringValue(d.client.GetInitConfig().Database)
+	}
+
+	conn, err := d.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	filter := client.SettingFilter{
+		Names:    mapSetValueToSlice[string](model.Names),
+		Category: model.Category.ValueString(),
+	}
+	settings, err := conn.SettingRepository().List(ctx, filter)
+	if err != nil {
+		res.Diagnostics.AddError("Error reading settings", err.Error())
+		return
+	}
+
+	model.Settings = make([]settingRowDataModel, len(settings))
+	for i, setting := range settings {
+		model.Settings[i] = settingRowDataModel{
+			Name:           types.StringValue(setting.Name),
+			Setting:        types.StringValue(setting.Setting),
+			Unit:           stringValueOrNull(setting.Unit),
+			Category:       types.StringValue(setting.Category),
+			Context:        types.StringValue(setting.Context),
+			Source:         types.StringValue(setting.Source),
+			PendingRestart: types.BoolValue(setting.PendingRestart),
+		}
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'settings' datasource")
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"regexp"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccSettingsDataSource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_settings_datasource_db",
+		Username: "test_settings_datasource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_settings"
+	mockResourceName := fmt.Sprintf("data.postgresql_settings.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `ALTER DATABASE test_settings_datasource_db SET work_mem = '8MB';`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Selected by name
+				Config: testAccSettingsToTFDataSource(t, mockResourceId, `names = ["WAL_LEVEL", "work_mem"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "database", runOpts.Database),
+					resource.TestCheckResourceAttr(mockResourceName, "settings.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "settings.0.name", "wal_level"),
+					resource.TestCheckResourceAttr(mockResourceName, "settings.0.setting", "replica"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "settings.0.unit"),
+					resource.TestCheckResourceAttr(mockResourceName, "settings.0.category", "Write-Ahead Log / Settings"),
+					resource.TestCheckResourceAttr(mockResourceName, "settings.0.context", "postmaster"),
+					resource.TestCheckResourceAttr(mockResourceName, "settings.0.pending_restart", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "settings.1.name", "work_mem"),
+					resource.TestCheckResourceAttr(mockResourceName, "settings.1.setting", "8192"),
+					resource.TestCheckResourceAttr(mockResourceName, "settings.1.unit", "kB"),
+					resource.TestCheckResourceAttr(mockResourceName, "settings.1.source", "database"),
+				),
+			},
+			{
+				// Selected by category, including the subcategories
+				Config: testAccSettingsToTFDataSource(t, mockResourceId, `
+					names    = ["wal_level", "work_mem"]
+					category = "Write-Ahead Log"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "settings.#", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "settings.0.name", "wal_level"),
+				),
+			},
+			{
+				// Every parameter
+				Config: testAccSettingsToTFDataSource(t, mockResourceId, ``),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(mockResourceName, "settings.#", regexp.MustCompile(`^[1-9][0-9]{2,}$`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccSettingsToTFDataSource(t *testing.T, resName, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`data "postgresql_settings" "%s" {
+			%s
+		}`, resName, body)
+}
@@ -0,0 +1,306 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"slices"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type sqlResource struct {
+	client client.PgClient
+}
+
+type sqlResourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	LastUpdated       types.String `tfsdk:"last_updated"`
+	Database          types.String `tfsdk:"database"`
+	CreateSql         types.String `tfsdk:"create_sql"`
+	CreateStatements  types.List   `tfsdk:"create_statements"`
+	DestroySql        types.String `tfsdk:"destroy_sql"`
+	DestroyStatements types.List   `tfsdk:"destroy_statements"`
+	ReadSql           types.String `tfsdk:"read_sql"`
+	Transaction       types.Bool   `tfsdk:"transaction"`
+	AssumeRole        types.String `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource              = &sqlResource{}
+	_ resource.ResourceWithConfigure = &sqlResource{}
+)
+
+func NewSqlResource() resource.Resource {
+	return &sqlResource{}
+}
+
+func (r *sqlResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'sql' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *sqlResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_sql"
+}
+
+func (r *sqlResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+	nonEmptyStatements := []validator.List{
+		listvalidator.SizeAtLeast(1),
+		listvalidator.ValueStringsAre(nonEmptyString...),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the SQL script, generated on creation",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the SQL script",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database the scripts run on. If not provided, the database from the provider configuration will be used.",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"create_sql": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Script creating the objects, possibly made of several statements. Changes run the destroy script of the previous version, then the new create script, in a single transaction unless `transaction` is `false`.",
+				Validators: append([]validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("create_statements")),
+				}, nonEmptyString...),
+			},
+			"create_statements": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Statements creating the objects, run in order. Alternative to `create_sql`, required when `transaction` is `false` for the commands that refuse to run in a multi-statement script, e.g. `CREATE INDEX CONCURRENTLY`.",
+				Validators:          nonEmptyStatements,
+			},
+			"destroy_sql": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Script dropping the objects, run when the resource is destroyed and before a new version of the create script. Nothing runs on destroy when neither `destroy_sql` nor `destroy_statements` is set.",
+				Validators: append([]validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("destroy_statements")),
+				}, nonEmptyString...),
+			},
+			"destroy_statements": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Statements dropping the objects, run in order. Alternative to `destroy_sql`.",
+				Validators:          nonEmptyStatements,
+			},
+			"read_sql": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Query detecting drift, run in a `READ ONLY` transaction on refresh. It must return at least one row while the objects exist; when it returns none, the objects are created again.",
+				Validators:          nonEmptyString,
+			},
+			"transaction": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the statements run in a single transaction, rolled back when one of them fails. Disable it for the commands that refuse to run inside a transaction, e.g. `CREATE DATABASE`. Defaults to `true`.",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET ROLE`) while running the scripts. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceSql,
+	}
+}
+
+func (r *sqlResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'sql' resource")
+
+	var model sqlResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	if err = conn.ScriptRepository().Exec(ctx, model.createStatements(), model.Transaction.ValueBool()); err != nil {
+		res.Diagnostics.AddError("Error running create SQL script", err.Error())
+		return
+	}
+
+	id := make([]byte, 8)
+	if _, err = rand.Read(id); err != nil {
+		res.Diagnostics.AddError("Error generating the SQL script identifier", err.Error())
+		return
+	}
+	model.Id = types.StringValue(hex.EncodeToString(id))
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'sql' resource")
+}
+
+func (r *sqlResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'sql' resource")
+
+	var model sqlResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// without a drift check, the objects are assumed to exist
+	if model.ReadSql.IsNull() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	found, err := conn.ScriptRepository().Check(ctx, model.ReadSql.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("Error running read SQL script", err.Error())
+		return
+	}
+
+	if !found {
+		tflog.Info(ctx, "The read SQL script returned no row, the objects are created again", map[string]any{"id": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+	tflog.Trace(ctx, "Read 'sql' resource")
+}
+
+func (r *sqlResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'sql' resource")
+
+	var stateModel sqlResourceModel
+	var planModel sqlResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// only a new create script runs, the other changes apply to the next runs
+	if !slices.Equal(stateModel.createStatements(), planModel.createStatements()) {
+		conn, err := r.client.GetConnection(ctx, planModel.Database.ValueString())
+		if err != nil {
+			res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+			return
+		}
+
+		ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+		err = conn.ScriptRepository().Replace(ctx, stateModel.destroyStatements(), planModel.createStatements(), planModel.Transaction.ValueBool())
+		if err != nil {
+			res.Diagnostics.AddError("Error replacing SQL script", err.Error())
+			return
+		}
+	}
+
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'sql' resource")
+}
+
+func (r *sqlResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'sql' resource")
+
+	var model sqlResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	statements := model.destroyStatements()
+	if len(statements) == 0 {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	if err = conn.ScriptRepository().Exec(ctx, statements, model.Transaction.ValueBool()); err != nil {
+		res.Diagnostics.AddError("Error running destroy SQL script", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'sql' resource")
+}
+
+func (rm *sqlResourceModel) createStatements() []string {
+	return scriptStatements(rm.CreateSql, rm.CreateStatements)
+}
+
+func (rm *sqlResourceModel) destroyStatements() []string {
+	return scriptStatements(rm.DestroySql, rm.DestroyStatements)
+}
+
+// scriptStatements returns the statements of a script, given either as a single string or as a list.
+func scriptStatements(script types.String, statements types.List) []string {
+	if !script.IsNull() && !script.IsUnknown() {
+		return []string{script.ValueString()}
+	}
+	return mapListValueToSlice(statements)
+}
+
+func (rm *sqlResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
@@ -0,0 +1,116 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"regexp"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccSqlResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_sql_resource_db",
+		Username: "test_sql_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_sql"
+	mockResourceName := fmt.Sprintf("postgresql_sql.%s", mockResourceId)
+	viewColumn := func(expected string) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			var column string
+			err := db.QueryRowContext(ctx, `SELECT attname FROM pg_catalog.pg_attribute WHERE attrelid = 'public.test_sql_view'::regclass;`).Scan(&column)
+			if err != nil {
+				return err
+			}
+			if column != expected {
+				return fmt.Errorf("expected the view column to be %s, got %s", expected, column)
+			}
+			return nil
+		}
+	}
+	// the commands refusing transactions run one by one
+	concurrently := `
+		resource "postgresql_sql" "test_sql_concurrently" {
+			create_statements  = [
+				"CREATE TABLE public.test_sql_table (id int);",
+				"CREATE INDEX CONCURRENTLY test_sql_idx ON public.test_sql_table (id);",
+			]
+			destroy_statements = ["DROP TABLE public.test_sql_table;"]
+			transaction        = false
+		}`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccSqlToTFResource(t, mockResourceId, "CREATE VIEW public.test_sql_view AS SELECT 1 AS a;") + concurrently,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestMatchResourceAttr(mockResourceName, "id", regexp.MustCompile(`^[0-9a-f]{16}$`)),
+					resource.TestCheckResourceAttr(mockResourceName, "database", runOpts.Database),
+					resource.TestCheckResourceAttr(mockResourceName, "transaction", "true"),
+					viewColumn("a"),
+					func(_ *terraform.State) error {
+						var exists bool
+						if err := db.QueryRowContext(ctx, `SELECT to_regclass('public.test_sql_idx') IS NOT NULL;`).Scan(&exists); err != nil {
+							return err
+						}
+						if !exists {
+							return fmt.Errorf("expected the index test_sql_idx to exist")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// Update testing - A failing create script keeps the previous objects
+				Config:      testAccSqlToTFResource(t, mockResourceId, "CREATE VIEW public.test_sql_view AS SELECT missing;") + concurrently,
+				ExpectError: regexp.MustCompile(`column "missing" does not exist`),
+			},
+			{
+				// Update testing - The objects are dropped and created again in a single transaction
+				Config: testAccSqlToTFResource(t, mockResourceId, "CREATE VIEW public.test_sql_view AS SELECT 2 AS b;") + concurrently,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					viewColumn("b"),
+				),
+			},
+			{
+				// Drift testing - Objects dropped outside of Terraform are created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP VIEW public.test_sql_view;`)
+					assert.NoError(t, err)
+				},
+				Config:             testAccSqlToTFResource(t, mockResourceId, "CREATE VIEW public.test_sql_view AS SELECT 2 AS b;") + concurrently,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccSqlToTFResource(t, mockResourceId, "CREATE VIEW public.test_sql_view AS SELECT 2 AS b;") + concurrently,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					viewColumn("b"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSqlToTFResource(t *testing.T, resId, createSql string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_sql" "%s" {
+			create_sql  = "%s"
+			destroy_sql = "DROP VIEW public.test_sql_view;"
+			read_sql    = "SELECT 1 FROM pg_catalog.pg_views WHERE schemaname = 'public' AND viewname = 'test_sql_view'"
+		}`, resId, createSql)
+}
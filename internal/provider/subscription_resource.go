@@ -0,0 +1,465 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type subscriptionResource struct {
+	client client.PgClient
+}
+
+type subscriptionResourceModel struct {
+	Id             types.String   `tfsdk:"id"`
+	LastUpdated    types.String   `tfsdk:"last_updated"`
+	Database       types.String   `tfsdk:"database"`
+	Name           types.String   `tfsdk:"name"`
+	ConnectionInfo types.String   `tfsdk:"connection_info"`
+	Publications   []types.String `tfsdk:"publications"`
+	SlotName       types.String   `tfsdk:"slot_name"`
+	CreateSlot     types.Bool     `tfsdk:"create_slot"`
+	CopyData       types.Bool     `tfsdk:"copy_data"`
+	Enabled        types.Bool     `tfsdk:"enabled"`
+	Streaming      types.String   `tfsdk:"streaming"`
+	Binary         types.Bool     `tfsdk:"binary"`
+	Owner          types.String   `tfsdk:"owner"`
+	Comment        types.String   `tfsdk:"comment"`
+	AssumeRole     types.String   `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                = &subscriptionResource{}
+	_ resource.ResourceWithConfigure   = &subscriptionResource{}
+	_ resource.ResourceWithImportState = &subscriptionResource{}
+	_ resource.ResourceWithModifyPlan  = &subscriptionResource{}
+)
+
+func NewSubscriptionResource() resource.Resource {
+	return &subscriptionResource{}
+}
+
+func (r *subscriptionResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'subscription' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *subscriptionResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_subscription"
+}
+
+func (r *subscriptionResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the subscription, in the format `database_name.subscription_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the subscription",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the subscription is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the subscription. Changes rename the subscription in place, but not its replication slot.",
+				Validators:          nonEmptyString,
+			},
+			"connection_info": schema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The libpq connection string to the publisher, e.g. `host=publisher dbname=app user=replicator password=secret`. It's never read back from the server.",
+				Validators:          nonEmptyString,
+			},
+			"publications": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the publications to subscribe to, on the publisher",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"slot_name": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the replication slot on the publisher. Defaults to the name of the subscription.",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"create_slot": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the replication slot is created on the publisher, otherwise it must exist. Only used when the subscription is created.",
+			},
+			"copy_data": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the existing data of the published tables is copied when the subscription is created, and when publications are added to an enabled subscription",
+			},
+			"enabled": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether the subscription is replicating",
+			},
+			"streaming": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(client.SubscriptionStreamingOff),
+				MarkdownDescription: "How the in-progress transactions are streamed: `off`, `on` (requires PostgreSQL 14 or later) or `parallel` (requires PostgreSQL 16 or later)",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.SubscriptionStreamingModes...),
+				},
+			},
+			"binary": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the publisher sends the data in binary format. Requires PostgreSQL 14 or later.",
+			},
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The owner of the subscription. If not provided, the subscription is owned by the role that creates it (see `assume_role`).",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the subscription",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET ROLE`) while managing the subscription. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceSubscription,
+	}
+}
+
+func (r *subscriptionResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var model subscriptionResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	db := plannedDatabase(r.client, model.Database)
+
+	switch model.Streaming.ValueString() {
+	case client.SubscriptionStreamingOn:
+		res.Diagnostics.Append(checkServerCapability(ctx, r.client, db, client.CapabilitySubscriptionStreaming, path.Root("streaming"), "The streaming of subscriptions")...)
+	case client.SubscriptionStreamingParallel:
+		res.Diagnostics.Append(checkServerCapability(ctx, r.client, db, client.CapabilityParallelStreaming, path.Root("streaming"), "The parallel streaming of subscriptions")...)
+	}
+	if model.Binary.ValueBool() {
+		res.Diagnostics.Append(checkServerCapability(ctx, r.client, db, client.CapabilitySubscriptionBinary, path.Root("binary"), "The binary format of subscriptions")...)
+	}
+
+	// the identifier is derived from the name, a rename produces a new one
+	if !req.State.Raw.IsNull() {
+		var stateModel subscriptionResourceModel
+		res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+		if !model.Name.Equal(stateModel.Name) {
+			res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		}
+	}
+}
+
+func (r *subscriptionResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'subscription' resource")
+
+	var model subscriptionResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	role := resolveAssumeRole(r.client, model.AssumeRole)
+	ctx = client.ContextWithAssumeRole(ctx, role)
+
+	// the subscription is owned by the assumed role, unless another owner is explicitly requested
+	expectedOwner := role
+	if !model.Owner.IsNull() && !model.Owner.IsUnknown() {
+		expectedOwner = model.Owner.ValueString()
+	}
+
+	pgModel := model.toPgModel()
+	pgModel.Owner = expectedOwner
+
+	repository := conn.SubscriptionRepository()
+	if err = repository.Create(ctx, pgModel); err != nil {
+		res.Diagnostics.AddError("Error creating subscription", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readSubscriptionModel(ctx, repository, model.Name.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("subscription", expectedOwner, model.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'subscription' resource")
+}
+
+func (r *subscriptionResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'subscription' resource")
+
+	var model subscriptionResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the subscription", "Id is required for reading subscription")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 2)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the subscription", "Id should be in the format 'database_name.subscription_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a subscription dropped outside of Terraform is removed from the state and planned again
+	repository := conn.SubscriptionRepository()
+	exists, err := repository.Exists(ctx, idParts[1])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading subscription", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Subscription not found, removing it from the state", map[string]any{"subscription": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readSubscriptionModel(ctx, repository, idParts[1], &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'subscription' resource")
+}
+
+func (r *subscriptionResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'subscription' resource")
+
+	var stateModel subscriptionResourceModel
+	var planModel subscriptionResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	current := stateModel.toPgModel()
+	desired := planModel.toPgModel()
+	if planModel.Owner.IsUnknown() {
+		desired.Owner = current.Owner
+	}
+
+	repository := conn.SubscriptionRepository()
+	_, err = repository.Update(ctx, client.SubscriptionUpdateParams{Current: current, Desired: desired})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating subscription", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readSubscriptionModel(ctx, repository, planModel.Name.ValueString(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("subscription", desired.Owner, planModel.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'subscription' resource")
+}
+
+func (r *subscriptionResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'subscription' resource")
+
+	var model subscriptionResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	if err = conn.SubscriptionRepository().Drop(ctx, model.Name.ValueString()); err != nil {
+		res.Diagnostics.AddError("Error deleting subscription", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'subscription' resource")
+}
+
+func (r *subscriptionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readSubscriptionModel reads the subscription into the target model. The connection info and the
+// creation options can't be read back, the ones of the target are kept.
+func readSubscriptionModel(ctx context.Context, repository client.SubscriptionRepository, name string, target *subscriptionResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading subscription: '%s'", name), err.Error())
+		return diags
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *subscriptionResourceModel) toPgModel() client.SubscriptionModel {
+	return client.SubscriptionModel{
+		Name:         rm.Name.ValueString(),
+		Database:     rm.Database.ValueString(),
+		ConnInfo:     rm.ConnectionInfo.ValueString(),
+		Publications: mapStringValuesToSlice(rm.Publications),
+		SlotName:     rm.SlotName.ValueString(),
+		CreateSlot:   rm.CreateSlot.ValueBool(),
+		CopyData:     rm.CopyData.ValueBool(),
+		Enabled:      rm.Enabled.ValueBool(),
+		Streaming:    rm.Streaming.ValueString(),
+		Binary:       rm.Binary.ValueBool(),
+		Owner:        rm.Owner.ValueString(),
+		Comment:      rm.Comment.ValueString(),
+	}
+}
+
+func (rm *subscriptionResourceModel) fromPgModel(pgModel client.SubscriptionModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Publications = mapSliceToStringValues(pgModel.Publications)
+	rm.SlotName = stringValueOrNull(pgModel.SlotName)
+	rm.Enabled = types.BoolValue(pgModel.Enabled)
+	rm.Streaming = types.StringValue(pgModel.Streaming)
+	rm.Binary = types.BoolValue(pgModel.Binary)
+	rm.Owner = types.StringValue(pgModel.Owner)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+}
+
+func (rm *subscriptionResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s", rm.Database.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *subscriptionResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
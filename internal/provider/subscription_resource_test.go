@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccSubscriptionResource(t *testing.T) {
+	// the streaming and binary options of subscriptions come with PostgreSQL 14
+	test.SkipBelowPostgres(t, 14)
+	nw := test.NewTestNetwork(t)
+	publisherOpts := test.PostgresContainerRunOptions{
+		Database:     "test_subscription_publisher_db",
+		Username:     "test_subscription_publisher_user",
+		Settings:     map[string]string{"wal_level": "logical"},
+		Network:      nw,
+		NetworkAlias: "publisher",
+	}
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_subscription_resource_db",
+		Username: "test_subscription_resource_user",
+		Network:  nw,
+	}
+	ctx := context.TODO()
+
+	publisher, err := postgres.Open(ctx, test.GetPostgresConnectionString(t, test.LoadPostgresTestContainer(t, publisherOpts, false)))
+	assert.NoError(t, err)
+	defer publisher.Close()
+
+	subscriber, err := postgres.Open(ctx, test.GetPostgresConnectionString(t, test.LoadPostgresTestContainer(t, runOpts, true)))
+	assert.NoError(t, err)
+	defer subscriber.Close()
+
+	connInfo := test.GetPostgresNetworkConnInfo(t, publisherOpts)
+	mockResourceId := "test_subscription"
+	mockResourceName := fmt.Sprintf("postgresql_subscription.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			table := `CREATE TABLE public.test_subscription_resource_table (id int PRIMARY KEY, name text);`
+			_, err := publisher.ExecContext(ctx, table+`
+				CREATE PUBLICATION test_subscription_resource_pub_a FOR TABLE public.test_subscription_resource_table;
+				CREATE PUBLICATION test_subscription_resource_pub_b;`)
+			assert.NoError(t, err)
+			_, err = subscriber.ExecContext(ctx, table)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccSubscriptionToTFResource(t, mockResourceId, "test_subscription_resource", connInfo, `
+					publications = ["test_subscription_resource_pub_a"]
+					comment      = "test comment"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.test_subscription_resource", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "publications.#", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "slot_name", "test_subscription_resource"),
+					resource.TestCheckResourceAttr(mockResourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "streaming", "off"),
+					resource.TestCheckResourceAttr(mockResourceName, "binary", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "owner", runOpts.Username),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment"),
+				),
+			},
+			{
+				// ImportState testing, the connection info and the creation options can't be read back
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "connection_info", "create_slot", "copy_data"},
+			},
+			{
+				// Update testing - Properties without re-creating the resource
+				Config: testAccSubscriptionToTFResource(t, mockResourceId, "test_subscription_resource_renamed", connInfo, `
+					publications = ["test_subscription_resource_pub_a", "test_subscription_resource_pub_b"]
+					enabled      = false
+					binary       = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.test_subscription_resource_renamed", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "publications.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "slot_name", "test_subscription_resource"),
+					resource.TestCheckResourceAttr(mockResourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "binary", "true"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "comment"),
+				),
+			},
+			{
+				// Drift testing - a subscription dropped outside of Terraform is planned again, the slot
+				// is kept on the publisher
+				PreConfig: func() {
+					_, err := subscriber.ExecContext(ctx, `ALTER SUBSCRIPTION test_subscription_resource_renamed SET (slot_name = NONE);`)
+					assert.NoError(t, err)
+					_, err = subscriber.ExecContext(ctx, `DROP SUBSCRIPTION test_subscription_resource_renamed;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccSubscriptionToTFResource(t, mockResourceId, "test_subscription_resource_renamed", connInfo, `
+					publications = ["test_subscription_resource_pub_a", "test_subscription_resource_pub_b"]
+					enabled      = false
+					binary       = true`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccSubscriptionToTFResource(t *testing.T, resId, name, connInfo, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_subscription" "%s" {
+			name            = "%s"
+			connection_info = "%s"
+			%s
+		}`, resId, name, connInfo, body)
+}
@@ -0,0 +1,360 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+// systemSettingContexts are the contexts of the parameters that ALTER SYSTEM can change, the
+// 'internal' ones being fixed when the server is built or initialized.
+var systemSettingContexts = []string{"user", "superuser", "backend", "superuser-backend", "sighup", "postmaster"}
+
+type systemSettingResource struct {
+	client client.PgClient
+}
+
+type systemSettingResourceModel struct {
+	Id             types.String `tfsdk:"id"`
+	LastUpdated    types.String `tfsdk:"last_updated"`
+	Name           types.String `tfsdk:"name"`
+	Value          types.String `tfsdk:"value"`
+	PendingRestart types.Bool   `tfsdk:"pending_restart"`
+	AssumeRole     types.String `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                = &systemSettingResource{}
+	_ resource.ResourceWithConfigure   = &systemSettingResource{}
+	_ resource.ResourceWithImportState = &systemSettingResource{}
+	_ resource.ResourceWithModifyPlan  = &systemSettingResource{}
+)
+
+func NewSystemSettingResource() resource.Resource {
+	return &systemSettingResource{}
+}
+
+func (r *systemSettingResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'system_setting' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *systemSettingResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_system_setting"
+}
+
+func (r *systemSettingResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the system setting, the name of the parameter",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the system setting",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the configuration parameter, e.g. `max_connections`, or of a custom parameter qualified by its prefix, e.g. `app.tenant`",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Value of the configuration parameter, written to `postgresql.auto.conf`. The configuration is reloaded after every change, the parameters of the `postmaster` context are only applied once the server restarts. It is validated against `pg_settings`; values with units, e.g. `1GB` and `1024MB`, are compared in the unit of the parameter.",
+			},
+			"pending_restart": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the value is waiting for a restart of the server to be applied",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET ROLE`) while managing the system setting. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceSystemSetting,
+	}
+}
+
+func (r *systemSettingResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	var model, stateModel systemSettingResourceModel
+
+	if !req.State.Raw.IsNull() {
+		res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	}
+	if !req.Plan.Raw.IsNull() {
+		res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	}
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// on destroy, the parameter is reset to the value of postgresql.conf
+	name := model.Name
+	if req.Plan.Raw.IsNull() {
+		name = stateModel.Name
+	}
+	if name.IsUnknown() || model.Value.IsUnknown() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	definition, err := conn.SystemSettingRepository().Definition(ctx, name.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(fmt.Sprintf("Error reading parameter: '%s'", name.ValueString()), err.Error())
+		return
+	}
+
+	if !req.Plan.Raw.IsNull() {
+		res.Diagnostics.Append(checkSettingValue(definition, name.ValueString(), model.Value.ValueString(), systemSettingContexts)...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	changed := req.State.Raw.IsNull() || req.Plan.Raw.IsNull() || !model.Name.Equal(stateModel.Name) ||
+		definition == nil || definition.Normalize(model.Value.ValueString()) != definition.Normalize(stateModel.Value.ValueString())
+	if definition != nil && definition.Context == "postmaster" && changed {
+		res.Diagnostics.AddWarning(
+			"Restart required",
+			fmt.Sprintf("The parameter '%s' has the context 'postmaster', the change is only applied once the server restarts.", definition.Name),
+		)
+	}
+}
+
+func (r *systemSettingResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'system_setting' resource")
+
+	var model systemSettingResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// the configuration is global to the server, any database connection manages it
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	repository := conn.SystemSettingRepository()
+	if err = repository.Create(ctx, model.toPgModel()); err != nil {
+		res.Diagnostics.AddError("Error creating system setting", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readSystemSettingModel(ctx, repository, model.toPgModel(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'system_setting' resource")
+}
+
+func (r *systemSettingResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'system_setting' resource")
+
+	var model systemSettingResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the system setting", "Id is required for reading system setting")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	setting := client.SystemSettingModel{Name: model.Id.ValueString(), Value: model.Value.ValueString()}
+	// a system setting reset outside of Terraform is removed from the state and planned again
+	repository := conn.SystemSettingRepository()
+	exists, err := repository.Exists(ctx, setting.Name)
+	if err != nil {
+		res.Diagnostics.AddError("Error reading system setting", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "System setting not found, removing it from the state", map[string]any{"setting": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readSystemSettingModel(ctx, repository, setting, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'system_setting' resource")
+}
+
+func (r *systemSettingResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'system_setting' resource")
+
+	var stateModel systemSettingResourceModel
+	var planModel systemSettingResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	repository := conn.SystemSettingRepository()
+	_, err = repository.Update(ctx, client.SystemSettingUpdateParams{Current: stateModel.toPgModel(), Desired: planModel.toPgModel()})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating system setting", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readSystemSettingModel(ctx, repository, planModel.toPgModel(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'system_setting' resource")
+}
+
+func (r *systemSettingResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'system_setting' resource")
+
+	var model systemSettingResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	if err = conn.SystemSettingRepository().Drop(ctx, model.Name.ValueString()); err != nil {
+		res.Diagnostics.AddError("Error deleting system setting", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'system_setting' resource")
+}
+
+func (r *systemSettingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readSystemSettingModel reads the setting into the target, keeping the spelling of the prior value
+// when postgresql.auto.conf holds an equivalent one, e.g. '1GB' for '1024MB'.
+func readSystemSettingModel(ctx context.Context, repository client.SystemSettingRepository, prior client.SystemSettingModel, target *systemSettingResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, prior.Name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading system setting: '%s'", prior.Name), err.Error())
+		return diags
+	}
+
+	definition, err := repository.Definition(ctx, prior.Name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading parameter: '%s'", prior.Name), err.Error())
+		return diags
+	}
+	actual.Value = client.PreserveSettingSpelling(definition, prior.Value, actual.Value)
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *systemSettingResourceModel) toPgModel() client.SystemSettingModel {
+	return client.SystemSettingModel{
+		Name:  rm.Name.ValueString(),
+		Value: rm.Value.ValueString(),
+	}
+}
+
+func (rm *systemSettingResourceModel) fromPgModel(pgModel client.SystemSettingModel) {
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Value = types.StringValue(pgModel.Value)
+	rm.PendingRestart = types.BoolValue(pgModel.PendingRestart)
+}
+
+func (rm *systemSettingResourceModel) SetId() {
+	rm.Id = types.StringValue(rm.Name.ValueString())
+}
+
+func (rm *systemSettingResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
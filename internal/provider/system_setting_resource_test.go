@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"regexp"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccSystemSettingResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_system_setting_resource_db",
+		Username: "test_system_setting_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_system_setting"
+	mockResourceName := fmt.Sprintf("postgresql_system_setting.%s", mockResourceId)
+	// the parameters of the postmaster context wait for a restart
+	restart := `
+		resource "postgresql_system_setting" "test_system_setting_restart" {
+			name  = "max_connections"
+			value = "150"
+		}`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Plan testing - The internal parameters are refused
+				Config:      testAccSystemSettingToTFResource(t, mockResourceId, "block_size", "16384"),
+				ExpectError: regexp.MustCompile(`The parameter 'block_size' has the context 'internal'`),
+			},
+			{
+				// Create and Read testing
+				Config: testAccSystemSettingToTFResource(t, mockResourceId, "work_mem", "64MB") + restart,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", "work_mem"),
+					resource.TestCheckResourceAttr(mockResourceName, "value", "64MB"),
+					resource.TestCheckResourceAttr(mockResourceName, "pending_restart", "false"),
+					resource.TestCheckResourceAttr("postgresql_system_setting.test_system_setting_restart", "value", "150"),
+					resource.TestCheckResourceAttr("postgresql_system_setting.test_system_setting_restart", "pending_restart", "true"),
+					func(_ *terraform.State) error {
+						var workMem string
+						if err := db.QueryRowContext(ctx, `SELECT setting FROM pg_catalog.pg_settings WHERE name = 'work_mem';`).Scan(&workMem); err != nil {
+							return err
+						}
+						if workMem != "65536" {
+							return fmt.Errorf("expected work_mem to be reloaded as 65536, got %s", workMem)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				// Update testing - The value is changed in place
+				Config: testAccSystemSettingToTFResource(t, mockResourceId, "work_mem", "1GB") + restart,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", "work_mem"),
+					resource.TestCheckResourceAttr(mockResourceName, "value", "1GB"),
+				),
+			},
+			{
+				// Plan testing - An equivalent value set outside of Terraform produces no diff
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `ALTER SYSTEM SET work_mem = '1048576kB';`)
+					assert.NoError(t, err)
+				},
+				Config:   testAccSystemSettingToTFResource(t, mockResourceId, "work_mem", "1GB") + restart,
+				PlanOnly: true,
+			},
+			{
+				// Drift testing - a system setting reset outside of Terraform is set again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `ALTER SYSTEM RESET work_mem;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccSystemSettingToTFResource(t, mockResourceId, "work_mem", "1GB") + restart,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "value", "1GB"),
+					resource.TestCheckResourceAttr(mockResourceName, "pending_restart", "false"),
+				),
+			},
+		},
+	})
+}
+
+func testAccSystemSettingToTFResource(t *testing.T, resId, name, value string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_system_setting" "%s" {
+			name  = "%s"
+			value = "%s"
+		}`, resId, name, value)
+}
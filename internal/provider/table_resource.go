@@ -0,0 +1,811 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"slices"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type tableResource struct {
+	client client.PgClient
+}
+
+type tableResourceModel struct {
+	Id                      types.String                 `tfsdk:"id"`
+	LastUpdated             types.String                 `tfsdk:"last_updated"`
+	Database                types.String                 `tfsdk:"database"`
+	Schema                  types.String                 `tfsdk:"schema"`
+	Name                    types.String                 `tfsdk:"name"`
+	Columns                 []tableColumnModel           `tfsdk:"columns"`
+	PrimaryKey              *tablePrimaryKeyModel        `tfsdk:"primary_key"`
+	UniqueConstraints       []tableUniqueConstraintModel `tfsdk:"unique_constraints"`
+	CheckConstraints        []tableCheckConstraintModel  `tfsdk:"check_constraints"`
+	ForeignKeys             []tableForeignKeyModel       `tfsdk:"foreign_keys"`
+	PartitionBy             types.String                 `tfsdk:"partition_by"`
+	Unlogged                types.Bool                   `tfsdk:"unlogged"`
+	Comment                 types.String                 `tfsdk:"comment"`
+	AllowDestructiveChanges types.Bool                   `tfsdk:"allow_destructive_changes"`
+	AssumeRole              types.String                 `tfsdk:"assume_role"`
+}
+
+type tableColumnModel struct {
+	Name      types.String `tfsdk:"name"`
+	Type      types.String `tfsdk:"type"`
+	Nullable  types.Bool   `tfsdk:"nullable"`
+	Default   types.String `tfsdk:"default"`
+	Identity  types.String `tfsdk:"identity"`
+	Generated types.String `tfsdk:"generated"`
+}
+
+type tablePrimaryKeyModel struct {
+	Name    types.String   `tfsdk:"name"`
+	Columns []types.String `tfsdk:"columns"`
+}
+
+type tableUniqueConstraintModel struct {
+	Name    types.String   `tfsdk:"name"`
+	Columns []types.String `tfsdk:"columns"`
+}
+
+type tableCheckConstraintModel struct {
+	Name       types.String `tfsdk:"name"`
+	Expression types.String `tfsdk:"expression"`
+}
+
+type tableForeignKeyModel struct {
+	Name              types.String   `tfsdk:"name"`
+	Columns           []types.String `tfsdk:"columns"`
+	ReferencesTable   types.String   `tfsdk:"references_table"`
+	ReferencesColumns []types.String `tfsdk:"references_columns"`
+	OnDelete          types.String   `tfsdk:"on_delete"`
+	OnUpdate          types.String   `tfsdk:"on_update"`
+}
+
+var (
+	_ resource.Resource                   = &tableResource{}
+	_ resource.ResourceWithConfigure      = &tableResource{}
+	_ resource.ResourceWithImportState    = &tableResource{}
+	_ resource.ResourceWithValidateConfig = &tableResource{}
+	_ resource.ResourceWithModifyPlan     = &tableResource{}
+)
+
+func NewTableResource() resource.Resource {
+	return &tableResource{}
+}
+
+func (r *tableResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'table' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *tableResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_table"
+}
+
+func (r *tableResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+	columnList := []validator.List{
+		listvalidator.SizeAtLeast(1),
+		listvalidator.UniqueValues(),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the table, in the format `database_name.schema_name.table_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the table",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the table is located. If not provided, the database from the provider configuration will be used. Changing it recreates the table and requires `allow_destructive_changes`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("public"),
+				MarkdownDescription: "Schema of the table. Changing it moves the table with `ALTER TABLE ... SET SCHEMA`.",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the table",
+			},
+			"columns": schema.ListNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "Columns of the table. Columns are matched by name: renaming a column drops it and adds a new one. A column added to an existing table goes after the existing ones, whatever its position in the list.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the column",
+							Validators:          nonEmptyString,
+						},
+						"type": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Data type of the column, e.g. `text` or `numeric(10, 2)`. Changing it requires `allow_destructive_changes`.",
+							Validators:          nonEmptyString,
+						},
+						"nullable": schema.BoolAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             booldefault.StaticBool(true),
+							MarkdownDescription: "Whether the column accepts null values. Defaults to `false` for identity and primary key columns, `true` otherwise.",
+						},
+						"default": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "SQL expression used as the default value of the column",
+							Validators: []validator.String{
+								stringvalidator.LengthAtLeast(1),
+								stringvalidator.ConflictsWith(
+									path.MatchRelative().AtParent().AtName("identity"),
+									path.MatchRelative().AtParent().AtName("generated"),
+								),
+							},
+						},
+						"identity": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Makes the column an identity column, generated `always` or `by_default`",
+							Validators: []validator.String{
+								stringvalidator.OneOf(client.TableIdentityAlways, client.TableIdentityByDefault),
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("generated")),
+							},
+						},
+						"generated": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "SQL expression of a stored generated column. Changing it recreates the column and requires `allow_destructive_changes`; removing it keeps the values as a regular column (PostgreSQL 13 or later).",
+							Validators:          nonEmptyString,
+						},
+					},
+				},
+			},
+			"primary_key": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Primary key of the table",
+				Attributes: map[string]schema.Attribute{
+					"name": schema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						MarkdownDescription: "Name of the primary key constraint. If not provided, PostgreSQL names it `<table>_pkey`.",
+						Validators:          nonEmptyString,
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"columns": schema.ListAttribute{
+						Required:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "Columns of the primary key",
+						Validators:          columnList,
+					},
+				},
+			},
+			"unique_constraints": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Unique constraints of the table",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the constraint",
+							Validators:          nonEmptyString,
+						},
+						"columns": schema.ListAttribute{
+							Required:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Columns that must be unique together",
+							Validators:          columnList,
+						},
+					},
+				},
+			},
+			"check_constraints": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Check constraints of the table",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the constraint",
+							Validators:          nonEmptyString,
+						},
+						"expression": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Boolean SQL expression that every row must satisfy",
+							Validators:          nonEmptyString,
+						},
+					},
+				},
+			},
+			"foreign_keys": schema.ListNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Foreign key constraints of the table",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Name of the constraint",
+							Validators:          nonEmptyString,
+						},
+						"columns": schema.ListAttribute{
+							Required:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Referencing columns of the table",
+							Validators:          columnList,
+						},
+						"references_table": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Referenced table, in the format `schema_name.table_name`",
+							Validators:          nonEmptyString,
+						},
+						"references_columns": schema.ListAttribute{
+							Required:            true,
+							ElementType:         types.StringType,
+							MarkdownDescription: "Referenced columns, in the same order as `columns`",
+							Validators:          columnList,
+						},
+						"on_delete": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(client.ForeignKeyActions[0]),
+							MarkdownDescription: "Action when a referenced row is deleted",
+							Validators: []validator.String{
+								stringvalidator.OneOf(client.ForeignKeyActions...),
+							},
+						},
+						"on_update": schema.StringAttribute{
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(client.ForeignKeyActions[0]),
+							MarkdownDescription: "Action when a referenced row is updated",
+							Validators: []validator.String{
+								stringvalidator.OneOf(client.ForeignKeyActions...),
+							},
+						},
+					},
+				},
+			},
+			"partition_by": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Partitioning of the table, e.g. `RANGE (created_at)`. Changing it recreates the table and requires `allow_destructive_changes`.",
+				Validators:          nonEmptyString,
+			},
+			"unlogged": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the table is unlogged",
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the table",
+				Validators:          nonEmptyString,
+			},
+			"allow_destructive_changes": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Allow changes that may lose data: dropping columns, changing column types or generated expressions and recreating the table. Without it such plans fail.",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the table. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceTable,
+	}
+}
+
+func (r *tableResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	var model tableResourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	columnNames := make([]string, 0, len(model.Columns))
+	for i, column := range model.Columns {
+		if column.Name.IsUnknown() {
+			continue
+		}
+		if slices.Contains(columnNames, column.Name.ValueString()) {
+			res.Diagnostics.AddAttributeError(
+				path.Root("columns").AtListIndex(i).AtName("name"),
+				"Duplicate column",
+				fmt.Sprintf("The column '%s' is defined more than once.", column.Name.ValueString()),
+			)
+		}
+		columnNames = append(columnNames, column.Name.ValueString())
+
+		if column.Nullable.ValueBool() && (model.isPrimaryKeyColumn(column.Name.ValueString()) || !column.Identity.IsNull()) {
+			res.Diagnostics.AddAttributeError(
+				path.Root("columns").AtListIndex(i).AtName("nullable"),
+				"Invalid attribute combination",
+				fmt.Sprintf("The column '%s' is an identity or primary key column and can't be nullable.", column.Name.ValueString()),
+			)
+		}
+	}
+}
+
+func (r *tableResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	// nested blocks computed from other resources are only checked once they are known
+	var columns, uniqueConstraints, checkConstraints, foreignKeys types.List
+	var primaryKey types.Object
+	res.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("columns"), &columns)...)
+	res.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("primary_key"), &primaryKey)...)
+	res.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("unique_constraints"), &uniqueConstraints)...)
+	res.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("check_constraints"), &checkConstraints)...)
+	res.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("foreign_keys"), &foreignKeys)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	if columns.IsUnknown() || primaryKey.IsUnknown() || uniqueConstraints.IsUnknown() || checkConstraints.IsUnknown() || foreignKeys.IsUnknown() {
+		return
+	}
+
+	var model, configModel tableResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	res.Diagnostics.Append(req.Config.Get(ctx, &configModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// identity and primary key columns are NOT NULL, unless configured otherwise
+	for i, column := range configModel.Columns {
+		if column.Nullable.IsNull() && (model.isPrimaryKeyColumn(column.Name.ValueString()) || !column.Identity.IsNull()) {
+			model.Columns[i].Nullable = types.BoolValue(false)
+		}
+	}
+
+	if !req.State.Raw.IsNull() {
+		var stateModel tableResourceModel
+
+		res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+
+		allowDestructive := model.AllowDestructiveChanges.ValueBool()
+
+		var replace []path.Path
+		if !model.Database.IsUnknown() && !model.Database.Equal(stateModel.Database) {
+			replace = append(replace, path.Root("database"))
+		}
+		if !model.PartitionBy.IsUnknown() && !model.PartitionBy.Equal(stateModel.PartitionBy) {
+			replace = append(replace, path.Root("partition_by"))
+		}
+
+		switch {
+		case len(replace) > 0 && allowDestructive:
+			res.RequiresReplace = append(res.RequiresReplace, replace...)
+		case len(replace) > 0:
+			for _, attrPath := range replace {
+				res.Diagnostics.AddAttributeError(
+					attrPath,
+					"Destructive change not allowed",
+					fmt.Sprintf("Changing '%s' recreates the table and loses its data. Set `allow_destructive_changes = true` to apply it.", attrPath),
+				)
+			}
+		case !allowDestructive:
+			conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+			if err != nil {
+				res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+				return
+			}
+
+			current, desired := stateModel.toPgModel(), model.toPgModel()
+			alterations, err := conn.TableRepository().PlanAlterations(ctx, current, desired)
+			if err != nil {
+				res.Diagnostics.AddWarning(
+					"Unable to normalize the table definition",
+					fmt.Sprintf("The column types are compared as written, a type only spelled differently is planned as a type change: %s", err),
+				)
+				alterations = client.PlanTableAlterations(current, desired)
+			}
+			for _, alteration := range alterations {
+				if alteration.Destructive {
+					res.Diagnostics.AddAttributeError(
+						path.Root("columns"),
+						"Destructive change not allowed",
+						fmt.Sprintf("The plan would %s, which may lose data. Set `allow_destructive_changes = true` to apply it.", alteration.Description),
+					)
+				}
+			}
+		}
+
+		// turning a generated column into a regular one relies on DROP EXPRESSION
+		for _, column := range model.Columns {
+			idx := slices.IndexFunc(stateModel.Columns, func(c tableColumnModel) bool { return c.Name.Equal(column.Name) })
+			if idx >= 0 && !stateModel.Columns[idx].Generated.IsNull() && column.Generated.IsNull() {
+				res.Diagnostics.Append(checkServerCapability(
+					ctx,
+					r.client,
+					plannedDatabase(r.client, model.Database),
+					client.CapabilityDropExpression,
+					path.Root("columns"),
+					fmt.Sprintf("Turning the generated column '%s' into a regular column", column.Name.ValueString()),
+				)...)
+			}
+		}
+
+		// the identifier is derived from the schema and name, changing them produces a new one
+		if !model.Name.Equal(stateModel.Name) || !model.Schema.Equal(stateModel.Schema) {
+			model.Id = types.StringUnknown()
+		}
+	}
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.Plan.Set(ctx, &model)...)
+}
+
+func (r *tableResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'table' resource")
+
+	var model tableResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	repository := conn.TableRepository()
+	if err = repository.Create(ctx, model.toPgModel()); err != nil {
+		res.Diagnostics.AddError("Error creating table", err.Error())
+		return
+	}
+
+	pgModel, err := repository.Get(ctx, model.Schema.ValueString(), model.Name.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(fmt.Sprintf("Error reading table: '%s'", model.Name.ValueString()), err.Error())
+		return
+	}
+	res.Diagnostics.Append(readTableModel(ctx, repository, pgModel, &model)...)
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'table' resource")
+}
+
+func (r *tableResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'table' resource")
+
+	var model tableResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the table", "Id is required for reading table")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 3)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the table", "Id should be in the format 'database_name.schema_name.table_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a table dropped outside of Terraform is removed from the state and planned again
+	exists, err := conn.TableRepository().Exists(ctx, idParts[1], idParts[2])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading table", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Table not found, removing it from the state", map[string]any{"table": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	repository := conn.TableRepository()
+	pgModel, err := repository.Get(ctx, idParts[1], idParts[2])
+	if err != nil {
+		res.Diagnostics.AddError(fmt.Sprintf("Error reading table: '%s'", model.Id.ValueString()), err.Error())
+		return
+	}
+	res.Diagnostics.Append(readTableModel(ctx, repository, pgModel, &model)...)
+
+	// imported tables get the defaults of the attributes that only exist in Terraform
+	if model.AllowDestructiveChanges.IsNull() {
+		model.AllowDestructiveChanges = types.BoolValue(false)
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'table' resource")
+}
+
+func (r *tableResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'table' resource")
+
+	var stateModel tableResourceModel
+	var planModel tableResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	repository := conn.TableRepository()
+	pgModel, err := repository.Update(ctx, client.TableUpdateParams{
+		Current:          stateModel.toPgModel(),
+		Desired:          planModel.toPgModel(),
+		AllowDestructive: planModel.AllowDestructiveChanges.ValueBool(),
+	})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating table", err.Error())
+		return
+	}
+	res.Diagnostics.Append(readTableModel(ctx, repository, pgModel, &planModel)...)
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'table' resource")
+}
+
+func (r *tableResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'table' resource")
+
+	var model tableResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	err = conn.TableRepository().Drop(ctx, model.Schema.ValueString(), model.Name.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("Error deleting table", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'table' resource")
+}
+
+func (r *tableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readTableModel maps the table read from the server into the target model. Types and expressions
+// keep the spelling of the target model when PostgreSQL stores an equivalent form, e.g. 'integer'
+// for 'int'.
+func readTableModel(ctx context.Context, repository client.TableRepository, pgModel *client.TableModel, target *tableResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	actual := *pgModel
+
+	if len(target.Columns) > 0 {
+		prior := target.toPgModel()
+		normalized, err := repository.Normalize(ctx, prior)
+		if err != nil {
+			// the prior definition may no longer be valid, e.g. it references a dropped type, or the
+			// role may lack the TEMPORARY privilege
+			diags.AddWarning(
+				"Unable to normalize the table definition",
+				fmt.Sprintf("The table '%s.%s' is read with the spelling of the server, the types and expressions written differently in the configuration show up as changes: %s", target.Schema.ValueString(), target.Name.ValueString(), err),
+			)
+		} else {
+			actual = client.PreserveTableSpelling(prior, *normalized, actual)
+		}
+	}
+
+	target.fromPgModel(actual)
+	return diags
+}
+
+func (rm *tableResourceModel) isPrimaryKeyColumn(name string) bool {
+	if rm.PrimaryKey == nil {
+		return false
+	}
+	return slices.Contains(mapStringValuesToSlice(rm.PrimaryKey.Columns), name)
+}
+
+func (rm *tableResourceModel) toPgModel() client.TableModel {
+	pgModel := client.TableModel{
+		Schema:      rm.Schema.ValueString(),
+		Name:        rm.Name.ValueString(),
+		Database:    rm.Database.ValueString(),
+		PartitionBy: rm.PartitionBy.ValueString(),
+		Unlogged:    rm.Unlogged.ValueBool(),
+		Comment:     rm.Comment.ValueString(),
+	}
+
+	for _, column := range rm.Columns {
+		pgModel.Columns = append(pgModel.Columns, client.TableColumn{
+			Name:      column.Name.ValueString(),
+			Type:      column.Type.ValueString(),
+			Nullable:  column.Nullable.ValueBool(),
+			Default:   column.Default.ValueString(),
+			Identity:  column.Identity.ValueString(),
+			Generated: column.Generated.ValueString(),
+		})
+	}
+	if rm.PrimaryKey != nil {
+		pgModel.PrimaryKey = &client.TablePrimaryKey{
+			Name:    rm.PrimaryKey.Name.ValueString(),
+			Columns: mapStringValuesToSlice(rm.PrimaryKey.Columns),
+		}
+	}
+	for _, unique := range rm.UniqueConstraints {
+		pgModel.UniqueConstraints = append(pgModel.UniqueConstraints, client.TableUniqueConstraint{
+			Name:    unique.Name.ValueString(),
+			Columns: mapStringValuesToSlice(unique.Columns),
+		})
+	}
+	for _, check := range rm.CheckConstraints {
+		pgModel.CheckConstraints = append(pgModel.CheckConstraints, client.TableCheckConstraint{
+			Name:       check.Name.ValueString(),
+			Expression: check.Expression.ValueString(),
+		})
+	}
+	for _, fk := range rm.ForeignKeys {
+		pgModel.ForeignKeys = append(pgModel.ForeignKeys, client.TableForeignKey{
+			Name:              fk.Name.ValueString(),
+			Columns:           mapStringValuesToSlice(fk.Columns),
+			ReferencesTable:   fk.ReferencesTable.ValueString(),
+			ReferencesColumns: mapStringValuesToSlice(fk.ReferencesColumns),
+			OnDelete:          fk.OnDelete.ValueString(),
+			OnUpdate:          fk.OnUpdate.ValueString(),
+		})
+	}
+
+	return pgModel
+}
+
+func (rm *tableResourceModel) fromPgModel(pgModel client.TableModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Schema = types.StringValue(pgModel.Schema)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.PartitionBy = stringValueOrNull(pgModel.PartitionBy)
+	rm.Unlogged = types.BoolValue(pgModel.Unlogged)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+
+	rm.Columns = nil
+	for _, column := range pgModel.Columns {
+		rm.Columns = append(rm.Columns, tableColumnModel{
+			Name:      types.StringValue(column.Name),
+			Type:      types.StringValue(column.Type),
+			Nullable:  types.BoolValue(column.Nullable),
+			Default:   stringValueOrNull(column.Default),
+			Identity:  stringValueOrNull(column.Identity),
+			Generated: stringValueOrNull(column.Generated),
+		})
+	}
+
+	rm.PrimaryKey = nil
+	if pgModel.PrimaryKey != nil {
+		rm.PrimaryKey = &tablePrimaryKeyModel{
+			Name:    types.StringValue(pgModel.PrimaryKey.Name),
+			Columns: mapSliceToStringValues(pgModel.PrimaryKey.Columns),
+		}
+	}
+
+	rm.UniqueConstraints = nil
+	for _, unique := range pgModel.UniqueConstraints {
+		rm.UniqueConstraints = append(rm.UniqueConstraints, tableUniqueConstraintModel{
+			Name:    types.StringValue(unique.Name),
+			Columns: mapSliceToStringValues(unique.Columns),
+		})
+	}
+
+	rm.CheckConstraints = nil
+	for _, check := range pgModel.CheckConstraints {
+		rm.CheckConstraints = append(rm.CheckConstraints, tableCheckConstraintModel{
+			Name:       types.StringValue(check.Name),
+			Expression: types.StringValue(check.Expression),
+		})
+	}
+
+	rm.ForeignKeys = nil
+	for _, fk := range pgModel.ForeignKeys {
+		rm.ForeignKeys = append(rm.ForeignKeys, tableForeignKeyModel{
+			Name:              types.StringValue(fk.Name),
+			Columns:           mapSliceToStringValues(fk.Columns),
+			ReferencesTable:   types.StringValue(fk.ReferencesTable),
+			ReferencesColumns: mapSliceToStringValues(fk.ReferencesColumns),
+			OnDelete:          types.StringValue(fk.OnDelete),
+			OnUpdate:          types.StringValue(fk.OnUpdate),
+		})
+	}
+}
+
+func (rm *tableResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s.%s", rm.Database.ValueString(), rm.Schema.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *tableResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
@@ -0,0 +1,164 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"regexp"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccTableResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_table_resource_db",
+		Username: "test_table_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_table"
+	mockResourceName := fmt.Sprintf("postgresql_table.%s", mockResourceId)
+	mockResourceIdentifier := fmt.Sprintf("%s.public.test_table_resource", runOpts.Database)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccTableToTFResource(t, mockResourceId, "test_table_resource", `
+					columns = [
+						{ name = "id", type = "int8", identity = "always" },
+						{ name = "code", type = "varchar(10)", nullable = false },
+						{ name = "price", type = "numeric(10, 2)", default = "0" },
+					]
+					primary_key       = { columns = ["id"] }
+					unique_constraints = [{ name = "test_table_code_key", columns = ["code"] }]
+					check_constraints = [{ name = "test_table_price_check", expression = "price >= 0" }]
+					comment = "test comment"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", mockResourceIdentifier),
+					resource.TestCheckResourceAttr(mockResourceName, "database", runOpts.Database),
+					resource.TestCheckResourceAttr(mockResourceName, "schema", "public"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.#", "3"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.0.type", "int8"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.0.nullable", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.2.type", "numeric(10, 2)"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.2.nullable", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "primary_key.name", "test_table_resource_pkey"),
+					resource.TestCheckResourceAttr(mockResourceName, "check_constraints.0.expression", "price >= 0"),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment"),
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "columns", "check_constraints"},
+			},
+			{
+				// Update testing - dropping a column is refused without the opt-in
+				Config: testAccTableToTFResource(t, mockResourceId, "test_table_resource", `
+					columns = [
+						{ name = "id", type = "int8", identity = "always" },
+						{ name = "code", type = "varchar(10)", nullable = false },
+					]
+					primary_key       = { columns = ["id"] }
+					unique_constraints = [{ name = "test_table_code_key", columns = ["code"] }]
+					comment = "test comment"`),
+				ExpectError: regexp.MustCompile("drop column 'price'"),
+			},
+			{
+				// Update testing - in place alterations
+				Config: testAccTableToTFResource(t, mockResourceId, "test_table_resource_modified", `
+					columns = [
+						{ name = "id", type = "int8", identity = "by_default" },
+						{ name = "code", type = "varchar(10)" },
+						{ name = "label", type = "text", default = "'none'" },
+					]
+					primary_key = { columns = ["id"] }
+					unlogged    = true
+					comment     = "test comment modified"
+					allow_destructive_changes = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_table_resource_modified", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.#", "3"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.0.identity", "by_default"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.1.nullable", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.2.default", "'none'"),
+					resource.TestCheckResourceAttr(mockResourceName, "primary_key.name", "test_table_resource_pkey"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "unique_constraints"),
+					resource.TestCheckResourceAttr(mockResourceName, "unlogged", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment modified"),
+				),
+			},
+			{
+				// Update testing - a column inserted in the middle keeps its position in the state
+				Config: testAccTableToTFResource(t, mockResourceId, "test_table_resource_modified", `
+					columns = [
+						{ name = "id", type = "int8", identity = "by_default" },
+						{ name = "note", type = "text" },
+						{ name = "code", type = "varchar(10)" },
+						{ name = "label", type = "text", default = "'none'" },
+					]
+					primary_key = { columns = ["id"] }
+					unlogged    = true
+					comment     = "test comment modified"
+					allow_destructive_changes = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "columns.#", "4"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.1.name", "note"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.2.name", "code"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.3.name", "label"),
+				),
+			},
+			{
+				// Update testing - a type only spelled differently is not a destructive change
+				Config: testAccTableToTFResource(t, mockResourceId, "test_table_resource_modified", `
+					columns = [
+						{ name = "id", type = "bigint", identity = "by_default" },
+						{ name = "note", type = "text" },
+						{ name = "code", type = "character varying(10)" },
+						{ name = "label", type = "text", default = "'none'" },
+					]
+					primary_key = { columns = ["id"] }
+					unlogged    = true
+					comment     = "test comment modified"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "columns.0.type", "bigint"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.2.type", "character varying(10)"),
+				),
+			},
+			{
+				// Drift testing - a table dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP TABLE public.test_table_resource_modified;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccTableToTFResource(t, mockResourceId, "test_table_resource_modified", `
+					columns = [{ name = "id", type = "bigint" }]
+					allow_destructive_changes = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_table_resource_modified", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTableToTFResource(t *testing.T, resId, name, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_table" "%s" {
+			name = "%s"
+			%s
+		}`, resId, name, body)
+}
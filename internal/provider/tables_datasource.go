@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"maps"
+	"terraform-provider-postgresql/internal/client"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &tablesDataSource{}
+	_ datasource.DataSourceWithConfigure = &tablesDataSource{}
+)
+
+type tablesDataSource struct {
+	client client.PgClient
+}
+
+type tablesDataSourceModel struct {
+	Database      types.String         `tfsdk:"database"`
+	SchemaRegex   types.String         `tfsdk:"schema_regex"`
+	NameRegex     types.String         `tfsdk:"name_regex"`
+	IncludeSystem types.Bool           `tfsdk:"include_system"`
+	Tables        []tableItemDataModel `tfsdk:"tables"`
+}
+
+type tableItemDataModel struct {
+	Schema      types.String `tfsdk:"schema"`
+	Name        types.String `tfsdk:"name"`
+	Owner       types.String `tfsdk:"owner"`
+	Partitioned types.Bool   `tfsdk:"partitioned"`
+	RowEstimate types.Int64  `tfsdk:"row_estimate"`
+	Comment     types.String `tfsdk:"comment"`
+}
+
+func NewTablesDataSource() datasource.DataSource {
+	return &tablesDataSource{}
+}
+
+func (d *tablesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, res *datasource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'tables' datasource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "Configured 'tables' datasource")
+	d.client = pgClient
+}
+
+func (d *tablesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, res *datasource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_tables"
+}
+
+func (d *tablesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, res *datasource.SchemaResponse) {
+	attributes := listFilterAttributes("tables", "the tables of `pg_catalog` and `information_schema`", true)
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"database": schema.StringAttribute{
+			Optional:            true,
+			Computed:            true,
+			MarkdownDescription: "Name of the database the tables are listed from. If not provided, the database from the provider configuration will be used.",
+			Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			},
+		},
+		"tables": schema.ListNestedAttribute{
+			Computed:            true,
+			MarkdownDescription: "The tables, ordered by schema and name",
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"schema": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The schema of the table",
+					},
+					"name": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Name of the table",
+					},
+					"owner": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "The owner of the table",
+					},
+					"partitioned": schema.BoolAttribute{
+						Computed:            true,
+						MarkdownDescription: "Whether the table is partitioned",
+					},
+					"row_estimate": schema.Int64Attribute{
+						Computed:            true,
+						MarkdownDescription: "Number of rows estimated by the planner, `-1` when the table was never analyzed (`0` before PostgreSQL 14)",
+					},
+					"comment": schema.StringAttribute{
+						Computed:            true,
+						MarkdownDescription: "Comment associated with the table",
+					},
+				},
+			},
+		},
+	})
+
+	res.Schema = schema.Schema{
+		Attributes:          attributes,
+		MarkdownDescription: mdDocDataSourceTables,
+	}
+}
+
+func (d *tablesDataSource) Read(ctx context.Context, req datasource.ReadRequest, res *datasource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'tables' datasource")
+
+	var model tablesDataSourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() {
+		model.Database = types.StringValue(d.client.GetInitConfig().Database)
+	}
+
+	conn, err := d.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	tables, err := conn.DiscoveryRepository().ListTables(ctx, toListFilter(model.NameRegex, model.SchemaRegex, model.IncludeSystem))
+	if err != nil {
+		res.Diagnostics.AddError("Error listing tables", err.Error())
+		return
+	}
+
+	model.Tables = make([]tableItemDataModel, len(tables))
+	for i, table := range tables {
+		model.Tables[i] = tableItemDataModel{
+			Schema:      types.StringValue(table.Schema),
+			Name:        types.StringValue(table.Name),
+			Owner:       types.StringValue(table.Owner),
+			Partitioned: types.BoolValue(table.Partitioned),
+			RowEstimate: types.Int64Value(table.RowEstimate),
+			Comment:     stringValueOrNull(table.Comment),
+		}
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'tables' datasource")
+}
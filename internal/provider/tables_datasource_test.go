@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccTablesDataSource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_tables_datasource_db",
+		Username: "test_tables_datasource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_tables"
+	mockResourceName := fmt.Sprintf("data.postgresql_tables.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `
+				CREATE SCHEMA reporting;
+				CREATE TABLE reporting.daily (day date);
+				COMMENT ON TABLE reporting.daily IS 'daily report';
+				CREATE TABLE public.events (created_at date) PARTITION BY RANGE (created_at);
+				CREATE TABLE public.events_2026 PARTITION OF public.events FOR VALUES FROM ('2026-01-01') TO ('2027-01-01');
+				CREATE TABLE public.users (id int);
+				INSERT INTO public.users SELECT generate_series(1, 10);
+				ANALYZE public.users;`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Every table, without the partitions
+				Config: testAccTablesToTFDataSource(t, mockResourceId, ``),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "database", runOpts.Database),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.#", "3"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.0.schema", "public"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.0.name", "events"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.0.owner", runOpts.Username),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.0.partitioned", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.1.name", "users"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.1.partitioned", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.1.row_estimate", "10"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "tables.1.comment"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.2.schema", "reporting"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.2.name", "daily"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.2.comment", "daily report"),
+				),
+			},
+			{
+				// Selected by schema and name
+				Config: testAccTablesToTFDataSource(t, mockResourceId, `
+					schema_regex = "^public$"
+					name_regex   = "^us"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "tables.#", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.0.name", "users"),
+				),
+			},
+			{
+				// With the system tables
+				Config: testAccTablesToTFDataSource(t, mockResourceId, `
+					schema_regex   = "^pg_catalog$"
+					name_regex     = "^pg_class$"
+					include_system = true`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "tables.#", "1"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.0.schema", "pg_catalog"),
+					resource.TestCheckResourceAttr(mockResourceName, "tables.0.name", "pg_class"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTablesToTFDataSource(t *testing.T, resName, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`data "postgresql_tables" "%s" {
+			%s
+		}`, resName, body)
+}
@@ -0,0 +1,387 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"regexp"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type tablespaceResource struct {
+	client client.PgClient
+}
+
+type tablespaceResourceModel struct {
+	Id                     types.String  `tfsdk:"id"`
+	LastUpdated            types.String  `tfsdk:"last_updated"`
+	Name                   types.String  `tfsdk:"name"`
+	Location               types.String  `tfsdk:"location"`
+	Owner                  types.String  `tfsdk:"owner"`
+	SeqPageCost            types.Float64 `tfsdk:"seq_page_cost"`
+	RandomPageCost         types.Float64 `tfsdk:"random_page_cost"`
+	EffectiveIoConcurrency types.Int64   `tfsdk:"effective_io_concurrency"`
+	Comment                types.String  `tfsdk:"comment"`
+	AssumeRole             types.String  `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                = &tablespaceResource{}
+	_ resource.ResourceWithConfigure   = &tablespaceResource{}
+	_ resource.ResourceWithImportState = &tablespaceResource{}
+	_ resource.ResourceWithModifyPlan  = &tablespaceResource{}
+)
+
+func NewTablespaceResource() resource.Resource {
+	return &tablespaceResource{}
+}
+
+func (r *tablespaceResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'tablespace' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *tablespaceResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_tablespace"
+}
+
+func (r *tablespaceResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+	pageCost := []validator.Float64{
+		float64validator.AtLeast(0),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the tablespace, its name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the tablespace",
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the tablespace. Changes rename the tablespace in place.",
+				Validators:          nonEmptyString,
+			},
+			"location": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Absolute path of the directory of the tablespace, on the server. It must exist, be empty and be owned by the PostgreSQL system user. Changing it creates the tablespace again.",
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^/`), "must be an absolute path"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The owner of the tablespace. If not provided, the tablespace is owned by the role that creates it (see `assume_role`).",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"seq_page_cost": schema.Float64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Planner cost of a sequentially fetched page of the relations in the tablespace. Overrides the `seq_page_cost` server setting.",
+				Validators:          pageCost,
+			},
+			"random_page_cost": schema.Float64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Planner cost of a non-sequentially fetched page of the relations in the tablespace. Overrides the `random_page_cost` server setting.",
+				Validators:          pageCost,
+			},
+			"effective_io_concurrency": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Number of concurrent disk I/O operations expected on the tablespace. Overrides the `effective_io_concurrency` server setting.",
+				Validators: []validator.Int64{
+					int64validator.Between(0, 1000),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the tablespace",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET ROLE`) while managing the tablespace. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceTablespace,
+	}
+}
+
+func (r *tablespaceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on create or destroy
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var model, stateModel tablespaceResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// the identifier is the name, a rename produces a new one
+	if !model.Name.Equal(stateModel.Name) {
+		res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+}
+
+func (r *tablespaceResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'tablespace' resource")
+
+	var model tablespaceResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// tablespaces are global to the server, any database connection manages them
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	role := resolveAssumeRole(r.client, model.AssumeRole)
+	ctx = client.ContextWithAssumeRole(ctx, role)
+
+	// the tablespace is owned by the assumed role, unless another owner is explicitly requested
+	expectedOwner := role
+	if !model.Owner.IsNull() && !model.Owner.IsUnknown() {
+		expectedOwner = model.Owner.ValueString()
+	}
+
+	pgModel := model.toPgModel()
+	pgModel.Owner = expectedOwner
+
+	repository := conn.TablespaceRepository()
+	if err = repository.Create(ctx, pgModel); err != nil {
+		res.Diagnostics.AddError("Error creating tablespace", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readTablespaceModel(ctx, repository, model.Name.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("tablespace", expectedOwner, model.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'tablespace' resource")
+}
+
+func (r *tablespaceResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'tablespace' resource")
+
+	var model tablespaceResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the tablespace", "Id is required for reading tablespace")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a tablespace dropped outside of Terraform is removed from the state and planned again
+	repository := conn.TablespaceRepository()
+	exists, err := repository.Exists(ctx, model.Id.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("Error reading tablespace", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Tablespace not found, removing it from the state", map[string]any{"tablespace": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readTablespaceModel(ctx, repository, model.Id.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'tablespace' resource")
+}
+
+func (r *tablespaceResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'tablespace' resource")
+
+	var stateModel tablespaceResourceModel
+	var planModel tablespaceResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	current := stateModel.toPgModel()
+	desired := planModel.toPgModel()
+	if planModel.Owner.IsUnknown() {
+		desired.Owner = current.Owner
+	}
+
+	repository := conn.TablespaceRepository()
+	_, err = repository.Update(ctx, client.TablespaceUpdateParams{Current: current, Desired: desired})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating tablespace", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readTablespaceModel(ctx, repository, planModel.Name.ValueString(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("tablespace", desired.Owner, planModel.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'tablespace' resource")
+}
+
+func (r *tablespaceResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'tablespace' resource")
+
+	var model tablespaceResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, r.client.GetInitConfig().Database)
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	if err = conn.TablespaceRepository().Drop(ctx, model.Name.ValueString()); err != nil {
+		res.Diagnostics.AddError("Error deleting tablespace", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'tablespace' resource")
+}
+
+func (r *tablespaceResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+func readTablespaceModel(ctx context.Context, repository client.TablespaceRepository, name string, target *tablespaceResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading tablespace: '%s'", name), err.Error())
+		return diags
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *tablespaceResourceModel) toPgModel() client.TablespaceModel {
+	return client.TablespaceModel{
+		Name:                   rm.Name.ValueString(),
+		Location:               rm.Location.ValueString(),
+		Owner:                  rm.Owner.ValueString(),
+		SeqPageCost:            knownFloat64Pointer(rm.SeqPageCost),
+		RandomPageCost:         knownFloat64Pointer(rm.RandomPageCost),
+		EffectiveIoConcurrency: knownInt64Pointer(rm.EffectiveIoConcurrency),
+		Comment:                rm.Comment.ValueString(),
+	}
+}
+
+func (rm *tablespaceResourceModel) fromPgModel(pgModel client.TablespaceModel) {
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Location = types.StringValue(pgModel.Location)
+	rm.Owner = types.StringValue(pgModel.Owner)
+	rm.SeqPageCost = float64ValueOrNull(pgModel.SeqPageCost)
+	rm.RandomPageCost = float64ValueOrNull(pgModel.RandomPageCost)
+	rm.EffectiveIoConcurrency = int64ValueOrNull(pgModel.EffectiveIoConcurrency)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+}
+
+func (rm *tablespaceResourceModel) SetId() {
+	rm.Id = types.StringValue(rm.Name.ValueString())
+}
+
+func (rm *tablespaceResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"regexp"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccTablespaceResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database:     "test_tablespace_resource_db",
+		Username:     "test_tablespace_resource_user",
+		WritableDirs: []string{"/mnt/tablespaces/hot"},
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_tablespace"
+	mockResourceName := fmt.Sprintf("postgresql_tablespace.%s", mockResourceId)
+	// the index is placed in the tablespace by reference
+	index := `
+		resource "postgresql_index" "test_tablespace_index" {
+			name       = "test_tablespace_resource_idx"
+			table      = "test_tablespace_resource_table"
+			keys       = [{ column = "id" }]
+			tablespace = postgresql_tablespace.test_tablespace.name
+		}`
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `
+				CREATE TABLE public.test_tablespace_resource_table (id int);
+				CREATE ROLE test_tablespace_owner;`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccTablespaceToTFResource(t, mockResourceId, "test_tablespace_resource", `
+					random_page_cost         = 1.1
+					effective_io_concurrency = 200
+					comment                  = "test comment"`) + index,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", "test_tablespace_resource"),
+					resource.TestCheckResourceAttr(mockResourceName, "location", "/mnt/tablespaces/hot"),
+					resource.TestCheckResourceAttr(mockResourceName, "owner", runOpts.Username),
+					resource.TestCheckResourceAttr(mockResourceName, "random_page_cost", "1.1"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "seq_page_cost"),
+					resource.TestCheckResourceAttr(mockResourceName, "effective_io_concurrency", "200"),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment"),
+					resource.TestCheckResourceAttr("postgresql_index.test_tablespace_index", "tablespace", "test_tablespace_resource"),
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				// Update testing - Properties without re-creating the resource
+				Config: testAccTablespaceToTFResource(t, mockResourceId, "test_tablespace_resource_renamed", `
+					seq_page_cost = 0.5
+					owner         = "test_tablespace_owner"`) + index,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", "test_tablespace_resource_renamed"),
+					resource.TestCheckResourceAttr(mockResourceName, "seq_page_cost", "0.5"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "random_page_cost"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "effective_io_concurrency"),
+					resource.TestCheckResourceAttr(mockResourceName, "owner", "test_tablespace_owner"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "comment"),
+					resource.TestCheckResourceAttr("postgresql_index.test_tablespace_index", "tablespace", "test_tablespace_resource_renamed"),
+				),
+			},
+			{
+				// Delete testing - Refused while a table is still stored in the tablespace
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `CREATE TABLE public.test_tablespace_archive (id int) TABLESPACE test_tablespace_resource_renamed;`)
+					assert.NoError(t, err)
+				},
+				Config:      `# every resource destroyed`,
+				ExpectError: regexp.MustCompile(`the tablespace is not empty: 'test_tablespace_resource_renamed' still holds table public.test_tablespace_archive`),
+			},
+			{
+				// the tablespace is destroyed at the end of the test, once empty
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP TABLE public.test_tablespace_archive;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccTablespaceToTFResource(t, mockResourceId, "test_tablespace_resource_renamed", `
+					seq_page_cost = 0.5
+					owner         = "test_tablespace_owner"`),
+			},
+			{
+				// Drift testing - a tablespace dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP TABLESPACE test_tablespace_resource_renamed;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccTablespaceToTFResource(t, mockResourceId, "test_tablespace_resource_renamed", `
+					seq_page_cost = 0.5
+					owner         = "test_tablespace_owner"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(mockResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTablespaceToTFResource(t *testing.T, resId, name, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_tablespace" "%s" {
+			name     = "%s"
+			location = "/mnt/tablespaces/hot"
+			%s
+		}`, resId, name, body)
+}
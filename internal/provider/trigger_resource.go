@@ -0,0 +1,574 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"slices"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type triggerResource struct {
+	client client.PgClient
+}
+
+type triggerResourceModel struct {
+	Id                types.String   `tfsdk:"id"`
+	LastUpdated       types.String   `tfsdk:"last_updated"`
+	Database          types.String   `tfsdk:"database"`
+	Schema            types.String   `tfsdk:"schema"`
+	Table             types.String   `tfsdk:"table"`
+	Name              types.String   `tfsdk:"name"`
+	Timing            types.String   `tfsdk:"timing"`
+	Events            []types.String `tfsdk:"events"`
+	UpdateColumns     []types.String `tfsdk:"update_columns"`
+	Level             types.String   `tfsdk:"level"`
+	When              types.String   `tfsdk:"when"`
+	OldTable          types.String   `tfsdk:"old_table"`
+	NewTable          types.String   `tfsdk:"new_table"`
+	Constraint        types.Bool     `tfsdk:"constraint"`
+	Deferrable        types.Bool     `tfsdk:"deferrable"`
+	InitiallyDeferred types.Bool     `tfsdk:"initially_deferred"`
+	Function          types.String   `tfsdk:"function"`
+	Arguments         []types.String `tfsdk:"arguments"`
+	FiringMode        types.String   `tfsdk:"firing_mode"`
+	Comment           types.String   `tfsdk:"comment"`
+	AssumeRole        types.String   `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                   = &triggerResource{}
+	_ resource.ResourceWithConfigure      = &triggerResource{}
+	_ resource.ResourceWithImportState    = &triggerResource{}
+	_ resource.ResourceWithValidateConfig = &triggerResource{}
+	_ resource.ResourceWithModifyPlan     = &triggerResource{}
+)
+
+func NewTriggerResource() resource.Resource {
+	return &triggerResource{}
+}
+
+func (r *triggerResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'trigger' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *triggerResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_trigger"
+}
+
+func (r *triggerResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+	requiresReplace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+	boolRequiresReplace := []planmodifier.Bool{
+		boolplanmodifier.RequiresReplace(),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the trigger, in the format `database_name.schema_name.table_name.trigger_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the trigger",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the trigger is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("public"),
+				MarkdownDescription: "Schema of the table, triggers always live in the schema of their table",
+				PlanModifiers:       requiresReplace,
+			},
+			"table": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the table, view or foreign table the trigger is attached to",
+				Validators:          nonEmptyString,
+				PlanModifiers:       requiresReplace,
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the trigger, unique among the triggers of the table. Changes rename the trigger in place.",
+				Validators:          nonEmptyString,
+			},
+			"timing": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "When the function is called: `BEFORE`, `AFTER` or `INSTEAD OF` the event. `INSTEAD OF` is only valid for views.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.TriggerTimings...),
+				},
+				PlanModifiers: requiresReplace,
+			},
+			"events": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Events firing the trigger: `INSERT`, `UPDATE`, `DELETE` or `TRUNCATE`",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(client.TriggerEvents...)),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"update_columns": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Columns limiting the `UPDATE` event (`UPDATE OF`), the trigger only fires when one of them is a target of the statement",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"level": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(client.TriggerForEachStatement),
+				MarkdownDescription: "Whether the function is called once for every affected `ROW`, or once per `STATEMENT`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.TriggerForEachRow, client.TriggerForEachStatement),
+				},
+				PlanModifiers: requiresReplace,
+			},
+			"when": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Boolean condition deciding whether the function is called, it may reference `OLD` and `NEW` in row level triggers",
+				Validators:          nonEmptyString,
+				PlanModifiers:       requiresReplace,
+			},
+			"old_table": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the transition table with the rows before the statement (`REFERENCING OLD TABLE`), only for `AFTER` triggers on `UPDATE` or `DELETE`",
+				Validators:          nonEmptyString,
+				PlanModifiers:       requiresReplace,
+			},
+			"new_table": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the transition table with the rows after the statement (`REFERENCING NEW TABLE`), only for `AFTER` triggers on `INSERT` or `UPDATE`",
+				Validators:          nonEmptyString,
+				PlanModifiers:       requiresReplace,
+			},
+			"constraint": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether it's a constraint trigger, which can be deferred. Constraint triggers must be `AFTER` and `FOR EACH ROW`.",
+				PlanModifiers:       boolRequiresReplace,
+			},
+			"deferrable": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the constraint trigger can be deferred to the end of the transaction",
+				PlanModifiers:       boolRequiresReplace,
+			},
+			"initially_deferred": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the deferrable constraint trigger is deferred by default",
+				PlanModifiers:       boolRequiresReplace,
+			},
+			"function": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Function called by the trigger, optionally schema qualified. It must return `trigger`.",
+				Validators:          nonEmptyString,
+				PlanModifiers:       requiresReplace,
+			},
+			"arguments": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Arguments passed to the function as string literals, in `TG_ARGV`",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"firing_mode": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(client.FiringModeOrigin),
+				MarkdownDescription: "The `session_replication_role` settings the trigger fires with: `origin` (and `local`), `replica`, `always` or `disabled`. Changes are applied in place, but not on views.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(client.FiringModes...),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the trigger",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the trigger. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceTrigger,
+	}
+}
+
+func (r *triggerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, res *resource.ValidateConfigResponse) {
+	var model triggerResourceModel
+
+	res.Diagnostics.Append(req.Config.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	invalid := func(attr, detail string) {
+		res.Diagnostics.AddAttributeError(path.Root(attr), "Invalid trigger definition", detail)
+	}
+	hasEvent := func(events ...string) bool {
+		return slices.ContainsFunc(model.Events, func(event types.String) bool {
+			return slices.Contains(events, event.ValueString())
+		})
+	}
+	timing, level := model.Timing.ValueString(), model.Level.ValueString()
+	timingKnown := !model.Timing.IsUnknown() && !model.Timing.IsNull()
+	levelKnown := !model.Level.IsUnknown() && !model.Level.IsNull()
+
+	if len(model.UpdateColumns) > 0 && !hasEvent(client.TriggerEventUpdate) {
+		invalid("update_columns", "The update_columns require the UPDATE event.")
+	}
+	if timingKnown && timing == client.TriggerTimingInsteadOf {
+		if levelKnown && level != client.TriggerForEachRow {
+			invalid("level", "INSTEAD OF triggers must be FOR EACH ROW.")
+		}
+		if !model.When.IsNull() {
+			invalid("when", "INSTEAD OF triggers can't have a WHEN condition.")
+		}
+		if len(model.UpdateColumns) > 0 {
+			invalid("update_columns", "INSTEAD OF triggers can't have update_columns.")
+		}
+	}
+	if levelKnown && level == client.TriggerForEachRow && hasEvent(client.TriggerEventTruncate) {
+		invalid("events", "TRUNCATE triggers must be FOR EACH STATEMENT.")
+	}
+	if model.Constraint.ValueBool() {
+		if (timingKnown && timing != client.TriggerTimingAfter) || (levelKnown && level != client.TriggerForEachRow) {
+			invalid("constraint", "Constraint triggers must be AFTER and FOR EACH ROW.")
+		}
+	} else if model.Deferrable.ValueBool() {
+		invalid("deferrable", "Only constraint triggers can be deferrable.")
+	}
+	if model.InitiallyDeferred.ValueBool() && !model.Deferrable.ValueBool() {
+		invalid("initially_deferred", "Only deferrable constraint triggers can be initially deferred.")
+	}
+	if timingKnown && timing != client.TriggerTimingAfter && (!model.OldTable.IsNull() || !model.NewTable.IsNull()) {
+		invalid("timing", "Transition tables (old_table, new_table) require an AFTER trigger.")
+	}
+	if !model.OldTable.IsNull() && !hasEvent(client.TriggerEventUpdate, client.TriggerEventDelete) {
+		invalid("old_table", "The old_table requires the UPDATE or DELETE event.")
+	}
+	if !model.NewTable.IsNull() && !hasEvent(client.TriggerEventInsert, client.TriggerEventUpdate) {
+		invalid("new_table", "The new_table requires the INSERT or UPDATE event.")
+	}
+}
+
+func (r *triggerResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on create or destroy
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var model, stateModel triggerResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	// the identifier is derived from the name, a rename produces a new one
+	if !model.Name.Equal(stateModel.Name) {
+		res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+}
+
+func (r *triggerResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'trigger' resource")
+
+	var model triggerResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	repository := conn.TriggerRepository()
+	if err = repository.Create(ctx, model.toPgModel()); err != nil {
+		res.Diagnostics.AddError("Error creating trigger", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readTriggerModel(ctx, repository, model.Schema.ValueString(), model.Table.ValueString(), model.Name.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'trigger' resource")
+}
+
+func (r *triggerResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'trigger' resource")
+
+	var model triggerResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the trigger", "Id is required for reading trigger")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 4)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the trigger", "Id should be in the format 'database_name.schema_name.table_name.trigger_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a trigger dropped outside of Terraform is removed from the state and planned again
+	repository := conn.TriggerRepository()
+	exists, err := repository.Exists(ctx, idParts[1], idParts[2], idParts[3])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading trigger", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Trigger not found, removing it from the state", map[string]any{"trigger": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readTriggerModel(ctx, repository, idParts[1], idParts[2], idParts[3], &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'trigger' resource")
+}
+
+func (r *triggerResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'trigger' resource")
+
+	var stateModel triggerResourceModel
+	var planModel triggerResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	repository := conn.TriggerRepository()
+	_, err = repository.Update(ctx, client.TriggerUpdateParams{Current: stateModel.toPgModel(), Desired: planModel.toPgModel()})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating trigger", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readTriggerModel(ctx, repository, planModel.Schema.ValueString(), planModel.Table.ValueString(), planModel.Name.ValueString(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'trigger' resource")
+}
+
+func (r *triggerResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'trigger' resource")
+
+	var model triggerResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	err = conn.TriggerRepository().Drop(ctx, model.Schema.ValueString(), model.Table.ValueString(), model.Name.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("Error deleting trigger", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'trigger' resource")
+}
+
+func (r *triggerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readTriggerModel reads the trigger into the target model, keeping the spelling of the condition
+// and function of the target when PostgreSQL normalizes them to the same definition.
+func readTriggerModel(ctx context.Context, repository client.TriggerRepository, schema, table, name string, target *triggerResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, schema, table, name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading trigger: '%s' on '%s.%s'", name, schema, table), err.Error())
+		return diags
+	}
+
+	if !target.Function.IsNull() && !target.Function.IsUnknown() {
+		prior := target.toPgModel()
+		normalized, err := repository.Normalize(ctx, prior)
+		if err != nil {
+			// the prior definition may no longer be valid, e.g. the function was dropped
+			tflog.Warn(ctx, "Unable to normalize the trigger definition, using the server spelling", map[string]any{"error": err.Error()})
+		} else {
+			*actual = client.PreserveTriggerSpelling(prior, *normalized, *actual)
+		}
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *triggerResourceModel) toPgModel() client.TriggerModel {
+	return client.TriggerModel{
+		Schema:            rm.Schema.ValueString(),
+		Table:             rm.Table.ValueString(),
+		Name:              rm.Name.ValueString(),
+		Database:          rm.Database.ValueString(),
+		Timing:            rm.Timing.ValueString(),
+		Events:            mapStringValuesToSlice(rm.Events),
+		UpdateColumns:     mapStringValuesToSlice(rm.UpdateColumns),
+		ForEach:           rm.Level.ValueString(),
+		When:              rm.When.ValueString(),
+		OldTable:          rm.OldTable.ValueString(),
+		NewTable:          rm.NewTable.ValueString(),
+		Constraint:        rm.Constraint.ValueBool(),
+		Deferrable:        rm.Deferrable.ValueBool(),
+		InitiallyDeferred: rm.InitiallyDeferred.ValueBool(),
+		Function:          rm.Function.ValueString(),
+		Arguments:         mapStringValuesToSlice(rm.Arguments),
+		FiringMode:        rm.FiringMode.ValueString(),
+		Comment:           rm.Comment.ValueString(),
+	}
+}
+
+func (rm *triggerResourceModel) fromPgModel(pgModel client.TriggerModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Schema = types.StringValue(pgModel.Schema)
+	rm.Table = types.StringValue(pgModel.Table)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Timing = types.StringValue(pgModel.Timing)
+	rm.Events = mapSliceToStringValues(pgModel.Events)
+	rm.UpdateColumns = mapSliceToStringValues(pgModel.UpdateColumns)
+	rm.Level = types.StringValue(pgModel.ForEach)
+	rm.When = stringValueOrNull(pgModel.When)
+	rm.OldTable = stringValueOrNull(pgModel.OldTable)
+	rm.NewTable = stringValueOrNull(pgModel.NewTable)
+	rm.Constraint = types.BoolValue(pgModel.Constraint)
+	rm.Deferrable = types.BoolValue(pgModel.Deferrable)
+	rm.InitiallyDeferred = types.BoolValue(pgModel.InitiallyDeferred)
+	rm.Function = types.StringValue(pgModel.Function)
+	rm.Arguments = mapSliceToStringValues(pgModel.Arguments)
+	rm.FiringMode = types.StringValue(pgModel.FiringMode)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+}
+
+func (rm *triggerResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s.%s.%s", rm.Database.ValueString(), rm.Schema.ValueString(), rm.Table.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *triggerResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
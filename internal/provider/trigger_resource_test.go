@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccTriggerResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_trigger_resource_db",
+		Username: "test_trigger_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_trigger"
+	mockResourceName := fmt.Sprintf("postgresql_trigger.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `
+				CREATE TABLE public.test_trigger_resource_table (id int, status text);
+				CREATE FUNCTION public.test_trigger_resource_func() RETURNS trigger LANGUAGE plpgsql AS $$ BEGIN RETURN NEW; END $$;`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccTriggerToTFResource(t, mockResourceId, "test_trigger_resource", `
+					firing_mode = "replica"
+					comment     = "test comment"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_trigger_resource_table.test_trigger_resource", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "timing", "BEFORE"),
+					resource.TestCheckResourceAttr(mockResourceName, "events.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "update_columns.0", "status"),
+					resource.TestCheckResourceAttr(mockResourceName, "level", "ROW"),
+					resource.TestCheckResourceAttr(mockResourceName, "when", "NEW.status IS NOT NULL"),
+					resource.TestCheckResourceAttr(mockResourceName, "function", "test_trigger_resource_func"),
+					resource.TestCheckResourceAttr(mockResourceName, "arguments.0", "audit"),
+					resource.TestCheckResourceAttr(mockResourceName, "firing_mode", "replica"),
+				),
+			},
+			{
+				// ImportState testing, the server spelling is used without a prior state
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "when", "function"},
+			},
+			{
+				// Update testing - Properties without re-creating the resource
+				Config: testAccTriggerToTFResource(t, mockResourceId, "test_trigger_resource_renamed", `
+					firing_mode = "disabled"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_trigger_resource_table.test_trigger_resource_renamed", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "firing_mode", "disabled"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "comment"),
+				),
+			},
+			{
+				// Drift testing - a trigger dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP TRIGGER test_trigger_resource_renamed ON public.test_trigger_resource_table;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccTriggerToTFResource(t, mockResourceId, "test_trigger_resource_renamed", `
+					firing_mode = "disabled"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(mockResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTriggerToTFResource(t *testing.T, resId, name, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_trigger" "%s" {
+			name           = "%s"
+			table          = "test_trigger_resource_table"
+			timing         = "BEFORE"
+			events         = ["INSERT", "UPDATE"]
+			update_columns = ["status"]
+			level          = "ROW"
+			when           = "NEW.status IS NOT NULL"
+			function       = "test_trigger_resource_func"
+			arguments      = ["audit"]
+			%s
+		}`, resId, name, body)
+}
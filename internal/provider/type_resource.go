@@ -0,0 +1,660 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type typeResource struct {
+	client client.PgClient
+}
+
+type typeResourceModel struct {
+	Id          types.String        `tfsdk:"id"`
+	LastUpdated types.String        `tfsdk:"last_updated"`
+	Database    types.String        `tfsdk:"database"`
+	Schema      types.String        `tfsdk:"schema"`
+	Name        types.String        `tfsdk:"name"`
+	Enum        *typeEnumModel      `tfsdk:"enum"`
+	Composite   *typeCompositeModel `tfsdk:"composite"`
+	Range       *typeRangeModel     `tfsdk:"range"`
+	Domain      *typeDomainModel    `tfsdk:"domain"`
+	Owner       types.String        `tfsdk:"owner"`
+	Comment     types.String        `tfsdk:"comment"`
+	AssumeRole  types.String        `tfsdk:"assume_role"`
+}
+
+type typeEnumModel struct {
+	Values []types.String `tfsdk:"values"`
+}
+
+type typeCompositeModel struct {
+	Attributes []typeAttributeModel `tfsdk:"attributes"`
+}
+
+type typeAttributeModel struct {
+	Name types.String `tfsdk:"name"`
+	Type types.String `tfsdk:"type"`
+}
+
+type typeRangeModel struct {
+	Subtype        types.String `tfsdk:"subtype"`
+	SubtypeOpclass types.String `tfsdk:"subtype_opclass"`
+	SubtypeDiff    types.String `tfsdk:"subtype_diff"`
+}
+
+type typeDomainModel struct {
+	BaseType         types.String                `tfsdk:"base_type"`
+	Default          types.String                `tfsdk:"default"`
+	NotNull          types.Bool                  `tfsdk:"not_null"`
+	CheckConstraints []tableCheckConstraintModel `tfsdk:"check_constraints"`
+}
+
+var (
+	_ resource.Resource                = &typeResource{}
+	_ resource.ResourceWithConfigure   = &typeResource{}
+	_ resource.ResourceWithImportState = &typeResource{}
+	_ resource.ResourceWithModifyPlan  = &typeResource{}
+)
+
+func NewTypeResource() resource.Resource {
+	return &typeResource{}
+}
+
+func (r *typeResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'type' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *typeResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_type"
+}
+
+func (r *typeResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the type, in the format `database_name.schema_name.type_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the type",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the type is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("public"),
+				MarkdownDescription: "Schema where the type is located. Changes move the type in place.",
+				Validators:          nonEmptyString,
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the type",
+				Validators:          nonEmptyString,
+			},
+			"enum": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Definition of an enum type. Exactly one of `enum`, `composite`, `range` or `domain` must be provided.",
+				Attributes: map[string]schema.Attribute{
+					"values": schema.ListAttribute{
+						Required:            true,
+						ElementType:         types.StringType,
+						MarkdownDescription: "Ordered labels of the enum. New labels are added in place at their position; labels can't be removed or reordered.",
+						Validators: []validator.List{
+							listvalidator.UniqueValues(),
+							listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+						},
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ExactlyOneOf(path.MatchRoot("composite"), path.MatchRoot("range"), path.MatchRoot("domain")),
+				},
+			},
+			"composite": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Definition of a composite type",
+				Attributes: map[string]schema.Attribute{
+					"attributes": schema.ListNestedAttribute{
+						Required:            true,
+						MarkdownDescription: "Attributes of the composite type. They are dropped, added and changed in place; new attributes must go after the existing ones, otherwise the type is replaced.",
+						Validators: []validator.List{
+							listvalidator.SizeAtLeast(1),
+						},
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									Required:            true,
+									MarkdownDescription: "Name of the attribute",
+									Validators:          nonEmptyString,
+								},
+								"type": schema.StringAttribute{
+									Required:            true,
+									MarkdownDescription: "Data type of the attribute",
+									Validators:          nonEmptyString,
+								},
+							},
+						},
+					},
+				},
+			},
+			"range": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Definition of a range type. Any change replaces the type.",
+				Attributes: map[string]schema.Attribute{
+					"subtype": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Element type of the range",
+						Validators:          nonEmptyString,
+					},
+					"subtype_opclass": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "B-tree operator class of the subtype. If not provided, the default operator class of the subtype.",
+						Validators:          nonEmptyString,
+					},
+					"subtype_diff": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Function returning the difference between two subtype values as `double precision`, used by GiST indexes",
+						Validators:          nonEmptyString,
+					},
+				},
+			},
+			"domain": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Definition of a domain. Changing the base type replaces the domain.",
+				Attributes: map[string]schema.Attribute{
+					"base_type": schema.StringAttribute{
+						Required:            true,
+						MarkdownDescription: "Underlying data type of the domain",
+						Validators:          nonEmptyString,
+					},
+					"default": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Default value expression of the domain",
+						Validators:          nonEmptyString,
+					},
+					"not_null": schema.BoolAttribute{
+						Optional:            true,
+						Computed:            true,
+						Default:             booldefault.StaticBool(false),
+						MarkdownDescription: "Whether the values of the domain can't be null",
+					},
+					"check_constraints": schema.ListNestedAttribute{
+						Optional:            true,
+						MarkdownDescription: "Check constraints of the domain, a changed constraint is dropped and added again",
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"name": schema.StringAttribute{
+									Required:            true,
+									MarkdownDescription: "Name of the constraint",
+									Validators:          nonEmptyString,
+								},
+								"expression": schema.StringAttribute{
+									Required:            true,
+									MarkdownDescription: "Boolean SQL expression that every value must satisfy, referencing the value as `VALUE`",
+									Validators:          nonEmptyString,
+								},
+							},
+						},
+					},
+				},
+			},
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The owner of the type. If not provided, the type is owned by the role that creates it (see `assume_role`).",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the type",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the type. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceType,
+	}
+}
+
+func (r *typeResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on create or destroy
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var model, stateModel typeResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	if !model.isKnown() {
+		return
+	}
+
+	desired := model.toPgModel()
+	_, err := client.PlanTypeAlterations(stateModel.toPgModel(), desired)
+	switch {
+	case errors.Is(err, client.ErrTypeRequiresReplace):
+		res.RequiresReplace = append(res.RequiresReplace, path.Root(desired.Kind))
+	case err != nil && desired.Kind == client.TypeKindEnum:
+		res.Diagnostics.AddAttributeError(
+			path.Root("enum").AtName("values"),
+			"Unsupported enum change",
+			fmt.Sprintf("%s. Add the new values without changing the existing ones, or create a new type.", err),
+		)
+	case err != nil:
+		res.Diagnostics.AddAttributeError(path.Root(desired.Kind), "Unsupported type change", err.Error())
+	}
+
+	// the identifier is derived from the schema and name, moving the type produces a new one
+	if !model.Name.Equal(stateModel.Name) || !model.Schema.Equal(stateModel.Schema) {
+		res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+	}
+}
+
+func (r *typeResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'type' resource")
+
+	var model typeResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	role := resolveAssumeRole(r.client, model.AssumeRole)
+	ctx = client.ContextWithAssumeRole(ctx, role)
+
+	// the type is owned by the assumed role, unless another owner is explicitly requested
+	expectedOwner := role
+	if !model.Owner.IsNull() && !model.Owner.IsUnknown() {
+		expectedOwner = model.Owner.ValueString()
+	}
+
+	pgModel := model.toPgModel()
+	pgModel.Owner = expectedOwner
+
+	repository := conn.TypeRepository()
+	if err = repository.Create(ctx, pgModel); err != nil {
+		res.Diagnostics.AddError("Error creating type", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readTypeModel(ctx, repository, model.Schema.ValueString(), model.Name.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("type", expectedOwner, model.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'type' resource")
+}
+
+func (r *typeResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'type' resource")
+
+	var model typeResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the type", "Id is required for reading type")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 3)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the type", "Id should be in the format 'database_name.schema_name.type_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a type dropped outside of Terraform is removed from the state and planned again
+	repository := conn.TypeRepository()
+	exists, err := repository.Exists(ctx, idParts[1], idParts[2])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading type", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "Type not found, removing it from the state", map[string]any{"type": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readTypeModel(ctx, repository, idParts[1], idParts[2], &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'type' resource")
+}
+
+func (r *typeResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'type' resource")
+
+	var stateModel typeResourceModel
+	var planModel typeResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	current := stateModel.toPgModel()
+	desired := planModel.toPgModel()
+	if planModel.Owner.IsUnknown() {
+		desired.Owner = current.Owner
+	}
+
+	repository := conn.TypeRepository()
+	_, err = repository.Update(ctx, client.TypeUpdateParams{Current: current, Desired: desired})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating type", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readTypeModel(ctx, repository, planModel.Schema.ValueString(), planModel.Name.ValueString(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("type", desired.Owner, planModel.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'type' resource")
+}
+
+func (r *typeResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'type' resource")
+
+	var model typeResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	err = conn.TypeRepository().Drop(ctx, model.Schema.ValueString(), model.Name.ValueString(), model.kind())
+	if err != nil {
+		res.Diagnostics.AddError("Error deleting type", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'type' resource")
+}
+
+func (r *typeResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readTypeModel reads the type into the target model, keeping the spelling of the types and
+// expressions of the target when PostgreSQL normalizes them to the same definition.
+func readTypeModel(ctx context.Context, repository client.TypeRepository, schema, name string, target *typeResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, schema, name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading type: '%s.%s'", schema, name), err.Error())
+		return diags
+	}
+
+	prior := target.toPgModel()
+	if prior.Kind == actual.Kind && prior.Kind != client.TypeKindEnum {
+		normalized, err := repository.Normalize(ctx, prior)
+		if err != nil {
+			// the prior definition may no longer be valid, e.g. a referenced type was dropped
+			tflog.Warn(ctx, "Unable to normalize the type definition, using the server spelling", map[string]any{"error": err.Error()})
+		} else {
+			*actual = client.PreserveTypeSpelling(prior, *normalized, *actual)
+		}
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+// isKnown reports whether the definition of the type is known, i.e. it doesn't depend on other resources.
+func (rm *typeResourceModel) isKnown() bool {
+	switch {
+	case rm.Enum != nil:
+		for _, value := range rm.Enum.Values {
+			if value.IsUnknown() {
+				return false
+			}
+		}
+	case rm.Composite != nil:
+		for _, attribute := range rm.Composite.Attributes {
+			if attribute.Name.IsUnknown() || attribute.Type.IsUnknown() {
+				return false
+			}
+		}
+	case rm.Range != nil:
+		return !rm.Range.Subtype.IsUnknown() && !rm.Range.SubtypeOpclass.IsUnknown() && !rm.Range.SubtypeDiff.IsUnknown()
+	case rm.Domain != nil:
+		if rm.Domain.BaseType.IsUnknown() || rm.Domain.Default.IsUnknown() || rm.Domain.NotNull.IsUnknown() {
+			return false
+		}
+		for _, check := range rm.Domain.CheckConstraints {
+			if check.Name.IsUnknown() || check.Expression.IsUnknown() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (rm *typeResourceModel) kind() string {
+	switch {
+	case rm.Enum != nil:
+		return client.TypeKindEnum
+	case rm.Composite != nil:
+		return client.TypeKindComposite
+	case rm.Range != nil:
+		return client.TypeKindRange
+	case rm.Domain != nil:
+		return client.TypeKindDomain
+	}
+	return ""
+}
+
+func (rm *typeResourceModel) toPgModel() client.TypeModel {
+	pgModel := client.TypeModel{
+		Schema:   rm.Schema.ValueString(),
+		Name:     rm.Name.ValueString(),
+		Database: rm.Database.ValueString(),
+		Kind:     rm.kind(),
+		Owner:    rm.Owner.ValueString(),
+		Comment:  rm.Comment.ValueString(),
+	}
+
+	switch {
+	case rm.Enum != nil:
+		pgModel.EnumValues = mapStringValuesToSlice(rm.Enum.Values)
+		if pgModel.EnumValues == nil {
+			pgModel.EnumValues = []string{}
+		}
+	case rm.Composite != nil:
+		for _, attribute := range rm.Composite.Attributes {
+			pgModel.Attributes = append(pgModel.Attributes, client.TypeAttribute{
+				Name: attribute.Name.ValueString(),
+				Type: attribute.Type.ValueString(),
+			})
+		}
+	case rm.Range != nil:
+		pgModel.Range = &client.TypeRange{
+			Subtype:        rm.Range.Subtype.ValueString(),
+			SubtypeOpclass: rm.Range.SubtypeOpclass.ValueString(),
+			SubtypeDiff:    rm.Range.SubtypeDiff.ValueString(),
+		}
+	case rm.Domain != nil:
+		pgModel.Domain = &client.TypeDomain{
+			BaseType: rm.Domain.BaseType.ValueString(),
+			Default:  rm.Domain.Default.ValueString(),
+			NotNull:  rm.Domain.NotNull.ValueBool(),
+		}
+		for _, check := range rm.Domain.CheckConstraints {
+			pgModel.Domain.CheckConstraints = append(pgModel.Domain.CheckConstraints, client.TypeCheckConstraint{
+				Name:       check.Name.ValueString(),
+				Expression: check.Expression.ValueString(),
+			})
+		}
+	}
+	return pgModel
+}
+
+func (rm *typeResourceModel) fromPgModel(pgModel client.TypeModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Schema = types.StringValue(pgModel.Schema)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Owner = types.StringValue(pgModel.Owner)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+
+	rm.Enum, rm.Composite, rm.Range, rm.Domain = nil, nil, nil, nil
+	switch pgModel.Kind {
+	case client.TypeKindEnum:
+		rm.Enum = &typeEnumModel{Values: mapSliceToStringValues(pgModel.EnumValues)}
+		if rm.Enum.Values == nil {
+			rm.Enum.Values = []types.String{}
+		}
+	case client.TypeKindComposite:
+		rm.Composite = &typeCompositeModel{}
+		for _, attribute := range pgModel.Attributes {
+			rm.Composite.Attributes = append(rm.Composite.Attributes, typeAttributeModel{
+				Name: types.StringValue(attribute.Name),
+				Type: types.StringValue(attribute.Type),
+			})
+		}
+	case client.TypeKindRange:
+		rm.Range = &typeRangeModel{
+			Subtype:        types.StringValue(pgModel.Range.Subtype),
+			SubtypeOpclass: stringValueOrNull(pgModel.Range.SubtypeOpclass),
+			SubtypeDiff:    stringValueOrNull(pgModel.Range.SubtypeDiff),
+		}
+	case client.TypeKindDomain:
+		rm.Domain = &typeDomainModel{
+			BaseType: types.StringValue(pgModel.Domain.BaseType),
+			Default:  stringValueOrNull(pgModel.Domain.Default),
+			NotNull:  types.BoolValue(pgModel.Domain.NotNull),
+		}
+		for _, check := range pgModel.Domain.CheckConstraints {
+			rm.Domain.CheckConstraints = append(rm.Domain.CheckConstraints, tableCheckConstraintModel{
+				Name:       types.StringValue(check.Name),
+				Expression: types.StringValue(check.Expression),
+			})
+		}
+	}
+}
+
+func (rm *typeResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s.%s", rm.Database.ValueString(), rm.Schema.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *typeResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
@@ -0,0 +1,103 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"regexp"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccTypeResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_type_resource_db",
+		Username: "test_type_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	enumResourceName := "postgresql_type.test_enum"
+	domainResourceName := "postgresql_type.test_domain"
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccTypeToTFResources(t, `["sad", "happy"]`, "VALUE > 0"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(enumResourceName, "id", fmt.Sprintf("%s.public.test_type_enum", runOpts.Database)),
+					resource.TestCheckResourceAttr(enumResourceName, "enum.values.#", "2"),
+					resource.TestCheckResourceAttr(enumResourceName, "owner", runOpts.Username),
+					resource.TestCheckResourceAttr(domainResourceName, "domain.base_type", "int4"),
+					resource.TestCheckResourceAttr(domainResourceName, "domain.not_null", "true"),
+					resource.TestCheckResourceAttr(domainResourceName, "domain.check_constraints.0.expression", "VALUE > 0"),
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            enumResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				// Update testing - Enum values and domain constraints without re-creating the resources
+				Config: testAccTypeToTFResources(t, `["angry", "sad", "ok", "happy", "euphoric"]`, "VALUE >= 1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(enumResourceName, "enum.values.#", "5"),
+					resource.TestCheckResourceAttr(enumResourceName, "enum.values.0", "angry"),
+					resource.TestCheckResourceAttr(enumResourceName, "enum.values.2", "ok"),
+					resource.TestCheckResourceAttr(domainResourceName, "domain.check_constraints.0.expression", "VALUE >= 1"),
+				),
+			},
+			{
+				// Removing enum values is refused at plan time
+				Config:      testAccTypeToTFResources(t, `["sad", "ok", "happy", "euphoric"]`, "VALUE >= 1"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Unsupported enum change`),
+			},
+			{
+				// Drift testing - a type dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP DOMAIN public.test_type_domain;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccTypeToTFResources(t, `["angry", "sad", "ok", "happy", "euphoric"]`, "VALUE >= 1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(domainResourceName, "domain.check_constraints.0.expression", "VALUE >= 1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccTypeToTFResources(t *testing.T, enumValues, domainCheck string) string {
+	t.Helper()
+	return fmt.Sprintf(`
+		resource "postgresql_type" "test_enum" {
+			name = "test_type_enum"
+			enum = {
+				values = %s
+			}
+		}
+
+		resource "postgresql_type" "test_domain" {
+			name   = "test_type_domain"
+			domain = {
+				base_type = "int4"
+				not_null  = true
+				check_constraints = [
+					{ name = "test_type_domain_check", expression = %q }
+				]
+			}
+		}`, enumValues, domainCheck)
+}
@@ -0,0 +1,329 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"strings"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type userMappingResource struct {
+	client client.PgClient
+}
+
+type userMappingResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	LastUpdated types.String `tfsdk:"last_updated"`
+	Database    types.String `tfsdk:"database"`
+	Server      types.String `tfsdk:"server"`
+	User        types.String `tfsdk:"user"`
+	Options     types.Map    `tfsdk:"options"`
+	AssumeRole  types.String `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                = &userMappingResource{}
+	_ resource.ResourceWithConfigure   = &userMappingResource{}
+	_ resource.ResourceWithImportState = &userMappingResource{}
+)
+
+func NewUserMappingResource() resource.Resource {
+	return &userMappingResource{}
+}
+
+func (r *userMappingResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'user_mapping' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *userMappingResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_user_mapping"
+}
+
+func (r *userMappingResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the user mapping, in the format `database_name.server_name.user_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the user mapping",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the user mapping is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"server": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the foreign server of the user mapping",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Role mapped to the foreign server. Use `public` for the mapping applying to every role without a mapping of its own.",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"options": schema.MapAttribute{
+				Optional:            true,
+				Sensitive:           true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Options of the user mapping, specific to the foreign data wrapper, e.g. `user` and `password` for `postgres_fdw`. Changes are applied in place, option by option.",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the user mapping. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceUserMapping,
+	}
+}
+
+func (r *userMappingResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'user_mapping' resource")
+
+	var model userMappingResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+
+	repository := conn.UserMappingRepository()
+	if err = repository.Create(ctx, model.toPgModel()); err != nil {
+		res.Diagnostics.AddError("Error creating user mapping", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readUserMappingModel(ctx, repository, model.Server.ValueString(), model.User.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'user_mapping' resource")
+}
+
+func (r *userMappingResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'user_mapping' resource")
+
+	var model userMappingResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the user mapping", "Id is required for reading user mapping")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 3)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the user mapping", "Id should be in the format 'database_name.server_name.user_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	// an user mapping dropped outside of Terraform is removed from the state and planned again
+	repository := conn.UserMappingRepository()
+	exists, err := repository.Exists(ctx, idParts[1], idParts[2])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading user mapping", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "User mapping not found, removing it from the state", map[string]any{"user_mapping": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readUserMappingModel(ctx, repository, idParts[1], idParts[2], &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'user_mapping' resource")
+}
+
+func (r *userMappingResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'user_mapping' resource")
+
+	var stateModel userMappingResourceModel
+	var planModel userMappingResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	repository := conn.UserMappingRepository()
+	_, err = repository.Update(ctx, client.UserMappingUpdateParams{Current: stateModel.toPgModel(), Desired: planModel.toPgModel()})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating user mapping", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readUserMappingModel(ctx, repository, planModel.Server.ValueString(), planModel.User.ValueString(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'user_mapping' resource")
+}
+
+func (r *userMappingResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'user_mapping' resource")
+
+	var model userMappingResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	if err = conn.UserMappingRepository().Drop(ctx, model.Server.ValueString(), model.User.ValueString()); err != nil {
+		res.Diagnostics.AddError("Error deleting user mapping", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'user_mapping' resource")
+}
+
+func (r *userMappingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readUserMappingModel reads the user mapping into the target. The options are kept as they are
+// when the server hides them from the role reading the mapping.
+func readUserMappingModel(ctx context.Context, repository client.UserMappingRepository, server, user string, target *userMappingResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, server, user)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading user mapping: '%s' for '%s'", server, user), err.Error())
+		return diags
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *userMappingResourceModel) toPgModel() client.UserMappingModel {
+	return client.UserMappingModel{
+		Server:   rm.Server.ValueString(),
+		User:     rm.User.ValueString(),
+		Database: rm.Database.ValueString(),
+		Options:  mapStringMapValue(rm.Options),
+	}
+}
+
+func (rm *userMappingResourceModel) fromPgModel(pgModel client.UserMappingModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Server = types.StringValue(pgModel.Server)
+	// keep the configured spelling of PUBLIC
+	if !strings.EqualFold(rm.User.ValueString(), pgModel.User) || pgModel.User != client.UserMappingPublic {
+		rm.User = types.StringValue(pgModel.User)
+	}
+	if pgModel.Options != nil {
+		rm.Options = mapToStringMapValue(pgModel.Options)
+	}
+}
+
+func (rm *userMappingResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s.%s", rm.Database.ValueString(), rm.Server.ValueString(), rm.User.ValueString()))
+}
+
+func (rm *userMappingResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
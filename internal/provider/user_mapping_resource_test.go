@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccUserMappingResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_user_mapping_resource_db",
+		Username: "test_user_mapping_resource_user",
+	}
+	ctx, db, remoteOpts := testAccPrepareForeignDataTestCase(t, runOpts)
+	defer db.Close()
+
+	mockResourceId := "test_user_mapping"
+	mockResourceName := fmt.Sprintf("postgresql_user_mapping.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, fmt.Sprintf(`
+				CREATE SERVER test_user_mapping_server FOREIGN DATA WRAPPER postgres_fdw OPTIONS (host 'remote', dbname '%s');`,
+				remoteOpts.Database))
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing
+				Config: testAccUserMappingToTFResource(t, mockResourceId, runOpts.Username, fmt.Sprintf(`{
+					user     = "%s"
+					password = "wrong"
+				}`, remoteOpts.Username)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.test_user_mapping_server.%s", runOpts.Database, runOpts.Username)),
+					resource.TestCheckResourceAttr(mockResourceName, "options.%", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "options.password", "wrong"),
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			{
+				// Update testing - Options set and added without re-creating the resource
+				Config: testAccUserMappingToTFResource(t, mockResourceId, runOpts.Username, fmt.Sprintf(`{
+					user     = "%s"
+					password = "%s"
+					sslmode  = "disable"
+				}`, remoteOpts.Username, remoteOpts.Password)),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "options.%", "3"),
+					resource.TestCheckResourceAttr(mockResourceName, "options.password", remoteOpts.Password),
+					resource.TestCheckResourceAttr(mockResourceName, "options.sslmode", "disable"),
+				),
+			},
+			{
+				// Update testing - Option dropped, the PUBLIC mapping created
+				Config: testAccUserMappingToTFResource(t, mockResourceId, runOpts.Username, fmt.Sprintf(`{
+					user     = "%s"
+					password = "%s"
+				}`, remoteOpts.Username, remoteOpts.Password)) + testAccUserMappingToTFResource(t, "test_public_mapping", "public", `{
+					user = "nobody"
+				}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "options.%", "2"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "options.sslmode"),
+					resource.TestCheckResourceAttr("postgresql_user_mapping.test_public_mapping", "id", fmt.Sprintf("%s.test_user_mapping_server.public", runOpts.Database)),
+				),
+			},
+			{
+				// Drift testing - a user mapping dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP USER MAPPING FOR test_user_mapping_resource_user SERVER test_user_mapping_server;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccUserMappingToTFResource(t, mockResourceId, runOpts.Username, fmt.Sprintf(`{
+					user     = "%s"
+					password = "%s"
+				}`, remoteOpts.Username, remoteOpts.Password)) + testAccUserMappingToTFResource(t, "test_public_mapping", "public", `{
+					user = "nobody"
+				}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(mockResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserMappingToTFResource(t *testing.T, resId, user, options string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_user_mapping" "%s" {
+			server  = "test_user_mapping_server"
+			user    = "%s"
+			options = %s
+		}
+		`, resId, user, options)
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"terraform-provider-postgresql/internal/client"
+)
+
+// commandTagValidator checks that a string is a command tag from the event trigger
+// firing matrix, see client.GetEventTriggerCommandTag. The comparison is case-insensitive.
+type commandTagValidator struct{}
+
+var _ validator.String = commandTagValidator{}
+
+func (v commandTagValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be a command tag supported by event triggers (PostgreSQL %d)", client.CommandTagCatalogVersion)
+}
+
+func (v commandTagValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v commandTagValidator) ValidateString(ctx context.Context, req validator.StringRequest, res *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, ok := client.GetEventTriggerCommandTag(req.ConfigValue.ValueString()); !ok {
+		res.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid command tag",
+			fmt.Sprintf("'%s' is not a command tag supported by event triggers, %s.", req.ConfigValue.ValueString(), v.Description(ctx)),
+		)
+	}
+}
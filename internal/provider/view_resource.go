@@ -0,0 +1,479 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"slices"
+	"terraform-provider-postgresql/internal/client"
+	"time"
+)
+
+type viewResource struct {
+	client client.PgClient
+}
+
+type viewResourceModel struct {
+	Id              types.String   `tfsdk:"id"`
+	LastUpdated     types.String   `tfsdk:"last_updated"`
+	Database        types.String   `tfsdk:"database"`
+	Schema          types.String   `tfsdk:"schema"`
+	Name            types.String   `tfsdk:"name"`
+	Query           types.String   `tfsdk:"query"`
+	Columns         []types.String `tfsdk:"columns"`
+	Owner           types.String   `tfsdk:"owner"`
+	SecurityBarrier types.Bool     `tfsdk:"security_barrier"`
+	SecurityInvoker types.Bool     `tfsdk:"security_invoker"`
+	CheckOption     types.String   `tfsdk:"check_option"`
+	Comment         types.String   `tfsdk:"comment"`
+	AssumeRole      types.String   `tfsdk:"assume_role"`
+}
+
+var (
+	_ resource.Resource                = &viewResource{}
+	_ resource.ResourceWithConfigure   = &viewResource{}
+	_ resource.ResourceWithImportState = &viewResource{}
+	_ resource.ResourceWithModifyPlan  = &viewResource{}
+)
+
+func NewViewResource() resource.Resource {
+	return &viewResource{}
+}
+
+func (r *viewResource) Configure(ctx context.Context, req resource.ConfigureRequest, res *resource.ConfigureResponse) {
+	tflog.Trace(ctx, "Configuring 'view' resource")
+
+	pgClient, diags := parsePgClientFromRequest(ctx, req)
+
+	res.Diagnostics.Append(diags...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	r.client = pgClient
+}
+
+func (r *viewResource) Metadata(_ context.Context, req resource.MetadataRequest, res *resource.MetadataResponse) {
+	res.TypeName = req.ProviderTypeName + "_view"
+}
+
+func (r *viewResource) Schema(_ context.Context, _ resource.SchemaRequest, res *resource.SchemaResponse) {
+	nonEmptyString := []validator.String{
+		stringvalidator.LengthAtLeast(1),
+	}
+
+	res.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The unique identifier for the view, in the format `database_name.schema_name.view_name`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp of the last modification of the view",
+			},
+			"database": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Name of the database where the view is located. If not provided, the database from the provider configuration will be used.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"schema": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("public"),
+				MarkdownDescription: "Schema where the view is located. Changes move the view in place.",
+				Validators:          nonEmptyString,
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the view",
+				Validators:          nonEmptyString,
+			},
+			"query": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The `SELECT` query of the view. It's compared with the definition returned by `pg_get_viewdef`, so formatting differences are not reported as changes. A query that removes, renames or retypes a column of the view recreates it, since `CREATE OR REPLACE VIEW` only adds columns at the end.",
+				Validators:          nonEmptyString,
+			},
+			"columns": schema.ListAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Names of the view columns. If not provided, the names are derived from the query. Renaming or removing a column recreates the view.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+				},
+			},
+			"owner": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The owner of the view. If not provided, the view is owned by the role that creates it (see `assume_role`).",
+				Validators:          nonEmptyString,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"security_barrier": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the view is a security barrier, see [Rules and Privileges](https://www.postgresql.org/docs/current/rules-privileges.html)",
+			},
+			"security_invoker": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Whether the underlying relations are checked against the privileges of the user of the view, instead of its owner. Requires PostgreSQL 15 or later.",
+			},
+			"check_option": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Check option of an updatable view, `LOCAL` or `CASCADED`",
+				Validators: []validator.String{
+					stringvalidator.OneOf("LOCAL", "CASCADED"),
+				},
+			},
+			"comment": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Comment associated with the view",
+			},
+			"assume_role": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Role to assume (`SET LOCAL ROLE`) while managing the view. Overrides the provider `assume_role` attribute.",
+				Validators:          nonEmptyString,
+			},
+		},
+		MarkdownDescription: mdDocResourceView,
+	}
+}
+
+func (r *viewResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, res *resource.ModifyPlanResponse) {
+	// nothing to check on destroy or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var model viewResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.SecurityInvoker.ValueBool() {
+		res.Diagnostics.Append(checkServerCapability(
+			ctx,
+			r.client,
+			plannedDatabase(r.client, model.Database),
+			client.CapabilitySecurityInvokerView,
+			path.Root("security_invoker"),
+			"The 'security_invoker' option",
+		)...)
+	}
+
+	// the identifier is derived from the schema and name, moving the view produces a new one
+	if !req.State.Raw.IsNull() {
+		var stateModel viewResourceModel
+
+		res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+		if res.Diagnostics.HasError() {
+			return
+		}
+		if !model.Name.Equal(stateModel.Name) || !model.Schema.Equal(stateModel.Schema) {
+			res.Diagnostics.Append(res.Plan.SetAttribute(ctx, path.Root("id"), types.StringUnknown())...)
+		}
+
+		// CREATE OR REPLACE VIEW only adds columns after the existing ones, the view is replaced
+		// when the new query removes, renames or retypes a column
+		queryChanged, columnsChanged := !model.Query.Equal(stateModel.Query), !slices.Equal(model.Columns, stateModel.Columns)
+		if (queryChanged || columnsChanged) && !model.Query.IsUnknown() && !slices.ContainsFunc(model.Columns, types.String.IsUnknown) {
+			conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+			if err != nil {
+				res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+				return
+			}
+			replace, err := conn.ViewRepository().RequiresReplace(ctx, stateModel.toPgModel(), model.toPgModel())
+			if err != nil {
+				res.Diagnostics.AddError("Error planning view changes", err.Error())
+				return
+			}
+			if replace && queryChanged {
+				res.RequiresReplace = append(res.RequiresReplace, path.Root("query"))
+			}
+			if replace && columnsChanged {
+				res.RequiresReplace = append(res.RequiresReplace, path.Root("columns"))
+			}
+		}
+	}
+}
+
+func (r *viewResource) Create(ctx context.Context, req resource.CreateRequest, res *resource.CreateResponse) {
+	tflog.Trace(ctx, "Creating 'view' resource")
+
+	var model viewResourceModel
+
+	res.Diagnostics.Append(req.Plan.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Database.IsNull() || model.Database.IsUnknown() {
+		model.Database = types.StringValue(r.client.GetInitConfig().Database)
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	role := resolveAssumeRole(r.client, model.AssumeRole)
+	ctx = client.ContextWithAssumeRole(ctx, role)
+
+	// the view is owned by the assumed role, unless another owner is explicitly requested
+	expectedOwner := role
+	if !model.Owner.IsNull() && !model.Owner.IsUnknown() {
+		expectedOwner = model.Owner.ValueString()
+	}
+
+	pgModel := model.toPgModel()
+	pgModel.Owner = expectedOwner
+
+	repository := conn.ViewRepository()
+	if err = repository.Create(ctx, pgModel); err != nil {
+		res.Diagnostics.AddError("Error creating view", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readViewModel(ctx, repository, model.Schema.ValueString(), model.Name.ValueString(), &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	model.SetId()
+	model.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("view", expectedOwner, model.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Created 'view' resource")
+}
+
+func (r *viewResource) Read(ctx context.Context, req resource.ReadRequest, res *resource.ReadResponse) {
+	tflog.Trace(ctx, "Reading 'view' resource")
+
+	var model viewResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	if model.Id.IsUnknown() || model.Id.IsNull() {
+		res.Diagnostics.AddError("Missing Identifier for the view", "Id is required for reading view")
+		return
+	}
+
+	idParts, ok := splitResourceId(model.Id.ValueString(), 3)
+	if !ok {
+		res.Diagnostics.AddError("Invalid Identifier for the view", "Id should be in the format 'database_name.schema_name.view_name'")
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, idParts[0])
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	// a view dropped outside of Terraform is removed from the state and planned again
+	repository := conn.ViewRepository()
+	exists, err := repository.Exists(ctx, idParts[1], idParts[2])
+	if err != nil {
+		res.Diagnostics.AddError("Error reading view", err.Error())
+		return
+	}
+	if !exists {
+		tflog.Warn(ctx, "View not found, removing it from the state", map[string]any{"view": model.Id.ValueString()})
+		res.State.RemoveResource(ctx)
+		return
+	}
+
+	res.Diagnostics.Append(readViewModel(ctx, repository, idParts[1], idParts[2], &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(res.State.Set(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Read 'view' resource")
+}
+
+func (r *viewResource) Update(ctx context.Context, req resource.UpdateRequest, res *resource.UpdateResponse) {
+	tflog.Trace(ctx, "Updating 'view' resource")
+
+	var stateModel viewResourceModel
+	var planModel viewResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &stateModel)...)
+	res.Diagnostics.Append(req.Plan.Get(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, stateModel.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, planModel.AssumeRole))
+
+	current := stateModel.toPgModel()
+	desired := planModel.toPgModel()
+	if planModel.Owner.IsUnknown() {
+		desired.Owner = current.Owner
+	}
+
+	repository := conn.ViewRepository()
+	_, err = repository.Update(ctx, client.ViewUpdateParams{Current: current, Desired: desired})
+	if err != nil {
+		res.Diagnostics.AddError("Error updating view", err.Error())
+		return
+	}
+
+	res.Diagnostics.Append(readViewModel(ctx, repository, planModel.Schema.ValueString(), planModel.Name.ValueString(), &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	planModel.SetId()
+	planModel.SetLastUpdated()
+
+	res.Diagnostics.Append(res.State.Set(ctx, &planModel)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	res.Diagnostics.Append(verifyOwner("view", desired.Owner, planModel.Owner.ValueString())...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+	tflog.Trace(ctx, "Updated 'view' resource")
+}
+
+func (r *viewResource) Delete(ctx context.Context, req resource.DeleteRequest, res *resource.DeleteResponse) {
+	tflog.Trace(ctx, "Deleting 'view' resource")
+
+	var model viewResourceModel
+
+	res.Diagnostics.Append(req.State.Get(ctx, &model)...)
+	if res.Diagnostics.HasError() {
+		return
+	}
+
+	conn, err := r.client.GetConnection(ctx, model.Database.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError(msgErrGetPgConnection, err.Error())
+		return
+	}
+
+	ctx = client.ContextWithAssumeRole(ctx, resolveAssumeRole(r.client, model.AssumeRole))
+	err = conn.ViewRepository().Drop(ctx, model.Schema.ValueString(), model.Name.ValueString())
+	if err != nil {
+		res.Diagnostics.AddError("Error deleting view", err.Error())
+		return
+	}
+	tflog.Trace(ctx, "Deleted 'view' resource")
+}
+
+func (r *viewResource) ImportState(ctx context.Context, req resource.ImportStateRequest, res *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, res)
+}
+
+// readViewModel reads the view into the target model. The query and columns keep the spelling of
+// the target model when PostgreSQL stores an equivalent form.
+func readViewModel(ctx context.Context, repository client.ViewRepository, schema, name string, target *viewResourceModel) diag.Diagnostics {
+	diags := diag.Diagnostics{}
+
+	actual, err := repository.Get(ctx, schema, name)
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error reading view: '%s.%s'", schema, name), err.Error())
+		return diags
+	}
+
+	if !target.Query.IsNull() && !target.Query.IsUnknown() {
+		prior := target.toPgModel()
+		normalized, err := repository.Normalize(ctx, prior)
+		if err != nil {
+			// the prior query may no longer be valid, e.g. a referenced table was dropped
+			tflog.Warn(ctx, "Unable to normalize the view query, using the server spelling", map[string]any{"error": err.Error()})
+		} else {
+			*actual = client.PreserveViewSpelling(prior, *normalized, *actual)
+		}
+	}
+
+	target.fromPgModel(*actual)
+	return diags
+}
+
+func (rm *viewResourceModel) toPgModel() client.ViewModel {
+	return client.ViewModel{
+		Schema:          rm.Schema.ValueString(),
+		Name:            rm.Name.ValueString(),
+		Database:        rm.Database.ValueString(),
+		Query:           rm.Query.ValueString(),
+		Columns:         mapStringValuesToSlice(rm.Columns),
+		Owner:           rm.Owner.ValueString(),
+		SecurityBarrier: rm.SecurityBarrier.ValueBool(),
+		SecurityInvoker: rm.SecurityInvoker.ValueBool(),
+		CheckOption:     rm.CheckOption.ValueString(),
+		Comment:         rm.Comment.ValueString(),
+	}
+}
+
+func (rm *viewResourceModel) fromPgModel(pgModel client.ViewModel) {
+	rm.Database = types.StringValue(pgModel.Database)
+	rm.Schema = types.StringValue(pgModel.Schema)
+	rm.Name = types.StringValue(pgModel.Name)
+	rm.Query = types.StringValue(pgModel.Query)
+	rm.Columns = mapSliceToStringValues(pgModel.Columns)
+	rm.Owner = types.StringValue(pgModel.Owner)
+	rm.SecurityBarrier = types.BoolValue(pgModel.SecurityBarrier)
+	rm.SecurityInvoker = types.BoolValue(pgModel.SecurityInvoker)
+	rm.CheckOption = stringValueOrNull(pgModel.CheckOption)
+	rm.Comment = stringValueOrNull(pgModel.Comment)
+}
+
+func (rm *viewResourceModel) SetId() {
+	rm.Id = types.StringValue(fmt.Sprintf("%s.%s.%s", rm.Database.ValueString(), rm.Schema.ValueString(), rm.Name.ValueString()))
+}
+
+func (rm *viewResourceModel) SetLastUpdated() {
+	rm.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+}
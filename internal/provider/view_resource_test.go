@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"gocloud.dev/postgres"
+	"terraform-provider-postgresql/internal/test"
+	"testing"
+)
+
+func TestAccViewResource(t *testing.T) {
+	runOpts := test.PostgresContainerRunOptions{
+		Database: "test_view_resource_db",
+		Username: "test_view_resource_user",
+	}
+	pgContainer := test.LoadPostgresTestContainer(t, runOpts, true)
+	connString := test.GetPostgresConnectionString(t, pgContainer)
+	ctx := context.TODO()
+
+	db, err := postgres.Open(ctx, connString)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mockResourceId := "test_view"
+	mockResourceName := fmt.Sprintf("postgresql_view.%s", mockResourceId)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		PreCheck: func() {
+			_, err := db.ExecContext(ctx, `CREATE TABLE public.test_view_resource_table (id int, email text, active bool);`)
+			assert.NoError(t, err)
+		},
+		Steps: []resource.TestStep{
+			{
+				// Create and Read testing, the query is kept as written
+				Config: testAccViewToTFResource(t, mockResourceId, "test_view_resource", `
+					query            = "select id, email from test_view_resource_table where active"
+					security_barrier = true
+					check_option     = "LOCAL"
+					comment          = "test comment"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_view_resource", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "query", "select id, email from test_view_resource_table where active"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "columns"),
+					resource.TestCheckResourceAttr(mockResourceName, "owner", runOpts.Username),
+					resource.TestCheckResourceAttr(mockResourceName, "security_barrier", "true"),
+					resource.TestCheckResourceAttr(mockResourceName, "security_invoker", "false"),
+					resource.TestCheckResourceAttr(mockResourceName, "check_option", "LOCAL"),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment"),
+				),
+			},
+			{
+				// ImportState testing
+				ResourceName:            mockResourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated", "query", "columns"},
+			},
+			{
+				// Update testing - renamed columns re-create the resource
+				Config: testAccViewToTFResource(t, mockResourceId, "test_view_resource_modified", `
+					query   = "SELECT id, email FROM test_view_resource_table"
+					columns = ["user_id", "user_email"]
+					comment = "test comment modified"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "id", fmt.Sprintf("%s.public.test_view_resource_modified", runOpts.Database)),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.#", "2"),
+					resource.TestCheckResourceAttr(mockResourceName, "security_barrier", "false"),
+					resource.TestCheckNoResourceAttr(mockResourceName, "check_option"),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment modified"),
+				),
+			},
+			{
+				// Update testing - a column added at the end without re-creating the resource
+				Config: testAccViewToTFResource(t, mockResourceId, "test_view_resource_modified", `
+					query   = "SELECT id, email, active FROM test_view_resource_table"
+					columns = ["user_id", "user_email", "user_active"]
+					comment = "test comment modified"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(mockResourceName, "columns.#", "3"),
+					resource.TestCheckResourceAttr(mockResourceName, "columns.2", "user_active"),
+					resource.TestCheckResourceAttr(mockResourceName, "comment", "test comment modified"),
+				),
+			},
+			{
+				// Drift testing - a view dropped outside of Terraform is created again
+				PreConfig: func() {
+					_, err := db.ExecContext(ctx, `DROP VIEW public.test_view_resource_modified;`)
+					assert.NoError(t, err)
+				},
+				Config: testAccViewToTFResource(t, mockResourceId, "test_view_resource_modified", `
+					query   = "SELECT id, email, active FROM test_view_resource_table"
+					columns = ["user_id", "user_email", "user_active"]
+					comment = "test comment modified"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet(mockResourceName, "id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccViewToTFResource(t *testing.T, resId, name, body string) string {
+	t.Helper()
+	return fmt.Sprintf(`resource "postgresql_view" "%s" {
+			name = "%s"
+			%s
+		}`, resId, name, body)
+}
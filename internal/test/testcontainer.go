@@ -2,11 +2,15 @@ package test
 
 import (
 	"context"
+	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/network"
 	"net/url"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -16,13 +20,70 @@ const (
 	testPGDefaultDb       = "test_tf_provider"
 	testPGDefaultUsername = "tester"
 	testPGDefaultPassword = "tester"
+
+	// testPGImageEnvVar overrides the default image, e.g. to run the whole suite against each of
+	// the SupportedPostgresImages.
+	testPGImageEnvVar = "TEST_POSTGRES_IMAGE"
 )
 
+// SupportedPostgresImages lists one image per PostgreSQL major version supported by the provider.
+var SupportedPostgresImages = []string{
+	"postgres:12-alpine",
+	"postgres:13-alpine",
+	"postgres:14-alpine",
+	"postgres:15-alpine",
+	"postgres:16-alpine",
+	"postgres:17-alpine",
+}
+
+// DefaultPostgresImage returns the image of the containers that don't ask for a specific one.
+func DefaultPostgresImage() string {
+	if image := os.Getenv(testPGImageEnvVar); image != "" {
+		return image
+	}
+	return testPGDefaultImage
+}
+
+// PostgresImageMajorVersion returns the PostgreSQL major version of an image of the
+// SupportedPostgresImages kind, e.g. 16 for postgres:16-alpine, or 0 when the tag doesn't tell.
+func PostgresImageMajorVersion(image string) int {
+	_, tag, found := strings.Cut(image, ":")
+	if !found {
+		return 0
+	}
+	major, _, _ := strings.Cut(tag, ".")
+	major, _, _ = strings.Cut(major, "-")
+	version, err := strconv.Atoi(major)
+	if err != nil {
+		return 0
+	}
+	return version
+}
+
+// SkipBelowPostgres skips a test that exercises a feature of PostgreSQL major version minMajor
+// or later when the suite runs against an older default image.
+func SkipBelowPostgres(t *testing.T, minMajor int) {
+	t.Helper()
+	image := DefaultPostgresImage()
+	if version := PostgresImageMajorVersion(image); version != 0 && version < minMajor {
+		t.Skipf("requires PostgreSQL %d or later, running against %s", minMajor, image)
+	}
+}
+
 type PostgresContainerRunOptions struct {
 	Image    string
 	Username string
 	Password string
 	Database string
+	// Settings are passed to the server as `-c name=value` options, e.g. wal_level.
+	Settings map[string]string
+	// Network attaches the container to a network shared with other containers, where it's reachable
+	// with the NetworkAlias host name (see GetPostgresNetworkConnInfo).
+	Network      *testcontainers.DockerNetwork
+	NetworkAlias string
+	// WritableDirs are mounted into the container, empty and owned by the server user, e.g. as the
+	// locations of tablespaces.
+	WritableDirs []string
 }
 
 func LoadPostgresTestContainer(t *testing.T, config PostgresContainerRunOptions, setEnVars bool) *postgres.PostgresContainer {
@@ -30,7 +91,7 @@ func LoadPostgresTestContainer(t *testing.T, config PostgresContainerRunOptions,
 	ctx := context.TODO()
 
 	if config.Image == "" {
-		config.Image = testPGDefaultImage
+		config.Image = DefaultPostgresImage()
 	}
 	if config.Username == "" {
 		config.Username = testPGDefaultUsername
@@ -48,6 +109,15 @@ func LoadPostgresTestContainer(t *testing.T, config PostgresContainerRunOptions,
 		postgres.WithPassword(config.Password),
 		postgres.BasicWaitStrategies(),
 	}
+	if len(config.Settings) > 0 {
+		opts = append(opts, withServerSettings(config.Settings))
+	}
+	if config.Network != nil {
+		opts = append(opts, network.WithNetwork([]string{config.NetworkAlias}, config.Network))
+	}
+	if len(config.WritableDirs) > 0 {
+		opts = append(opts, withWritableDirs(config.WritableDirs))
+	}
 
 	pgContainer, err := postgres.Run(ctx, config.Image, opts...)
 	assert.NoError(t, err)
@@ -89,3 +159,66 @@ func GetPostgresConnectionString(t *testing.T, container *postgres.PostgresConta
 
 	return connString + queryParams.Encode()
 }
+
+// NewTestNetwork creates a network for containers that must reach each other, e.g. a publisher
+// and a subscriber. The network is removed when the test ends.
+func NewTestNetwork(t *testing.T) *testcontainers.DockerNetwork {
+	t.Helper()
+	ctx := context.TODO()
+
+	nw, err := network.New(ctx)
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, nw.Remove(ctx))
+	})
+	return nw
+}
+
+// GetPostgresNetworkConnInfo returns the libpq connection string of a container as seen from
+// the other containers of its network, e.g. the connection of a subscription.
+func GetPostgresNetworkConnInfo(t *testing.T, config PostgresContainerRunOptions) string {
+	t.Helper()
+	assert.NotEmpty(t, config.NetworkAlias)
+
+	if config.Username == "" {
+		config.Username = testPGDefaultUsername
+	}
+	if config.Password == "" {
+		config.Password = testPGDefaultPassword
+	}
+	if config.Database == "" {
+		config.Database = testPGDefaultDb
+	}
+	return fmt.Sprintf("host=%s port=5432 dbname=%s user=%s password=%s", config.NetworkAlias, config.Database, config.Username, config.Password)
+}
+
+func withServerSettings(settings map[string]string) testcontainers.CustomizeRequestOption {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return func(req *testcontainers.GenericContainerRequest) error {
+		for _, name := range names {
+			req.Cmd = append(req.Cmd, "-c", fmt.Sprintf("%s=%s", name, settings[name]))
+		}
+		return nil
+	}
+}
+
+// withWritableDirs mounts an empty tmpfs on each directory, handed over to the server user once
+// the container is started.
+func withWritableDirs(dirs []string) testcontainers.CustomizeRequestOption {
+	return func(req *testcontainers.GenericContainerRequest) error {
+		if req.Tmpfs == nil {
+			req.Tmpfs = map[string]string{}
+		}
+		for _, dir := range dirs {
+			req.Tmpfs[dir] = "rw"
+		}
+
+		chown := testcontainers.NewRawCommand(append([]string{"chown", "postgres:postgres"}, dirs...))
+		return testcontainers.WithStartupCommand(chown)(req)
+	}
+}
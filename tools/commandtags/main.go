@@ -0,0 +1,208 @@
+// Command commandtags generates the command tag catalog of the event triggers from the
+// "Event Trigger Firing Matrix" of the PostgreSQL documentation, published once per major version.
+//
+// Run it through "go generate ./internal/client/...", it needs access to www.postgresql.org.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+)
+
+const matrixURL = "https://www.postgresql.org/docs/%d/event-trigger-matrix.html"
+
+// commandTag is a row of the matrix, it mirrors client.EventTriggerCommandTag.
+type commandTag struct {
+	Tag          string
+	SQLDrop      bool
+	TableRewrite bool
+	MinVersion   int
+}
+
+func main() {
+	from := flag.Int("from", 12, "first PostgreSQL major version covered by the catalog")
+	to := flag.Int("to", 17, "last PostgreSQL major version covered by the catalog")
+	output := flag.String("output", "pg_command_tags_gen.go", "file the catalog is written to")
+	pkg := flag.String("package", "client", "package of the generated file")
+	flag.Parse()
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	matrices := make(map[int][]commandTag)
+	for version := *from; version <= *to; version++ {
+		page, err := fetchMatrix(httpClient, fmt.Sprintf(matrixURL, version))
+		if err != nil {
+			log.Fatalf("fetching the matrix of PostgreSQL %d: %v", version, err)
+		}
+		matrices[version], err = parseMatrix(page)
+		if err != nil {
+			log.Fatalf("parsing the matrix of PostgreSQL %d: %v", version, err)
+		}
+	}
+
+	catalog, err := mergeMatrices(*from, *to, matrices)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := render(*pkg, *from, *to, catalog)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*output, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func fetchMatrix(httpClient *http.Client, url string) (string, error) {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	return string(body), nil
+}
+
+var (
+	tableRegexp = regexp.MustCompile(`(?s)<table[^>]*>.*?</table>`)
+	rowRegexp   = regexp.MustCompile(`(?s)<tr[^>]*>(.*?)</tr>`)
+	cellRegexp  = regexp.MustCompile(`(?s)<t[hd][^>]*>(.*?)</t[hd]>`)
+	tagRegexp   = regexp.MustCompile(`<[^>]*>`)
+)
+
+// cellText returns the text of a table cell, without markup nor the zero width spaces
+// the documentation puts in the event names.
+func cellText(cell string) string {
+	text := html.UnescapeString(tagRegexp.ReplaceAllString(cell, ""))
+	return strings.TrimSpace(strings.ReplaceAll(text, "\u200b", ""))
+}
+
+// parseMatrix reads the command tags of the matrix page, the columns are found by their header.
+func parseMatrix(page string) ([]commandTag, error) {
+	for _, table := range tableRegexp.FindAllString(page, -1) {
+		rows := rowRegexp.FindAllStringSubmatch(table, -1)
+		if len(rows) == 0 {
+			continue
+		}
+
+		columns := make(map[string]int)
+		for i, cell := range cellRegexp.FindAllStringSubmatch(rows[0][1], -1) {
+			columns[cellText(cell[1])] = i
+		}
+		if _, ok := columns["Command Tag"]; !ok {
+			continue
+		}
+		for _, name := range []string{"ddl_command_start", "ddl_command_end", "sql_drop", "table_rewrite"} {
+			if _, ok := columns[name]; !ok {
+				return nil, fmt.Errorf("the matrix has no '%s' column", name)
+			}
+		}
+
+		var tags []commandTag
+		for _, row := range rows[1:] {
+			var cells []string
+			for _, cell := range cellRegexp.FindAllStringSubmatch(row[1], -1) {
+				cells = append(cells, cellText(cell[1]))
+			}
+			if len(cells) < len(columns) {
+				return nil, fmt.Errorf("the row '%s' has %d cells, expected %d", strings.Join(cells, " "), len(cells), len(columns))
+			}
+
+			tag := cells[columns["Command Tag"]]
+			// every command tag fires the ddl_command_* events, the catalog does not record them
+			if cells[columns["ddl_command_start"]] != "X" || cells[columns["ddl_command_end"]] != "X" {
+				return nil, fmt.Errorf("the command tag '%s' does not fire the ddl_command_* events", tag)
+			}
+			tags = append(tags, commandTag{
+				Tag:          tag,
+				SQLDrop:      cells[columns["sql_drop"]] == "X",
+				TableRewrite: cells[columns["table_rewrite"]] == "X",
+			})
+		}
+		return tags, nil
+	}
+	return nil, fmt.Errorf("the page has no command tag table")
+}
+
+// mergeMatrices builds the catalog from the matrix of each version, the events of a tag are the ones
+// of the latest matrix and a tag missing from the first one gets the version it appeared in.
+func mergeMatrices(from, to int, matrices map[int][]commandTag) ([]commandTag, error) {
+	byTag := make(map[string]commandTag)
+	for version := from; version <= to; version++ {
+		for _, c := range matrices[version] {
+			if prior, ok := byTag[c.Tag]; ok {
+				c.MinVersion = prior.MinVersion
+			} else if version > from {
+				c.MinVersion = version * 10000
+			}
+			byTag[c.Tag] = c
+		}
+	}
+
+	latest := make(map[string]bool)
+	for _, c := range matrices[to] {
+		latest[c.Tag] = true
+	}
+
+	catalog := make([]commandTag, 0, len(byTag))
+	for tag, c := range byTag {
+		// the catalog has no upper bound, a removed tag needs a decision
+		if !latest[tag] {
+			return nil, fmt.Errorf("the command tag '%s' is not in the matrix of PostgreSQL %d anymore", tag, to)
+		}
+		catalog = append(catalog, c)
+	}
+	slices.SortFunc(catalog, func(a, b commandTag) int {
+		return strings.Compare(a.Tag, b.Tag)
+	})
+	return catalog, nil
+}
+
+var catalogTemplate = template.Must(template.New("catalog").Parse(`// Code generated by tools/commandtags; DO NOT EDIT.
+
+package {{ .Package }}
+
+// CommandTagCatalogVersion is the latest PostgreSQL major version covered by the command tag catalog.
+const CommandTagCatalogVersion = {{ .To }}
+
+// eventTriggerCommandTags is generated from the "Event Trigger Firing Matrix" of the PostgreSQL
+// documentation, from PostgreSQL {{ .From }} up to CommandTagCatalogVersion. Sorted by tag.
+var eventTriggerCommandTags = []EventTriggerCommandTag{
+{{- range .Catalog }}
+	{Tag: {{ printf "%q" .Tag }}{{ if .SQLDrop }}, SQLDrop: true{{ end }}{{ if .TableRewrite }}, TableRewrite: true{{ end }}{{ if .MinVersion }}, MinVersion: {{ .MinVersion }}{{ end }}},
+{{- end }}
+}
+`))
+
+func render(pkg string, from, to int, catalog []commandTag) ([]byte, error) {
+	var buf bytes.Buffer
+	err := catalogTemplate.Execute(&buf, struct {
+		Package string
+		From    int
+		To      int
+		Catalog []commandTag
+	}{pkg, from, to, catalog})
+	if err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+// matrixPage follows the markup of the documentation, the event names hold zero width spaces.
+const matrixPage = `<table class="table" summary="Event Trigger Support by Command Tag" border="1">
+<thead><tr><th>Command Tag</th><th><code class="literal">ddl_&#8203;command_&#8203;start</code></th>
+<th><code class="literal">ddl_&#8203;command_&#8203;end</code></th><th><code class="literal">sql_&#8203;drop</code></th>
+<th><code class="literal">table_&#8203;rewrite</code></th><th>Notes</th></tr></thead>
+<tbody>
+<tr><td align="left"><code class="literal">ALTER TABLE</code></td><td align="center"><code class="literal">X</code></td>
+<td align="center"><code class="literal">X</code></td><td align="center"><code class="literal">X</code></td>
+<td align="center"><code class="literal">X</code></td><td align="left"> </td></tr>
+<tr><td align="left"><code class="literal">CREATE TABLE</code></td><td align="center"><code class="literal">X</code></td>
+<td align="center"><code class="literal">X</code></td><td align="center"><code class="literal">-</code></td>
+<td align="center"><code class="literal">-</code></td><td align="left"> </td></tr>
+</tbody></table>`
+
+func TestParseMatrix(t *testing.T) {
+	tags, err := parseMatrix(`<table><tr><td>navigation</td></tr></table>` + matrixPage)
+	require.NoError(t, err)
+	assert.Equal(t, []commandTag{
+		{Tag: "ALTER TABLE", SQLDrop: true, TableRewrite: true},
+		{Tag: "CREATE TABLE"},
+	}, tags)
+
+	_, err = parseMatrix(`<table><tr><td>navigation</td></tr></table>`)
+	assert.ErrorContains(t, err, "no command tag table")
+}
+
+func TestMergeMatrices(t *testing.T) {
+	t.Run("MinVersion", func(t *testing.T) {
+		catalog, err := mergeMatrices(16, 17, map[int][]commandTag{
+			16: {{Tag: "DROP TABLE", SQLDrop: true}},
+			17: {{Tag: "REINDEX"}, {Tag: "DROP TABLE", SQLDrop: true}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []commandTag{
+			{Tag: "DROP TABLE", SQLDrop: true},
+			{Tag: "REINDEX", MinVersion: 170000},
+		}, catalog)
+	})
+
+	t.Run("RemovedTag", func(t *testing.T) {
+		_, err := mergeMatrices(16, 17, map[int][]commandTag{
+			16: {{Tag: "CREATE TABLE"}, {Tag: "DROP TABLE"}},
+			17: {{Tag: "DROP TABLE"}},
+		})
+		assert.ErrorContains(t, err, "'CREATE TABLE' is not in the matrix of PostgreSQL 17")
+	})
+}